@@ -38,6 +38,14 @@ func main() {
 		log.Panic(err)
 	}
 
+	// global-api <config> migrate ...: chay migration roi thoat, khong start app
+	if len(os.Args) > 2 && os.Args[2] == "migrate" {
+		if err := runMigrate(appConfig, os.Args[3:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	fcm, err := messaging.NewFirebaseApp(*appConfig)
 	if err != nil {
 		log.Panic(err)
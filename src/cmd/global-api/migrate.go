@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	config2 "sen-global-api/config"
+	"sen-global-api/internal/migrations"
+	"sen-global-api/pkg/mysql"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const migrateUsage = `usage: global-api <config> migrate <command>
+  up [N]       apply the pending migrations, at most N
+  down N       revert the last N applied migrations
+  status       list the migrations and whether they are applied
+  create NAME  write empty up/down SQL files for a new migration`
+
+// runMigrate handles `global-api <config> migrate ...`, it runs instead of the app.
+func runMigrate(appConfig *config2.AppConfig, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing command\n%s", migrateUsage)
+	}
+
+	// create only writes files, it needs no database
+	if args[0] == "create" {
+		if len(args) < 2 {
+			return fmt.Errorf("missing migration name\n%s", migrateUsage)
+		}
+		upPath, downPath, err := migrations.Create(migrations.SQLDir(), args[1], time.Now())
+		if err != nil {
+			return err
+		}
+		fmt.Println("created", upPath)
+		fmt.Println("created", downPath)
+		return nil
+	}
+
+	dbConn, err := mysql.Establish(*appConfig)
+	if err != nil {
+		return err
+	}
+	migrator, err := migrations.NewMigrator(dbConn)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		n, err := migrateCount(args, 0)
+		if err != nil {
+			return err
+		}
+		applied, err := migrator.Up(n)
+		for _, m := range applied {
+			fmt.Printf("applied %d_%s\n", m.Version, m.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("no pending migration")
+		}
+		return err
+	case "down":
+		if len(args) < 2 {
+			return fmt.Errorf("down needs the number of migrations to revert\n%s", migrateUsage)
+		}
+		n, err := migrateCount(args, 0)
+		if err != nil {
+			return err
+		}
+		reverted, err := migrator.Down(n)
+		for _, m := range reverted {
+			fmt.Printf("reverted %d_%s\n", m.Version, m.Name)
+		}
+		return err
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, s := range statuses {
+			state, appliedAt := "pending", ""
+			if s.Applied {
+				state, appliedAt = "applied", s.AppliedAt.Format(time.RFC3339)
+			}
+			if s.ChecksumMismatch {
+				state = "edited"
+			}
+			if s.Missing {
+				state = "missing"
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Version, s.Name, state, appliedAt)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("unknown command %q\n%s", args[0], migrateUsage)
+	}
+}
+
+func migrateCount(args []string, fallback int) (int, error) {
+	if len(args) < 2 {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(args[1])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid number of migrations %q", args[1])
+	}
+	return n, nil
+}
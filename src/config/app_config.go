@@ -38,6 +38,79 @@ type Messaging struct {
 	ServiceAccount string `env-required:"true" yaml:"service_account"`
 }
 
+type RealtimeConfig struct {
+	// FirestoreAdapter keeps mirroring device settings to Firestore for the devices not yet on the realtime stream,
+	// on by default until every device has migrated
+	FirestoreAdapter *bool `yaml:"firestore_adapter"`
+}
+
+func (c RealtimeConfig) FirestoreAdapterEnabled() bool {
+	return c.FirestoreAdapter == nil || *c.FirestoreAdapter
+}
+
+// RateLimitRule overrides the default limit of a route group, the zero fields keep the default.
+type RateLimitRule struct {
+	Limit             int   `yaml:"limit"`
+	IPLimit           int   `yaml:"ip_limit"`
+	WindowSeconds     int   `yaml:"window_seconds"`
+	FailuresOnly      *bool `yaml:"failures_only"`
+	LockoutSeconds    int   `yaml:"lockout_seconds"`
+	MaxLockoutSeconds int   `yaml:"max_lockout_seconds"`
+}
+
+type RateLimitConfig struct {
+	Disabled bool                     `yaml:"disabled" env:"RATE_LIMIT_DISABLED"`
+	Groups   map[string]RateLimitRule `yaml:"groups"`
+}
+
+// DataLogConfig tunes the request audit log (DataLog table), the zero fields keep the defaults.
+type DataLogConfig struct {
+	RetentionDays    int `yaml:"retention_days" env:"DATA_LOG_RETENTION_DAYS"`
+	MaxPayloadBytes  int `yaml:"max_payload_bytes"`
+	MaxResponseBytes int `yaml:"max_response_bytes"`
+	BufferSize       int `yaml:"buffer_size"`
+	// SampleRates keeps a fraction (0..1) of the requests per route, e.g. "/v1/form": 0.2
+	SampleRates map[string]float64 `yaml:"sample_rates"`
+	// PIIFields are masked in the payloads on top of the passwords and tokens
+	PIIFields []string `yaml:"pii_fields"`
+}
+
+// HealthConfig tunes the readiness checks, the zero fields keep the defaults.
+type HealthConfig struct {
+	// CheckTimeoutSeconds bounds each dependency check, 3s by default
+	CheckTimeoutSeconds int `yaml:"check_timeout_seconds" env:"HEALTH_CHECK_TIMEOUT_SECONDS"`
+	// DrainSeconds is how long the instance reports not ready before the server stops, 5s by default
+	DrainSeconds int `yaml:"drain_seconds" env:"HEALTH_DRAIN_SECONDS"`
+}
+
+// HeartbeatConfig tunes the device heartbeats, the zero fields keep the defaults.
+type HeartbeatConfig struct {
+	// IntervalSeconds is the beat interval sent back to the devices, 60s by default
+	IntervalSeconds int `yaml:"interval_seconds" env:"HEARTBEAT_INTERVAL_SECONDS"`
+	// OnlineMissedBeats is how many beats a device may miss before it shows offline in the fleet view, 3 by default
+	OnlineMissedBeats int `yaml:"online_missed_beats"`
+}
+
+// FeatureFlagConfig tunes the feature flags, the zero fields keep the defaults.
+type FeatureFlagConfig struct {
+	// File reads the flags from a YAML file instead of the database, for the tests and the local runs
+	File string `yaml:"file" env:"FEATURE_FLAGS_FILE"`
+	// RefreshSeconds is how long an instance keeps the flags before reloading them, 30s by default
+	RefreshSeconds int `yaml:"refresh_seconds" env:"FEATURE_FLAGS_REFRESH_SECONDS"`
+}
+
+// ReportConfig tunes the PDF reports, the zero fields keep the defaults.
+type ReportConfig struct {
+	// FontDir holds DejaVuSans.ttf and DejaVuSans-Bold.ttf, config/fonts by default
+	FontDir string `yaml:"font_dir" env:"REPORT_FONT_DIR"`
+}
+
+// MetricsConfig protects the Prometheus endpoint served on the public port.
+type MetricsConfig struct {
+	// Token is the bearer token the scraper sends to /metrics, the endpoint is not served without one
+	Token string `yaml:"token" env:"METRICS_TOKEN"`
+}
+
 type AppConfig struct {
 	S3                              S3             `yaml:"s3"`
 	Config                          *common.Config `yaml:"config"`
@@ -50,6 +123,18 @@ type AppConfig struct {
 	DefaultCronJobIntervalInMinutes uint8          `env-required:"true" yaml:"default_cron_job_interval_in_minutes" env:"DEFAULT_CRON_JOB_INTERVAL"`
 	SMTP                            SMTPConfig     `yaml:"smtp"`
 	Messaging                       Messaging      `yaml:"messaging"`
+	Realtime                        RealtimeConfig `yaml:"realtime"`
+	// QRLoginTokenExpireDurationInDay is the lifetime of the login tokens printed in the user QR codes, 365 days by default
+	QRLoginTokenExpireDurationInDay int             `yaml:"qr_login_token_expire_duration_in_day" env:"QR_LOGIN_TOKEN_EXPIRE_DURATION_IN_DAY"`
+	RateLimit                       RateLimitConfig `yaml:"rate_limit"`
+	DataLog                         DataLogConfig   `yaml:"data_log"`
+	Health                          HealthConfig    `yaml:"health"`
+	// ShutdownTimeoutSeconds bounds the wait for the background jobs on shutdown, 30s by default
+	ShutdownTimeoutSeconds int               `yaml:"shutdown_timeout_seconds" env:"SHUTDOWN_TIMEOUT_SECONDS"`
+	FeatureFlags           FeatureFlagConfig `yaml:"feature_flags"`
+	Heartbeat              HeartbeatConfig   `yaml:"heartbeat"`
+	Report                 ReportConfig      `yaml:"report"`
+	Metrics                MetricsConfig     `yaml:"metrics"`
 }
 
 // globalAppConfig lưu cấu hình hiện tại của ứng dụng để có thể dùng ở mọi nơi
@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"net/http"
@@ -9,13 +10,30 @@ import (
 	"os/signal"
 	"sen-global-api/config"
 	"sen-global-api/docs"
+	"sen-global-api/internal/data/repository"
 	"sen-global-api/internal/database"
+	senfirebase "sen-global-api/internal/firebase"
 	"sen-global-api/internal/middleware"
 	"sen-global-api/internal/router"
+	"sen-global-api/pkg/appcache"
 	"sen-global-api/pkg/common"
+	"sen-global-api/pkg/featureflag"
+	"sen-global-api/pkg/health"
+	"sen-global-api/pkg/heartbeat"
+	"sen-global-api/pkg/lifecycle"
+	"sen-global-api/pkg/metrics"
 	"sen-global-api/pkg/mysql"
+	"sen-global-api/pkg/qrlogin"
+	"sen-global-api/pkg/ratelimit"
+	"sen-global-api/pkg/realtime"
+	senredis "sen-global-api/pkg/redis"
+	"sen-global-api/pkg/report"
 	"sen-global-api/pkg/sheet"
+	"sen-global-api/pkg/signedtoken"
+	"sen-global-api/pkg/uploader"
+	"sort"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -25,6 +43,8 @@ import (
 	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/consul/api/watch"
 	"github.com/hung-senbox/senbox-cache-service/pkg/redis"
+	goredis "github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
 
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
@@ -47,11 +67,28 @@ func Run(appConfig *config.AppConfig, fcm *firebase.App) error {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	// khoa ky token (QR login, enrollment, form grant) phai init truoc khi migrate QR code cu
+	signedtoken.Init(appConfig.AuthorizeEncryptKey)
+	qrlogin.Init(time.Duration(appConfig.QRLoginTokenExpireDurationInDay) * 24 * time.Hour)
+
+	// font tieng Viet cho bao cao PDF, thieu font thi chi loi khi tao bao cao
+	if err := report.Init(appConfig.Report.FontDir); err != nil {
+		log.Error(fmt.Errorf("app - Run - report.Init: %w", err))
+	}
+
+	// scheduler, worker nen va writer dang ky start/stop hook o day, stop theo thu tu khi shutdown
+	lifecycle.Init(time.Duration(appConfig.ShutdownTimeoutSeconds) * time.Second)
+
 	// 1. Database
 	dbConn, err := mysql.Establish(*appConfig)
 	if err != nil {
 		log.Fatal("Could not connect to database ", err)
 	}
+	if sqlDB, err := dbConn.DB(); err == nil {
+		if err := metrics.RegisterDB("mysql", sqlDB); err != nil {
+			log.Error(fmt.Errorf("app - Run - metrics.RegisterDB: %w", err))
+		}
+	}
 
 	err = database.Seed(dbConn, appConfig.Config, "/internal/database/seed.sql")
 	if err != nil {
@@ -87,13 +124,12 @@ func Run(appConfig *config.AppConfig, fcm *firebase.App) error {
 	}
 
 	setupConsul(client, consulHost, appConfig)
-	go updateHealthCheck(client)
 	usecase.ConsulClient = client
 
 	// 4. Init server & routes
 	handler := gin.New()
 	//handler.Use(middleware.BodyLimit(20<<20), gin.CustomRecovery(middleware.RecoveryHandler), middleware.CORS())
-	handler.Use(gin.CustomRecovery(middleware.RecoveryHandler), middleware.CORS())
+	handler.Use(middleware.RequestID(), middleware.Metrics(), gin.CustomRecovery(middleware.RecoveryHandler), middleware.CORS())
 
 	// cache redis
 	cacheClientRedis, err := redis.InitRedisCache(appConfig.Config.RedisCacheConfig.Host, appConfig.Config.RedisCacheConfig.Port, appConfig.Config.RedisCacheConfig.Password, appConfig.Config.RedisCacheConfig.DB)
@@ -102,11 +138,101 @@ func Run(appConfig *config.AppConfig, fcm *firebase.App) error {
 		return err
 	}
 
+	// realtime push cho device, fan-out qua Redis pub/sub
+	realtimeAdapters := make([]realtime.Adapter, 0)
+	if appConfig.Realtime.FirestoreAdapterEnabled() {
+		realtimeAdapters = append(realtimeAdapters, senfirebase.DeviceSettingsAdapter{
+			SettingRepo: repository.NewOrganizationSettingRepository(dbConn),
+		})
+	}
+	redisClient := senredis.InitRedisCache(appConfig)
+	realtimeHub := realtime.Init(redisClient, realtimeAdapters...)
+	realtimeCtx, stopRealtime := context.WithCancel(ctx)
+	lifecycle.Default().Register(lifecycle.Hook{
+		Name:  "realtime_hub",
+		Phase: lifecycle.PhaseWorker,
+		Start: func(context.Context) error {
+			go realtimeHub.Run(realtimeCtx)
+			return nil
+		},
+		Stop: func(context.Context) error {
+			stopRealtime()
+			return nil
+		},
+	})
+	if err := metrics.RegisterQueue("realtime_subscribers", realtimeHub.Pending); err != nil {
+		log.Error(fmt.Errorf("app - Run - metrics.RegisterQueue: %w", err))
+	}
+
+	// rate limit login, refresh token, device register va password QR, dung chung Redis
+	if !appConfig.RateLimit.Disabled {
+		ratelimit.Init(redisClient, middleware.RateLimitRules(appConfig.RateLimit))
+	}
+
+	// cache doc menu, setting cho app
+	appcache.Init(redisClient)
+
+	// heartbeat cua device ghi vao Redis, rollup vao DB moi phut
+	heartbeat.Init(redisClient)
+
+	// feature flag: doc tu DB (hoac file YAML khi chay local / test), middleware gan evaluator vao moi request
+	var featureFlagProvider featureflag.Provider = &usecase.FeatureFlagUseCase{Repo: repository.NewFeatureFlagRepository(dbConn)}
+	if appConfig.FeatureFlags.File != "" {
+		featureFlagProvider = featureflag.NewFileProvider(appConfig.FeatureFlags.File)
+	}
+	featureflag.Init(featureFlagProvider, time.Duration(appConfig.FeatureFlags.RefreshSeconds)*time.Second)
+	handler.Use(middleware.FeatureFlags(repository.SessionRepository{
+		OrganizationRepository: &repository.OrganizationRepository{DBConn: dbConn},
+		AuthorizeEncryptKey:    appConfig.AuthorizeEncryptKey,
+	}, &repository.DeviceRepository{DBConn: dbConn}))
+
+	// audit log cua request, ghi bat dong bo theo batch
+	dataLogWriter := middleware.InitDataLog(repository.NewDataLogRepository(dbConn), appConfig.DataLog)
+	if err := metrics.RegisterQueue("data_log", dataLogWriter.Depth); err != nil {
+		log.Error(fmt.Errorf("app - Run - metrics.RegisterQueue: %w", err))
+	}
+	// flush sau cung, sau khi worker da ghi xong
+	dataLogCtx, stopDataLog := context.WithCancel(ctx)
+	dataLogDone := make(chan struct{})
+	lifecycle.Default().Register(lifecycle.Hook{
+		Name:  "data_log_writer",
+		Phase: lifecycle.PhaseFlush,
+		Start: func(context.Context) error {
+			go func() {
+				dataLogWriter.Run(dataLogCtx)
+				close(dataLogDone)
+			}()
+			return nil
+		},
+		Stop: func(stopCtx context.Context) error {
+			stopDataLog()
+			select {
+			case <-dataLogDone:
+				return nil
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+		},
+	})
+
+	// readiness: MySQL, Redis va Consul, S3, Google chi warning
+	healthChecker := newHealthChecker(appConfig, dbConn, redisClient, client, userSpreadsheet, uploaderSpreadsheet)
+	go updateHealthCheck(client, healthChecker)
+
 	router.Route(handler, dbConn, userSpreadsheet, uploaderSpreadsheet, *appConfig, fcm, client, cacheClientRedis)
+	lifecycle.Default().Start()
 
 	docs.SwaggerInfo.BasePath = "/"
 	handler.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	handler.GET("/health", healthCheck)
+	handler.GET("/health/live", healthCheck)
+	handler.GET("/health/ready", readinessCheck(healthChecker))
+	// /metrics nam tren cong public nen bat buoc token, khong cau hinh token thi khong mo
+	if appConfig.Metrics.Token != "" {
+		handler.GET("/metrics", middleware.MetricsToken(appConfig.Metrics.Token), gin.WrapH(metrics.Handler()))
+	} else {
+		log.Warn("app - Run - metrics.token is empty, /metrics is not served")
+	}
 
 	httpServer := common.NewServer(handler, common.Port(appConfig.Config.HTTP.Port))
 
@@ -117,40 +243,136 @@ func Run(appConfig *config.AppConfig, fcm *firebase.App) error {
 	select {
 	case s := <-interrupt:
 		log.Info("app - Run - signal: " + s.String())
-		deregisterConsul(client)
+		// bao not-ready cho Consul/LB, doi cac request dang chay truoc khi dung server
+		drain(client, healthChecker, appConfig.Health)
 	case err := <-httpServer.Notify():
 		log.Error(fmt.Errorf("app - Run - httpServer.Notify: %w", err))
-		deregisterConsul(client)
 	}
+	deregisterConsul(client)
 
 	// 6. Shutdown
 	err = httpServer.Shutdown()
 	if err != nil {
 		log.Error(fmt.Errorf("app - Run - httpServer.Shutdown: %w", err))
-		deregisterConsul(client)
 	}
 
+	// scheduler -> worker -> flush, job dang chay duoc doi trong ShutdownTimeoutSeconds
+	lifecycle.Default().Stop()
+
 	return err
 }
 
+// healthCheck la liveness: process con song thi tra 200, khong check dependency
 func healthCheck(c *gin.Context) {
-	c.Status(200)
+	c.JSON(http.StatusOK, gin.H{"status": health.StatusPass})
 }
 
-func updateHealthCheck(client *api.Client) {
+// readinessCheck tra 503 khi MySQL/Redis loi hoac dang shutdown, kem chi tiet tung check
+func readinessCheck(checker *health.Checker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := checker.Run(c.Request.Context())
+		if !report.Ready() {
+			c.JSON(http.StatusServiceUnavailable, report)
+			return
+		}
+		c.JSON(http.StatusOK, report)
+	}
+}
+
+func newHealthChecker(appConfig *config.AppConfig, dbConn *gorm.DB, redisClient *goredis.Client, consulClient *api.Client, userSpreadsheet, uploaderSpreadsheet *sheet.Spreadsheet) *health.Checker {
+	s3Bucket := appConfig.S3.SenboxFormSubmitBucket
+	s3Provider := uploader.NewS3Provider(
+		s3Bucket.AccessKey,
+		s3Bucket.SecretKey,
+		s3Bucket.BucketName,
+		s3Bucket.Region,
+		s3Bucket.Domain,
+		s3Bucket.CloudfrontKeyGroupID,
+		s3Bucket.CloudfrontKeyPath,
+	)
+
+	return health.NewChecker(time.Duration(appConfig.Health.CheckTimeoutSeconds)*time.Second,
+		health.Check{Name: "mysql", Critical: true, Run: func(ctx context.Context) error {
+			sqlDB, err := dbConn.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		}},
+		health.Check{Name: "redis", Critical: true, Run: func(ctx context.Context) error {
+			return redisClient.Ping(ctx).Err()
+		}},
+		health.Check{Name: "consul", Run: func(ctx context.Context) error {
+			leader, err := consulClient.Status().LeaderWithQueryOptions((&api.QueryOptions{}).WithContext(ctx))
+			if err != nil {
+				return err
+			}
+			if leader == "" {
+				return errors.New("consul has no leader")
+			}
+			return nil
+		}},
+		health.Check{Name: "s3", Run: s3Provider.Ping},
+		health.Check{Name: "google_user_credentials", Run: userSpreadsheet.CheckCredentials},
+		health.Check{Name: "google_uploader_credentials", Run: uploaderSpreadsheet.CheckCredentials},
+	)
+}
+
+// updateHealthCheck day ket qua readiness vao TTL check cua Consul, loi Consul chi log, khong kill process
+func updateHealthCheck(client *api.Client, checker *health.Checker) {
 	ticker := time.NewTicker(time.Second * 5)
 
 	for {
-		err := client.Agent().UpdateTTL(checkID, "online", api.HealthPassing)
-		// _, _, err := client.Agent().AgentHealthServiceByID(serviceID)
-		if err != nil {
-			// log.Fatalf("Failed to update health check: %v", err)
-			log.Fatalf("Failed to check AgentHealthService: %v", err)
-		}
+		updateConsulTTL(client, checker.Run(context.Background()))
 		<-ticker.C
 	}
 }
 
+func updateConsulTTL(client *api.Client, report health.Report) {
+	status := api.HealthPassing
+	switch report.Status {
+	case health.StatusWarn:
+		status = api.HealthWarning
+	case health.StatusFail:
+		status = api.HealthCritical
+	}
+
+	output := "online"
+	if report.Draining {
+		output = "draining"
+	}
+	failed := make([]string, 0)
+	for name, result := range report.Checks {
+		if result.Status == health.StatusFail {
+			failed = append(failed, name+": "+result.Error)
+		}
+	}
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		output += "; " + strings.Join(failed, "; ")
+	}
+
+	start := time.Now()
+	err := client.Agent().UpdateTTL(checkID, output, status)
+	metrics.ObserveCall(metrics.ServiceConsul, "update_ttl", start, err)
+	if err != nil {
+		log.Errorf("Failed to update Consul TTL check: %v", err)
+	}
+}
+
+// drain danh dau not-ready va cho LB/Consul ngung gui request moi
+func drain(client *api.Client, checker *health.Checker, cfg config.HealthConfig) {
+	checker.SetDraining()
+	updateConsulTTL(client, checker.Run(context.Background()))
+
+	drainFor := time.Duration(cfg.DrainSeconds) * time.Second
+	if drainFor <= 0 {
+		drainFor = 5 * time.Second
+	}
+	log.Infof("app - Run - draining for %s", drainFor)
+	time.Sleep(drainFor)
+}
+
 func setupConsul(client *api.Client, consulHost string, appConfig *config.AppConfig) {
 	hostname := appConfig.Config.Registry.Host
 	// hostname, _ := os.Hostname()
@@ -220,6 +442,6 @@ func deregisterConsul(client *api.Client) {
 	// Deregister service
 	err := client.Agent().ServiceDeregister(serviceID)
 	if err != nil {
-		log.Fatalf("Failed to deregister service: %v", err)
+		log.Errorf("Failed to deregister service: %v", err)
 	}
 }
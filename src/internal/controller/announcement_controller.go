@@ -0,0 +1,348 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AnnouncementController struct {
+	AnnouncementUseCase *usecase.AnnouncementUseCase
+}
+
+// ---------- audience ----------
+
+func (c *AnnouncementController) GetFeed(ctx *gin.Context) {
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	orgID := ctx.Query("organization_id")
+	if orgID == "" {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:  http.StatusBadRequest,
+			Error: "organization_id is required",
+		})
+		return
+	}
+
+	res, err := c.AnnouncementUseCase.GetFeed(ctx, orgID, userID, ctx.Query("device_id"))
+	if err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, usecase.ErrAnnouncementAccessDenied) {
+			code = http.StatusForbidden
+		}
+		ctx.JSON(code, response.FailedResponse{
+			Code:    code,
+			Message: "Failed to get announcements",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *AnnouncementController) MarkRead(ctx *gin.Context) {
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	var req request.ReadAnnouncementRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+				Code:    http.StatusBadRequest,
+				Message: "Invalid request body",
+				Error:   err.Error(),
+			})
+			return
+		}
+	}
+
+	if err := c.AnnouncementUseCase.MarkRead(ctx, ctx.Param("id"), userID, req); err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, usecase.ErrAnnouncementAccessDenied) {
+			code = http.StatusForbidden
+		}
+		ctx.JSON(code, response.FailedResponse{
+			Code:    code,
+			Message: "Failed to mark announcement as read",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Announcement marked as read",
+	})
+}
+
+// ---------- manage (organization manager) ----------
+
+func (c *AnnouncementController) GetByOrganization(ctx *gin.Context) {
+	c.getByOrganization(ctx, false)
+}
+
+func (c *AnnouncementController) Create(ctx *gin.Context) {
+	c.create(ctx, false)
+}
+
+func (c *AnnouncementController) GetByID(ctx *gin.Context) {
+	c.getByID(ctx, false)
+}
+
+func (c *AnnouncementController) Update(ctx *gin.Context) {
+	c.update(ctx, false)
+}
+
+func (c *AnnouncementController) Delete(ctx *gin.Context) {
+	c.delete(ctx, false)
+}
+
+func (c *AnnouncementController) GetReads(ctx *gin.Context) {
+	c.getReads(ctx, false)
+}
+
+// ---------- manage (admin) ----------
+
+func (c *AnnouncementController) GetByOrganization4Admin(ctx *gin.Context) {
+	c.getByOrganization(ctx, true)
+}
+
+func (c *AnnouncementController) Create4Admin(ctx *gin.Context) {
+	c.create(ctx, true)
+}
+
+func (c *AnnouncementController) GetByID4Admin(ctx *gin.Context) {
+	c.getByID(ctx, true)
+}
+
+func (c *AnnouncementController) Update4Admin(ctx *gin.Context) {
+	c.update(ctx, true)
+}
+
+func (c *AnnouncementController) Delete4Admin(ctx *gin.Context) {
+	c.delete(ctx, true)
+}
+
+func (c *AnnouncementController) GetReads4Admin(ctx *gin.Context) {
+	c.getReads(ctx, true)
+}
+
+func (c *AnnouncementController) getByOrganization(ctx *gin.Context, asAdmin bool) {
+	orgID := ctx.Query("organization_id")
+	if orgID == "" {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:  http.StatusBadRequest,
+			Error: "organization_id is required",
+		})
+		return
+	}
+	if !c.authorizeOrganization(ctx, orgID, asAdmin) {
+		return
+	}
+
+	res, err := c.AnnouncementUseCase.GetByOrganization(orgID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get announcements",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *AnnouncementController) create(ctx *gin.Context, asAdmin bool) {
+	var req request.SaveAnnouncementRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if !c.authorizeOrganization(ctx, req.OrganizationID, asAdmin) {
+		return
+	}
+
+	userID, _ := getUserID(ctx)
+	res, err := c.AnnouncementUseCase.Create(req, userID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to create announcement",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Announcement created successfully",
+		Data:    res,
+	})
+}
+
+func (c *AnnouncementController) getByID(ctx *gin.Context, asAdmin bool) {
+	if !c.authorizeAnnouncement(ctx, asAdmin) {
+		return
+	}
+
+	res, err := c.AnnouncementUseCase.GetByID(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, response.FailedResponse{
+			Code:    http.StatusNotFound,
+			Message: "Failed to get announcement",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *AnnouncementController) update(ctx *gin.Context, asAdmin bool) {
+	var req request.SaveAnnouncementRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if !c.authorizeAnnouncement(ctx, asAdmin) {
+		return
+	}
+
+	res, err := c.AnnouncementUseCase.Update(ctx.Param("id"), req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to update announcement",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Announcement updated successfully",
+		Data:    res,
+	})
+}
+
+func (c *AnnouncementController) delete(ctx *gin.Context, asAdmin bool) {
+	if !c.authorizeAnnouncement(ctx, asAdmin) {
+		return
+	}
+
+	if err := c.AnnouncementUseCase.Delete(ctx.Param("id")); err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to delete announcement",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Announcement deleted successfully",
+	})
+}
+
+func (c *AnnouncementController) getReads(ctx *gin.Context, asAdmin bool) {
+	if !c.authorizeAnnouncement(ctx, asAdmin) {
+		return
+	}
+
+	res, err := c.AnnouncementUseCase.GetReads(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to get read receipts",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+// authorizeOrganization lets admins through, other users must manage the organization.
+func (c *AnnouncementController) authorizeOrganization(ctx *gin.Context, orgID string, asAdmin bool) bool {
+	if asAdmin {
+		return true
+	}
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return false
+	}
+	if !c.AnnouncementUseCase.CanManage(orgID, userID) {
+		ctx.JSON(http.StatusForbidden, response.FailedResponse{
+			Code:  http.StatusForbidden,
+			Error: "Only organization managers can manage announcements",
+		})
+		return false
+	}
+	return true
+}
+
+func (c *AnnouncementController) authorizeAnnouncement(ctx *gin.Context, asAdmin bool) bool {
+	if asAdmin {
+		return true
+	}
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return false
+	}
+	if !c.AnnouncementUseCase.CanManageAnnouncement(ctx.Param("id"), userID) {
+		ctx.JSON(http.StatusForbidden, response.FailedResponse{
+			Code:  http.StatusForbidden,
+			Error: "Only organization managers can manage announcements",
+		})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,198 @@
+package controller
+
+import (
+	"net/http"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/usecase"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AppReleaseController struct {
+	AppReleaseUseCase *usecase.AppReleaseUseCase
+}
+
+// CheckVersion is called by the devices on startup: ok, update_available or update_required.
+func (c *AppReleaseController) CheckVersion(ctx *gin.Context) {
+	var req request.CheckAppVersionRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid query",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	tokenDeviceID, _ := getDeviceID(ctx)
+	res, err := c.AppReleaseUseCase.Check(req, tokenDeviceID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to check app version",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *AppReleaseController) GetReleases4Admin(ctx *gin.Context) {
+	var req request.GetAppReleasesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid query",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.AppReleaseUseCase.List(req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get app releases",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *AppReleaseController) CreateRelease4Admin(ctx *gin.Context) {
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	var req request.CreateAppReleaseRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.AppReleaseUseCase.Create(req, userID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to create app release",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "App release created successfully",
+		Data:    res,
+	})
+}
+
+func (c *AppReleaseController) UpdateRelease4Admin(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:  http.StatusBadRequest,
+			Error: err.Error(),
+		})
+		return
+	}
+
+	var req request.UpdateAppReleaseRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.AppReleaseUseCase.Update(uint(id), req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to update app release",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "App release updated successfully",
+		Data:    res,
+	})
+}
+
+func (c *AppReleaseController) DeleteRelease4Admin(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:  http.StatusBadRequest,
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := c.AppReleaseUseCase.Delete(uint(id)); err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to delete app release",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "App release deleted successfully",
+	})
+}
+
+// GetVersionDistribution4Admin reports the app versions of the devices per organization.
+func (c *AppReleaseController) GetVersionDistribution4Admin(ctx *gin.Context) {
+	var req request.GetAppVersionDistributionRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid query",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.AppReleaseUseCase.GetVersionDistribution(req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get app version distribution",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
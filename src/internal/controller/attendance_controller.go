@@ -0,0 +1,278 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AttendanceController struct {
+	AttendanceUseCase *usecase.AttendanceUseCase
+}
+
+func (c *AttendanceController) CheckInOut(ctx *gin.Context) {
+	var req request.AttendanceCheckRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	if !c.AttendanceUseCase.CanRecord(userID, req.OrganizationID) {
+		ctx.JSON(http.StatusForbidden, response.FailedResponse{
+			Code:    http.StatusForbidden,
+			Message: "Access Denied",
+			Error:   "only the teachers and managers of the organization can record its attendance",
+		})
+		return
+	}
+
+	res, err := c.AttendanceUseCase.CheckInOut(req, userID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to record attendance",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *AttendanceController) ManualRecord(ctx *gin.Context) {
+	var req request.ManualAttendanceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	if !c.AttendanceUseCase.CanRecord(userID, req.OrganizationID) {
+		ctx.JSON(http.StatusForbidden, response.FailedResponse{
+			Code:    http.StatusForbidden,
+			Message: "Access Denied",
+			Error:   "only the teachers and managers of the organization can record its attendance",
+		})
+		return
+	}
+
+	res, err := c.AttendanceUseCase.ManualRecord(req, userID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to record attendance",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *AttendanceController) GetDailySheet(ctx *gin.Context) {
+	c.getDailySheet(ctx, false)
+}
+
+func (c *AttendanceController) GetDailySheet4Admin(ctx *gin.Context) {
+	c.getDailySheet(ctx, true)
+}
+
+func (c *AttendanceController) getDailySheet(ctx *gin.Context, asAdmin bool) {
+	orgID := ctx.Query("organization_id")
+	if orgID == "" {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:  http.StatusBadRequest,
+			Error: "organization_id is required",
+		})
+		return
+	}
+
+	if !asAdmin {
+		userID, ok := getUserID(ctx)
+		if !ok {
+			ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+				Code:  http.StatusUnauthorized,
+				Error: "Unauthorized: invalid user_id",
+			})
+			return
+		}
+		if !c.AttendanceUseCase.CanRecord(userID, orgID) {
+			ctx.JSON(http.StatusForbidden, response.FailedResponse{
+				Code:    http.StatusForbidden,
+				Message: "Access Denied",
+				Error:   "only the teachers and managers of the organization can see its attendance",
+			})
+			return
+		}
+	}
+
+	date := ctx.DefaultQuery("date", time.Now().Format("2006-01-02"))
+
+	res, err := c.AttendanceUseCase.GetDailySheet(orgID, date, ctx.Query("department_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to get attendance sheet",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+// GetStudentMonth is used by guardians to follow the attendance of their child.
+func (c *AttendanceController) GetStudentMonth(ctx *gin.Context) {
+	studentID := ctx.Param("student_id")
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	if !c.AttendanceUseCase.CanViewStudent(studentID, userID) {
+		ctx.JSON(http.StatusForbidden, response.FailedResponse{
+			Code:    http.StatusForbidden,
+			Message: "Access Denied",
+		})
+		return
+	}
+
+	c.getStudentMonth(ctx, studentID)
+}
+
+func (c *AttendanceController) GetStudentMonth4Admin(ctx *gin.Context) {
+	c.getStudentMonth(ctx, ctx.Param("student_id"))
+}
+
+func (c *AttendanceController) getStudentMonth(ctx *gin.Context, studentID string) {
+	month := ctx.DefaultQuery("month", time.Now().Format("2006-01"))
+
+	res, err := c.AttendanceUseCase.GetStudentMonth(studentID, month)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to get attendance",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *AttendanceController) GetSetting(ctx *gin.Context) {
+	res, err := c.AttendanceUseCase.GetSetting(ctx.Param("organization_id"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get attendance setting",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *AttendanceController) UpdateSetting(ctx *gin.Context) {
+	var req request.UpdateAttendanceSettingRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.AttendanceUseCase.UpdateSetting(req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to update attendance setting",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Attendance setting updated successfully",
+		Data:    res,
+	})
+}
+
+func (c *AttendanceController) ExportMonthlyReport(ctx *gin.Context) {
+	orgID := ctx.Query("organization_id")
+	if orgID == "" {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:  http.StatusBadRequest,
+			Error: "organization_id is required",
+		})
+		return
+	}
+
+	month := ctx.DefaultQuery("month", time.Now().Format("2006-01"))
+
+	content, fileName, err := c.AttendanceUseCase.ExportMonthlyReport(orgID, month)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to export attendance report",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	ctx.Data(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", content)
+}
@@ -0,0 +1,323 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BookingController struct {
+	BookingUseCase *usecase.BookingUseCase
+}
+
+// ---------- resource (admin) ----------
+
+func (c *BookingController) CreateResource(ctx *gin.Context) {
+	var req request.CreateBookableResourceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.BookingUseCase.CreateResource(req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to create resource",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Resource created successfully",
+		Data:    res,
+	})
+}
+
+func (c *BookingController) UpdateResource(ctx *gin.Context) {
+	var req request.UpdateBookableResourceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.BookingUseCase.UpdateResource(req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to update resource",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Resource updated successfully",
+		Data:    res,
+	})
+}
+
+func (c *BookingController) DeleteResource(ctx *gin.Context) {
+	if err := c.BookingUseCase.DeleteResource(ctx.Param("id")); err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to delete resource",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Resource deleted successfully",
+	})
+}
+
+func (c *BookingController) ReplaceRules(ctx *gin.Context) {
+	var req request.UpdateBookingRulesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.BookingUseCase.ReplaceRules(ctx.Param("id"), req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to update availability rules",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Availability rules updated successfully",
+		Data:    res,
+	})
+}
+
+func (c *BookingController) GetReservationsByResource(ctx *gin.Context) {
+	today := time.Now().Format("2006-01-02")
+	res, err := c.BookingUseCase.GetReservationsByResource(
+		ctx.Param("id"),
+		ctx.DefaultQuery("from", today),
+		ctx.DefaultQuery("to", today),
+	)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to get reservations",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *BookingController) CancelReservation4Admin(ctx *gin.Context) {
+	c.cancelReservation(ctx, true)
+}
+
+// ---------- resource / slots ----------
+
+func (c *BookingController) GetResources(ctx *gin.Context) {
+	res, err := c.BookingUseCase.GetResources(ctx.Query("organization_id"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get resources",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *BookingController) GetResource(ctx *gin.Context) {
+	res, err := c.BookingUseCase.GetResource(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, response.FailedResponse{
+			Code:    http.StatusNotFound,
+			Message: "Failed to get resource",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *BookingController) GetSlots(ctx *gin.Context) {
+	from, to := slotRange(ctx)
+	res, err := c.BookingUseCase.GetSlots(ctx.Param("id"), from, to)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to get slots",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+// GetSlotsByQuestion is called by the CalendarBooking form component to show the live slots.
+func (c *BookingController) GetSlotsByQuestion(ctx *gin.Context) {
+	from, to := slotRange(ctx)
+	res, err := c.BookingUseCase.GetSlotsByQuestion(ctx.Param("question_id"), from, to)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to get slots",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+// ---------- reservation ----------
+
+func (c *BookingController) Reserve(ctx *gin.Context) {
+	var req request.CreateBookingReservationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	res, err := c.BookingUseCase.Reserve(req, userID)
+	if err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, usecase.ErrBookingSlotUnavailable) {
+			code = http.StatusConflict
+		}
+		ctx.JSON(code, response.FailedResponse{
+			Code:    code,
+			Message: "Failed to book slot",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Slot booked successfully",
+		Data:    res,
+	})
+}
+
+func (c *BookingController) GetMyReservations(ctx *gin.Context) {
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	res, err := c.BookingUseCase.GetMyReservations(userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get reservations",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *BookingController) CancelReservation(ctx *gin.Context) {
+	c.cancelReservation(ctx, false)
+}
+
+func (c *BookingController) cancelReservation(ctx *gin.Context, asAdmin bool) {
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	if err := c.BookingUseCase.Cancel(ctx.Param("id"), userID, asAdmin); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to cancel reservation",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Reservation cancelled successfully",
+	})
+}
+
+// slotRange reads the from/to query dates, defaulting to the next 7 days.
+func slotRange(ctx *gin.Context) (string, string) {
+	now := time.Now()
+	from := ctx.DefaultQuery("from", now.Format("2006-01-02"))
+	to := ctx.DefaultQuery("to", now.AddDate(0, 0, 6).Format("2006-01-02"))
+	return from, to
+}
@@ -0,0 +1,265 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ChildPrivacyController struct {
+	ChildPrivacyUseCase *usecase.ChildPrivacyUseCase
+}
+
+func (c *ChildPrivacyController) GetConsent(ctx *gin.Context) {
+	childID := ctx.Param("id")
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	if !c.ChildPrivacyUseCase.IsGuardian(childID, userID) {
+		ctx.JSON(http.StatusForbidden, response.FailedResponse{
+			Code:    http.StatusForbidden,
+			Message: "Access Denied",
+		})
+		return
+	}
+
+	c.getConsent(ctx, childID)
+}
+
+func (c *ChildPrivacyController) GetConsent4Admin(ctx *gin.Context) {
+	c.getConsent(ctx, ctx.Param("id"))
+}
+
+func (c *ChildPrivacyController) getConsent(ctx *gin.Context, childID string) {
+	consent, err := c.ChildPrivacyUseCase.GetConsent(childID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get consent",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: consent,
+	})
+}
+
+func (c *ChildPrivacyController) UpdateConsent(ctx *gin.Context) {
+	var req request.UpdateChildConsentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	if err := c.ChildPrivacyUseCase.UpdateConsent(ctx.Param("id"), userID, req); err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrChildPrivacyAccessDenied) {
+			code = http.StatusForbidden
+		}
+		ctx.JSON(code, response.FailedResponse{
+			Code:    code,
+			Message: "Failed to update consent",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Consent updated successfully",
+	})
+}
+
+func (c *ChildPrivacyController) RequestExport(ctx *gin.Context) {
+	c.requestExport(ctx, false)
+}
+
+func (c *ChildPrivacyController) RequestExport4Admin(ctx *gin.Context) {
+	c.requestExport(ctx, true)
+}
+
+func (c *ChildPrivacyController) requestExport(ctx *gin.Context, asAdmin bool) {
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	res, err := c.ChildPrivacyUseCase.RequestExport(ctx.Param("id"), userID, asAdmin)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrChildPrivacyAccessDenied) {
+			code = http.StatusForbidden
+		}
+		ctx.JSON(code, response.FailedResponse{
+			Code:    code,
+			Message: "Failed to request data export",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, response.SucceedResponse{
+		Code:    http.StatusAccepted,
+		Message: "Data export requested",
+		Data:    res,
+	})
+}
+
+func (c *ChildPrivacyController) RequestErasure(ctx *gin.Context) {
+	c.requestErasure(ctx, false)
+}
+
+func (c *ChildPrivacyController) RequestErasure4Admin(ctx *gin.Context) {
+	c.requestErasure(ctx, true)
+}
+
+func (c *ChildPrivacyController) requestErasure(ctx *gin.Context, asAdmin bool) {
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	res, err := c.ChildPrivacyUseCase.RequestErasure(ctx.Param("id"), userID, asAdmin)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrChildPrivacyAccessDenied) {
+			code = http.StatusForbidden
+		}
+		ctx.JSON(code, response.FailedResponse{
+			Code:    code,
+			Message: "Failed to request data erasure",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, response.SucceedResponse{
+		Code:    http.StatusAccepted,
+		Message: "Data erasure requested",
+		Data:    res,
+	})
+}
+
+func (c *ChildPrivacyController) GetRequestsByChild(ctx *gin.Context) {
+	childID := ctx.Param("id")
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	if !c.ChildPrivacyUseCase.IsGuardian(childID, userID) {
+		ctx.JSON(http.StatusForbidden, response.FailedResponse{
+			Code:    http.StatusForbidden,
+			Message: "Access Denied",
+		})
+		return
+	}
+
+	res, err := c.ChildPrivacyUseCase.GetRequestsByChild(childID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get data requests",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *ChildPrivacyController) GetAllRequests4Admin(ctx *gin.Context) {
+	res, err := c.ChildPrivacyUseCase.GetAllRequests()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get data requests",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *ChildPrivacyController) DownloadExport(ctx *gin.Context) {
+	c.downloadExport(ctx, false)
+}
+
+func (c *ChildPrivacyController) DownloadExport4Admin(ctx *gin.Context) {
+	c.downloadExport(ctx, true)
+}
+
+func (c *ChildPrivacyController) downloadExport(ctx *gin.Context, asAdmin bool) {
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	url, err := c.ChildPrivacyUseCase.GetExportURL(ctx.Param("request_id"), userID, asAdmin)
+	if err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, usecase.ErrChildPrivacyAccessDenied) {
+			code = http.StatusForbidden
+		}
+		ctx.JSON(code, response.FailedResponse{
+			Code:    code,
+			Message: "Failed to get export",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: url,
+	})
+}
@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"net/http"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DataLogController struct {
+	DataLogUseCase *usecase.DataLogUseCase
+}
+
+func (c *DataLogController) GetDataLogs4Admin(ctx *gin.Context) {
+	var req request.SearchDataLogRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid query",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.DataLogUseCase.Search(req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to get data logs",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"net/http"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeviceCommandController struct {
+	DeviceCommandUseCase *usecase.DeviceCommandUseCase
+}
+
+func (c *DeviceCommandController) CreateCommand(ctx *gin.Context) {
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	var req request.CreateDeviceCommandRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.DeviceCommandUseCase.Enqueue(req, userID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to queue device command",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Device command queued successfully",
+		Data:    res,
+	})
+}
+
+func (c *DeviceCommandController) GetCommand(ctx *gin.Context) {
+	res, err := c.DeviceCommandUseCase.GetCommand(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, response.FailedResponse{
+			Code:    http.StatusNotFound,
+			Message: "Failed to get device command",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *DeviceCommandController) GetHistory(ctx *gin.Context) {
+	var req request.GetDeviceCommandHistoryRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid query",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.DeviceCommandUseCase.GetHistory(ctx.Param("device_id"), req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get device commands",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+// Poll is called by the devices to get their pending commands.
+func (c *DeviceCommandController) Poll(ctx *gin.Context) {
+	var req request.GetDeviceCommandsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid query",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	deviceID, ok := tokenDevice(ctx, req.DeviceID)
+	if !ok {
+		return
+	}
+
+	res, err := c.DeviceCommandUseCase.Poll(deviceID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get device commands",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+// Acknowledge is called by the devices with the result of a command.
+func (c *DeviceCommandController) Acknowledge(ctx *gin.Context) {
+	var req request.AckDeviceCommandRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	deviceID, ok := tokenDevice(ctx, req.DeviceID)
+	if !ok {
+		return
+	}
+	req.DeviceID = deviceID
+
+	res, err := c.DeviceCommandUseCase.Acknowledge(ctx.Param("id"), req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to acknowledge device command",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
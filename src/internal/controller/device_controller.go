@@ -14,7 +14,6 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"github.com/samber/lo"
 	"github.com/tiendc/gofn"
 	"gorm.io/gorm"
@@ -49,6 +48,8 @@ type DeviceController struct {
 	*usecase.ChildUseCase
 	*usecase.DeviceUsecase
 	*usecase.ValuesAppCurrentUseCase
+	*usecase.ChildPrivacyUseCase
+	FormPasswordUseCase *usecase.VerifyFormPasswordUseCase
 }
 
 func (receiver *DeviceController) GetDeviceByID(c *gin.Context) {
@@ -586,6 +587,7 @@ func (receiver *DeviceController) TakeNote(context *gin.Context) {
 // @Param req body request.SubmitFormRequest true "Send Email Params"
 // @Success      200  {object}  response.SucceedResponse
 // @Failure      400  {object}  response.FailedResponse
+// @Failure      403  {object}  response.FailedResponse
 // @Failure      404  {object}  response.FailedResponse
 // @Failure      500  {object}  response.FailedResponse
 // @Router       /v1/form/submit [post]
@@ -608,6 +610,10 @@ func (receiver *DeviceController) SubmitForm(context *gin.Context) {
 		return
 	}
 
+	if !checkFormAccess(context, receiver.FormPasswordUseCase, *form, req.FormGrant, req.FormPassword) {
+		return
+	}
+
 	// Lấy user từ token
 	user, err := receiver.GetUserFromToken(context)
 	if err != nil {
@@ -629,27 +635,48 @@ func (receiver *DeviceController) SubmitForm(context *gin.Context) {
 			return
 		}
 
-		// Nếu user hiện tại KHÔNG phải parent và cũng KHÔNG phải super admin → cấm
+		// Nếu user hiện tại KHÔNG phải guardian của child và cũng KHÔNG phải super admin → cấm
 		isSuperAdmin := lo.ContainsBy(user.Roles, func(role entity.SRole) bool {
 			return role.Role == entity.SuperAdmin
 		})
 
-		if !isSuperAdmin && user.ID.String() != parentID {
-			context.JSON(http.StatusUnauthorized, response.FailedResponse{
-				Code:  http.StatusUnauthorized,
-				Error: "Unauthorized: only parent or super admin can submit form for child",
-			})
-			return
+		if !isSuperAdmin {
+			isGuardian, err := receiver.IsParentOfChild(user.ID.String(), *req.ChildID)
+			if err != nil {
+				context.JSON(http.StatusInternalServerError, response.FailedResponse{
+					Code:  http.StatusInternalServerError,
+					Error: "Failed to check guardian permission: " + err.Error(),
+				})
+				return
+			}
+			if !isGuardian {
+				context.JSON(http.StatusForbidden, response.FailedResponse{
+					Code:  http.StatusForbidden,
+					Error: "Access denied: only a guardian or super admin can submit form for child",
+				})
+				return
+			}
 		}
 
 		req.UserID = parentID // Ưu tiên parentID
+
+		// Chỉ lưu submission cho child khi phụ huynh đã đồng ý chia sẻ dữ liệu
+		if receiver.ChildPrivacyUseCase != nil {
+			if err := receiver.CheckDataSharingConsent(*req.ChildID); err != nil {
+				context.JSON(http.StatusForbidden, response.FailedResponse{
+					Code:  http.StatusForbidden,
+					Error: err.Error(),
+				})
+				return
+			}
+		}
 	} else {
 		// Không có childID → lấy user từ token
 		req.UserID = user.ID.String()
 	}
 
 	// Gửi câu trả lời form
-	err = receiver.AnswerForm(form.ID, req)
+	score, err := receiver.AnswerForm(context.Request.Context(), form.ID, req)
 	if err != nil {
 		context.JSON(http.StatusNotAcceptable, response.FailedResponse{
 			Code:  http.StatusNotAcceptable,
@@ -661,6 +688,7 @@ func (receiver *DeviceController) SubmitForm(context *gin.Context) {
 	context.JSON(http.StatusOK, response.SucceedResponse{
 		Code:    http.StatusOK,
 		Message: "Succeed",
+		Data:    score,
 	})
 }
 
@@ -1274,13 +1302,23 @@ func (receiver *DeviceController) GetSubmissionByCondition(context *gin.Context)
 			return
 		}
 
-		// Nếu không phải super admin và cũng không phải chính parent thì từ chối
-		if !isSuperAdmin && user.ID != uuid.MustParse(parentID) {
-			context.JSON(http.StatusUnauthorized, response.FailedResponse{
-				Code:  http.StatusUnauthorized,
-				Error: "Unauthorized: only super admin or child's parent can access",
-			})
-			return
+		// Nếu không phải super admin thì phải là guardian được phép xem submission của child
+		if !isSuperAdmin {
+			canView, err := receiver.ChildUseCase.CanViewSubmissions(user.ID.String(), *req.ChildID)
+			if err != nil {
+				context.JSON(http.StatusInternalServerError, response.FailedResponse{
+					Code:  http.StatusInternalServerError,
+					Error: "Failed to check guardian permission: " + err.Error(),
+				})
+				return
+			}
+			if !canView {
+				context.JSON(http.StatusForbidden, response.FailedResponse{
+					Code:  http.StatusForbidden,
+					Error: "Access denied: only super admin or a guardian allowed to view submissions can access",
+				})
+				return
+			}
 		}
 
 		// Gán parentID làm userID thực thi
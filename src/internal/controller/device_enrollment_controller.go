@@ -0,0 +1,231 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeviceEnrollmentController struct {
+	DeviceEnrollmentUseCase *usecase.DeviceEnrollmentUseCase
+}
+
+// Enroll is called by a fresh device after scanning the enrollment QR code of an organization.
+func (c *DeviceEnrollmentController) Enroll(ctx *gin.Context) {
+	var req request.EnrollDeviceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	tokenDeviceID, _ := getDeviceID(ctx)
+	res, err := c.DeviceEnrollmentUseCase.Enroll(ctx.Request.Context(), req, tokenDeviceID)
+	if err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, usecase.ErrDeviceEnrolledElsewhere) {
+			code = http.StatusForbidden
+		}
+		ctx.JSON(code, response.FailedResponse{
+			Code:    code,
+			Message: "Failed to enroll device",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Device enrolled successfully",
+		Data:    res,
+	})
+}
+
+func (c *DeviceEnrollmentController) CreateCode(ctx *gin.Context) {
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	var req request.CreateDeviceEnrollmentCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.DeviceEnrollmentUseCase.CreateCode(req, userID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to create enrollment code",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Enrollment code created successfully",
+		Data:    res,
+	})
+}
+
+func (c *DeviceEnrollmentController) GetCodes(ctx *gin.Context) {
+	var req request.GetDeviceEnrollmentCodesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid query",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.DeviceEnrollmentUseCase.GetCodes(req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get enrollment codes",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *DeviceEnrollmentController) GetCode(ctx *gin.Context) {
+	res, err := c.DeviceEnrollmentUseCase.GetCode(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, response.FailedResponse{
+			Code:    http.StatusNotFound,
+			Message: "Failed to get enrollment code",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *DeviceEnrollmentController) RevokeCode(ctx *gin.Context) {
+	res, err := c.DeviceEnrollmentUseCase.RevokeCode(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to revoke enrollment code",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Enrollment code revoked successfully",
+		Data:    res,
+	})
+}
+
+func (c *DeviceEnrollmentController) GetLimits(ctx *gin.Context) {
+	var req request.GetDeviceLimitsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid query",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.DeviceEnrollmentUseCase.GetLimits(req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get device limits",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *DeviceEnrollmentController) SaveLimit(ctx *gin.Context) {
+	userID, _ := getUserID(ctx)
+
+	var req request.SaveDeviceLimitRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.DeviceEnrollmentUseCase.SaveLimit(req, userID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to save device limit",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Device limit saved successfully",
+		Data:    res,
+	})
+}
+
+func (c *DeviceEnrollmentController) DeleteLimit(ctx *gin.Context) {
+	var req request.DeleteDeviceLimitRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid query",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if err := c.DeviceEnrollmentUseCase.DeleteLimit(req); err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to delete device limit",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Device limit deleted successfully",
+	})
+}
@@ -0,0 +1,165 @@
+package controller
+
+import (
+	"net/http"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeviceHeartbeatController struct {
+	DeviceHeartbeatUseCase *usecase.DeviceHeartbeatUseCase
+}
+
+// Heartbeat is called by the devices every interval_seconds of the response.
+func (c *DeviceHeartbeatController) Heartbeat(ctx *gin.Context) {
+	var req request.DeviceHeartbeatRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	deviceID, ok := tokenDevice(ctx, req.DeviceID)
+	if !ok {
+		return
+	}
+	req.DeviceID = deviceID
+
+	res, err := c.DeviceHeartbeatUseCase.Record(ctx.Request.Context(), req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to record heartbeat",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+// GetFleet is the fleet view of an organization for its managers.
+func (c *DeviceHeartbeatController) GetFleet(ctx *gin.Context) {
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	orgID := ctx.Param("organization_id")
+	if !c.DeviceHeartbeatUseCase.IsManager(userID, orgID) {
+		ctx.JSON(http.StatusForbidden, response.FailedResponse{
+			Code:    http.StatusForbidden,
+			Message: "Access Denied",
+			Error:   "only the managers of the organization can see its devices",
+		})
+		return
+	}
+
+	c.getFleet(ctx, orgID)
+}
+
+func (c *DeviceHeartbeatController) GetFleet4Admin(ctx *gin.Context) {
+	c.getFleet(ctx, ctx.Param("organization_id"))
+}
+
+func (c *DeviceHeartbeatController) getFleet(ctx *gin.Context, orgID string) {
+	res, err := c.DeviceHeartbeatUseCase.GetFleet(ctx.Request.Context(), orgID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get device fleet",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *DeviceHeartbeatController) GetAlertSetting(ctx *gin.Context) {
+	res, err := c.DeviceHeartbeatUseCase.GetAlertSetting(ctx.Param("organization_id"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get device alert setting",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *DeviceHeartbeatController) UpdateAlertSetting(ctx *gin.Context) {
+	var req request.UpdateDeviceAlertSettingRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.DeviceHeartbeatUseCase.UpdateAlertSetting(req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to update device alert setting",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Device alert setting updated successfully",
+		Data:    res,
+	})
+}
+
+func (c *DeviceHeartbeatController) GetAlerts(ctx *gin.Context) {
+	var req request.GetDeviceOfflineAlertsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid query",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.DeviceHeartbeatUseCase.GetAlerts(req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get device offline alerts",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"net/http"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeviceModeProfileController struct {
+	DeviceModeProfileUseCase *usecase.DeviceModeProfileUseCase
+}
+
+func (c *DeviceModeProfileController) GetProfiles(ctx *gin.Context) {
+	var req request.GetDeviceModeProfilesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid query",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.DeviceModeProfileUseCase.GetProfiles(req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get device mode profiles",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *DeviceModeProfileController) SaveProfile(ctx *gin.Context) {
+	userID, _ := getUserID(ctx)
+
+	var req request.SaveDeviceModeProfileRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.DeviceModeProfileUseCase.SaveProfile(ctx.Request.Context(), req, userID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to save device mode profile",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Device mode profile saved successfully",
+		Data:    res,
+	})
+}
+
+func (c *DeviceModeProfileController) DeleteProfile(ctx *gin.Context) {
+	if err := c.DeviceModeProfileUseCase.DeleteProfile(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to delete device mode profile",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Device mode profile deleted successfully",
+	})
+}
+
+// GetDeviceProfile returns the profile applied to a device, null when its mode has no profile.
+func (c *DeviceModeProfileController) GetDeviceProfile(ctx *gin.Context) {
+	res, err := c.DeviceModeProfileUseCase.GetDeviceProfile(ctx.Request.Context(), ctx.Param("device_id"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, response.FailedResponse{
+			Code:    http.StatusNotFound,
+			Message: "Failed to get device mode profile",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
@@ -55,7 +55,7 @@ func (receiver *FormController) CreateForm(context *gin.Context) {
 	context.JSON(http.StatusOK, response.SaveFormResponse{Data: response.SaveFormResponseData{
 		ID:          form.ID,
 		Spreadsheet: form.SpreadsheetUrl,
-		Password:    form.Password,
+		HasPassword: form.PasswordHash != "",
 		Note:        form.Note,
 		CreatedAt:   form.CreatedAt,
 		UpdatedAt:   form.UpdatedAt,
@@ -185,7 +185,7 @@ func (receiver *FormController) UpdateForm(context *gin.Context) {
 		Data: response.GetFormListResponseData{
 			ID:          form.ID,
 			Spreadsheet: form.SpreadsheetUrl,
-			Password:    form.Password,
+			HasPassword: form.PasswordHash != "",
 			Note:        form.Note,
 			CreatedAt:   form.CreatedAt,
 			UpdatedAt:   form.UpdatedAt,
@@ -313,7 +313,7 @@ func (receiver *FormController) importForms(context *gin.Context, index usecase.
 		})
 		return
 	}
-	err := receiver.ImportFormsUseCase.ImportForms(req, index)
+	err := receiver.ImportFormsUseCase.WithContext(context.Request.Context()).ImportForms(req, index)
 	if err != nil {
 		context.JSON(http.StatusInternalServerError, response.FailedResponse{
 			Code:  http.StatusInternalServerError,
@@ -356,7 +356,7 @@ func (receiver *FormController) ImportFormsPartially(context *gin.Context) {
 		return
 	}
 
-	err := receiver.ImportFormsUseCase.ImportFormsPartially(req.SpreadsheetURL, req.TabName)
+	err := receiver.ImportFormsUseCase.WithContext(context.Request.Context()).ImportFormsPartially(req.SpreadsheetURL, req.TabName)
 	if err != nil {
 		context.JSON(http.StatusInternalServerError, response.FailedResponse{
 			Code:  http.StatusInternalServerError,
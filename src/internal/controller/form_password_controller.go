@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FormPasswordController struct {
+	*usecase.VerifyFormPasswordUseCase
+}
+
+// VerifyFormPassword godoc
+// @Summary Verify the password of a form
+// @Description Checks the password of a protected form and returns a grant to send as form_grant when getting
+// @Description the questions and submitting the form. The form is locked for a while after too many wrong passwords.
+// @Tags Form
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param request body request.VerifyFormPasswordRequest true "Form and password"
+// @Success 200 {object} response.SucceedResponse{data=response.FormGrantResponse}
+// @Failure 400 {object} response.FailedResponse
+// @Failure 401 {object} response.FailedResponse
+// @Failure 429 {object} response.FailedResponse
+// @Router /v1/form/verify-password [post]
+func (receiver *FormPasswordController) VerifyFormPassword(context *gin.Context) {
+	var req request.VerifyFormPasswordRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:  http.StatusBadRequest,
+			Error: err.Error(),
+		})
+		return
+	}
+
+	res, err := receiver.Verify(req)
+	if err != nil {
+		status := http.StatusBadRequest
+		var locked *usecase.PasswordLockedError
+		if errors.As(err, &locked) {
+			setRetryAfter(context, locked.Until)
+			status = http.StatusTooManyRequests
+		} else if errors.Is(err, usecase.ErrWrongPassword) {
+			status = http.StatusUnauthorized
+		}
+		context.JSON(status, response.FailedResponse{
+			Code:  status,
+			Error: err.Error(),
+		})
+		return
+	}
+
+	context.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Password verified",
+		Data:    res,
+	})
+}
+
+// checkFormAccess answers 403 (429 while the form is locked) and returns false when a protected form
+// is called without a valid grant or password, the data tells the app which form to ask the password of.
+func checkFormAccess(context *gin.Context, formPassword *usecase.VerifyFormPasswordUseCase, form entity.SForm, grant string, password string) bool {
+	if formPassword == nil {
+		return true
+	}
+	err := formPassword.CheckAccess(form, grant, password)
+	if err == nil {
+		return true
+	}
+
+	status := http.StatusForbidden
+	var locked *usecase.PasswordLockedError
+	if errors.As(err, &locked) {
+		setRetryAfter(context, locked.Until)
+		status = http.StatusTooManyRequests
+	}
+	context.JSON(status, response.FailedResponse{
+		Code:  status,
+		Error: err.Error(),
+		Data: response.QuestionListResponseData{
+			QuestionListData: []response.QuestionListData{},
+			PasswordRequired: true,
+			FormName:         form.Name,
+			FormId:           form.ID,
+		},
+	})
+	return false
+}
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"net/http"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/usecase"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FormScoringController struct {
+	FormScoringUseCase *usecase.FormScoringUseCase
+}
+
+func (c *FormScoringController) GetScoring(ctx *gin.Context) {
+	formID, ok := parseUintParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	res, err := c.FormScoringUseCase.GetScoring(formID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, response.FailedResponse{
+			Code:    http.StatusNotFound,
+			Message: "Failed to get form scoring",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *FormScoringController) UpdateScoring(ctx *gin.Context) {
+	formID, ok := parseUintParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var req request.UpdateFormScoringRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.FormScoringUseCase.UpdateScoring(formID, req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to update form scoring",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Form scoring updated successfully",
+		Data:    res,
+	})
+}
+
+func (c *FormScoringController) DeleteScoring(ctx *gin.Context) {
+	formID, ok := parseUintParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.FormScoringUseCase.DeleteScoring(formID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to delete form scoring",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Form scoring deleted successfully",
+	})
+}
+
+func (c *FormScoringController) GetSubmissionScore(ctx *gin.Context) {
+	submissionID, ok := parseUintParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	res, err := c.FormScoringUseCase.GetSubmissionScore(submissionID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, response.FailedResponse{
+			Code:    http.StatusNotFound,
+			Message: "Failed to get submission score",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func parseUintParam(ctx *gin.Context, name string) (uint64, bool) {
+	id, err := strconv.ParseUint(ctx.Param(name), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:  http.StatusBadRequest,
+			Error: name + " is invalid",
+		})
+		return 0, false
+	}
+	return id, true
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -432,6 +433,14 @@ func (ic *ImageController) UploadImage4GW(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	// owner bắt buộc để kiểm tra consent của child, bỏ trống thì sẽ lọt qua
+	if req.OwnerID == "" || !value.OwnerRole(req.OwnerRole).IsValid() {
+		c.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:  http.StatusBadRequest,
+			Error: "owner_id and a valid owner_role are required",
+		})
+		return
+	}
 
 	// mở file
 	file, err := req.File.Open()
@@ -460,11 +469,17 @@ func (ic *ImageController) UploadImage4GW(c *gin.Context) {
 		FileName:  req.FileName,
 		ImageName: req.ImageName,
 		Mode:      req.Mode,
+		OwnerID:   req.OwnerID,
+		OwnerRole: req.OwnerRole,
 	}
 	res, err := ic.UploadImageUseCase.UploadImagev3(data, uploadReq)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.FailedResponse{
-			Code:  http.StatusInternalServerError,
+		code := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrPhotoConsentNotGranted) {
+			code = http.StatusForbidden
+		}
+		c.JSON(code, response.FailedResponse{
+			Code:  code,
 			Error: err.Error(),
 		})
 		return
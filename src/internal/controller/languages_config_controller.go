@@ -171,3 +171,30 @@ func getUserID(ctx *gin.Context) (string, bool) {
 	userID, ok := val.(string)
 	return userID, ok
 }
+
+// getDeviceID returns the device of the device token, set by the OptionalDevice and SecuredDevice middlewares.
+func getDeviceID(ctx *gin.Context) (string, bool) {
+	deviceID := ctx.GetString("device_id")
+	return deviceID, deviceID != ""
+}
+
+// tokenDevice returns the device of the device token, answering 403 when the request names another device.
+func tokenDevice(ctx *gin.Context, requestDeviceID string) (string, bool) {
+	deviceID, ok := getDeviceID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid device token",
+		})
+		return "", false
+	}
+	if requestDeviceID != "" && requestDeviceID != deviceID {
+		ctx.JSON(http.StatusForbidden, response.FailedResponse{
+			Code:    http.StatusForbidden,
+			Message: "Access Denied",
+			Error:   "device_id does not match the device token",
+		})
+		return "", false
+	}
+	return deviceID, true
+}
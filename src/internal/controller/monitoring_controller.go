@@ -9,11 +9,11 @@ type MonitoringController struct {
 }
 
 type responseGoogleAPIRequest struct {
-	TotalRequestInitDevice      int `json:"total_request_init_device"`
-	TotalRequestImportToDo      int `json:"total_request_import_to_do"`
-	TotalRequestImportForm      int `json:"total_request_import_form"`
-	TotalRequestGETScreenButton int `json:"total_request_get_screen_button"`
-	TotalRequestGETTopButton    int `json:"total_request_get_top_button"`
+	TotalRequestInitDevice      int64 `json:"total_request_init_device"`
+	TotalRequestImportToDo      int64 `json:"total_request_import_to_do"`
+	TotalRequestImportForm      int64 `json:"total_request_import_form"`
+	TotalRequestGETScreenButton int64 `json:"total_request_get_screen_button"`
+	TotalRequestGETTopButton    int64 `json:"total_request_get_top_button"`
 }
 
 // GetGoogleAPIMonitoring godoc
@@ -26,10 +26,10 @@ type responseGoogleAPIRequest struct {
 // @Router /v1/admin/monitor/google-api [get]
 func (c *MonitoringController) GetGoogleAPIMonitoring(context *gin.Context) {
 	context.JSON(200, responseGoogleAPIRequest{
-		TotalRequestInitDevice:      monitor.TotalRequestInitDevice,
-		TotalRequestImportToDo:      monitor.TotalRequestImportToDo,
-		TotalRequestImportForm:      monitor.TotalRequestImportForm,
-		TotalRequestGETScreenButton: monitor.TotalRequestGETScreenButton,
-		TotalRequestGETTopButton:    monitor.TotalRequestGETTopButton,
+		TotalRequestInitDevice:      monitor.TotalRequestInitDevice.Load(),
+		TotalRequestImportToDo:      monitor.TotalRequestImportToDo.Load(),
+		TotalRequestImportForm:      monitor.TotalRequestImportForm.Load(),
+		TotalRequestGETScreenButton: monitor.TotalRequestGETScreenButton.Load(),
+		TotalRequestGETTopButton:    monitor.TotalRequestGETTopButton.Load(),
 	})
 }
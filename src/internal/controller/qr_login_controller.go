@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"net/http"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type QRLoginController struct {
+	QRLoginUseCase *usecase.QRLoginUseCase
+}
+
+// RotateMine lets a user replace a lost QR login code.
+func (c *QRLoginController) RotateMine(ctx *gin.Context) {
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+	c.rotate(ctx, userID)
+}
+
+func (c *QRLoginController) Rotate4Admin(ctx *gin.Context) {
+	c.rotate(ctx, ctx.Param("id"))
+}
+
+func (c *QRLoginController) Revoke4Admin(ctx *gin.Context) {
+	if err := c.QRLoginUseCase.Revoke(ctx.Param("id")); err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to revoke QR login",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "QR login revoked successfully",
+	})
+}
+
+func (c *QRLoginController) rotate(ctx *gin.Context, userID string) {
+	res, err := c.QRLoginUseCase.Rotate(userID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to rotate QR login",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "QR login rotated successfully",
+		Data:    res,
+	})
+}
@@ -25,6 +25,7 @@ type QuestionController struct {
 	FindDeviceFromRequestCase            usecase.FindDeviceFromRequestCase
 	GetUserDeviceUseCase                 usecase.GetUserDeviceUseCase
 	GetDeviceByIDUseCase                 usecase.GetDeviceByIDUseCase
+	FormPasswordUseCase                  *usecase.VerifyFormPasswordUseCase
 }
 
 // GetFormQRCode Get Form's Questions by QR Code godoc
@@ -58,6 +59,10 @@ func (receiver *QuestionController) GetFormQRCode(context *gin.Context) {
 		return
 	}
 
+	if !checkFormAccess(context, receiver.FormPasswordUseCase, *form, req.FormGrant, req.FormPassword) {
+		return
+	}
+
 	succeedRes, failedRes := receiver.GetQuestionByFormUseCase.GetQuestionByForm(*form)
 	if failedRes != nil {
 		context.JSON(http.StatusBadRequest, failedRes)
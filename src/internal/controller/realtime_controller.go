@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/pkg/realtime"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+const realtimeHeartbeatInterval = 25 * time.Second
+
+type RealtimeController struct {
+	DeviceRepository *repository.DeviceRepository
+	Hub              *realtime.Hub
+}
+
+// Stream is the Server-Sent Events endpoint of the devices: it subscribes to the user channel, plus the device
+// and organization channels when device_id / organization_id are given, the device must be one the user
+// registered or logged in on. A reconnecting client sends the Last-Event-ID header (or last_event_id query)
+// to receive the events it missed.
+func (c *RealtimeController) Stream(ctx *gin.Context) {
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	channels := []string{realtime.UserChannel(userID)}
+
+	deviceID := ctx.Query("device_id")
+	if deviceID != "" {
+		if _, err := c.DeviceRepository.GetDeviceByID(deviceID); err != nil {
+			ctx.JSON(http.StatusNotFound, response.FailedResponse{
+				Code:  http.StatusNotFound,
+				Error: "device not found",
+			})
+			return
+		}
+		isUser, err := c.DeviceRepository.IsUserOfDevice(userID, deviceID)
+		if err != nil || !isUser {
+			ctx.JSON(http.StatusForbidden, response.FailedResponse{
+				Code:    http.StatusForbidden,
+				Message: "Access Denied",
+				Error:   "the device is not one of the user",
+			})
+			return
+		}
+		channels = append(channels, realtime.DeviceChannel(deviceID))
+	}
+
+	orgID := ctx.Query("organization_id")
+	if orgID != "" {
+		if deviceID == "" {
+			ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+				Code:  http.StatusBadRequest,
+				Error: "device_id is required to subscribe to an organization",
+			})
+			return
+		}
+		inOrg, err := c.DeviceRepository.CheckDeviceExistInOrganization(deviceID, orgID)
+		if err != nil || !inOrg {
+			ctx.JSON(http.StatusForbidden, response.FailedResponse{
+				Code:    http.StatusForbidden,
+				Message: "Access Denied",
+				Error:   "device does not belong to the organization",
+			})
+			return
+		}
+		channels = append(channels, realtime.OrganizationChannel(orgID))
+	}
+
+	lastEventID := ctx.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = ctx.Query("last_event_id")
+	}
+	if lastEventID != "" && !realtime.IsEventID(lastEventID) {
+		// id khong hop le thi bo qua, stream tu event moi
+		lastEventID = ""
+	}
+
+	// subscribe truoc khi replay de khong mat event o giua
+	sub := c.Hub.Subscribe(channels...)
+	defer c.Hub.Unsubscribe(sub)
+
+	missed, err := c.Hub.Replay(ctx.Request.Context(), lastEventID, channels...)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to replay events",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	// WriteTimeout cua server cat stream sau 30s, stream giu ket noi toi khi client dong
+	if err := http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		log.Warn("RealtimeController.Stream: clear write deadline: ", err)
+	}
+
+	header := ctx.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	fmt.Fprint(ctx.Writer, "retry: 3000\n\n")
+	replayed := make(map[string]bool, len(missed))
+	for _, event := range missed {
+		replayed[event.ID] = true
+		writeRealtimeEvent(ctx, event)
+	}
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(realtimeHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case event := <-sub.C:
+			if replayed[event.ID] {
+				continue
+			}
+			writeRealtimeEvent(ctx, event)
+		case <-heartbeat.C:
+			fmt.Fprint(ctx.Writer, ": ping\n\n")
+		}
+		ctx.Writer.Flush()
+	}
+}
+
+func writeRealtimeEvent(ctx *gin.Context, event realtime.Event) {
+	fmt.Fprintf(ctx.Writer, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data)
+}
@@ -1,13 +1,17 @@
 package controller
 
 import (
+	"errors"
+	"math"
 	"net/http"
 	"sen-global-api/internal/domain/request"
 	"sen-global-api/internal/domain/response"
 	"sen-global-api/internal/domain/usecase"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type RedirectUrlController struct {
@@ -17,6 +21,7 @@ type RedirectUrlController struct {
 	*usecase.UpdateRedirectUrlUseCase
 	*usecase.GetRedirectUrlByQRCodeUseCase
 	*usecase.ImportRedirectUrlsUseCase
+	*usecase.RedirectUrlAnalyticsUseCase
 }
 
 // @Summary Create redirect url
@@ -50,12 +55,12 @@ func (receiver *RedirectUrlController) CreateRedirectUrl(context *gin.Context) {
 	}
 
 	context.JSON(http.StatusOK, response.SaveRedirectUrlResponse{Data: response.SaveRedirectUrlResponseData{
-		ID:        form.ID,
-		QRCode:    form.QRCode,
-		TargetUrl: form.TargetUrl,
-		Password:  form.Password,
-		CreatedAt: form.CreatedAt,
-		UpdatedAt: form.UpdatedAt,
+		ID:          form.ID,
+		QRCode:      form.QRCode,
+		TargetUrl:   form.TargetUrl,
+		HasPassword: form.HashPassword != nil,
+		CreatedAt:   form.CreatedAt,
+		UpdatedAt:   form.UpdatedAt,
 	}})
 }
 
@@ -181,14 +186,18 @@ func (receiver *RedirectUrlController) UpdateRedirectUrl(context *gin.Context) {
 	}
 	context.JSON(http.StatusOK, response.UpdateRedirectUrlResponse{
 		Data: response.GetRedirectUrlListResponseData{
-			ID:           form.ID,
-			QRCode:       form.QRCode,
-			TargetUrl:    form.TargetUrl,
-			Password:     form.Password,
-			Hint:         form.Hint,
-			HashPassword: form.HashPassword,
-			CreatedAt:    form.CreatedAt,
-			UpdatedAt:    form.UpdatedAt,
+			ID:          form.ID,
+			QRCode:      form.QRCode,
+			TargetUrl:   form.TargetUrl,
+			HasPassword: form.HashPassword != nil,
+			Hint:        form.Hint,
+			ValidFrom:   form.ValidFrom,
+			ValidUntil:  form.ValidUntil,
+			MaxUses:     form.MaxUses,
+			UseCount:    form.UseCount,
+			Targets:     form.Targets,
+			CreatedAt:   form.CreatedAt,
+			UpdatedAt:   form.UpdatedAt,
 		},
 	})
 }
@@ -200,11 +209,14 @@ func (receiver *RedirectUrlController) UpdateRedirectUrl(context *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer {token}"
-// @Param qrcode query string true "QR Code"
+// @Param qr_code query string true "QR Code"
+// @Param device_id query string false "Scanning device"
+// @Param organization_id query string false "Organization of the scanning device"
 // @Success 200 {object} response.GetRedirectUrlResponse
 // @Failure 400 {object} response.FailedResponse
 // @Failure 401 {object} response.FailedResponse
 // @Failure 403 {object} response.FailedResponse
+// @Failure 410 {object} response.FailedResponse
 // @Failure 500 {object} response.FailedResponse
 // @Router /v1/redirect-url [get]
 func (receiver *RedirectUrlController) GetRedirectUrlByQRCode(context *gin.Context) {
@@ -216,28 +228,105 @@ func (receiver *RedirectUrlController) GetRedirectUrlByQRCode(context *gin.Conte
 		})
 		return
 	}
-	form, err := receiver.GetByQRCode(req.QRCode)
+	form, err := receiver.Resolve(req, usecase.RedirectUrlScanContext{
+		UserAgent:      context.Request.UserAgent(),
+		AcceptLanguage: context.GetHeader("Accept-Language"),
+	})
 	if err != nil {
-		context.JSON(http.StatusInternalServerError, response.FailedResponse{
-			Code:  http.StatusInternalServerError,
+		status := redirectUrlErrorStatus(context, err)
+		context.JSON(status, response.FailedResponse{
+			Code:  status,
 			Error: err.Error(),
 		})
 		return
 	}
 	context.JSON(http.StatusOK, response.GetRedirectUrlResponse{
 		Data: response.GetRedirectUrlListResponseData{
-			ID:           form.ID,
-			QRCode:       form.QRCode,
-			TargetUrl:    form.TargetUrl,
-			Password:     form.Password,
-			Hint:         form.Hint,
-			HashPassword: form.HashPassword,
-			CreatedAt:    form.CreatedAt,
-			UpdatedAt:    form.UpdatedAt,
+			ID:          form.ID,
+			QRCode:      form.QRCode,
+			TargetUrl:   form.TargetUrl,
+			HasPassword: form.HashPassword != nil,
+			Hint:        form.Hint,
+			CreatedAt:   form.CreatedAt,
+			UpdatedAt:   form.UpdatedAt,
 		},
 	})
 }
 
+// Verify Redirect Url Password godoc
+// @Summary Resolve a password protected QR code
+// @Description Checks the password of the QR code and returns its target. The code is locked for a while after too many wrong passwords.
+// @Tags Redirect Url
+// @Accept json
+// @Produce json
+// @Param request body request.VerifyRedirectUrlPasswordRequest true "QR code and password"
+// @Success 200 {object} response.GetRedirectUrlResponse
+// @Failure 400 {object} response.FailedResponse
+// @Failure 401 {object} response.FailedResponse
+// @Failure 410 {object} response.FailedResponse
+// @Failure 429 {object} response.FailedResponse
+// @Router /v1/redirect-url/verify [post]
+func (receiver *RedirectUrlController) VerifyRedirectUrlPassword(context *gin.Context) {
+	var req request.VerifyRedirectUrlPasswordRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:  http.StatusBadRequest,
+			Error: err.Error(),
+		})
+		return
+	}
+	form, err := receiver.ResolveWithPassword(req, usecase.RedirectUrlScanContext{
+		UserAgent:      context.Request.UserAgent(),
+		AcceptLanguage: context.GetHeader("Accept-Language"),
+	})
+	if err != nil {
+		status := redirectUrlErrorStatus(context, err)
+		context.JSON(status, response.FailedResponse{
+			Code:  status,
+			Error: err.Error(),
+		})
+		return
+	}
+	context.JSON(http.StatusOK, response.GetRedirectUrlResponse{
+		Data: response.GetRedirectUrlListResponseData{
+			ID:          form.ID,
+			QRCode:      form.QRCode,
+			TargetUrl:   form.TargetUrl,
+			HasPassword: form.HashPassword != nil,
+			Hint:        form.Hint,
+			CreatedAt:   form.CreatedAt,
+			UpdatedAt:   form.UpdatedAt,
+		},
+	})
+}
+
+// redirectUrlErrorStatus maps the resolve errors to a status, setting Retry-After on a locked code.
+func redirectUrlErrorStatus(context *gin.Context, err error) int {
+	var locked *usecase.PasswordLockedError
+	switch {
+	case errors.As(err, &locked):
+		setRetryAfter(context, locked.Until)
+		return http.StatusTooManyRequests
+	case errors.Is(err, usecase.ErrWrongPassword):
+		return http.StatusUnauthorized
+	case errors.Is(err, usecase.ErrRedirectUrlNotStarted),
+		errors.Is(err, usecase.ErrRedirectUrlExpired),
+		errors.Is(err, usecase.ErrRedirectUrlExhausted):
+		return http.StatusGone
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+func setRetryAfter(context *gin.Context, until time.Time) {
+	seconds := int(math.Ceil(time.Until(until).Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	context.Header("Retry-After", strconv.Itoa(seconds))
+}
+
 // Import Redirect Urls godoc
 // @Summary Import Redirect Urls
 // @Description Import Redirect Urls
@@ -317,3 +406,94 @@ func (receiver *RedirectUrlController) ImportPartiallyRedirectUrls(context *gin.
 		Message: "Redirect Urls imported",
 	})
 }
+
+// Redirect Url Dashboard godoc
+// @Summary Redirect Url scan dashboard
+// @Description Scans per QR code over a period, the most scanned first, codes never scanned included
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param from query string false "First day (YYYY-MM-DD), 30 days before to by default"
+// @Param to query string false "Last day (YYYY-MM-DD), today by default"
+// @Param organization_id query string false "Only count the scans of the organization"
+// @Success 200 {object} response.SucceedResponse
+// @Failure 400 {object} response.FailedResponse
+// @Failure 500 {object} response.FailedResponse
+// @Router /v1/admin/redirect-url/dashboard [get]
+func (receiver *RedirectUrlController) GetRedirectUrlDashboard(context *gin.Context) {
+	var req request.GetRedirectUrlAnalyticsRequest
+	if err := context.ShouldBindQuery(&req); err != nil {
+		context.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:  http.StatusBadRequest,
+			Error: err.Error(),
+		})
+		return
+	}
+
+	res, err := receiver.GetDashboard(req)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:  http.StatusBadRequest,
+			Error: err.Error(),
+		})
+		return
+	}
+
+	context.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+// Redirect Url Analytics godoc
+// @Summary Redirect Url scan analytics
+// @Description Scans of one QR code by day, hour, target, organization, language and outcome
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path int true "Redirect Url ID"
+// @Param from query string false "First day (YYYY-MM-DD), 30 days before to by default"
+// @Param to query string false "Last day (YYYY-MM-DD), today by default"
+// @Success 200 {object} response.SucceedResponse
+// @Failure 400 {object} response.FailedResponse
+// @Failure 404 {object} response.FailedResponse
+// @Router /v1/admin/redirect-url/{id}/analytics [get]
+func (receiver *RedirectUrlController) GetRedirectUrlAnalytics(context *gin.Context) {
+	id, err := strconv.ParseUint(context.Param("id"), 10, 64)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:  http.StatusBadRequest,
+			Error: err.Error(),
+		})
+		return
+	}
+
+	var req request.GetRedirectUrlAnalyticsRequest
+	if err := context.ShouldBindQuery(&req); err != nil {
+		context.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:  http.StatusBadRequest,
+			Error: err.Error(),
+		})
+		return
+	}
+
+	res, err := receiver.GetAnalytics(id, req)
+	if err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, usecase.ErrRedirectUrlNotFound) {
+			code = http.StatusNotFound
+		}
+		context.JSON(code, response.FailedResponse{
+			Code:  code,
+			Error: err.Error(),
+		})
+		return
+	}
+
+	context.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
@@ -0,0 +1,281 @@
+package controller
+
+import (
+	"net/http"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportController struct {
+	ReportUseCase *usecase.ReportUseCase
+}
+
+func (c *ReportController) GenerateSubmissionReport(ctx *gin.Context) {
+	c.generateSubmissionReport(ctx, false)
+}
+
+func (c *ReportController) GenerateSubmissionReport4Admin(ctx *gin.Context) {
+	c.generateSubmissionReport(ctx, true)
+}
+
+func (c *ReportController) generateSubmissionReport(ctx *gin.Context, asAdmin bool) {
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	res, err := c.ReportUseCase.GenerateSubmissionReport(ctx.Param("submission_id"), userID, asAdmin)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to generate submission report",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Report generated successfully",
+		Data:    res,
+	})
+}
+
+func (c *ReportController) GenerateStudentReport(ctx *gin.Context) {
+	c.generateStudentReport(ctx, false)
+}
+
+func (c *ReportController) GenerateStudentReport4Admin(ctx *gin.Context) {
+	c.generateStudentReport(ctx, true)
+}
+
+func (c *ReportController) generateStudentReport(ctx *gin.Context, asAdmin bool) {
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	var req request.ReportPeriodRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.ReportUseCase.GenerateStudentReport(ctx.Param("student_id"), req, userID, asAdmin)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to generate student report",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Report generated successfully",
+		Data:    res,
+	})
+}
+
+func (c *ReportController) GenerateClassReport(ctx *gin.Context) {
+	c.generateClassReport(ctx, false)
+}
+
+func (c *ReportController) GenerateClassReport4Admin(ctx *gin.Context) {
+	c.generateClassReport(ctx, true)
+}
+
+func (c *ReportController) generateClassReport(ctx *gin.Context, asAdmin bool) {
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	var req request.GenerateClassReportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.ReportUseCase.GenerateClassReport(req, userID, asAdmin)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to generate class report",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Report generated successfully",
+		Data:    res,
+	})
+}
+
+func (c *ReportController) DownloadReport(ctx *gin.Context) {
+	c.downloadReport(ctx, false)
+}
+
+func (c *ReportController) DownloadReport4Admin(ctx *gin.Context) {
+	c.downloadReport(ctx, true)
+}
+
+func (c *ReportController) downloadReport(ctx *gin.Context, asAdmin bool) {
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	res, err := c.ReportUseCase.GetReport(ctx.Param("id"), userID, asAdmin)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to get report",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *ReportController) GetReports(ctx *gin.Context) {
+	var req request.GetReportsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid query",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.ReportUseCase.GetReports(req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to get reports",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *ReportController) CreateJob(ctx *gin.Context) {
+	userID, ok := getUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	var req request.CreateReportJobRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.ReportUseCase.CreateJob(req, userID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Failed to create report job",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, response.SucceedResponse{
+		Code:    http.StatusAccepted,
+		Message: "Report job is running",
+		Data:    res,
+	})
+}
+
+func (c *ReportController) GetJobs(ctx *gin.Context) {
+	var req request.GetReportJobsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid query",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	res, err := c.ReportUseCase.GetJobs(req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get report jobs",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
+
+func (c *ReportController) GetJob(ctx *gin.Context) {
+	res, err := c.ReportUseCase.GetJob(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, response.FailedResponse{
+			Code:    http.StatusNotFound,
+			Message: "Failed to get report job",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: res,
+	})
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"bufio"
+	"errors"
 	"net/http"
 	"regexp"
 	"sen-global-api/helper"
@@ -11,6 +12,7 @@ import (
 	"sen-global-api/internal/domain/usecase"
 	"sen-global-api/internal/domain/value"
 	"sen-global-api/pkg/consulapi/gateway"
+	"sen-global-api/pkg/logger"
 	"sen-global-api/pkg/uploader"
 	"strconv"
 	"strings"
@@ -19,7 +21,6 @@ import (
 	"github.com/samber/lo"
 
 	"github.com/gin-gonic/gin"
-	log "github.com/sirupsen/logrus"
 )
 
 type UserEntityController struct {
@@ -58,6 +59,7 @@ type UserEntityController struct {
 	*usecase.PreRegisterUseCase
 	usecase.GenerateOwnerCodeUseCase
 	*usecase.ValuesAppCurrentUseCase
+	*usecase.ChildPrivacyUseCase
 	ProfileGateway gateway.ProfileGateway
 }
 
@@ -867,7 +869,7 @@ func (receiver *UserEntityController) CreateChildForParent(context *gin.Context)
 func (receiver *UserEntityController) UpdateUserEntity(context *gin.Context) {
 	var req request.UpdateUserEntityRequest
 	if err := context.ShouldBindJSON(&req); err != nil {
-		log.Error(err)
+		logger.FromContext(context).Error(err)
 		context.JSON(http.StatusBadRequest, response.FailedResponse{
 			Code:  http.StatusBadRequest,
 			Error: err.Error(),
@@ -897,7 +899,7 @@ func (receiver *UserEntityController) UpdateUserEntity(context *gin.Context) {
 
 	err := receiver.UpdateUserEntityUseCase.UpdateUserEntity(req)
 	if err != nil {
-		log.Error(err)
+		logger.FromContext(context).Error(err)
 
 		context.JSON(http.StatusBadRequest, response.FailedResponse{
 			Code:  http.StatusBadRequest,
@@ -1516,6 +1518,167 @@ func (ctl *UserEntityController) UpdateChild(c *gin.Context) {
 	})
 }
 
+func (receiver *UserEntityController) GetChildGuardians(context *gin.Context) {
+	childID := context.Param("id")
+	if childID == "" {
+		context.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Missing child ID",
+		})
+		return
+	}
+
+	userID, ok := getUserID(context)
+	if !ok {
+		context.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	isGuardian, _ := receiver.ChildUseCase.IsParentOfChild(userID, childID)
+	if !isGuardian {
+		context.JSON(http.StatusForbidden, response.FailedResponse{
+			Code:    http.StatusForbidden,
+			Message: "Access Denied",
+		})
+		return
+	}
+
+	guardians, err := receiver.ChildUseCase.GetGuardians(childID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get guardians",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	context.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: guardians,
+	})
+}
+
+func (receiver *UserEntityController) AddChildGuardian(context *gin.Context) {
+	var req request.AddChildGuardianRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	userID, ok := getUserID(context)
+	if !ok {
+		context.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	if err := receiver.ChildUseCase.AddGuardian(req, userID); err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrGuardianAccessDenied) {
+			code = http.StatusForbidden
+		}
+		context.JSON(code, response.FailedResponse{
+			Code:    code,
+			Message: "Failed to add guardian",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	context.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Guardian added successfully",
+	})
+}
+
+func (receiver *UserEntityController) UpdateChildGuardian(context *gin.Context) {
+	var req request.UpdateChildGuardianRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	userID, ok := getUserID(context)
+	if !ok {
+		context.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	if err := receiver.ChildUseCase.UpdateGuardian(req, userID); err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrGuardianAccessDenied) {
+			code = http.StatusForbidden
+		}
+		context.JSON(code, response.FailedResponse{
+			Code:    code,
+			Message: "Failed to update guardian",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	context.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Guardian updated successfully",
+	})
+}
+
+func (receiver *UserEntityController) RemoveChildGuardian(context *gin.Context) {
+	childID := context.Param("id")
+	guardianUserID := context.Param("user_id")
+	if childID == "" || guardianUserID == "" {
+		context.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Missing child ID or user ID",
+		})
+		return
+	}
+
+	userID, ok := getUserID(context)
+	if !ok {
+		context.JSON(http.StatusUnauthorized, response.FailedResponse{
+			Code:  http.StatusUnauthorized,
+			Error: "Unauthorized: invalid user_id",
+		})
+		return
+	}
+
+	if err := receiver.ChildUseCase.RemoveGuardian(childID, guardianUserID, userID); err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrGuardianAccessDenied) {
+			code = http.StatusForbidden
+		}
+		context.JSON(code, response.FailedResponse{
+			Code:    code,
+			Message: "Failed to remove guardian",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	context.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: "Guardian removed successfully",
+	})
+}
+
 func (receiver *UserEntityController) SearchUser4WebAdmin(c *gin.Context) {
 	role := c.Query("role")
 	name := strings.ToLower(strings.TrimSpace(c.Query("name")))
@@ -1937,6 +2100,20 @@ func (receiver *UserEntityController) UploadAvatarV2(c *gin.Context) {
 		return
 	}
 
+	if receiver.ChildPrivacyUseCase != nil {
+		if err := receiver.ChildPrivacyUseCase.CheckOwnerPhotoConsent(ownerID, ownerRole); err != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, usecase.ErrPhotoConsentNotGranted) {
+				code = http.StatusForbidden
+			}
+			c.JSON(code, response.FailedResponse{
+				Code:  code,
+				Error: err.Error(),
+			})
+			return
+		}
+	}
+
 	fileName := c.PostForm("file_name")
 	if fileName == "" {
 		c.JSON(http.StatusBadRequest, response.FailedResponse{
@@ -2724,6 +2901,85 @@ func (receiver *UserEntityController) GetStudentsByParentID4Gw(context *gin.Cont
 	})
 }
 
+func (receiver *UserEntityController) GetChildGuardians4Gateway(context *gin.Context) {
+	childID := context.Param("child_id")
+	if childID == "" {
+		context.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Missing child ID",
+		})
+		return
+	}
+
+	guardians, err := receiver.ChildUseCase.GetGuardians(childID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get guardians",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	context.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: guardians,
+	})
+}
+
+func (receiver *UserEntityController) GetChildGuardianPermission4Gateway(context *gin.Context) {
+	childID := context.Param("child_id")
+	userID := context.Param("user_id")
+	if childID == "" || userID == "" {
+		context.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Missing child ID or user ID",
+		})
+		return
+	}
+
+	permission, err := receiver.ChildUseCase.GetGuardianPermission(childID, userID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get guardian permission",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	context.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: permission,
+	})
+}
+
+func (receiver *UserEntityController) GetChildrenByGuardian4Gateway(context *gin.Context) {
+	userID := context.Param("user_id")
+	if userID == "" {
+		context.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Missing user ID",
+		})
+		return
+	}
+
+	childIDs, err := receiver.ChildUseCase.GetChildIDsByGuardian(userID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get children",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	context.JSON(http.StatusOK, response.SucceedResponse{
+		Code: http.StatusOK,
+		Data: childIDs,
+	})
+}
+
 func (receiver *UserEntityController) MigrateParentDepartmentGroup(context *gin.Context) {
 	organizationID := context.Param("organization_id")
 	if organizationID == "" {
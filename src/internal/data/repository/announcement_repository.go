@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"errors"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/value"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AnnouncementRepository struct {
+	DBConn *gorm.DB
+}
+
+func NewAnnouncementRepository(dbConn *gorm.DB) *AnnouncementRepository {
+	return &AnnouncementRepository{DBConn: dbConn}
+}
+
+func (r *AnnouncementRepository) preload() *gorm.DB {
+	return r.DBConn.
+		Preload("Translations", func(db *gorm.DB) *gorm.DB { return db.Order("language_id ASC") }).
+		Preload("Targets").
+		Preload("Attachments", func(db *gorm.DB) *gorm.DB { return db.Order("`order` ASC") })
+}
+
+// Create saves the announcement with its translations, targets and attachments.
+func (r *AnnouncementRepository) Create(announcement *entity.Announcement) error {
+	return r.DBConn.Create(announcement).Error
+}
+
+// Replace saves the announcement and swaps its translations, targets and attachments for the given ones.
+func (r *AnnouncementRepository) Replace(announcement *entity.Announcement) error {
+	return r.DBConn.Transaction(func(tx *gorm.DB) error {
+		id := announcement.ID
+		if err := tx.Where("announcement_id = ?", id).Delete(&entity.AnnouncementTranslation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("announcement_id = ?", id).Delete(&entity.AnnouncementTarget{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("announcement_id = ?", id).Delete(&entity.AnnouncementAttachment{}).Error; err != nil {
+			return err
+		}
+		return tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(announcement).Error
+	})
+}
+
+func (r *AnnouncementRepository) Delete(id string) error {
+	return r.DBConn.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{
+			&entity.AnnouncementTranslation{},
+			&entity.AnnouncementTarget{},
+			&entity.AnnouncementAttachment{},
+			&entity.AnnouncementRead{},
+		} {
+			if err := tx.Where("announcement_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Where("id = ?", id).Delete(&entity.Announcement{}).Error
+	})
+}
+
+func (r *AnnouncementRepository) GetByID(id string) (*entity.Announcement, error) {
+	var announcement entity.Announcement
+	err := r.preload().Where("id = ?", id).First(&announcement).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &announcement, nil
+}
+
+func (r *AnnouncementRepository) GetByOrganization(orgID string) ([]entity.Announcement, error) {
+	var announcements []entity.Announcement
+	err := r.preload().
+		Where("organization_id = ?", orgID).
+		Order("created_at DESC").
+		Find(&announcements).Error
+	return announcements, err
+}
+
+// GetActiveByOrganization returns the published announcements of the organization visible at `now`.
+func (r *AnnouncementRepository) GetActiveByOrganization(orgID string, now time.Time) ([]entity.Announcement, error) {
+	var announcements []entity.Announcement
+	err := r.preload().
+		Where("organization_id = ? AND status = ?", orgID, value.AnnouncementStatusPublished).
+		Where("publish_at IS NULL OR publish_at <= ?", now).
+		Where("expire_at IS NULL OR expire_at > ?", now).
+		Order("COALESCE(publish_at, created_at) DESC").
+		Find(&announcements).Error
+	return announcements, err
+}
+
+// GetDueForPush returns the published announcements visible at `now` whose push has not been sent yet.
+func (r *AnnouncementRepository) GetDueForPush(now time.Time) ([]entity.Announcement, error) {
+	var announcements []entity.Announcement
+	err := r.preload().
+		Where("status = ? AND pushed_at IS NULL", value.AnnouncementStatusPublished).
+		Where("publish_at IS NULL OR publish_at <= ?", now).
+		Where("expire_at IS NULL OR expire_at > ?", now).
+		Find(&announcements).Error
+	return announcements, err
+}
+
+func (r *AnnouncementRepository) MarkPushed(id string, at time.Time) error {
+	return r.DBConn.Model(&entity.Announcement{}).
+		Where("id = ?", id).
+		Update("pushed_at", at).Error
+}
+
+// ---------- read receipt ----------
+
+// CreateRead records a read receipt, reading twice keeps the first receipt.
+func (r *AnnouncementRepository) CreateRead(read *entity.AnnouncementRead) error {
+	return r.DBConn.Clauses(clause.OnConflict{DoNothing: true}).Create(read).Error
+}
+
+func (r *AnnouncementRepository) GetReadsByAnnouncement(id string) ([]entity.AnnouncementRead, error) {
+	var reads []entity.AnnouncementRead
+	err := r.DBConn.Where("announcement_id = ?", id).Order("read_at ASC").Find(&reads).Error
+	return reads, err
+}
+
+// GetReadAnnouncementIDs returns the ids, among the given ones, already read by the user.
+func (r *AnnouncementRepository) GetReadAnnouncementIDs(userID string, ids []string) (map[string]bool, error) {
+	read := make(map[string]bool)
+	if len(ids) == 0 {
+		return read, nil
+	}
+
+	var readIDs []string
+	err := r.DBConn.Model(&entity.AnnouncementRead{}).
+		Where("user_id = ? AND announcement_id IN ?", userID, ids).
+		Distinct().
+		Pluck("announcement_id", &readIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range readIDs {
+		read[id] = true
+	}
+	return read, nil
+}
+
+// CountReads returns the number of distinct readers per announcement.
+func (r *AnnouncementRepository) CountReads(ids []string) (map[string]int64, error) {
+	counts := make(map[string]int64)
+	if len(ids) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		AnnouncementID string
+		Total          int64
+	}
+	err := r.DBConn.Model(&entity.AnnouncementRead{}).
+		Select("announcement_id, COUNT(DISTINCT user_id) AS total").
+		Where("announcement_id IN ?", ids).
+		Group("announcement_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.AnnouncementID] = row.Total
+	}
+	return counts, nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/value"
+
+	"gorm.io/gorm"
+)
+
+type AppReleaseRepository struct {
+	DBConn *gorm.DB
+}
+
+func NewAppReleaseRepository(dbConn *gorm.DB) *AppReleaseRepository {
+	return &AppReleaseRepository{DBConn: dbConn}
+}
+
+func (r *AppReleaseRepository) Create(release *entity.AppRelease) error {
+	return r.DBConn.Create(release).Error
+}
+
+func (r *AppReleaseRepository) Save(release *entity.AppRelease) error {
+	return r.DBConn.Save(release).Error
+}
+
+func (r *AppReleaseRepository) Delete(id uint) error {
+	return r.DBConn.Delete(&entity.AppRelease{}, id).Error
+}
+
+func (r *AppReleaseRepository) GetByID(id uint) (*entity.AppRelease, error) {
+	var release entity.AppRelease
+	if err := r.DBConn.First(&release, id).Error; err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
+
+func (r *AppReleaseRepository) GetByPlatformAndVersion(platform value.DeviceType, version string) (*entity.AppRelease, error) {
+	var release entity.AppRelease
+	err := r.DBConn.Where("platform = ? AND version = ?", platform, version).First(&release).Error
+	if err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
+
+// List returns the releases of a platform (every platform when empty), the semver order is done by the caller.
+func (r *AppReleaseRepository) List(platform value.DeviceType, activeOnly bool) ([]entity.AppRelease, error) {
+	query := r.DBConn.Model(&entity.AppRelease{})
+	if platform != "" {
+		query = query.Where("platform = ?", platform)
+	}
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var releases []entity.AppRelease
+	err := query.Order("created_at DESC").Find(&releases).Error
+	return releases, err
+}
+
+// AppVersionCount is the number of devices of an organization running a version.
+type AppVersionCount struct {
+	OrganizationID   string
+	OrganizationName string
+	Platform         value.DeviceType
+	AppVersion       string
+	Total            int64
+}
+
+// CountVersionsByOrganization groups the devices of each organization by platform and app version.
+func (r *AppReleaseRepository) CountVersionsByOrganization(organizationID string) ([]AppVersionCount, error) {
+	query := r.DBConn.Table("s_org_devices AS od").
+		Select(`od.organization_id, o.organization_name, d.platform, d.app_version, COUNT(*) AS total`).
+		Joins("JOIN s_device AS d ON d.id = od.device_id").
+		Joins("LEFT JOIN s_organization AS o ON o.id = od.organization_id")
+	if organizationID != "" {
+		query = query.Where("od.organization_id = ?", organizationID)
+	}
+
+	var rows []AppVersionCount
+	err := query.Group("od.organization_id, o.organization_name, d.platform, d.app_version").
+		Order("od.organization_id, d.platform, d.app_version").
+		Scan(&rows).Error
+	return rows, err
+}
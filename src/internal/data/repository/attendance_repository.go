@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"errors"
+	"sen-global-api/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type AttendanceRepository struct {
+	DBConn *gorm.DB
+}
+
+func NewAttendanceRepository(dbConn *gorm.DB) *AttendanceRepository {
+	return &AttendanceRepository{DBConn: dbConn}
+}
+
+// ---------- setting ----------
+
+func (r *AttendanceRepository) GetSettingByOrganizationID(orgID string) (*entity.AttendanceSetting, error) {
+	var setting entity.AttendanceSetting
+	err := r.DBConn.Where("organization_id = ?", orgID).First(&setting).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (r *AttendanceRepository) GetAllSettings() ([]entity.AttendanceSetting, error) {
+	var settings []entity.AttendanceSetting
+	err := r.DBConn.Find(&settings).Error
+	return settings, err
+}
+
+func (r *AttendanceRepository) SaveSetting(setting *entity.AttendanceSetting) error {
+	return r.DBConn.Save(setting).Error
+}
+
+// ---------- event ----------
+
+func (r *AttendanceRepository) CreateEvent(event *entity.AttendanceEvent) error {
+	return r.DBConn.Create(event).Error
+}
+
+// ---------- record ----------
+
+func (r *AttendanceRepository) GetRecord(studentID, date string) (*entity.AttendanceRecord, error) {
+	var record entity.AttendanceRecord
+	err := r.DBConn.Where("student_id = ? AND date = ?", studentID, date).First(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (r *AttendanceRepository) CreateRecord(record *entity.AttendanceRecord) error {
+	return r.DBConn.Create(record).Error
+}
+
+func (r *AttendanceRepository) UpdateRecord(record *entity.AttendanceRecord) error {
+	return r.DBConn.Save(record).Error
+}
+
+func (r *AttendanceRepository) GetRecordsByDate(orgID, date, departmentID string) ([]entity.AttendanceRecord, error) {
+	var records []entity.AttendanceRecord
+	query := r.DBConn.Where("organization_id = ? AND date = ?", orgID, date)
+	if departmentID != "" {
+		query = query.Where("department_id = ?", departmentID)
+	}
+	err := query.Find(&records).Error
+	return records, err
+}
+
+// GetRecordsByRange returns records with fromDate <= date <= toDate, dates are "YYYY-MM-DD" so string comparison works.
+func (r *AttendanceRepository) GetRecordsByRange(orgID, fromDate, toDate string) ([]entity.AttendanceRecord, error) {
+	var records []entity.AttendanceRecord
+	err := r.DBConn.
+		Where("organization_id = ? AND date >= ? AND date <= ?", orgID, fromDate, toDate).
+		Order("date ASC").
+		Find(&records).Error
+	return records, err
+}
+
+func (r *AttendanceRepository) GetRecordsByStudent(studentID, fromDate, toDate string) ([]entity.AttendanceRecord, error) {
+	var records []entity.AttendanceRecord
+	err := r.DBConn.
+		Where("student_id = ? AND date >= ? AND date <= ?", studentID, fromDate, toDate).
+		Order("date ASC").
+		Find(&records).Error
+	return records, err
+}
+
+// GetLastDepartments returns, for every student of the organization, the department they were last seen in.
+func (r *AttendanceRepository) GetLastDepartments(orgID string) (map[string]string, error) {
+	var records []entity.AttendanceRecord
+	err := r.DBConn.
+		Select("student_id", "department_id").
+		Where("organization_id = ? AND department_id <> ''", orgID).
+		Order("date ASC").
+		Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	departments := make(map[string]string, len(records))
+	for _, record := range records {
+		departments[record.StudentID] = record.DepartmentID
+	}
+	return departments, nil
+}
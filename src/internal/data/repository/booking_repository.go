@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"errors"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/value"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BookingRepository struct {
+	DBConn *gorm.DB
+}
+
+func NewBookingRepository(dbConn *gorm.DB) *BookingRepository {
+	return &BookingRepository{DBConn: dbConn}
+}
+
+func (r *BookingRepository) WithTx(tx *gorm.DB) *BookingRepository {
+	return &BookingRepository{DBConn: tx}
+}
+
+// ---------- resource ----------
+
+func (r *BookingRepository) CreateResource(resource *entity.BookableResource) error {
+	return r.DBConn.Create(resource).Error
+}
+
+func (r *BookingRepository) UpdateResource(resource *entity.BookableResource) error {
+	return r.DBConn.Save(resource).Error
+}
+
+func (r *BookingRepository) DeleteResource(id string) error {
+	return r.DBConn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("resource_id = ?", id).Delete(&entity.BookingAvailabilityRule{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&entity.BookableResource{}).Error
+	})
+}
+
+func (r *BookingRepository) GetResourceByID(id string) (*entity.BookableResource, error) {
+	var resource entity.BookableResource
+	err := r.DBConn.Where("id = ?", id).First(&resource).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// LockResource reads the resource with a row lock so concurrent reservations on it are serialized.
+// Must be called inside a transaction.
+func (r *BookingRepository) LockResource(id string) (*entity.BookableResource, error) {
+	var resource entity.BookableResource
+	err := r.DBConn.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&resource).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &resource, nil
+}
+
+func (r *BookingRepository) GetResourcesByOrganization(orgID string) ([]entity.BookableResource, error) {
+	var resources []entity.BookableResource
+	query := r.DBConn.Order("name ASC")
+	if orgID != "" {
+		query = query.Where("organization_id = ?", orgID)
+	}
+	err := query.Find(&resources).Error
+	return resources, err
+}
+
+// ---------- availability rule ----------
+
+func (r *BookingRepository) GetRulesByResource(resourceID string) ([]entity.BookingAvailabilityRule, error) {
+	var rules []entity.BookingAvailabilityRule
+	err := r.DBConn.Where("resource_id = ?", resourceID).Order("weekday ASC, start_time ASC").Find(&rules).Error
+	return rules, err
+}
+
+// ReplaceRules swaps every rule of the resource for the given ones.
+func (r *BookingRepository) ReplaceRules(resourceID string, rules []entity.BookingAvailabilityRule) error {
+	return r.DBConn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("resource_id = ?", resourceID).Delete(&entity.BookingAvailabilityRule{}).Error; err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		return tx.Create(&rules).Error
+	})
+}
+
+// ---------- reservation ----------
+
+func (r *BookingRepository) CreateReservation(reservation *entity.BookingReservation) error {
+	return r.DBConn.Create(reservation).Error
+}
+
+func (r *BookingRepository) UpdateReservation(reservation *entity.BookingReservation) error {
+	return r.DBConn.Save(reservation).Error
+}
+
+func (r *BookingRepository) GetReservationByID(id string) (*entity.BookingReservation, error) {
+	var reservation entity.BookingReservation
+	err := r.DBConn.Where("id = ?", id).First(&reservation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// CountOverlapping counts the confirmed reservations of a resource overlapping [startAt, endAt).
+func (r *BookingRepository) CountOverlapping(resourceID string, startAt, endAt time.Time) (int64, error) {
+	var count int64
+	err := r.DBConn.Model(&entity.BookingReservation{}).
+		Where("resource_id = ? AND status = ? AND start_at < ? AND end_at > ?",
+			resourceID, value.BookingReservationStatusConfirmed, endAt, startAt).
+		Count(&count).Error
+	return count, err
+}
+
+// GetConfirmedInRange returns the confirmed reservations of a resource overlapping [from, to).
+func (r *BookingRepository) GetConfirmedInRange(resourceID string, from, to time.Time) ([]entity.BookingReservation, error) {
+	var reservations []entity.BookingReservation
+	err := r.DBConn.
+		Where("resource_id = ? AND status = ? AND start_at < ? AND end_at > ?",
+			resourceID, value.BookingReservationStatusConfirmed, to, from).
+		Order("start_at ASC").
+		Find(&reservations).Error
+	return reservations, err
+}
+
+func (r *BookingRepository) GetReservationsByResource(resourceID string, from, to time.Time) ([]entity.BookingReservation, error) {
+	var reservations []entity.BookingReservation
+	err := r.DBConn.
+		Where("resource_id = ? AND start_at < ? AND end_at > ?", resourceID, to, from).
+		Order("start_at ASC").
+		Find(&reservations).Error
+	return reservations, err
+}
+
+func (r *BookingRepository) GetUpcomingByUser(userID string, from time.Time) ([]entity.BookingReservation, error) {
+	var reservations []entity.BookingReservation
+	err := r.DBConn.
+		Where("user_id = ? AND end_at > ?", userID, from).
+		Order("start_at ASC").
+		Find(&reservations).Error
+	return reservations, err
+}
+
+// GetDueReminders returns confirmed reservations starting before `until` whose reminder has not been sent yet.
+func (r *BookingRepository) GetDueReminders(now, until time.Time) ([]entity.BookingReservation, error) {
+	var reservations []entity.BookingReservation
+	err := r.DBConn.
+		Where("status = ? AND reminder_sent = ? AND start_at > ? AND start_at <= ?",
+			value.BookingReservationStatusConfirmed, false, now, until).
+		Find(&reservations).Error
+	return reservations, err
+}
+
+func (r *BookingRepository) MarkReminderSent(id string) error {
+	return r.DBConn.Model(&entity.BookingReservation{}).
+		Where("id = ?", id).
+		Update("reminder_sent", true).Error
+}
+
+func (r *BookingRepository) AttachSubmission(ids []string, submissionID uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.DBConn.Model(&entity.BookingReservation{}).
+		Where("id IN ?", ids).
+		Update("submission_id", submissionID).Error
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"errors"
+	"sen-global-api/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type ChildConsentRepository struct {
+	DBConn *gorm.DB
+}
+
+func (r *ChildConsentRepository) GetByChildID(childID string) (*entity.ChildConsent, error) {
+	var consent entity.ChildConsent
+	err := r.DBConn.Where("child_id = ?", childID).First(&consent).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &consent, nil
+}
+
+func (r *ChildConsentRepository) Create(consent *entity.ChildConsent) error {
+	return r.DBConn.Create(consent).Error
+}
+
+func (r *ChildConsentRepository) Update(consent *entity.ChildConsent) error {
+	return r.DBConn.Save(consent).Error
+}
+
+// IsChild reports whether id is the id of a child, whose photos need the consent of its primary contact.
+func (r *ChildConsentRepository) IsChild(id string) (bool, error) {
+	var count int64
+	err := r.DBConn.Model(&entity.SChild{}).Where("id = ?", id).Count(&count).Error
+	return count > 0, err
+}
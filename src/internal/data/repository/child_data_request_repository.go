@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"errors"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/value"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type ChildDataRequestRepository struct {
+	DBConn *gorm.DB
+}
+
+func (r *ChildDataRequestRepository) Create(req *entity.ChildDataRequest) error {
+	return r.DBConn.Create(req).Error
+}
+
+func (r *ChildDataRequestRepository) GetByID(id string) (*entity.ChildDataRequest, error) {
+	var req entity.ChildDataRequest
+	err := r.DBConn.Where("id = ?", id).First(&req).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &req, nil
+}
+
+func (r *ChildDataRequestRepository) GetByChildID(childID string) ([]entity.ChildDataRequest, error) {
+	var requests []entity.ChildDataRequest
+	err := r.DBConn.Where("child_id = ?", childID).Order("created_at DESC").Find(&requests).Error
+	return requests, err
+}
+
+func (r *ChildDataRequestRepository) GetAll() ([]entity.ChildDataRequest, error) {
+	var requests []entity.ChildDataRequest
+	err := r.DBConn.Order("created_at DESC").Find(&requests).Error
+	return requests, err
+}
+
+func (r *ChildDataRequestRepository) UpdateStatus(id string, status value.ChildDataRequestStatus) error {
+	return r.DBConn.Model(&entity.ChildDataRequest{}).
+		Where("id = ?", id).
+		Update("status", status).Error
+}
+
+func (r *ChildDataRequestRepository) MarkDone(id string, resultKey string, summary datatypes.JSON) error {
+	now := time.Now()
+	return r.DBConn.Model(&entity.ChildDataRequest{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":       value.ChildDataRequestStatusDone,
+			"result_key":   resultKey,
+			"summary":      summary,
+			"completed_at": &now,
+		}).Error
+}
+
+func (r *ChildDataRequestRepository) MarkFailed(id string, reason string) error {
+	now := time.Now()
+	return r.DBConn.Model(&entity.ChildDataRequest{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":       value.ChildDataRequestStatusFailed,
+			"error":        reason,
+			"completed_at": &now,
+		}).Error
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"errors"
+	"sen-global-api/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type ChildGuardianRepository struct {
+	DBConn *gorm.DB
+}
+
+func NewChildGuardianRepository(dbConn *gorm.DB) *ChildGuardianRepository {
+	return &ChildGuardianRepository{DBConn: dbConn}
+}
+
+func (r *ChildGuardianRepository) Create(guardian *entity.SChildGuardian) error {
+	return r.DBConn.Create(guardian).Error
+}
+
+func (r *ChildGuardianRepository) Update(guardian *entity.SChildGuardian) error {
+	return r.DBConn.Save(guardian).Error
+}
+
+func (r *ChildGuardianRepository) GetByChildID(childID string) ([]entity.SChildGuardian, error) {
+	var guardians []entity.SChildGuardian
+	err := r.DBConn.
+		Where("child_id = ?", childID).
+		Order("is_primary_contact DESC, created_at ASC").
+		Find(&guardians).Error
+	return guardians, err
+}
+
+func (r *ChildGuardianRepository) GetByGuardianUserID(userID string) ([]entity.SChildGuardian, error) {
+	var guardians []entity.SChildGuardian
+	err := r.DBConn.Where("guardian_user_id = ?", userID).Find(&guardians).Error
+	return guardians, err
+}
+
+func (r *ChildGuardianRepository) GetByChildAndGuardian(childID, userID string) (*entity.SChildGuardian, error) {
+	var guardian entity.SChildGuardian
+	err := r.DBConn.
+		Where("child_id = ? AND guardian_user_id = ?", childID, userID).
+		First(&guardian).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &guardian, nil
+}
+
+// ClearPrimaryContact unsets the primary contact flag for every guardian of the child.
+func (r *ChildGuardianRepository) ClearPrimaryContact(childID string) error {
+	return r.DBConn.Model(&entity.SChildGuardian{}).
+		Where("child_id = ?", childID).
+		Update("is_primary_contact", false).Error
+}
+
+func (r *ChildGuardianRepository) Delete(childID, userID string) error {
+	return r.DBConn.
+		Where("child_id = ? AND guardian_user_id = ?", childID, userID).
+		Delete(&entity.SChildGuardian{}).Error
+}
+
+func (r *ChildGuardianRepository) WithTx(tx *gorm.DB) *ChildGuardianRepository {
+	return &ChildGuardianRepository{DBConn: tx}
+}
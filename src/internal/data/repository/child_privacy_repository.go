@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/value"
+
+	"gorm.io/gorm"
+)
+
+// ChildPrivacyRepository gathers and erases every record that belongs to a child across tables.
+type ChildPrivacyRepository struct {
+	DBConn *gorm.DB
+}
+
+type ChildDataSnapshot struct {
+	Child              *entity.SChild              `json:"child"`
+	Guardians          []entity.SChildGuardian     `json:"guardians"`
+	Consent            *entity.ChildConsent        `json:"consent"`
+	Submissions        []entity.SSubmission        `json:"submissions"`
+	Answers            []entity.SAnswer            `json:"answers"`
+	UserImages         []entity.UserImages         `json:"user_images"`
+	Images             []entity.SImage             `json:"images"`
+	Menus              []entity.ChildMenu          `json:"menus"`
+	LanguagesConfigs   []entity.LanguagesConfig    `json:"languages_configs"`
+	ValuesAppHistories []entity.ValuesAppHistories `json:"values_app_histories"`
+	AccountsLogs       []entity.AccountsLog        `json:"accounts_logs"`
+}
+
+func (r *ChildPrivacyRepository) Collect(childID string) (*ChildDataSnapshot, error) {
+	snapshot := &ChildDataSnapshot{}
+
+	var child entity.SChild
+	if err := r.DBConn.Where("id = ?", childID).Limit(1).Find(&child).Error; err != nil {
+		return nil, err
+	}
+	if child.ChildName != "" {
+		snapshot.Child = &child
+	}
+
+	if err := r.DBConn.Where("child_id = ?", childID).Find(&snapshot.Guardians).Error; err != nil {
+		return nil, err
+	}
+
+	var consent entity.ChildConsent
+	if err := r.DBConn.Where("child_id = ?", childID).Limit(1).Find(&consent).Error; err != nil {
+		return nil, err
+	}
+	if consent.ChildID != "" {
+		snapshot.Consent = &consent
+	}
+
+	if err := r.DBConn.Omit("Form", "User").Where("child_id = ?", childID).Find(&snapshot.Submissions).Error; err != nil {
+		return nil, err
+	}
+
+	submissionIDs := make([]uint64, 0, len(snapshot.Submissions))
+	for _, s := range snapshot.Submissions {
+		submissionIDs = append(submissionIDs, s.ID)
+	}
+	if len(submissionIDs) > 0 {
+		if err := r.DBConn.Where("submission_id IN ?", submissionIDs).Find(&snapshot.Answers).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.DBConn.
+		Where("owner_id = ? AND owner_role = ?", childID, value.OwnerRoleChild).
+		Find(&snapshot.UserImages).Error; err != nil {
+		return nil, err
+	}
+
+	imageIDs := make([]uint64, 0, len(snapshot.UserImages))
+	for _, ui := range snapshot.UserImages {
+		imageIDs = append(imageIDs, ui.ImageID)
+	}
+	if len(imageIDs) > 0 {
+		if err := r.DBConn.Where("id IN ?", imageIDs).Find(&snapshot.Images).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.DBConn.Where("child_id = ?", childID).Find(&snapshot.Menus).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.DBConn.
+		Where("owner_id = ? AND owner_role = ?", childID, value.OwnerRoleLangChild).
+		Find(&snapshot.LanguagesConfigs).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.DBConn.
+		Where("value1 = ? OR value3 = ?", childID, childID).
+		Find(&snapshot.ValuesAppHistories).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.DBConn.Where("user_id = ?", childID).Find(&snapshot.AccountsLogs).Error; err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// Erase deletes every record of the child inside the given transaction and returns the number of rows removed per table.
+// Files on the upload provider must be removed by the caller once the transaction is committed.
+func (r *ChildPrivacyRepository) Erase(tx *gorm.DB, snapshot *ChildDataSnapshot, childID string) (map[string]int64, error) {
+	summary := make(map[string]int64)
+
+	del := func(table string, query *gorm.DB, model interface{}) error {
+		res := query.Delete(model)
+		if res.Error != nil {
+			return res.Error
+		}
+		summary[table] = res.RowsAffected
+		return nil
+	}
+
+	submissionIDs := make([]uint64, 0, len(snapshot.Submissions))
+	for _, s := range snapshot.Submissions {
+		submissionIDs = append(submissionIDs, s.ID)
+	}
+	if len(submissionIDs) > 0 {
+		if err := del("s_answer", tx.Where("submission_id IN ?", submissionIDs), &entity.SAnswer{}); err != nil {
+			return nil, err
+		}
+		if err := del("s_submission", tx.Where("id IN ?", submissionIDs), &entity.SSubmission{}); err != nil {
+			return nil, err
+		}
+	}
+
+	imageIDs := make([]uint64, 0, len(snapshot.Images))
+	for _, img := range snapshot.Images {
+		imageIDs = append(imageIDs, img.ID)
+	}
+	if err := del("user_images", tx.Where("owner_id = ? AND owner_role = ?", childID, value.OwnerRoleChild), &entity.UserImages{}); err != nil {
+		return nil, err
+	}
+	if len(imageIDs) > 0 {
+		if err := del("s_image", tx.Where("id IN ?", imageIDs), &entity.SImage{}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := del("child_menu", tx.Where("child_id = ?", childID), &entity.ChildMenu{}); err != nil {
+		return nil, err
+	}
+	if err := del("languages_config", tx.Where("owner_id = ? AND owner_role = ?", childID, value.OwnerRoleLangChild), &entity.LanguagesConfig{}); err != nil {
+		return nil, err
+	}
+	if err := del("values_app_histories", tx.Where("value1 = ? OR value3 = ?", childID, childID), &entity.ValuesAppHistories{}); err != nil {
+		return nil, err
+	}
+	if err := del("values_app_current", tx.Where("value1 = ? OR value3 = ?", childID, childID), &entity.ValuesAppCurrent{}); err != nil {
+		return nil, err
+	}
+	if err := del("accounts_log", tx.Where("user_id = ?", childID), &entity.AccountsLog{}); err != nil {
+		return nil, err
+	}
+	if err := del("child_consent", tx.Where("child_id = ?", childID), &entity.ChildConsent{}); err != nil {
+		return nil, err
+	}
+	if err := del("s_child_guardian", tx.Where("child_id = ?", childID), &entity.SChildGuardian{}); err != nil {
+		return nil, err
+	}
+	if err := del("s_parent_childs", tx.Where("child_id = ?", childID), &entity.SParentChilds{}); err != nil {
+		return nil, err
+	}
+	if err := del("s_child", tx.Where("id = ?", childID), &entity.SChild{}); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
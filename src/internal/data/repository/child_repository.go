@@ -47,6 +47,16 @@ func (r *ChildRepository) GetByParentID(userID string) ([]entity.SChild, error)
 	return children, err
 }
 
+// Get all children the user is a guardian of, via s_child_guardian
+func (r *ChildRepository) GetByGuardianUserID(userID string) ([]entity.SChild, error) {
+	var children []entity.SChild
+	err := r.DB.
+		Joins("JOIN s_child_guardian cg ON cg.child_id = s_child.id").
+		Where("cg.guardian_user_id = ?", userID).
+		Find(&children).Error
+	return children, err
+}
+
 // Get child by ID
 func (r *ChildRepository) GetByID(id string) (*entity.SChild, error) {
 	var child entity.SChild
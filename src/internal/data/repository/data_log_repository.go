@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/pkg/mysql"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const dataLogTable = "data_log"
+
+type DataLogRepository struct {
+	DBConn *gorm.DB
+}
+
+func NewDataLogRepository(dbConn *gorm.DB) *DataLogRepository {
+	return &DataLogRepository{DBConn: dbConn}
+}
+
+type DataLogFilter struct {
+	Endpoint   string
+	Method     string
+	Status     string
+	StatusCode int
+	UserID     string
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+	Page       int
+	Limit      int
+}
+
+func (r *DataLogRepository) CreateBatch(logs []entity.DataLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	return r.DBConn.CreateInBatches(logs, 100).Error
+}
+
+func (r *DataLogRepository) Search(filter DataLogFilter) ([]entity.DataLog, int64, error) {
+	query := mysql.Replica(r.DBConn).Model(&entity.DataLog{})
+	if filter.Endpoint != "" {
+		if strings.HasSuffix(filter.Endpoint, "*") {
+			query = query.Where("endpoint LIKE ?", strings.TrimSuffix(filter.Endpoint, "*")+"%")
+		} else {
+			query = query.Where("endpoint = ?", filter.Endpoint)
+		}
+	}
+	if filter.Method != "" {
+		query = query.Where("method = ?", strings.ToUpper(filter.Method))
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.StatusCode > 0 {
+		query = query.Where("status_code = ?", filter.StatusCode)
+	}
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var logs []entity.DataLog
+	err := query.Order("created_at DESC").
+		Offset((filter.Page - 1) * filter.Limit).
+		Limit(filter.Limit).
+		Find(&logs).Error
+	return logs, total, err
+}
+
+// DeleteBefore removes the logs older than cutoff by chunks, so the table is never locked for long.
+func (r *DataLogRepository) DeleteBefore(cutoff time.Time, chunk int) (int64, error) {
+	var deleted int64
+	for {
+		res := r.DBConn.Exec("DELETE FROM "+dataLogTable+" WHERE created_at < ? LIMIT ?", cutoff, chunk)
+		if res.Error != nil {
+			return deleted, res.Error
+		}
+		deleted += res.RowsAffected
+		if res.RowsAffected < int64(chunk) {
+			return deleted, nil
+		}
+	}
+}
+
+// ---------- monthly partitions ----------
+
+// dataLogPartition is named after the month it holds, e.g. p202610.
+func dataLogPartition(month time.Time) string {
+	return month.Format("p200601")
+}
+
+func firstOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// maintenance runs the partition DDL without the default query timeout, it can outlast it on a large table.
+func (r *DataLogRepository) maintenance() *gorm.DB {
+	return r.DBConn.WithContext(mysql.WithoutTimeout(context.Background()))
+}
+
+// Partitions returns the monthly partitions of the table, the migration data_log_partitions created them.
+func (r *DataLogRepository) Partitions() ([]string, error) {
+	var names []string
+	err := r.DBConn.Raw(`SELECT PARTITION_NAME FROM information_schema.PARTITIONS
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND PARTITION_NAME IS NOT NULL
+		ORDER BY PARTITION_ORDINAL_POSITION`, dataLogTable).Scan(&names).Error
+	return names, err
+}
+
+// EnsurePartitions splits pmax so that every month up to `ahead` months after now has its own partition.
+func (r *DataLogRepository) EnsurePartitions(existing []string, now time.Time, ahead int) error {
+	has := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		has[name] = true
+	}
+
+	parts := make([]string, 0)
+	for i := 0; i <= ahead; i++ {
+		month := firstOfMonth(now).AddDate(0, i, 0)
+		if has[dataLogPartition(month)] {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("PARTITION %s VALUES LESS THAN ('%s')",
+			dataLogPartition(month), month.AddDate(0, 1, 0).Format("2006-01-02")))
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	parts = append(parts, "PARTITION pmax VALUES LESS THAN (MAXVALUE)")
+
+	return r.maintenance().Exec("ALTER TABLE " + dataLogTable +
+		" REORGANIZE PARTITION pmax INTO (" + strings.Join(parts, ", ") + ")").Error
+}
+
+// DropPartitionsBefore drops the monthly partitions holding only logs older than cutoff.
+func (r *DataLogRepository) DropPartitionsBefore(existing []string, cutoff time.Time) ([]string, error) {
+	limit := dataLogPartition(firstOfMonth(cutoff))
+	dropped := make([]string, 0)
+	for _, name := range existing {
+		// pYYYYMM sorts like the months, pmax is never dropped
+		if name == "pmax" || len(name) != len(limit) || name >= limit {
+			continue
+		}
+		dropped = append(dropped, name)
+	}
+	if len(dropped) == 0 {
+		return dropped, nil
+	}
+	err := r.maintenance().Exec("ALTER TABLE " + dataLogTable + " DROP PARTITION " + strings.Join(dropped, ", ")).Error
+	return dropped, err
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/value"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type DeviceCommandRepository struct {
+	DBConn *gorm.DB
+}
+
+func NewDeviceCommandRepository(dbConn *gorm.DB) *DeviceCommandRepository {
+	return &DeviceCommandRepository{DBConn: dbConn}
+}
+
+var pendingDeviceCommandStatuses = []value.DeviceCommandStatus{
+	value.DeviceCommandStatusQueued,
+	value.DeviceCommandStatusDelivered,
+}
+
+func (r *DeviceCommandRepository) Create(commands []entity.DeviceCommand) error {
+	if len(commands) == 0 {
+		return nil
+	}
+	return r.DBConn.Create(&commands).Error
+}
+
+func (r *DeviceCommandRepository) GetByID(id string) (*entity.DeviceCommand, error) {
+	var command entity.DeviceCommand
+	if err := r.DBConn.Where("id = ?", id).First(&command).Error; err != nil {
+		return nil, err
+	}
+	return &command, nil
+}
+
+// GetPending returns the commands the device has still to run, the oldest first.
+func (r *DeviceCommandRepository) GetPending(deviceID string, now time.Time) ([]entity.DeviceCommand, error) {
+	var commands []entity.DeviceCommand
+	err := r.DBConn.
+		Where("device_id = ? AND status IN ? AND expires_at > ?", deviceID, pendingDeviceCommandStatuses, now).
+		Order("created_at ASC").
+		Find(&commands).Error
+	return commands, err
+}
+
+// MarkDelivered moves the queued commands to delivered, the ones already delivered keep their first delivery.
+func (r *DeviceCommandRepository) MarkDelivered(ids []string, via string, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.DBConn.Model(&entity.DeviceCommand{}).
+		Where("id IN ? AND status = ?", ids, value.DeviceCommandStatusQueued).
+		Updates(map[string]interface{}{
+			"status":        value.DeviceCommandStatusDelivered,
+			"delivered_via": via,
+			"delivered_at":  at,
+		}).Error
+}
+
+// Complete saves the acknowledgement of a pending command, false when the command is not pending anymore.
+func (r *DeviceCommandRepository) Complete(command *entity.DeviceCommand) (bool, error) {
+	res := r.DBConn.Model(&entity.DeviceCommand{}).
+		Where("id = ? AND status IN ?", command.ID, pendingDeviceCommandStatuses).
+		Updates(map[string]interface{}{
+			"status":          command.Status,
+			"result":          command.Result,
+			"error":           command.Error,
+			"delivered_at":    command.DeliveredAt,
+			"acknowledged_at": command.AcknowledgedAt,
+		})
+	return res.RowsAffected > 0, res.Error
+}
+
+// ExpireDue expires the pending commands past their expiry.
+func (r *DeviceCommandRepository) ExpireDue(now time.Time) (int64, error) {
+	res := r.DBConn.Model(&entity.DeviceCommand{}).
+		Where("status IN ? AND expires_at <= ?", pendingDeviceCommandStatuses, now).
+		Update("status", value.DeviceCommandStatusExpired)
+	return res.RowsAffected, res.Error
+}
+
+// GetHistory returns the commands of a device, the newest first.
+func (r *DeviceCommandRepository) GetHistory(deviceID string, status string, limit int, offset int) ([]entity.DeviceCommand, int64, error) {
+	query := r.DBConn.Model(&entity.DeviceCommand{}).Where("device_id = ?", deviceID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var commands []entity.DeviceCommand
+	err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&commands).Error
+	return commands, total, err
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"errors"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/value"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DeviceEnrollmentRepository struct {
+	DBConn *gorm.DB
+}
+
+func NewDeviceEnrollmentRepository(dbConn *gorm.DB) *DeviceEnrollmentRepository {
+	return &DeviceEnrollmentRepository{DBConn: dbConn}
+}
+
+func (r *DeviceEnrollmentRepository) WithTx(tx *gorm.DB) *DeviceEnrollmentRepository {
+	return &DeviceEnrollmentRepository{DBConn: tx}
+}
+
+// ---------- code ----------
+
+func (r *DeviceEnrollmentRepository) CreateCode(code *entity.DeviceEnrollmentCode) error {
+	return r.DBConn.Create(code).Error
+}
+
+func (r *DeviceEnrollmentRepository) SaveCode(code *entity.DeviceEnrollmentCode) error {
+	return r.DBConn.Save(code).Error
+}
+
+// GetCode returns nil when the code does not exist.
+func (r *DeviceEnrollmentRepository) GetCode(id string) (*entity.DeviceEnrollmentCode, error) {
+	var code entity.DeviceEnrollmentCode
+	err := r.DBConn.Where("id = ?", id).First(&code).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &code, nil
+}
+
+// LockCode reads the code with a row lock so concurrent enrollments do not exceed its quota.
+// Must be called inside a transaction.
+func (r *DeviceEnrollmentRepository) LockCode(id string) (*entity.DeviceEnrollmentCode, error) {
+	var code entity.DeviceEnrollmentCode
+	err := r.DBConn.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&code).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &code, nil
+}
+
+// GetCodes returns the codes of an organization, the newest first.
+func (r *DeviceEnrollmentRepository) GetCodes(orgID string, limit int, offset int) ([]entity.DeviceEnrollmentCode, int64, error) {
+	query := r.DBConn.Model(&entity.DeviceEnrollmentCode{}).Where("organization_id = ?", orgID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var codes []entity.DeviceEnrollmentCode
+	err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&codes).Error
+	return codes, total, err
+}
+
+func (r *DeviceEnrollmentRepository) IncrementUsed(codeID string) error {
+	return r.DBConn.Model(&entity.DeviceEnrollmentCode{}).
+		Where("id = ?", codeID).
+		UpdateColumn("used_count", gorm.Expr("used_count + 1")).Error
+}
+
+// ---------- enrollment ----------
+
+// GetEnrollment returns nil when the device was not enrolled by the code.
+func (r *DeviceEnrollmentRepository) GetEnrollment(codeID string, deviceID string) (*entity.DeviceEnrollment, error) {
+	var enrollment entity.DeviceEnrollment
+	err := r.DBConn.Where("code_id = ? AND device_id = ?", codeID, deviceID).First(&enrollment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &enrollment, nil
+}
+
+func (r *DeviceEnrollmentRepository) CreateEnrollment(enrollment *entity.DeviceEnrollment) error {
+	return r.DBConn.Create(enrollment).Error
+}
+
+func (r *DeviceEnrollmentRepository) GetEnrollments(codeID string) ([]entity.DeviceEnrollment, error) {
+	var enrollments []entity.DeviceEnrollment
+	err := r.DBConn.Where("code_id = ?", codeID).Order("created_at ASC").Find(&enrollments).Error
+	return enrollments, err
+}
+
+// ---------- device ----------
+
+// GetOrgDevice returns nil when the device is not in the organization.
+func (r *DeviceEnrollmentRepository) GetOrgDevice(orgID string, deviceID string) (*entity.SOrgDevices, error) {
+	var orgDevice entity.SOrgDevices
+	err := r.DBConn.Where("organization_id = ? AND device_id = ?", orgID, deviceID).First(&orgDevice).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &orgDevice, nil
+}
+
+func (r *DeviceEnrollmentRepository) CreateOrgDevice(orgDevice *entity.SOrgDevices) error {
+	return r.DBConn.Create(orgDevice).Error
+}
+
+// SetDeviceProfile gives the device component values profile to the device.
+func (r *DeviceEnrollmentRepository) SetDeviceProfile(deviceID string, profileID int64) error {
+	return r.DBConn.Model(&entity.SDevice{}).
+		Where("id = ?", deviceID).
+		Update("device_component_values_id", profileID).Error
+}
+
+// AddDeviceMenus adds the components missing from the menu of the device.
+func (r *DeviceEnrollmentRepository) AddDeviceMenus(deviceID string, menus []entity.SDeviceMenuV2) error {
+	if len(menus) == 0 {
+		return nil
+	}
+	var existing []string
+	if err := r.DBConn.Model(&entity.SDeviceMenuV2{}).
+		Where("device_id = ?", deviceID).
+		Pluck("component_id", &existing).Error; err != nil {
+		return err
+	}
+	has := make(map[string]bool, len(existing))
+	for _, id := range existing {
+		has[id] = true
+	}
+
+	missing := make([]entity.SDeviceMenuV2, 0, len(menus))
+	for _, m := range menus {
+		if !has[m.ComponentID.String()] {
+			missing = append(missing, m)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return r.DBConn.Create(&missing).Error
+}
+
+// ---------- limit ----------
+
+func (r *DeviceEnrollmentRepository) GetLimits(scope value.DeviceLimitScope) ([]entity.DeviceLimit, error) {
+	var limits []entity.DeviceLimit
+	query := r.DBConn.Order("scope ASC, scope_id ASC")
+	if scope != "" {
+		query = query.Where("scope = ?", scope)
+	}
+	err := query.Find(&limits).Error
+	return limits, err
+}
+
+func (r *DeviceEnrollmentRepository) GetLimit(scope value.DeviceLimitScope, scopeID string) (*entity.DeviceLimit, error) {
+	var limit entity.DeviceLimit
+	if err := r.DBConn.Where("scope = ? AND scope_id = ?", scope, scopeID).First(&limit).Error; err != nil {
+		return nil, err
+	}
+	return &limit, nil
+}
+
+// SaveLimit creates or updates the limit of its scope.
+func (r *DeviceEnrollmentRepository) SaveLimit(limit *entity.DeviceLimit) error {
+	return r.DBConn.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "scope"}, {Name: "scope_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"max_devices", "updated_by", "updated_at"}),
+	}).Create(limit).Error
+}
+
+func (r *DeviceEnrollmentRepository) DeleteLimit(scope value.DeviceLimitScope, scopeID string) error {
+	return r.DBConn.Where("scope = ? AND scope_id = ?", scope, scopeID).Delete(&entity.DeviceLimit{}).Error
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"errors"
+	"sen-global-api/internal/domain/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DeviceHeartbeatRepository struct {
+	DBConn *gorm.DB
+}
+
+func NewDeviceHeartbeatRepository(dbConn *gorm.DB) *DeviceHeartbeatRepository {
+	return &DeviceHeartbeatRepository{DBConn: dbConn}
+}
+
+// Upsert saves the last heartbeat of each device, an older beat (rolled up late) does not replace a newer one.
+func (r *DeviceHeartbeatRepository) Upsert(heartbeats []entity.DeviceHeartbeat) error {
+	if len(heartbeats) == 0 {
+		return nil
+	}
+	return r.DBConn.Clauses(heartbeatUpsertClause()).Create(&heartbeats).Error
+}
+
+// heartbeatUpsertClause keeps the row of the newest beat. MySQL assigns from left to right, once last_seen_at
+// is updated the condition stays true for the next columns.
+func heartbeatUpsertClause() clause.OnConflict {
+	columns := []string{"last_seen_at", "user_id", "app_version", "battery_level", "charging",
+		"storage_free_mb", "storage_total_mb", "network", "signal_level", "updated_at"}
+	assignments := make(clause.Set, 0, len(columns))
+	for _, column := range columns {
+		assignments = append(assignments, clause.Assignment{
+			Column: clause.Column{Name: column},
+			Value:  gorm.Expr("IF(VALUES(last_seen_at) >= last_seen_at, VALUES(`" + column + "`), `" + column + "`)"),
+		})
+	}
+	return clause.OnConflict{
+		Columns:   []clause.Column{{Name: "device_id"}},
+		DoUpdates: assignments,
+	}
+}
+
+// SyncAppVersions copies the app version of the last heartbeat to the devices, for the app release reports.
+func (r *DeviceHeartbeatRepository) SyncAppVersions(deviceIDs []string) error {
+	if len(deviceIDs) == 0 {
+		return nil
+	}
+	return r.DBConn.Exec(`UPDATE s_device d JOIN device_heartbeat h ON h.device_id = d.id
+		SET d.app_version = h.app_version
+		WHERE d.id IN ? AND h.app_version <> '' AND d.app_version <> h.app_version`, deviceIDs).Error
+}
+
+func (r *DeviceHeartbeatRepository) GetByDeviceIDs(deviceIDs []string) ([]entity.DeviceHeartbeat, error) {
+	var heartbeats []entity.DeviceHeartbeat
+	if len(deviceIDs) == 0 {
+		return heartbeats, nil
+	}
+	err := r.DBConn.Where("device_id IN ?", deviceIDs).Find(&heartbeats).Error
+	return heartbeats, err
+}
+
+// GetOrgDevices returns the devices of an organization with their nick name.
+func (r *DeviceHeartbeatRepository) GetOrgDevices(orgID string) ([]entity.SOrgDevices, error) {
+	var orgDevices []entity.SOrgDevices
+	err := r.DBConn.Preload("Device").
+		Where("organization_id = ?", orgID).
+		Order("created_index ASC").
+		Find(&orgDevices).Error
+	return orgDevices, err
+}
+
+// GetAlertSetting returns nil when the organization has no setting.
+func (r *DeviceHeartbeatRepository) GetAlertSetting(orgID string) (*entity.DeviceAlertSetting, error) {
+	var setting entity.DeviceAlertSetting
+	err := r.DBConn.Where("organization_id = ?", orgID).First(&setting).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (r *DeviceHeartbeatRepository) GetEnabledAlertSettings() ([]entity.DeviceAlertSetting, error) {
+	var settings []entity.DeviceAlertSetting
+	err := r.DBConn.Where("enabled = ?", true).Find(&settings).Error
+	return settings, err
+}
+
+func (r *DeviceHeartbeatRepository) SaveAlertSetting(setting *entity.DeviceAlertSetting) error {
+	return r.DBConn.Save(setting).Error
+}
+
+func (r *DeviceHeartbeatRepository) GetOpenAlerts(orgID string) ([]entity.DeviceOfflineAlert, error) {
+	var alerts []entity.DeviceOfflineAlert
+	err := r.DBConn.Where("organization_id = ? AND resolved_at IS NULL", orgID).Find(&alerts).Error
+	return alerts, err
+}
+
+func (r *DeviceHeartbeatRepository) CreateAlerts(alerts []entity.DeviceOfflineAlert) error {
+	if len(alerts) == 0 {
+		return nil
+	}
+	return r.DBConn.Create(&alerts).Error
+}
+
+// ResolveDeviceAlerts closes the open alerts of devices beating again.
+func (r *DeviceHeartbeatRepository) ResolveDeviceAlerts(deviceIDs []string, at time.Time) error {
+	if len(deviceIDs) == 0 {
+		return nil
+	}
+	return r.DBConn.Model(&entity.DeviceOfflineAlert{}).
+		Where("device_id IN ? AND resolved_at IS NULL", deviceIDs).
+		Update("resolved_at", at).Error
+}
+
+// GetAlerts returns the alerts of an organization, the newest first.
+func (r *DeviceHeartbeatRepository) GetAlerts(orgID string, limit int, offset int) ([]entity.DeviceOfflineAlert, int64, error) {
+	query := r.DBConn.Model(&entity.DeviceOfflineAlert{}).Where("organization_id = ?", orgID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var alerts []entity.DeviceOfflineAlert
+	err := query.Order("alerted_at DESC").Limit(limit).Offset(offset).Find(&alerts).Error
+	return alerts, total, err
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"errors"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/value"
+
+	"gorm.io/gorm"
+)
+
+type DeviceModeProfileRepository struct {
+	DBConn *gorm.DB
+}
+
+func NewDeviceModeProfileRepository(dbConn *gorm.DB) *DeviceModeProfileRepository {
+	return &DeviceModeProfileRepository{DBConn: dbConn}
+}
+
+// GetAll returns the default profiles and, with orgID, the profiles of the organization.
+func (r *DeviceModeProfileRepository) GetAll(orgID string) ([]entity.DeviceModeProfile, error) {
+	var profiles []entity.DeviceModeProfile
+	err := r.DBConn.Where("organization_id IN ?", []string{"", orgID}).
+		Order("organization_id ASC, mode ASC").
+		Find(&profiles).Error
+	return profiles, err
+}
+
+// GetByID returns nil when the profile does not exist.
+func (r *DeviceModeProfileRepository) GetByID(id string) (*entity.DeviceModeProfile, error) {
+	var profile entity.DeviceModeProfile
+	err := r.DBConn.Where("id = ?", id).First(&profile).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// Get returns the profile of the mode in the organization, "" for the default one, nil when there is none.
+func (r *DeviceModeProfileRepository) Get(mode value.DeviceMode, orgID string) (*entity.DeviceModeProfile, error) {
+	var profile entity.DeviceModeProfile
+	err := r.DBConn.Where("mode = ? AND organization_id = ?", mode, orgID).First(&profile).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (r *DeviceModeProfileRepository) Save(profile *entity.DeviceModeProfile) error {
+	return r.DBConn.Save(profile).Error
+}
+
+func (r *DeviceModeProfileRepository) Delete(id string) error {
+	return r.DBConn.Where("id = ?", id).Delete(&entity.DeviceModeProfile{}).Error
+}
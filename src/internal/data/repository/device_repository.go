@@ -8,6 +8,7 @@ import (
 	"sen-global-api/internal/domain/request"
 	"sen-global-api/internal/domain/response"
 	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/mysql"
 	"time"
 
 	"github.com/google/uuid"
@@ -43,12 +44,12 @@ func (receiver *DeviceRepository) GetDeviceList(request request.GetListDeviceReq
 	var err error
 	var count int64
 	if request.Keyword != "" {
-		err = receiver.DBConn.Raw("SELECT * FROM s_device WHERE device_name LIKE ? OR id LIKE ? AND row_no != ?"+
+		err = mysql.Replica(receiver.DBConn).Raw("SELECT * FROM s_device WHERE device_name LIKE ? OR id LIKE ? AND row_no != ?"+
 			"ORDER BY created_at DESC LIMIT ? OFFSET ?", "%"+request.Keyword+"%", "%"+request.Keyword+"%", 0,
 			limit, (request.Page-1)*limit).
 			Find(&devices).Error
 		if err == nil {
-			err = receiver.DBConn.Model(&entity.SDevice{}).
+			err = mysql.Replica(receiver.DBConn).Model(&entity.SDevice{}).
 				Where("id LIKE ? AND row_no != ?", "%"+request.Keyword+"%", 0).
 				Or("id LIKE ?", "%"+request.Keyword+"%").
 				Count(&count).Error
@@ -145,6 +146,22 @@ func (receiver *DeviceRepository) GetDeviceByID(deviceID string) (*entity.SDevic
 	return &device, err
 }
 
+// IsUserOfDevice tells whether the user registered the device or logged in on it.
+func (receiver *DeviceRepository) IsUserOfDevice(userID string, deviceID string) (bool, error) {
+	var count int64
+	err := receiver.DBConn.Model(&entity.SUserDevices{}).
+		Where("user_id = ? AND device_id = ?", userID, deviceID).
+		Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+
+	err = receiver.DBConn.Model(&entity.UserDevicesLogin{}).
+		Where("user_id = ? AND device_id = ?", userID, deviceID).
+		Count(&count).Error
+	return count > 0, err
+}
+
 func (receiver *DeviceRepository) UpdateDevice(device *entity.SDevice) (*entity.SDevice, error) {
 	err := receiver.DBConn.Save(&device).Error
 	if err != nil {
@@ -172,6 +189,13 @@ func (receiver *DeviceRepository) CopyOutputFromDevice(sourceDevice entity.SDevi
 	return receiver.DBConn.Save(&targetDevice).Error
 }
 
+// UpdateAppVersion records the version reported by the device, the device must exist.
+func (receiver *DeviceRepository) UpdateAppVersion(deviceID string, platform value.DeviceType, appVersion string) error {
+	return receiver.DBConn.Model(&entity.SDevice{}).
+		Where("id = ?", deviceID).
+		Updates(map[string]interface{}{"platform": platform, "app_version": appVersion}).Error
+}
+
 func (receiver *DeviceRepository) SaveDevices(devices []entity.SDevice) error {
 	if len(devices) == 0 {
 		return nil
@@ -315,11 +339,37 @@ func (receiver *DeviceRepository) CheckOrgDeviceExist(req request.RegisteringDev
 	return nil
 }
 
+// DefaultUserDeviceLimit is the number of devices of a user without device limit, the organizations are
+// unlimited by default.
+const DefaultUserDeviceLimit = 2
+
+// GetDeviceLimit returns the max devices of the scope, def when the scope has no limit. 0 is unlimited.
+func (receiver *DeviceRepository) GetDeviceLimit(scope value.DeviceLimitScope, scopeID string, def int) (int, error) {
+	var limit entity.DeviceLimit
+	err := receiver.DBConn.Where("scope = ? AND scope_id = ?", scope, scopeID).First(&limit).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return def, nil
+		}
+		return 0, err
+	}
+	return limit.MaxDevices, nil
+}
+
 func (receiver *DeviceRepository) CheckDeviceLimitation(userID string) error {
+	limit, err := receiver.GetDeviceLimit(value.DeviceLimitScopeUser, userID, DefaultUserDeviceLimit)
+	if err != nil {
+		log.Error("UserEntityRepository.CheckDeviceLimitation: " + err.Error())
+		return errors.New("failed to get device limit")
+	}
+	if limit == 0 {
+		return nil
+	}
+
 	queryCheck := receiver.DBConn.Table("s_user_devices").Where("user_id = ?", userID)
 
 	var deviceCount int64
-	err := queryCheck.Count(&deviceCount).Error
+	err = queryCheck.Count(&deviceCount).Error
 
 	if err != nil {
 		log.Error("UserEntityRepository.CheckDeviceLimitation: " + err.Error())
@@ -330,13 +380,37 @@ func (receiver *DeviceRepository) CheckDeviceLimitation(userID string) error {
 		return errors.New("failed to get device count")
 	}
 
-	if deviceCount > 1 {
+	if deviceCount >= int64(limit) {
 		return errors.New("device limitation reached")
 	}
 
 	return nil
 }
 
+// CheckOrgDeviceLimitation checks one more device can join the organization.
+func (receiver *DeviceRepository) CheckOrgDeviceLimitation(orgID string) error {
+	limit, err := receiver.GetDeviceLimit(value.DeviceLimitScopeOrganization, orgID, 0)
+	if err != nil {
+		log.Error("DeviceRepository.CheckOrgDeviceLimitation: " + err.Error())
+		return errors.New("failed to get device limit")
+	}
+	if limit == 0 {
+		return nil
+	}
+
+	var deviceCount int64
+	if err := receiver.DBConn.Table("s_org_devices").Where("organization_id = ?", orgID).Count(&deviceCount).Error; err != nil {
+		log.Error("DeviceRepository.CheckOrgDeviceLimitation: " + err.Error())
+		return errors.New("failed to get device count")
+	}
+
+	if deviceCount >= int64(limit) {
+		return errors.New("organization device limitation reached")
+	}
+
+	return nil
+}
+
 func (receiver *DeviceRepository) RegisteringDeviceForUser(user *entity.SUserEntity, req request.RegisterDeviceRequest) (*string, error) {
 	var deviceID *string
 	device, err := receiver.GetDeviceByID(req.DeviceUUID)
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"sen-global-api/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type FeatureFlagRepository struct {
+	DBConn *gorm.DB
+}
+
+func NewFeatureFlagRepository(dbConn *gorm.DB) *FeatureFlagRepository {
+	return &FeatureFlagRepository{DBConn: dbConn}
+}
+
+func (r *FeatureFlagRepository) List() ([]entity.FeatureFlag, error) {
+	var flags []entity.FeatureFlag
+	err := r.DBConn.Order("`key` ASC").Find(&flags).Error
+	return flags, err
+}
+
+func (r *FeatureFlagRepository) GetByKey(key string) (*entity.FeatureFlag, error) {
+	var flag entity.FeatureFlag
+	if err := r.DBConn.Where("`key` = ?", key).First(&flag).Error; err != nil {
+		return nil, err
+	}
+	return &flag, nil
+}
+
+// Create, Save and Delete write the flag and its audit row in one transaction.
+
+func (r *FeatureFlagRepository) Create(flag *entity.FeatureFlag, audit *entity.FeatureFlagAudit) error {
+	return r.DBConn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(flag).Error; err != nil {
+			return err
+		}
+		return tx.Create(audit).Error
+	})
+}
+
+func (r *FeatureFlagRepository) Save(flag *entity.FeatureFlag, audit *entity.FeatureFlagAudit) error {
+	return r.DBConn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(flag).Error; err != nil {
+			return err
+		}
+		return tx.Create(audit).Error
+	})
+}
+
+func (r *FeatureFlagRepository) Delete(key string, audit *entity.FeatureFlagAudit) error {
+	return r.DBConn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("`key` = ?", key).Delete(&entity.FeatureFlag{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(audit).Error
+	})
+}
+
+// GetAudits returns the changes of a flag (every flag when empty), the newest first.
+func (r *FeatureFlagRepository) GetAudits(flagKey string, limit int, offset int) ([]entity.FeatureFlagAudit, int64, error) {
+	query := r.DBConn.Model(&entity.FeatureFlagAudit{})
+	if flagKey != "" {
+		query = query.Where("flag_key = ?", flagKey)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var audits []entity.FeatureFlagAudit
+	err := query.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&audits).Error
+	return audits, total, err
+}
@@ -8,6 +8,8 @@ import (
 	"sen-global-api/internal/domain/request"
 	"sen-global-api/internal/domain/response"
 	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/mysql"
+	"sen-global-api/pkg/passwordhash"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -38,12 +40,21 @@ func (receiver *FormRepository) GetFormByID(id uint64) (*entity.SForm, error) {
 }
 
 func (receiver *FormRepository) SaveForm(request parameters.SaveFormParams) (*entity.SForm, error) {
+	passwordHash := request.Password
+	if passwordHash != "" && !passwordhash.IsHash(passwordHash) {
+		hashed, err := passwordhash.Hash(passwordHash)
+		if err != nil {
+			return nil, err
+		}
+		passwordHash = hashed
+	}
+
 	form := entity.SForm{
 		Note:           request.Note,
 		Name:           request.Name,
 		SpreadsheetUrl: request.SpreadsheetUrl,
 		SpreadsheetID:  request.SpreadsheetID,
-		Password:       request.Password,
+		PasswordHash:   passwordHash,
 		Status:         value.Active,
 		SheetName:      request.SheetName,
 	}
@@ -96,8 +107,8 @@ func (receiver *FormRepository) GetFormList(request request.GetFormListRequest)
 	var err error
 	var count int64
 	if request.Keyword != "" {
-		err = receiver.DBConn.Where("note LIKE ?", "%"+request.Keyword+"%").Offset(offset).Limit(limit).Find(&forms).Error
-		receiver.DBConn.Model(&entity.SForm{}).Where("note LIKE ?", "%"+request.Keyword+"%").Count(&count)
+		err = mysql.Replica(receiver.DBConn).Where("note LIKE ?", "%"+request.Keyword+"%").Offset(offset).Limit(limit).Find(&forms).Error
+		mysql.Replica(receiver.DBConn).Model(&entity.SForm{}).Where("note LIKE ?", "%"+request.Keyword+"%").Count(&count)
 	} else {
 		err = receiver.DBConn.Offset(offset).Limit(limit).Find(&forms).Error
 		receiver.DBConn.Model(&entity.SForm{}).Count(&count)
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"errors"
+	"sen-global-api/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type FormScoringRepository struct {
+	DBConn *gorm.DB
+}
+
+func NewFormScoringRepository(dbConn *gorm.DB) *FormScoringRepository {
+	return &FormScoringRepository{DBConn: dbConn}
+}
+
+func (r *FormScoringRepository) GetByFormID(formID uint64) (*entity.FormScoring, error) {
+	var scoring entity.FormScoring
+	err := r.DBConn.Where("form_id = ?", formID).First(&scoring).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &scoring, nil
+}
+
+func (r *FormScoringRepository) Save(scoring *entity.FormScoring) error {
+	return r.DBConn.Save(scoring).Error
+}
+
+func (r *FormScoringRepository) DeleteByFormID(formID uint64) error {
+	return r.DBConn.Where("form_id = ?", formID).Delete(&entity.FormScoring{}).Error
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"sen-global-api/internal/domain/entity/menu"
 	"sen-global-api/internal/domain/request"
+	"sen-global-api/pkg/mysql"
 
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
@@ -20,7 +21,7 @@ func NewMenuRepository(dbConn *gorm.DB) *MenuRepository {
 
 func (receiver *MenuRepository) GetSuperAdminMenu() ([]menu.SuperAdminMenu, error) {
 	var menus []menu.SuperAdminMenu
-	err := receiver.DBConn.Model(&menu.SuperAdminMenu{}).
+	err := mysql.Replica(receiver.DBConn).Model(&menu.SuperAdminMenu{}).
 		Preload("Component").
 		Find(&menus).Error
 	if err != nil {
@@ -33,7 +34,7 @@ func (receiver *MenuRepository) GetSuperAdminMenu() ([]menu.SuperAdminMenu, erro
 
 func (receiver *MenuRepository) GetOrgMenu(orgID string) ([]menu.OrgMenu, error) {
 	var menus []menu.OrgMenu
-	err := receiver.DBConn.Model(&menu.OrgMenu{}).
+	err := mysql.Replica(receiver.DBConn).Model(&menu.OrgMenu{}).
 		Where("organization_id = ?", orgID).
 		Preload("Component").
 		Find(&menus).Error
@@ -48,7 +49,7 @@ func (receiver *MenuRepository) GetOrgMenu(orgID string) ([]menu.OrgMenu, error)
 func (receiver *MenuRepository) GetUserMenu(userID string) ([]menu.UserMenu, error) {
 	var menus []menu.UserMenu
 
-	err := receiver.DBConn.Model(&menu.UserMenu{}).
+	err := mysql.Replica(receiver.DBConn).Model(&menu.UserMenu{}).
 		Where("user_id = ?", userID).
 		Preload("Component").
 		Find(&menus).Error
@@ -63,7 +64,7 @@ func (receiver *MenuRepository) GetUserMenu(userID string) ([]menu.UserMenu, err
 func (receiver *MenuRepository) GetDeviceMenu(deviceID string) ([]menu.DeviceMenu, error) {
 	var menus []menu.DeviceMenu
 
-	err := receiver.DBConn.Model(&menu.DeviceMenu{}).
+	err := mysql.Replica(receiver.DBConn).Model(&menu.DeviceMenu{}).
 		Preload("Component").
 		Joins("INNER JOIN s_organization o ON o.id = device_menu.organization_id").
 		Joins("INNER JOIN s_org_devices od ON od.organization_id = o.id").
@@ -80,7 +81,7 @@ func (receiver *MenuRepository) GetDeviceMenu(deviceID string) ([]menu.DeviceMen
 func (receiver *MenuRepository) GetDeviceMenuByOrg(organizationID string) ([]menu.DeviceMenu, error) {
 	var menus []menu.DeviceMenu
 
-	err := receiver.DBConn.Model(&menu.DeviceMenu{}).
+	err := mysql.Replica(receiver.DBConn).Model(&menu.DeviceMenu{}).
 		Preload("Component").
 		Where("organization_id = ?", organizationID).
 		Find(&menus).Error
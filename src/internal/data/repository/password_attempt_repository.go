@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"errors"
+	"sen-global-api/internal/domain/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PasswordAttemptRepository struct {
+	DBConn *gorm.DB
+}
+
+func NewPasswordAttemptRepository(dbConn *gorm.DB) *PasswordAttemptRepository {
+	return &PasswordAttemptRepository{DBConn: dbConn}
+}
+
+// GetLockedUntil returns the end of the lock of the code, nil when the code is not locked.
+func (r *PasswordAttemptRepository) GetLockedUntil(scope, key string) (*time.Time, error) {
+	var attempt entity.SPasswordAttempt
+	err := r.DBConn.Where("scope = ? AND `key` = ?", scope, key).First(&attempt).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if attempt.LockedUntil == nil || !attempt.LockedUntil.After(time.Now()) {
+		return nil, nil
+	}
+	return attempt.LockedUntil, nil
+}
+
+// RecordFailure counts a failed check; after maxFailures failures in a row the code is locked for lockFor.
+// It returns the end of the lock when this failure locks the code.
+func (r *PasswordAttemptRepository) RecordFailure(scope, key string, maxFailures int, lockFor time.Duration) (*time.Time, error) {
+	var lockedUntil *time.Time
+	err := r.DBConn.Transaction(func(tx *gorm.DB) error {
+		attempt := entity.SPasswordAttempt{Scope: scope, Key: key}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("scope = ? AND `key` = ?", scope, key).
+			FirstOrCreate(&attempt).Error
+		if err != nil {
+			return err
+		}
+
+		// the previous lock is over, start counting again
+		if attempt.LockedUntil != nil && !attempt.LockedUntil.After(time.Now()) {
+			attempt.FailedCount = 0
+			attempt.LockedUntil = nil
+		}
+
+		attempt.FailedCount++
+		if attempt.FailedCount >= maxFailures {
+			until := time.Now().Add(lockFor)
+			attempt.LockedUntil = &until
+			attempt.FailedCount = 0
+			lockedUntil = &until
+		}
+		attempt.UpdatedAt = time.Now()
+		return tx.Save(&attempt).Error
+	})
+	return lockedUntil, err
+}
+
+func (r *PasswordAttemptRepository) Reset(scope, key string) error {
+	return r.DBConn.Where("scope = ? AND `key` = ?", scope, key).Delete(&entity.SPasswordAttempt{}).Error
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"errors"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/pkg/qrlogin"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type QRLoginTokenRepository struct {
+	DBConn *gorm.DB
+}
+
+func NewQRLoginTokenRepository(dbConn *gorm.DB) *QRLoginTokenRepository {
+	return &QRLoginTokenRepository{DBConn: dbConn}
+}
+
+// Issue creates a new login token for the user and stores its QR payload in s_user_entity.qr_login.
+// Previous tokens stay valid until revoked. Run it on the transaction creating the user.
+func (r *QRLoginTokenRepository) Issue(userID uuid.UUID, username string) (string, error) {
+	token := entity.SQRLoginToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(qrlogin.TTL()),
+	}
+	if err := r.DBConn.Create(&token).Error; err != nil {
+		return "", err
+	}
+
+	payload := qrlogin.Payload(username, qrlogin.Sign(token.ID.String(), userID.String(), token.ExpiresAt))
+	err := r.DBConn.Model(&entity.SUserEntity{}).
+		Where("id = ?", userID).
+		UpdateColumn("qr_login", payload).Error
+	if err != nil {
+		return "", err
+	}
+	return payload, nil
+}
+
+// Verify checks a token read from a QR code: signature and expiry first, then that it has not been revoked.
+func (r *QRLoginTokenRepository) Verify(userID, token string) error {
+	tokenID, err := qrlogin.Verify(token, userID)
+	if err != nil {
+		return err
+	}
+
+	var row entity.SQRLoginToken
+	err = r.DBConn.Where("id = ? AND user_id = ?", tokenID, userID).First(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return qrlogin.ErrInvalidToken
+		}
+		return err
+	}
+	if row.RevokedAt != nil {
+		return errors.New("QR login token has been revoked")
+	}
+
+	return r.DBConn.Model(&row).UpdateColumn("last_used_at", time.Now()).Error
+}
+
+// Revoke invalidates every QR login token of the user and clears the QR code.
+func (r *QRLoginTokenRepository) Revoke(userID string) error {
+	return r.DBConn.Transaction(func(tx *gorm.DB) error {
+		return revokeQRLoginTokens(tx, userID)
+	})
+}
+
+// Rotate revokes the QR login tokens of the user and issues a new one, it returns the new QR payload.
+func (r *QRLoginTokenRepository) Rotate(userID string) (string, error) {
+	var user entity.SUserEntity
+	if err := r.DBConn.Where("id = ?", userID).First(&user).Error; err != nil {
+		return "", err
+	}
+
+	var payload string
+	err := r.DBConn.Transaction(func(tx *gorm.DB) error {
+		if err := revokeQRLoginTokens(tx, userID); err != nil {
+			return err
+		}
+		var err error
+		payload, err = NewQRLoginTokenRepository(tx).Issue(user.ID, user.Username)
+		return err
+	})
+	return payload, err
+}
+
+func revokeQRLoginTokens(tx *gorm.DB, userID string) error {
+	err := tx.Model(&entity.SQRLoginToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		UpdateColumn("revoked_at", time.Now()).Error
+	if err != nil {
+		return err
+	}
+	return tx.Model(&entity.SUserEntity{}).
+		Where("id = ?", userID).
+		UpdateColumn("qr_login", "").Error
+}
@@ -7,6 +7,7 @@ import (
 	"sen-global-api/internal/domain/request"
 	"sen-global-api/internal/domain/response"
 	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/mysql"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -46,8 +47,8 @@ func (receiver *RedirectUrlRepository) GetList(req request.GetRedirectUrlListReq
 	var err error
 	var count int64
 	if req.Keyword != "" {
-		err = receiver.DBConn.Where("qr_code LIKE ?", "%"+req.Keyword+"%").Offset(offset).Limit(limit).Find(&urls).Error
-		receiver.DBConn.Model(&entity.SRedirectUrl{}).Where("qr_code LIKE ?", "%"+req.Keyword+"%").Count(&count)
+		err = mysql.Replica(receiver.DBConn).Where("qr_code LIKE ?", "%"+req.Keyword+"%").Offset(offset).Limit(limit).Find(&urls).Error
+		mysql.Replica(receiver.DBConn).Model(&entity.SRedirectUrl{}).Where("qr_code LIKE ?", "%"+req.Keyword+"%").Count(&count)
 	} else {
 		err = receiver.DBConn.Offset(offset).Limit(limit).Find(&urls).Error
 		receiver.DBConn.Model(&entity.SRedirectUrl{}).Count(&count)
@@ -80,7 +81,7 @@ func (receiver *RedirectUrlRepository) Delete(id uint64) error {
 
 func (receiver *RedirectUrlRepository) GetByID(id uint64) (*entity.SRedirectUrl, error) {
 	var url entity.SRedirectUrl
-	err := receiver.DBConn.First(&url, id).Error
+	err := receiver.DBConn.Preload("Targets", orderRedirectUrlTargets).First(&url, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -88,26 +89,58 @@ func (receiver *RedirectUrlRepository) GetByID(id uint64) (*entity.SRedirectUrl,
 }
 
 func (receiver *RedirectUrlRepository) Update(form *entity.SRedirectUrl) error {
-	return receiver.DBConn.Save(form).Error
+	return receiver.DBConn.Omit("Targets").Save(form).Error
 }
 
 func (receiver *RedirectUrlRepository) GetByQRCode(qrCode string) (*entity.SRedirectUrl, error) {
 	var url entity.SRedirectUrl
-	err := receiver.DBConn.Where("qr_code = ?", qrCode).First(&url).Error
+	err := receiver.DBConn.Preload("Targets", orderRedirectUrlTargets).Where("qr_code = ?", qrCode).First(&url).Error
 	if err != nil {
 		return nil, err
 	}
 	return &url, nil
 }
 
-func (receiver *RedirectUrlRepository) SaveRedirectUrl(qrCode string, targetUrl string, password string, status string, hint string, hashPwd *string) error {
+func orderRedirectUrlTargets(db *gorm.DB) *gorm.DB {
+	return db.Order("`order` ASC, id ASC")
+}
+
+// ReplaceTargets swaps the targets of the redirect url for the given ones.
+func (receiver *RedirectUrlRepository) ReplaceTargets(id uint64, targets []entity.SRedirectUrlTarget) error {
+	return receiver.DBConn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("redirect_url_id = ?", id).Delete(&entity.SRedirectUrlTarget{}).Error; err != nil {
+			return err
+		}
+		if len(targets) == 0 {
+			return nil
+		}
+		for i := range targets {
+			targets[i].ID = 0
+			targets[i].RedirectUrlID = id
+		}
+		return tx.Create(&targets).Error
+	})
+}
+
+// IncrementUseCount counts one use of the redirect url, it returns false when the usage cap is already reached.
+func (receiver *RedirectUrlRepository) IncrementUseCount(id uint64) (bool, error) {
+	result := receiver.DBConn.Model(&entity.SRedirectUrl{}).
+		Where("id = ? AND (max_uses IS NULL OR use_count < max_uses)", id).
+		UpdateColumn("use_count", gorm.Expr("use_count + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (receiver *RedirectUrlRepository) SaveRedirectUrl(qrCode string, targetUrl string, status string, hint string, hashPwd *string) error {
 	spreadSheetStatus, err := value.GetImportSpreadsheetStatusFromString(status)
 	if err != nil {
 		return err
 	}
 	switch spreadSheetStatus {
 	case value.ImportSpreadsheetStatusNew:
-		return receiver.saveNewRedirectUrl(qrCode, targetUrl, password, hint, hashPwd)
+		return receiver.saveNewRedirectUrl(qrCode, targetUrl, hint, hashPwd)
 	case value.ImportSpreadsheetStatusDeleted:
 		return receiver.DBConn.Where("qr_code = ?", qrCode).Delete(&entity.SRedirectUrl{}).Error
 	case value.ImportSpreadsheetStatusSkip:
@@ -117,20 +150,15 @@ func (receiver *RedirectUrlRepository) SaveRedirectUrl(qrCode string, targetUrl
 	}
 }
 
-func (receiver *RedirectUrlRepository) saveNewRedirectUrl(code string, url string, password string, hint string, hashPwd *string) error {
-	var pwd *string = nil
-	if password != "" {
-		pwd = &password
-	}
+func (receiver *RedirectUrlRepository) saveNewRedirectUrl(code string, url string, hint string, hashPwd *string) error {
 	redirectUrl := entity.SRedirectUrl{
 		QRCode:       code,
 		TargetUrl:    url,
-		Password:     pwd,
 		Hint:         hint,
 		HashPassword: hashPwd,
 	}
 	return receiver.DBConn.Table("s_redirect_url").Clauses(
 		clause.OnConflict{Columns: []clause.Column{{Name: "qr_code"}},
-			DoUpdates: clause.AssignmentColumns([]string{"target_url", "password", "hint", "hash_password"}),
+			DoUpdates: clause.AssignmentColumns([]string{"target_url", "hint", "hash_password"}),
 		}).Create(&redirectUrl).Error
 }
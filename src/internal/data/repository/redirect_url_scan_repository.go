@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/mysql"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RedirectUrlScanRepository struct {
+	DBConn *gorm.DB
+}
+
+func NewRedirectUrlScanRepository(dbConn *gorm.DB) *RedirectUrlScanRepository {
+	return &RedirectUrlScanRepository{DBConn: dbConn}
+}
+
+func (r *RedirectUrlScanRepository) Create(scan *entity.SRedirectUrlScan) error {
+	return r.DBConn.Create(scan).Error
+}
+
+// RedirectUrlScanSummary aggregates the scans of one QR code over a period.
+type RedirectUrlScanSummary struct {
+	RedirectUrlID uint64
+	QRCode        string
+	TargetUrl     string
+	UseCount      uint64
+	MaxUses       *uint64
+	ValidFrom     *time.Time
+	ValidUntil    *time.Time
+	TotalScans    int64
+	ResolvedScans int64
+	UniqueDevices int64
+	LastScanAt    *time.Time
+}
+
+// GetSummaries returns every QR code with its scans in [from, to), codes never scanned included.
+func (r *RedirectUrlScanRepository) GetSummaries(from, to time.Time, orgID string) ([]RedirectUrlScanSummary, error) {
+	join := "LEFT JOIN s_redirect_url_scan AS s ON s.redirect_url_id = u.id AND s.scanned_at >= ? AND s.scanned_at < ?"
+	args := []interface{}{from, to}
+	if orgID != "" {
+		join += " AND s.organization_id = ?"
+		args = append(args, orgID)
+	}
+
+	var rows []RedirectUrlScanSummary
+	err := mysql.Replica(r.DBConn).Table("s_redirect_url AS u").
+		Select(`u.id AS redirect_url_id, u.qr_code, u.target_url, u.use_count, u.max_uses, u.valid_from, u.valid_until,
+			COUNT(s.id) AS total_scans,
+			COALESCE(SUM(CASE WHEN s.outcome = ? THEN 1 ELSE 0 END), 0) AS resolved_scans,
+			COUNT(DISTINCT NULLIF(s.device_id, '')) AS unique_devices,
+			MAX(s.scanned_at) AS last_scan_at`, value.RedirectUrlScanResolved).
+		Joins(join, args...).
+		Group("u.id").
+		Order("total_scans DESC, u.qr_code ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// RedirectUrlScanBucket is the number of scans of one group (day, target, organization, ...).
+type RedirectUrlScanBucket struct {
+	Key   string
+	Total int64
+}
+
+// CountBy groups the scans of a QR code in [from, to) by the given SQL expression.
+func (r *RedirectUrlScanRepository) CountBy(redirectUrlID uint64, from, to time.Time, expr string) ([]RedirectUrlScanBucket, error) {
+	var rows []RedirectUrlScanBucket
+	err := mysql.Replica(r.DBConn).Model(&entity.SRedirectUrlScan{}).
+		Select(expr+" AS `key`, COUNT(*) AS total").
+		Where("redirect_url_id = ? AND scanned_at >= ? AND scanned_at < ?", redirectUrlID, from, to).
+		Group("`key`").
+		Order("`key` ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *RedirectUrlScanRepository) GetRecent(redirectUrlID uint64, limit int) ([]entity.SRedirectUrlScan, error) {
+	var scans []entity.SRedirectUrlScan
+	err := r.DBConn.Where("redirect_url_id = ?", redirectUrlID).
+		Order("scanned_at DESC").
+		Limit(limit).
+		Find(&scans).Error
+	return scans, err
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"errors"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/value"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ReportRepository struct {
+	DBConn *gorm.DB
+}
+
+func NewReportRepository(dbConn *gorm.DB) *ReportRepository {
+	return &ReportRepository{DBConn: dbConn}
+}
+
+func (r *ReportRepository) Create(report *entity.Report) error {
+	return r.DBConn.Create(report).Error
+}
+
+func (r *ReportRepository) GetByID(id string) (*entity.Report, error) {
+	var report entity.Report
+	err := r.DBConn.Where("id = ?", id).First(&report).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (r *ReportRepository) GetBySubject(reportType value.ReportType, subjectID string) ([]entity.Report, error) {
+	var reports []entity.Report
+	err := r.DBConn.
+		Where("type = ? AND subject_id = ?", reportType, subjectID).
+		Order("created_at DESC").
+		Find(&reports).Error
+	return reports, err
+}
+
+func (r *ReportRepository) GetByJobID(jobID string) ([]entity.Report, error) {
+	var reports []entity.Report
+	err := r.DBConn.Where("job_id = ?", jobID).Order("created_at ASC").Find(&reports).Error
+	return reports, err
+}
+
+// GetJobSubjectIDs returns the subjects already generated by the job, a resumed job skips them.
+func (r *ReportRepository) GetJobSubjectIDs(jobID string) (map[string]bool, error) {
+	var ids []string
+	err := r.DBConn.Model(&entity.Report{}).Where("job_id = ?", jobID).Pluck("subject_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		res[id] = true
+	}
+	return res, nil
+}
+
+func (r *ReportRepository) CreateJob(job *entity.ReportJob) error {
+	return r.DBConn.Create(job).Error
+}
+
+func (r *ReportRepository) GetJob(id string) (*entity.ReportJob, error) {
+	var job entity.ReportJob
+	err := r.DBConn.Where("id = ?", id).First(&job).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *ReportRepository) GetJobs(orgID string, limit, offset int) ([]entity.ReportJob, int64, error) {
+	query := r.DBConn.Model(&entity.ReportJob{})
+	if orgID != "" {
+		query = query.Where("organization_id = ?", orgID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var jobs []entity.ReportJob
+	err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&jobs).Error
+	return jobs, total, err
+}
+
+// GetResumableJobs returns the jobs interrupted by a shutdown and the pending or processing ones
+// without progress since staleBefore, left by a crash.
+func (r *ReportRepository) GetResumableJobs(staleBefore time.Time) ([]entity.ReportJob, error) {
+	var jobs []entity.ReportJob
+	err := r.DBConn.
+		Where("status = ? OR (status IN ? AND updated_at < ?)",
+			value.ReportJobStatusInterrupted,
+			[]value.ReportJobStatus{value.ReportJobStatusPending, value.ReportJobStatusProcessing}, staleBefore).
+		Order("created_at ASC").
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// ClaimJob marks the job as resumed by this instance, false when another instance already took it.
+func (r *ReportRepository) ClaimJob(id string, staleBefore time.Time) (bool, error) {
+	res := r.DBConn.Model(&entity.ReportJob{}).
+		Where("id = ? AND (status = ? OR (status IN ? AND updated_at < ?))",
+			id, value.ReportJobStatusInterrupted,
+			[]value.ReportJobStatus{value.ReportJobStatusPending, value.ReportJobStatusProcessing}, staleBefore).
+		Updates(map[string]interface{}{
+			"status":     value.ReportJobStatusProcessing,
+			"updated_at": time.Now(),
+		})
+	return res.RowsAffected == 1, res.Error
+}
+
+// InterruptJob releases a job stopped by a shutdown, the next start resumes it without waiting for it to be stale.
+func (r *ReportRepository) InterruptJob(id string) error {
+	return r.DBConn.Model(&entity.ReportJob{}).
+		Where("id = ? AND status = ?", id, value.ReportJobStatusProcessing).
+		Update("status", value.ReportJobStatusInterrupted).Error
+}
+
+// StartJob sets the progress of a started or resumed job, generated is the number of reports already done.
+func (r *ReportRepository) StartJob(id string, total, generated int) error {
+	return r.DBConn.Model(&entity.ReportJob{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":    value.ReportJobStatusProcessing,
+			"total":     total,
+			"generated": generated,
+			"failed":    0,
+		}).Error
+}
+
+// CountJobReport adds one generated or failed report to the job progress, the updated_at it sets
+// keeps the running job from being seen as stale by the other instances.
+func (r *ReportRepository) CountJobReport(id string, failed bool) error {
+	column := "generated"
+	if failed {
+		column = "failed"
+	}
+	return r.DBConn.Model(&entity.ReportJob{}).
+		Where("id = ?", id).
+		Update(column, gorm.Expr(column+" + 1")).Error
+}
+
+func (r *ReportRepository) FinishJob(id string, status value.ReportJobStatus, reason string) error {
+	now := time.Now()
+	return r.DBConn.Model(&entity.ReportJob{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"error":        reason,
+			"completed_at": &now,
+		}).Error
+}
@@ -25,14 +25,13 @@ func (receiver *SessionRepository) VerifyPassword(password string, hashed string
 	return bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password))
 }
 
-func (receiver *SessionRepository) VerifyPassword4LoginQr(password string, hashedPassword string, loginType value.LoginType) error {
+// VerifyPassword4LoginQr checks the signed login token of a scanned QR code, or the password on register.
+func (receiver *SessionRepository) VerifyPassword4LoginQr(password string, user *entity.SUserEntity, loginType value.LoginType) error {
 	if loginType == value.ForScan {
-		if password != hashedPassword {
-			return errors.New("invalid QR password")
-		}
+		return NewQRLoginTokenRepository(receiver.DBConn).Verify(user.ID.String(), password)
 	}
 	if loginType == value.ForRegister {
-		return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+		return bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 	}
 	return nil
 }
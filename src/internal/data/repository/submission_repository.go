@@ -2,6 +2,7 @@ package repository
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sen-global-api/internal/domain/entity"
 	"sen-global-api/internal/domain/response"
@@ -40,6 +41,8 @@ type CreateSubmissionParams struct {
 	StudentCustomID *string
 	UserCustomID    *string
 	StudentID       string
+	ChildID         string
+	Score           *entity.SubmissionScore
 }
 
 type GetSubmissionByConditionParam struct {
@@ -99,6 +102,15 @@ func (receiver *SubmissionRepository) CreateSubmission(params CreateSubmissionPa
 		StudentCustomID: studentCustomID,
 		UserCustomID:    userCustomID,
 		StudentID:       params.StudentID,
+		ChildID:         params.ChildID,
+	}
+
+	if params.Score != nil {
+		scoreInJSON, err := json.Marshal(params.Score)
+		if err != nil {
+			return 0, err
+		}
+		submission.Score = scoreInJSON
 	}
 
 	if err := receiver.DBConn.Create(&submission).Error; err != nil {
@@ -118,6 +130,33 @@ func (receiver *SubmissionRepository) FindRecentByFormID(formID uint64, userID s
 	return submission, err
 }
 
+func (receiver *SubmissionRepository) GetByID(id uint64) (*entity.SSubmission, error) {
+	var submission entity.SSubmission
+	err := receiver.DBConn.Where("id = ?", id).First(&submission).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &submission, nil
+}
+
+// GetDetailByID returns the submission with its form and user, nil when not found.
+func (receiver *SubmissionRepository) GetDetailByID(id uint64) (*entity.SSubmission, error) {
+	var submission entity.SSubmission
+	err := receiver.DBConn.Preload("Form").Preload("User").Where("id = ?", id).First(&submission).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &submission, nil
+}
+
 func (receiver *SubmissionRepository) DuplicateSubmissions(params CreateSubmissionParams) error {
 	items := make([]entity.SubmissionDataItem, 0)
 	for _, item := range params.SubmissionData.Items {
@@ -409,3 +448,34 @@ func (r *SubmissionRepository) GetSubmissionByCreatedAtAndForms(createdAfter tim
 
 	return submissions, nil
 }
+
+// GetByStudentID returns the submissions made for the student between from and to, oldest first.
+func (r *SubmissionRepository) GetByStudentID(studentID string, from, to time.Time) ([]entity.SSubmission, error) {
+	var submissions []entity.SSubmission
+	err := r.DBConn.
+		Preload("Form").
+		Where("student_id = ? AND created_at BETWEEN ? AND ?", studentID, from, to).
+		Order("created_at ASC").
+		Find(&submissions).Error
+	return submissions, err
+}
+
+type StudentSubmissionStat struct {
+	StudentID string
+	Total     int
+	LastAt    time.Time
+}
+
+// GetStudentStats counts the submissions of each student between from and to.
+func (r *SubmissionRepository) GetStudentStats(studentIDs []string, from, to time.Time) ([]StudentSubmissionStat, error) {
+	var stats []StudentSubmissionStat
+	if len(studentIDs) == 0 {
+		return stats, nil
+	}
+	err := r.DBConn.Model(&entity.SSubmission{}).
+		Select("student_id, COUNT(*) AS total, MAX(created_at) AS last_at").
+		Where("student_id IN ? AND created_at BETWEEN ? AND ?", studentIDs, from, to).
+		Group("student_id").
+		Scan(&stats).Error
+	return stats, err
+}
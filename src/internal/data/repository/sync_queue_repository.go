@@ -3,6 +3,7 @@ package repository
 import (
 	"sen-global-api/internal/domain/entity"
 	"sen-global-api/internal/domain/value"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -91,6 +92,39 @@ func (r *SyncQueueRepository) UpdateStatusByID(id uint64, status value.SyncQueue
 		Update("status", status).Error
 }
 
+// UpdateProgress records the last submission synced, so an interrupted sync resumes after it.
+func (r *SyncQueueRepository) UpdateProgress(id uint64, lastSubmissionID uint64, lastSubmittedAt time.Time) error {
+	return r.DBConn.Model(&entity.SyncQueue{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"last_submission_id": lastSubmissionID,
+			"last_submitted_at":  lastSubmittedAt,
+		}).Error
+}
+
+// GetResumable returns the queues interrupted by a shutdown and the pending ones
+// without progress since staleBefore, left by a crash.
+func (r *SyncQueueRepository) GetResumable(staleBefore time.Time) ([]entity.SyncQueue, error) {
+	var queues []entity.SyncQueue
+	err := r.DBConn.
+		Where("status = ? OR (status = ? AND updated_at < ?)",
+			value.SyncQueueStatusInterrupted, value.SyncQueueStatusPending, staleBefore).
+		Find(&queues).Error
+	return queues, err
+}
+
+// ClaimForResume marks the queue as resumed by this instance, false when another instance already took it.
+func (r *SyncQueueRepository) ClaimForResume(id uint64, staleBefore time.Time) (bool, error) {
+	res := r.DBConn.Model(&entity.SyncQueue{}).
+		Where("id = ? AND (status = ? OR (status = ? AND updated_at < ?))",
+			id, value.SyncQueueStatusInterrupted, value.SyncQueueStatusPending, staleBefore).
+		Updates(map[string]interface{}{
+			"status":     value.SyncQueueStatusPending,
+			"updated_at": time.Now(),
+		})
+	return res.RowsAffected == 1, res.Error
+}
+
 func (r *SyncQueueRepository) GetAllAutoSync() ([]entity.SyncQueue, error) {
 	var queues []entity.SyncQueue
 	if err := r.DBConn.
@@ -287,6 +287,13 @@ func (receiver *UserEntityRepository) CreateUser(req request.CreateUserEntityReq
 		return errors.New("failed to create user " + req.Username)
 	}
 
+	userReq.QRLogin, err = NewQRLoginTokenRepository(tx).Issue(userReq.ID, userReq.Username)
+	if err != nil {
+		log.Error("UserRepository.CreateUser: " + err.Error())
+		tx.Rollback()
+		return errors.New("failed to create user " + req.Username)
+	}
+
 	var organization entity.SOrganization
 	err = tx.Model(&entity.SOrganization{}).
 		Where("organization_name = 'HOME STUDY'").
@@ -380,6 +387,13 @@ func (receiver *UserEntityRepository) CreateChildForParent(parentID string, req
 			return errors.New("failed to create child " + req.Username)
 		}
 
+		child.QRLogin, err = NewQRLoginTokenRepository(tx).Issue(child.ID, child.Username)
+		if err != nil {
+			log.Error("UserRepository.CreateChildForParent: " + err.Error())
+			tx.Rollback()
+			return errors.New("failed to create child " + req.Username)
+		}
+
 		var organization entity.SOrganization
 		err = tx.Model(&entity.SOrganization{}).
 			Where("organization_name = 'SENBOX WAITLIST'").
@@ -433,6 +447,22 @@ func (receiver *UserEntityRepository) CreateChildForParent(parentID string, req
 		return errors.New("failed to assign child for parent")
 	}
 
+	// keep guardianship in sync
+	err = receiver.DBConn.
+		Where("child_id = ? AND guardian_user_id = ?", child.ID.String(), parentID).
+		Attrs(entity.SChildGuardian{
+			ID:                 uuid.New(),
+			Relationship:       value.GuardianRelationshipLegalGuardian,
+			IsPrimaryContact:   true,
+			CanPickup:          true,
+			CanViewSubmissions: true,
+		}).
+		FirstOrCreate(&entity.SChildGuardian{ChildID: child.ID.String(), GuardianUserID: parentID}).Error
+	if err != nil {
+		log.Error("UserRepository.CreateChildForParent: " + err.Error())
+		return errors.New("failed to assign guardian for child")
+	}
+
 	return nil
 }
 
@@ -536,6 +566,18 @@ func (receiver *UserEntityRepository) UpdateUserDevice(req request.UpdateUserDev
 	return nil
 }
 
+// GetUserIDsByOrganizationAndRoles returns the users of the organization holding one of the roles.
+func (receiver *UserEntityRepository) GetUserIDsByOrganizationAndRoles(organizationID string, roles []string) ([]string, error) {
+	var userIDs []string
+	err := receiver.DBConn.Table("s_user_roles").
+		Joins("INNER JOIN s_role ON s_role.id = s_user_roles.role_id").
+		Joins("INNER JOIN s_user_organizations ON s_user_organizations.user_id = s_user_roles.user_id").
+		Where("s_user_organizations.organization_id = ? AND s_role.role IN ?", organizationID, roles).
+		Distinct().
+		Pluck("s_user_roles.user_id", &userIDs).Error
+	return userIDs, err
+}
+
 func (receiver *UserEntityRepository) UpdateUserRole(req request.UpdateUserRoleRequest) error {
 	user, err := receiver.GetByID(request.GetUserEntityByIDRequest{ID: req.UserID})
 
@@ -2,14 +2,15 @@ package database
 
 import (
 	"bufio"
+	"context"
 	"math/rand"
 	"os"
 	"path/filepath"
 	"runtime"
 	"sen-global-api/internal/domain/entity"
-	"sen-global-api/internal/domain/entity/components"
-	"sen-global-api/internal/domain/entity/menu"
+	"sen-global-api/internal/migrations"
 	"sen-global-api/pkg/common"
+	"sen-global-api/pkg/mysql"
 	"time"
 
 	"gorm.io/gorm/clause"
@@ -27,92 +28,30 @@ var (
 
 func Seed(db *gorm.DB, config *common.Config, seedSQLFile string) error {
 
-	// Migrate the schema
-	err := db.AutoMigrate(
-		&entity.SAppKey{},
-		&entity.SQuestion{},
-		&entity.SForm{},
-		&entity.SRedirectUrl{},
-		&entity.SSetting{},
-		&entity.SToDo{},
-		&entity.SSubmission{},
-		&entity.SFormQuestion{},
-		&entity.SMobileDevice{},
-		&entity.SCodeCounting{},
-		&entity.SDevice{},
-		&entity.SOrganization{},
-		&entity.SDeviceComponentValues{},
-		&entity.SRole{},
-		&entity.SUserEntity{},
-		&entity.SUserRoles{},
-		&entity.SFunctionClaim{},
-		&entity.SFunctionClaimPermission{},
-		&entity.SUserDevices{},
-		&entity.SImage{},
-		&entity.SVideo{},
-		&entity.SAudio{},
-		&entity.SPdf{},
-		&entity.SUserFCMToken{},
-		&entity.SUserFunctionAuthorize{},
-		&entity.SUserOrg{},
-		&entity.SOrgFormApplication{},
-		&entity.SPreRegister{},
-		&components.Component{},
-		&menu.SuperAdminMenu{},
-		&menu.OrgMenu{},
-		&menu.UserMenu{},
-		&menu.DeviceMenu{},
-		&entity.PublicImage{},
-		&entity.SStaffFormApplication{},
-		&entity.SStudentFormApplication{},
-		&entity.STeacherFormApplication{},
-		&entity.SOrgDevices{},
-		&entity.MemoryComponentValue{},
-		&entity.SUserParentChild{},
-		&entity.SAnswer{},
-		&entity.SRoleOrgSignUp{},
-		&entity.SChild{},
-		&entity.ChildMenu{},
-		&entity.StudentMenu{},
-		&entity.TeacherMenu{},
-		&entity.StaffMenu{},
-		&entity.OrganizationMenuTemplate{},
-		&entity.SyncQueue{},
-		&entity.UserBlockSetting{},
-		&entity.SDeviceMenuV2{},
-		&entity.ParentMenu{},
-		&entity.StudentBlockSetting{},
-		&entity.OrganizationSetting{},
-		&entity.LanguagesConfig{},
-		&entity.OrganizationNewsSetting{},
-		&entity.UserImages{},
-		&entity.AppConfig{},
-		&entity.TeacherMenuOrganization{},
-		&entity.UserDevicesLogin{},
-		&entity.UserSetting{},
-		&entity.DepartmentMenu{},
-		&entity.DepartmentMenuOrganization{},
-		//&entity.ClassroomMenu{},
-		&entity.ValuesAppCurrent{},
-		&entity.AccountsLog{},
-		&entity.SuperAdminEmergencyMenu{},
-		&entity.OrganizationEmergencyMenu{},
-		&entity.LanguageSetting{},
-		&entity.DataLog{},
-		&entity.OrganizationSettingMenu{},
-		&entity.MessageLanguage{},
-		&entity.SParent{},
-		&entity.SParentChilds{},
-		&entity.StudentMenuOrganization{},
-		&entity.ValuesAppHistories{},
-	)
+	if config.Env == common.ModeDevelopment {
+		// in development the models are the source of truth, the versioned migrations run on top
+		if err := db.WithContext(mysql.WithoutTimeout(context.Background())).AutoMigrate(migrations.Entities()...); err != nil {
+			return err
+		}
+	}
 
-	// Seed
-	log.Debug("Seeding database...")
+	migrator, err := migrations.NewMigrator(db)
 	if err != nil {
 		return err
 	}
 
+	if config.Env == common.ModeDevelopment || config.MigrateOnBoot {
+		if _, err := migrator.Up(0); err != nil {
+			return err
+		}
+	} else if err := migrator.CheckUpToDate(); err != nil {
+		// production never alters the tables at boot, run `migrate up` before deploying
+		return err
+	}
+
+	// Seed
+	log.Debug("Seeding database...")
+
 	//Seeding data
 	file, err := os.Open(Root + seedSQLFile)
 	if err != nil {
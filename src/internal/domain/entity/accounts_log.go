@@ -11,5 +11,6 @@ type AccountsLog struct {
 	UserID         string                `gorm:"column:user_id;type:varchar(255);not null default ''"`
 	OrganizationID string                `gorm:"column:organization_id;type:varchar(255);not null default ''"`
 	DeviceID       string                `gorm:"column:device_id;type:varchar(255);not null default ''"`
+	Detail         string                `gorm:"column:detail;type:varchar(512);not null;default:''"`
 	Created        time.Time             `gorm:"default:CURRENT_TIMESTAMP;not null"`
 }
@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"sen-global-api/internal/domain/value"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Announcement is an organization news item. It is visible between PublishAt and ExpireAt to its targets,
+// or to the whole organization when it has no target. PushedAt is set once the publish push has been sent.
+type Announcement struct {
+	ID             uuid.UUID                `gorm:"type:char(36);primary_key" json:"id"`
+	OrganizationID string                   `gorm:"type:varchar(255);not null;index" json:"organization_id"`
+	Status         value.AnnouncementStatus `gorm:"type:varchar(20);not null;default:'draft'" json:"status"`
+	PublishAt      *time.Time               `gorm:"index" json:"publish_at"`
+	ExpireAt       *time.Time               `json:"expire_at"`
+	PushedAt       *time.Time               `json:"pushed_at"`
+	CreatedBy      string                   `gorm:"type:varchar(255);not null;default:''" json:"created_by"`
+	CreatedAt      time.Time                `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time                `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Translations []AnnouncementTranslation `gorm:"foreignKey:AnnouncementID;constraint:OnDelete:CASCADE" json:"translations"`
+	Targets      []AnnouncementTarget      `gorm:"foreignKey:AnnouncementID;constraint:OnDelete:CASCADE" json:"targets"`
+	Attachments  []AnnouncementAttachment  `gorm:"foreignKey:AnnouncementID;constraint:OnDelete:CASCADE" json:"attachments"`
+}
+
+func (a *Announcement) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return
+}
+
+// AnnouncementTranslation is the title/body of an announcement in one language of LanguageSetting.
+type AnnouncementTranslation struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	AnnouncementID uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_announcement_language" json:"announcement_id"`
+	LanguageID     uint      `gorm:"not null;uniqueIndex:idx_announcement_language" json:"language_id"`
+	Title          string    `gorm:"type:varchar(255);not null" json:"title"`
+	Body           string    `gorm:"type:text" json:"body"`
+}
+
+type AnnouncementTarget struct {
+	ID             uint                         `gorm:"primaryKey;autoIncrement" json:"id"`
+	AnnouncementID uuid.UUID                    `gorm:"type:char(36);not null;index" json:"announcement_id"`
+	TargetType     value.AnnouncementTargetType `gorm:"type:varchar(20);not null" json:"target_type"`
+	TargetID       string                       `gorm:"type:varchar(255);not null" json:"target_id"`
+}
+
+// AnnouncementAttachment references a file of the image or PDF store by its key.
+type AnnouncementAttachment struct {
+	ID             uint                             `gorm:"primaryKey;autoIncrement" json:"id"`
+	AnnouncementID uuid.UUID                        `gorm:"type:char(36);not null;index" json:"announcement_id"`
+	Type           value.AnnouncementAttachmentType `gorm:"type:varchar(20);not null" json:"type"`
+	Key            string                           `gorm:"type:varchar(255);not null" json:"key"`
+	Name           string                           `gorm:"type:varchar(255);not null;default:''" json:"name"`
+	Order          int                              `gorm:"not null;default:0" json:"order"`
+}
+
+// AnnouncementRead is the read receipt of a user, on a given device when read from a device.
+type AnnouncementRead struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	AnnouncementID uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_announcement_reader" json:"announcement_id"`
+	UserID         string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_announcement_reader" json:"user_id"`
+	DeviceID       string    `gorm:"type:varchar(255);not null;default:'';uniqueIndex:idx_announcement_reader" json:"device_id"`
+	ReadAt         time.Time `gorm:"autoCreateTime" json:"read_at"`
+}
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"sen-global-api/internal/domain/value"
+	"time"
+)
+
+// AppRelease is a published version of the app on one platform.
+// A device older than MinSupportedVersion must update, RolloutPercentage limits the devices offered this release.
+type AppRelease struct {
+	ID                  uint             `gorm:"primaryKey;autoIncrement" json:"id"`
+	Platform            value.DeviceType `gorm:"type:varchar(16);not null;uniqueIndex:idx_app_release_platform_version" json:"platform"`
+	Version             string           `gorm:"type:varchar(64);not null;uniqueIndex:idx_app_release_platform_version" json:"version"`
+	ReleaseNotes        string           `gorm:"type:text" json:"release_notes"`
+	MinSupportedVersion string           `gorm:"type:varchar(64);not null;default:''" json:"min_supported_version"`
+	RolloutPercentage   int              `gorm:"not null" json:"rollout_percentage"`
+	StoreURL            string           `gorm:"type:varchar(512);not null;default:''" json:"store_url"`
+	// a withdrawn release is no longer offered, its min supported version no longer applies
+	IsActive  bool      `gorm:"not null" json:"is_active"`
+	CreatedBy string    `gorm:"type:varchar(255);not null;default:''" json:"created_by"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"sen-global-api/internal/domain/value"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AttendanceEvent is a raw check-in/check-out coming from a QR scan, a device login or a teacher.
+type AttendanceEvent struct {
+	ID             uuid.UUID                 `gorm:"type:char(36);primary_key" json:"id"`
+	OrganizationID string                    `gorm:"type:varchar(255);not null;index:idx_attendance_event_org_time" json:"organization_id"`
+	StudentID      string                    `gorm:"type:varchar(255);not null;index" json:"student_id"`
+	DepartmentID   string                    `gorm:"type:varchar(255);not null;default:''" json:"department_id"`
+	Type           value.AttendanceEventType `gorm:"type:varchar(20);not null" json:"type"`
+	Source         value.AttendanceSource    `gorm:"type:varchar(20);not null" json:"source"`
+	DeviceID       string                    `gorm:"type:varchar(255);not null;default:''" json:"device_id"`
+	RecordedBy     string                    `gorm:"type:varchar(255);not null;default:''" json:"recorded_by"`
+	Note           string                    `gorm:"type:text" json:"note"`
+	OccurredAt     time.Time                 `gorm:"not null;index:idx_attendance_event_org_time" json:"occurred_at"`
+	CreatedAt      time.Time                 `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (e *AttendanceEvent) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return
+}
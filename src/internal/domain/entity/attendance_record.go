@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"sen-global-api/internal/domain/value"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AttendanceRecord is one row of the daily attendance sheet, one per student per day.
+// Date is stored as "YYYY-MM-DD" in the organization timezone.
+type AttendanceRecord struct {
+	ID             uuid.UUID              `gorm:"type:char(36);primary_key" json:"id"`
+	OrganizationID string                 `gorm:"type:varchar(255);not null;index:idx_attendance_record_org_date" json:"organization_id"`
+	DepartmentID   string                 `gorm:"type:varchar(255);not null;default:''" json:"department_id"`
+	StudentID      string                 `gorm:"type:varchar(255);not null;uniqueIndex:idx_attendance_record_student_date" json:"student_id"`
+	Date           string                 `gorm:"type:varchar(10);not null;uniqueIndex:idx_attendance_record_student_date;index:idx_attendance_record_org_date" json:"date"`
+	Status         value.AttendanceStatus `gorm:"type:varchar(20);not null" json:"status"`
+	CheckInAt      *time.Time             `json:"check_in_at"`
+	CheckOutAt     *time.Time             `json:"check_out_at"`
+	ParentNotified bool                   `gorm:"not null;default:false" json:"parent_notified"`
+	Note           string                 `gorm:"type:text" json:"note"`
+	UpdatedBy      string                 `gorm:"type:varchar(255);not null;default:''" json:"updated_by"`
+	CreatedAt      time.Time              `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time              `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (r *AttendanceRecord) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
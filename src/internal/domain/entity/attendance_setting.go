@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AttendanceSetting holds the school hours of an organization used for late/absent detection.
+// Times are "HH:MM" in the organization timezone, working days are ISO weekdays (1 = Monday).
+type AttendanceSetting struct {
+	ID                    uuid.UUID `gorm:"type:char(36);primary_key" json:"id"`
+	OrganizationID        string    `gorm:"type:varchar(255);not null;uniqueIndex" json:"organization_id"`
+	Timezone              string    `gorm:"type:varchar(64);not null;default:'Asia/Ho_Chi_Minh'" json:"timezone"`
+	StartTime             string    `gorm:"type:varchar(5);not null;default:'07:30'" json:"start_time"`
+	LateAfterMinutes      int       `gorm:"not null" json:"late_after_minutes"`
+	AbsentAfter           string    `gorm:"type:varchar(5);not null;default:'10:00'" json:"absent_after"`
+	WorkingDays           string    `gorm:"type:varchar(20);not null;default:'1,2,3,4,5'" json:"working_days"`
+	NotifyParentOnAbsence bool      `gorm:"not null" json:"notify_parent_on_absence"`
+	CreatedAt             time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt             time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (s *AttendanceSetting) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return
+}
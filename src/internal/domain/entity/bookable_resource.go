@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"sen-global-api/internal/domain/value"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BookableResource is something that can be reserved by time slot: a room, a teacher's office hours or a device.
+// Capacity is the number of reservations allowed on the same slot.
+type BookableResource struct {
+	ID              uuid.UUID                  `gorm:"type:char(36);primary_key" json:"id"`
+	OrganizationID  string                     `gorm:"type:varchar(255);not null;index" json:"organization_id"`
+	Name            string                     `gorm:"type:varchar(255);not null" json:"name"`
+	Type            value.BookableResourceType `gorm:"type:varchar(20);not null" json:"type"`
+	OwnerID         string                     `gorm:"type:varchar(255);not null;default:''" json:"owner_id"`
+	Capacity        int                        `gorm:"not null;default:1" json:"capacity"`
+	SlotMinutes     int                        `gorm:"not null;default:30" json:"slot_minutes"`
+	ReminderMinutes int                        `gorm:"not null;default:60" json:"reminder_minutes"`
+	Timezone        string                     `gorm:"type:varchar(64);not null;default:'Asia/Ho_Chi_Minh'" json:"timezone"`
+	IsActive        bool                       `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt       time.Time                  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time                  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (r *BookableResource) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BookingAvailabilityRule opens a resource on a weekday (1 = Monday) between StartTime and EndTime ("HH:MM").
+// ValidFrom/ValidTo ("YYYY-MM-DD") optionally limit the rule to a period.
+type BookingAvailabilityRule struct {
+	ID         uuid.UUID `gorm:"type:char(36);primary_key" json:"id"`
+	ResourceID string    `gorm:"type:char(36);not null;index" json:"resource_id"`
+	Weekday    int       `gorm:"not null" json:"weekday"`
+	StartTime  string    `gorm:"type:varchar(5);not null" json:"start_time"`
+	EndTime    string    `gorm:"type:varchar(5);not null" json:"end_time"`
+	ValidFrom  string    `gorm:"type:varchar(10);not null;default:''" json:"valid_from"`
+	ValidTo    string    `gorm:"type:varchar(10);not null;default:''" json:"valid_to"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (r *BookingAvailabilityRule) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"sen-global-api/internal/domain/value"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BookingReservation struct {
+	ID           uuid.UUID                      `gorm:"type:char(36);primary_key" json:"id"`
+	ResourceID   string                         `gorm:"type:char(36);not null;index:idx_booking_reservation_resource_time" json:"resource_id"`
+	UserID       string                         `gorm:"type:varchar(255);not null;index" json:"user_id"`
+	SubmissionID uint64                         `gorm:"not null;default:0" json:"submission_id"`
+	QuestionID   string                         `gorm:"type:varchar(255);not null;default:''" json:"question_id"`
+	StartAt      time.Time                      `gorm:"not null;index:idx_booking_reservation_resource_time" json:"start_at"`
+	EndAt        time.Time                      `gorm:"not null" json:"end_at"`
+	Status       value.BookingReservationStatus `gorm:"type:varchar(20);not null" json:"status"`
+	Note         string                         `gorm:"type:text" json:"note"`
+	ReminderSent bool                           `gorm:"not null;default:false" json:"reminder_sent"`
+	CancelledAt  *time.Time                     `json:"cancelled_at"`
+	CreatedAt    time.Time                      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time                      `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (r *BookingReservation) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChildConsent stores the consents given by a child's guardian.
+// A child without a record has not given any consent.
+type ChildConsent struct {
+	ID                 uuid.UUID `gorm:"column:id;type:char(36);primaryKey" json:"id"`
+	ChildID            string    `gorm:"column:child_id;type:char(36);not null;uniqueIndex" json:"child_id"`
+	PhotoConsent       bool      `gorm:"column:photo_consent;type:tinyint(1);not null;default:0" json:"photo_consent"`
+	DataSharingConsent bool      `gorm:"column:data_sharing_consent;type:tinyint(1);not null;default:0" json:"data_sharing_consent"`
+	UpdatedBy          string    `gorm:"column:updated_by;type:char(36);not null;default:''" json:"updated_by"`
+	CreatedAt          time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	UpdatedAt          time.Time `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"sen-global-api/internal/domain/value"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// ChildDataRequest is both the job record and the audit trail of an export or erasure of a child's data.
+// Rows are never deleted, even after the child itself has been erased.
+type ChildDataRequest struct {
+	ID          uuid.UUID                    `gorm:"column:id;type:char(36);primaryKey" json:"id"`
+	ChildID     string                       `gorm:"column:child_id;type:char(36);not null;index" json:"child_id"`
+	RequestedBy string                       `gorm:"column:requested_by;type:char(36);not null" json:"requested_by"`
+	Type        value.ChildDataRequestType   `gorm:"column:type;type:varchar(32);not null" json:"type"`
+	Status      value.ChildDataRequestStatus `gorm:"column:status;type:varchar(32);not null;default:'pending'" json:"status"`
+	ResultKey   string                       `gorm:"column:result_key;type:varchar(255);not null;default:''" json:"-"`
+	Summary     datatypes.JSON               `gorm:"column:summary;type:json" json:"summary"`
+	Error       string                       `gorm:"column:error;type:text" json:"error"`
+	CompletedAt *time.Time                   `gorm:"column:completed_at" json:"completed_at"`
+	CreatedAt   time.Time                    `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time                    `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+}
@@ -16,6 +16,10 @@ type DataLog struct {
 	Params       datatypes.JSON `gorm:"type:json" json:"params,omitempty"`
 	Headers      datatypes.JSON `gorm:"type:json" json:"headers,omitempty"`
 	Status       string         `gorm:"type:varchar(20);not null" json:"status"`
+	StatusCode   int            `gorm:"column:status_code;not null;default:0" json:"status_code"`
 	ErrorMessage *string        `gorm:"type:text" json:"error_message,omitempty"`
-	CreatedAt    time.Time      `gorm:"type:datetime(3);not null;default:CURRENT_TIMESTAMP(3)" json:"created_at"`
+	UserID       string         `gorm:"column:user_id;type:varchar(255);not null;default:'';index:idx_data_log_user" json:"user_id"`
+	RequestID    string         `gorm:"column:request_id;type:varchar(128);not null;default:''" json:"request_id"`
+	LatencyMs    int64          `gorm:"column:latency_ms;not null;default:0" json:"latency_ms"`
+	CreatedAt    time.Time      `gorm:"type:datetime(3);not null;default:CURRENT_TIMESTAMP(3);index:idx_data_log_created" json:"created_at"`
 }
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"sen-global-api/internal/domain/value"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// DeviceCommand is a remote command queued for a device. It is pushed by a FCM data message and also returned
+// by the device poll until the device acknowledges it or it expires.
+type DeviceCommand struct {
+	ID       uuid.UUID                 `gorm:"type:char(36);primary_key" json:"id"`
+	DeviceID string                    `gorm:"type:varchar(36);not null;index:idx_device_command_device" json:"device_id"`
+	Type     value.DeviceCommandType   `gorm:"type:varchar(32);not null" json:"type"`
+	Payload  datatypes.JSON            `json:"payload"`
+	Status   value.DeviceCommandStatus `gorm:"type:varchar(16);not null;index" json:"status"`
+	// fcm or poll, empty while queued
+	DeliveredVia   string         `gorm:"type:varchar(8);not null;default:''" json:"delivered_via"`
+	Result         datatypes.JSON `json:"result"`
+	Error          string         `gorm:"type:text" json:"error"`
+	CreatedBy      string         `gorm:"type:varchar(36);not null;default:''" json:"created_by"`
+	ExpiresAt      time.Time      `gorm:"not null;index" json:"expires_at"`
+	DeliveredAt    *time.Time     `json:"delivered_at"`
+	AcknowledgedAt *time.Time     `json:"acknowledged_at"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime;index:idx_device_command_device" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (c *DeviceCommand) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return
+}
+
+// IsPending tells whether the device has still to run the command.
+func (c *DeviceCommand) IsPending(now time.Time) bool {
+	return (c.Status == value.DeviceCommandStatusQueued || c.Status == value.DeviceCommandStatusDelivered) &&
+		now.Before(c.ExpiresAt)
+}
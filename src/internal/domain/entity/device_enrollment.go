@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"sen-global-api/internal/domain/value"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DeviceEnrollmentCode lets up to Quota devices join an organization by scanning its QR code,
+// the QR code holds a signed token of the code (see pkg/enrollment).
+type DeviceEnrollmentCode struct {
+	ID             uuid.UUID `gorm:"type:char(36);primary_key" json:"id"`
+	OrganizationID string    `gorm:"type:varchar(255);not null;index" json:"organization_id"`
+	// the department menus are given to the enrolled devices
+	DepartmentID string     `gorm:"type:varchar(255);not null;default:''" json:"department_id"`
+	Name         string     `gorm:"type:varchar(255);not null;default:''" json:"name"`
+	Quota        int        `gorm:"not null" json:"quota"`
+	UsedCount    int        `gorm:"not null;default:0" json:"used_count"`
+	ExpiresAt    time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	CreatedBy    string     `gorm:"type:varchar(36);not null;default:''" json:"created_by"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (c *DeviceEnrollmentCode) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return
+}
+
+// DeviceEnrollment is a device enrolled by a code, scanning the same code again does not use the quota.
+type DeviceEnrollment struct {
+	ID             uuid.UUID `gorm:"type:char(36);primary_key" json:"id"`
+	CodeID         uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_device_enrollment_code_device" json:"code_id"`
+	DeviceID       string    `gorm:"type:varchar(36);not null;uniqueIndex:idx_device_enrollment_code_device" json:"device_id"`
+	OrganizationID string    `gorm:"type:varchar(255);not null" json:"organization_id"`
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (e *DeviceEnrollment) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return
+}
+
+// DeviceLimit is the maximum number of devices of an organization or a user, replacing the default limit.
+// 0 is unlimited.
+type DeviceLimit struct {
+	ID         uuid.UUID              `gorm:"type:char(36);primary_key" json:"id"`
+	Scope      value.DeviceLimitScope `gorm:"type:varchar(16);not null;uniqueIndex:idx_device_limit_scope" json:"scope"`
+	ScopeID    string                 `gorm:"type:varchar(255);not null;uniqueIndex:idx_device_limit_scope" json:"scope_id"`
+	MaxDevices int                    `gorm:"not null" json:"max_devices"`
+	UpdatedBy  string                 `gorm:"type:varchar(36);not null;default:''" json:"updated_by"`
+	CreatedAt  time.Time              `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time              `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (l *DeviceLimit) BeforeCreate(tx *gorm.DB) (err error) {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DeviceHeartbeat is the last heartbeat of a device, rolled up from Redis every minute (see pkg/heartbeat).
+type DeviceHeartbeat struct {
+	DeviceID       string    `gorm:"type:varchar(36);primaryKey" json:"device_id"`
+	LastSeenAt     time.Time `gorm:"not null;index" json:"last_seen_at"`
+	UserID         string    `gorm:"type:varchar(36);not null;default:''" json:"user_id"`
+	AppVersion     string    `gorm:"type:varchar(64);not null;default:''" json:"app_version"`
+	BatteryLevel   *int      `json:"battery_level"`
+	Charging       *bool     `json:"charging"`
+	StorageFreeMB  *int64    `json:"storage_free_mb"`
+	StorageTotalMB *int64    `json:"storage_total_mb"`
+	Network        string    `gorm:"type:varchar(16);not null;default:''" json:"network"`
+	SignalLevel    *int      `json:"signal_level"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// DeviceAlertSetting tells when the managers of an organization are alerted of its offline devices.
+// The working days and timezone are the ones of the attendance setting of the organization.
+type DeviceAlertSetting struct {
+	ID                  uuid.UUID `gorm:"type:char(36);primary_key" json:"id"`
+	OrganizationID      string    `gorm:"type:varchar(255);not null;uniqueIndex" json:"organization_id"`
+	Enabled             bool      `gorm:"not null" json:"enabled"`
+	OfflineAfterMinutes int       `gorm:"not null;default:15" json:"offline_after_minutes"`
+	StartTime           string    `gorm:"type:varchar(5);not null;default:'07:30'" json:"start_time"`
+	EndTime             string    `gorm:"type:varchar(5);not null;default:'16:30'" json:"end_time"`
+	NotifyPush          bool      `gorm:"not null" json:"notify_push"`
+	NotifyEmail         bool      `gorm:"not null" json:"notify_email"`
+	CreatedAt           time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt           time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (s *DeviceAlertSetting) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return
+}
+
+// DeviceOfflineAlert is an offline period of a device the managers were alerted of, open until the device beats again.
+type DeviceOfflineAlert struct {
+	ID             uuid.UUID  `gorm:"type:char(36);primary_key" json:"id"`
+	OrganizationID string     `gorm:"type:varchar(255);not null;index:idx_device_offline_alert_org" json:"organization_id"`
+	DeviceID       string     `gorm:"type:varchar(36);not null;index" json:"device_id"`
+	LastSeenAt     time.Time  `gorm:"not null" json:"last_seen_at"`
+	AlertedAt      time.Time  `gorm:"not null;index:idx_device_offline_alert_org" json:"alerted_at"`
+	ResolvedAt     *time.Time `json:"resolved_at"`
+}
+
+func (a *DeviceOfflineAlert) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"sen-global-api/internal/domain/value"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// DeviceModeProfile is the behaviour of the devices in a mode (T/S/P/L). A profile of an organization replaces
+// the default profile of the mode (empty OrganizationID), a mode without profile is unrestricted.
+// The empty lists allow everything.
+type DeviceModeProfile struct {
+	ID             uuid.UUID        `gorm:"type:char(36);primary_key" json:"id"`
+	Mode           value.DeviceMode `gorm:"type:varchar(32);not null;uniqueIndex:idx_device_mode_profile" json:"mode"`
+	OrganizationID string           `gorm:"type:varchar(255);not null;default:'';uniqueIndex:idx_device_mode_profile" json:"organization_id"`
+	Name           string           `gorm:"type:varchar(255);not null;default:''" json:"name"`
+	// a user must log in before using the device
+	RequireLogin bool `gorm:"not null" json:"require_login"`
+	// roles which can log in on the device and whose endpoints the device can call
+	AllowedRoles datatypes.JSONType[[]string] `gorm:"type:json;not null" json:"allowed_roles"`
+	// menus and components shown on the device
+	AllowedComponentIDs datatypes.JSONType[[]string] `gorm:"type:json;not null" json:"allowed_component_ids"`
+	// question types enabled on the device, on top of the EnableOnMobile of each question
+	MobileQuestionTypes datatypes.JSONType[[]string] `gorm:"type:json;not null" json:"mobile_question_types"`
+	// back to the home screen after this idle time, 0 never
+	IdleTimeoutSeconds int `gorm:"not null;default:0" json:"idle_timeout_seconds"`
+	// the idle timeout also logs the user out
+	AutoLogoutOnIdle bool `gorm:"not null" json:"auto_logout_on_idle"`
+	// longest session on the device, 0 unlimited
+	AutoLogoutAfterMinutes int `gorm:"not null;default:0" json:"auto_logout_after_minutes"`
+	// daily logout at this "HH:MM" time of the device, empty never
+	AutoLogoutAt string    `gorm:"type:varchar(5);not null;default:''" json:"auto_logout_at"`
+	UpdatedBy    string    `gorm:"type:varchar(36);not null;default:''" json:"updated_by"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (p *DeviceModeProfile) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return
+}
+
+// AllowsRole tells whether the device can be used by the role.
+func (p *DeviceModeProfile) AllowsRole(role Role) bool {
+	roles := p.AllowedRoles.Data
+	if len(roles) == 0 {
+		return true
+	}
+	for _, r := range roles {
+		if r == role.String() {
+			return true
+		}
+	}
+	return false
+}
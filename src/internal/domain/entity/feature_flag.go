@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"sen-global-api/pkg/featureflag"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// FeatureFlag is a flag evaluated by pkg/featureflag, see featureflag.Flag for the rules.
+type FeatureFlag struct {
+	Key            string                                 `gorm:"type:varchar(100);primaryKey" json:"key"`
+	Description    string                                 `gorm:"type:varchar(512);not null;default:''" json:"description"`
+	Kind           featureflag.Kind                       `gorm:"type:varchar(16);not null" json:"kind"`
+	Enabled        bool                                   `gorm:"not null" json:"enabled"`
+	Variants       datatypes.JSONType[[]string]           `gorm:"type:json;not null" json:"variants"`
+	DefaultVariant string                                 `gorm:"type:varchar(100);not null" json:"default_variant"`
+	OffVariant     string                                 `gorm:"type:varchar(100);not null" json:"off_variant"`
+	Rules          datatypes.JSONType[[]featureflag.Rule] `gorm:"type:json;not null" json:"rules"`
+	UpdatedBy      string                                 `gorm:"type:varchar(255);not null;default:''" json:"updated_by"`
+	CreatedAt      time.Time                              `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time                              `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (f FeatureFlag) ToFlag() featureflag.Flag {
+	return featureflag.Flag{
+		Key:            f.Key,
+		Kind:           f.Kind,
+		Enabled:        f.Enabled,
+		Variants:       f.Variants.Data,
+		DefaultVariant: f.DefaultVariant,
+		OffVariant:     f.OffVariant,
+		Rules:          f.Rules.Data,
+	}
+}
+
+type FeatureFlagAction string
+
+const (
+	FeatureFlagActionCreate FeatureFlagAction = "create"
+	FeatureFlagActionUpdate FeatureFlagAction = "update"
+	FeatureFlagActionDelete FeatureFlagAction = "delete"
+)
+
+// FeatureFlagAudit records each change of a flag with the flag before and after it (null on create / delete).
+type FeatureFlagAudit struct {
+	ID        uint64            `gorm:"primaryKey;autoIncrement" json:"id"`
+	FlagKey   string            `gorm:"type:varchar(100);not null;index:idx_feature_flag_audit_key_created,priority:1" json:"flag_key"`
+	Action    FeatureFlagAction `gorm:"type:varchar(16);not null" json:"action"`
+	Before    datatypes.JSON    `gorm:"type:json" json:"before"`
+	After     datatypes.JSON    `gorm:"type:json" json:"after"`
+	ChangedBy string            `gorm:"type:varchar(255);not null;default:''" json:"changed_by"`
+	CreatedAt time.Time         `gorm:"autoCreateTime;index:idx_feature_flag_audit_key_created,priority:2" json:"created_at"`
+}
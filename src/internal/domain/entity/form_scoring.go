@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// ScoringQuestion tells how one question of the form is scored.
+// Points maps an option name (or a numeric answer) to its points; scale, number and button_count answers
+// without a matching entry count their own numeric value. MaxPoints is the best score of a numeric question.
+type ScoringQuestion struct {
+	QuestionID string             `json:"question_id"`
+	Section    string             `json:"section"`
+	Weight     float64            `json:"weight"`
+	Points     map[string]float64 `json:"points"`
+	MaxPoints  float64            `json:"max_points"`
+}
+
+// ScoringLevel maps a score to a label: the level is the one with the highest Min reached.
+type ScoringLevel struct {
+	Min   float64 `json:"min"`
+	Label string  `json:"label"`
+}
+
+type ScoringRubric struct {
+	Questions     []ScoringQuestion         `json:"questions"`
+	Levels        []ScoringLevel            `json:"levels"`
+	SectionLevels map[string][]ScoringLevel `json:"section_levels"`
+}
+
+// FormScoring is the optional rubric of a form, applied to every submission of the form.
+type FormScoring struct {
+	ID        uuid.UUID                         `gorm:"type:char(36);primary_key" json:"id"`
+	FormID    uint64                            `gorm:"not null;uniqueIndex" json:"form_id"`
+	Rubric    datatypes.JSONType[ScoringRubric] `gorm:"type:json;not null" json:"rubric"`
+	IsActive  bool                              `gorm:"not null" json:"is_active"`
+	CreatedAt time.Time                         `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time                         `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (s *FormScoring) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return
+}
+
+type SubmissionScoreItem struct {
+	QuestionID string  `json:"question_id"`
+	Section    string  `json:"section"`
+	Points     float64 `json:"points"`
+	MaxPoints  float64 `json:"max_points"`
+}
+
+type SubmissionSectionScore struct {
+	Section  string  `json:"section"`
+	Score    float64 `json:"score"`
+	MaxScore float64 `json:"max_score"`
+	Level    string  `json:"level"`
+}
+
+// SubmissionScore is the result of the form rubric, stored with the submission.
+type SubmissionScore struct {
+	Total    float64                  `json:"total"`
+	MaxScore float64                  `json:"max_score"`
+	Level    string                   `json:"level"`
+	Sections []SubmissionSectionScore `json:"sections"`
+	Items    []SubmissionScoreItem    `json:"items"`
+}
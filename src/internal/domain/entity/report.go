@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"sen-global-api/internal/domain/value"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Report is a generated PDF stored by the upload provider. SubjectID is the submission id, the student id or the
+// department id depending on the type, JobID is set when the report was generated by a batch job.
+type Report struct {
+	ID             uuid.UUID        `gorm:"type:char(36);primary_key" json:"id"`
+	Type           value.ReportType `gorm:"type:varchar(32);not null;index:idx_report_subject" json:"type"`
+	SubjectID      string           `gorm:"type:varchar(255);not null;index:idx_report_subject" json:"subject_id"`
+	OrganizationID string           `gorm:"type:varchar(255);not null;default:'';index" json:"organization_id"`
+	JobID          string           `gorm:"type:varchar(36);not null;default:'';index" json:"job_id"`
+	FileName       string           `gorm:"type:varchar(255);not null" json:"file_name"`
+	Key            string           `gorm:"type:varchar(255);not null" json:"-"`
+	Size           int64            `gorm:"not null;default:0" json:"size"`
+	CreatedBy      string           `gorm:"type:varchar(255);not null;default:''" json:"created_by"`
+	CreatedAt      time.Time        `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (r *Report) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
+
+// ReportJob generates the reports of a whole organization or class in background, e.g. the end-of-term
+// profiles of every student. FromDate and ToDate are "YYYY-MM-DD".
+type ReportJob struct {
+	ID             uuid.UUID             `gorm:"type:char(36);primary_key" json:"id"`
+	Type           value.ReportType      `gorm:"type:varchar(32);not null" json:"type"`
+	OrganizationID string                `gorm:"type:varchar(255);not null;index" json:"organization_id"`
+	DepartmentID   string                `gorm:"type:varchar(255);not null;default:''" json:"department_id"`
+	FromDate       string                `gorm:"type:varchar(10);not null" json:"from_date"`
+	ToDate         string                `gorm:"type:varchar(10);not null" json:"to_date"`
+	Status         value.ReportJobStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	Total          int                   `gorm:"not null;default:0" json:"total"`
+	Generated      int                   `gorm:"not null;default:0" json:"generated"`
+	Failed         int                   `gorm:"not null;default:0" json:"failed"`
+	Error          string                `gorm:"type:text" json:"error"`
+	CreatedBy      string                `gorm:"type:varchar(255);not null;default:''" json:"created_by"`
+	CompletedAt    *time.Time            `json:"completed_at"`
+	CreatedAt      time.Time             `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time             `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (j *ReportJob) BeforeCreate(tx *gorm.DB) (err error) {
+	if j.ID == uuid.Nil {
+		j.ID = uuid.New()
+	}
+	return
+}
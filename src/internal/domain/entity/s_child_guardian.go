@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"sen-global-api/internal/domain/value"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SChildGuardian links a child (SChild or child user entity) to one of its guardians (user entity).
+// It replaces SParentChilds and SUserParentChild as the source of truth for guardianship.
+type SChildGuardian struct {
+	ID                 uuid.UUID                  `gorm:"column:id;type:char(36);primaryKey" json:"id"`
+	ChildID            string                     `gorm:"column:child_id;type:char(36);not null;uniqueIndex:idx_child_guardian" json:"child_id"`
+	GuardianUserID     string                     `gorm:"column:guardian_user_id;type:char(36);not null;uniqueIndex:idx_child_guardian;index" json:"guardian_user_id"`
+	Relationship       value.GuardianRelationship `gorm:"column:relationship;type:varchar(32);not null;default:'legal_guardian'" json:"relationship"`
+	IsPrimaryContact   bool                       `gorm:"column:is_primary_contact;type:tinyint(1);not null;default:0" json:"is_primary_contact"`
+	CanPickup          bool                       `gorm:"column:can_pickup;type:tinyint(1);not null;default:0" json:"can_pickup"`
+	CanViewSubmissions bool                       `gorm:"column:can_view_submissions;type:tinyint(1);not null" json:"can_view_submissions"`
+	CreatedAt          time.Time                  `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	UpdatedAt          time.Time                  `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+}
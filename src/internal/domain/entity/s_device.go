@@ -17,6 +17,7 @@ type SDevice struct {
 	ButtonUrl               string                 `gorm:"type:varchar(255);not null;default:''"`
 	Note                    string                 `gorm:"type:varchar(255);not null;default:''"`
 	AppVersion              string                 `gorm:"type:varchar(255);not null;default:''"`
+	Platform                value.DeviceType       `gorm:"type:varchar(16);not null;default:''"`
 	RowNo                   int                    `gorm:"type:int;not null;default:0"`
 	DeviceComponentValuesID int64                  `gorm:"column:device_component_values_id;default:1"`
 	DeviceComponentValues   SDeviceComponentValues `gorm:"foreignKey:DeviceComponentValuesID;references:id;constraint:OnDelete:CASCADE"`
@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// SPasswordAttempt counts the failed password checks of one protected code (a QR redirect, a form, ...).
+type SPasswordAttempt struct {
+	Scope       string     `gorm:"type:varchar(32);primary_key"`
+	Key         string     `gorm:"type:varchar(255);primary_key"`
+	FailedCount int        `gorm:"not null;default:0"`
+	LockedUntil *time.Time `gorm:"default:null"`
+	UpdatedAt   time.Time  `gorm:"default:CURRENT_TIMESTAMP;not null"`
+}
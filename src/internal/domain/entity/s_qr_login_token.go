@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SQRLoginToken tracks a login token printed in a user QR code so it can be revoked before it expires.
+type SQRLoginToken struct {
+	ID         uuid.UUID  `gorm:"type:char(36);primary_key"`
+	UserID     uuid.UUID  `gorm:"type:char(36);not null;index"`
+	ExpiresAt  time.Time  `gorm:"not null"`
+	RevokedAt  *time.Time `gorm:"default:null"`
+	LastUsedAt *time.Time `gorm:"default:null"`
+	CreatedAt  time.Time  `gorm:"default:CURRENT_TIMESTAMP;not null"`
+}
@@ -3,12 +3,18 @@ package entity
 import "time"
 
 type SRedirectUrl struct {
-	ID           uint64    `gorm:"primary_key;auto_increment;not null"`
-	QRCode       string    `gorm:"type:varchar(255);not null;unique"`
-	TargetUrl    string    `gorm:"type:varchar(255);not null"`
-	Password     *string   `gorm:"type:varchar(32);"`
-	Hint         string    `gorm:"type:varchar(255);default:''"`
-	HashPassword *string   `gorm:"type:varchar(255);default:null"`
-	CreatedAt    time.Time `gorm:"default:CURRENT_TIMESTAMP;not null"`
-	UpdatedAt    time.Time `gorm:"default:CURRENT_TIMESTAMP;not null"`
+	ID        uint64 `gorm:"primary_key;auto_increment;not null"`
+	QRCode    string `gorm:"type:varchar(255);not null;unique"`
+	TargetUrl string `gorm:"type:varchar(255);not null"`
+	Hint      string `gorm:"type:varchar(255);default:''"`
+	// HashPassword is the bcrypt hash of the password protecting the code, the plaintext is never stored
+	HashPassword *string    `gorm:"type:varchar(255);default:null"`
+	ValidFrom    *time.Time `gorm:"default:null"`
+	ValidUntil   *time.Time `gorm:"default:null"`
+	// MaxUses caps the number of resolved scans, nil means unlimited
+	MaxUses   *uint64              `gorm:"default:null"`
+	UseCount  uint64               `gorm:"not null;default:0"`
+	Targets   []SRedirectUrlTarget `gorm:"foreignKey:RedirectUrlID;constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time            `gorm:"default:CURRENT_TIMESTAMP;not null"`
+	UpdatedAt time.Time            `gorm:"default:CURRENT_TIMESTAMP;not null"`
 }
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"sen-global-api/internal/domain/value"
+	"time"
+)
+
+// SRedirectUrlScan logs one scan of a QR code and the target it resolved to.
+type SRedirectUrlScan struct {
+	ID             uint64  `gorm:"primary_key;auto_increment;not null" json:"id"`
+	RedirectUrlID  uint64  `gorm:"not null;index:idx_redirect_url_scan_url_time" json:"redirect_url_id"`
+	QRCode         string  `gorm:"type:varchar(255);not null" json:"qr_code"`
+	DeviceID       string  `gorm:"type:varchar(255);default:''" json:"device_id"`
+	OrganizationID string  `gorm:"type:varchar(36);default:'';index" json:"organization_id"`
+	UserAgent      string  `gorm:"type:varchar(512);default:''" json:"user_agent"`
+	Language       string  `gorm:"type:varchar(16);default:''" json:"language"`
+	TargetID       *uint64 `gorm:"default:null" json:"target_id"`
+	ResolvedUrl    string  `gorm:"type:varchar(255);default:''" json:"resolved_url"`
+	// Outcome is "resolved", or the reason the scan was refused (not_started, expired, exhausted)
+	Outcome   value.RedirectUrlScanOutcome `gorm:"type:varchar(32);not null;default:'resolved'" json:"outcome"`
+	ScannedAt time.Time                    `gorm:"not null;index:idx_redirect_url_scan_url_time" json:"scanned_at"`
+}
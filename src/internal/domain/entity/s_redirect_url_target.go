@@ -0,0 +1,23 @@
+package entity
+
+// SRedirectUrlTarget is an alternative destination of a QR code.
+// Targets with a rule (language, organization or time window) are tried in Order, the first match wins;
+// otherwise one of the targets without rule is picked at random according to its Weight.
+// When nothing applies the QR code falls back to SRedirectUrl.TargetUrl.
+type SRedirectUrlTarget struct {
+	ID            uint64 `gorm:"primary_key;auto_increment;not null" json:"id"`
+	RedirectUrlID uint64 `gorm:"not null;index" json:"redirect_url_id"`
+	TargetUrl     string `gorm:"type:varchar(255);not null" json:"target_url"`
+	Weight        uint   `gorm:"not null;default:1" json:"weight"`
+	// Language matches the primary tag of the Accept-Language header, e.g. "vi"
+	Language       string `gorm:"type:varchar(16);default:''" json:"language"`
+	OrganizationID string `gorm:"type:varchar(36);default:''" json:"organization_id"`
+	// StartTime and EndTime ("HH:MM") bound the time of day, a window may wrap over midnight
+	StartTime string `gorm:"type:varchar(5);default:''" json:"start_time"`
+	EndTime   string `gorm:"type:varchar(5);default:''" json:"end_time"`
+	Order     int    `gorm:"column:order;not null;default:0" json:"order"`
+}
+
+func (t SRedirectUrlTarget) HasRule() bool {
+	return t.Language != "" || t.OrganizationID != "" || t.StartTime != ""
+}
@@ -31,4 +31,6 @@ type SSubmission struct {
 	StudentCustomID string         `gorm:"column:student_custom_id;type:varchar(255);not null;default:''"`
 	UserCustomID    string         `gorm:"column:user_custom_id;type:varchar(255);not null;default:''"`
 	StudentID       string         `gorm:"column:student_id;type:varchar(255);not null;default:''"`
+	ChildID         string         `gorm:"column:child_id;type:varchar(255);not null;default:'';index"`
+	Score           datatypes.JSON `gorm:"column:score;type:json;default:null"`
 }
@@ -1,7 +1,6 @@
 package entity
 
 import (
-	"fmt"
 	"html"
 	"strings"
 	"time"
@@ -55,7 +54,8 @@ func (user *SUserEntity) BeforeCreate(tx *gorm.DB) (err error) {
 	user.Username = strings.ToLower(html.EscapeString(strings.TrimSpace(user.Username)))
 	user.BlockedAt = time.Time{}
 
-	user.QRLogin = fmt.Sprintf("SENBOX.ORG/[USERNAME-PASSWORD]:%s:%s", user.Username, user.Password)
+	// the QR code carries a signed login token issued by QRLoginTokenRepository.Issue, never the password hash
+	user.QRLogin = ""
 
 	// Tính CreatedIndex = MAX(created_index) + 1 theo OrganizationID
 	var maxIndex int
@@ -0,0 +1,31 @@
+package request
+
+type AnnouncementTranslationRequest struct {
+	LanguageID uint   `json:"language_id" binding:"required"`
+	Title      string `json:"title" binding:"required"`
+	Body       string `json:"body"`
+}
+
+type AnnouncementTargetRequest struct {
+	TargetType string `json:"target_type" binding:"required"` // department | role | device
+	TargetID   string `json:"target_id" binding:"required"`
+}
+
+type AnnouncementAttachmentRequest struct {
+	Type string `json:"type" binding:"required"` // image | pdf
+	Key  string `json:"key" binding:"required"`
+}
+
+type SaveAnnouncementRequest struct {
+	OrganizationID string                           `json:"organization_id" binding:"required"`
+	Status         string                           `json:"status"`     // draft | published, default draft
+	PublishAt      string                           `json:"publish_at"` // RFC3339, empty = on publish
+	ExpireAt       string                           `json:"expire_at"`  // RFC3339, empty = never
+	Translations   []AnnouncementTranslationRequest `json:"translations" binding:"required"`
+	Targets        []AnnouncementTargetRequest      `json:"targets"` // empty = whole organization
+	Attachments    []AnnouncementAttachmentRequest  `json:"attachments"`
+}
+
+type ReadAnnouncementRequest struct {
+	DeviceID string `json:"device_id"`
+}
@@ -0,0 +1,38 @@
+package request
+
+import "sen-global-api/internal/domain/value"
+
+type CreateAppReleaseRequest struct {
+	Platform            value.DeviceType `json:"platform" binding:"required"`
+	Version             string           `json:"version" binding:"required"`
+	ReleaseNotes        string           `json:"release_notes"`
+	MinSupportedVersion string           `json:"min_supported_version"`
+	// 100 by default
+	RolloutPercentage *int   `json:"rollout_percentage"`
+	StoreURL          string `json:"store_url"`
+	// true by default
+	IsActive *bool `json:"is_active"`
+}
+
+// UpdateAppReleaseRequest changes the given fields, the platform and version of a release are fixed.
+type UpdateAppReleaseRequest struct {
+	ReleaseNotes        *string `json:"release_notes"`
+	MinSupportedVersion *string `json:"min_supported_version"`
+	RolloutPercentage   *int    `json:"rollout_percentage"`
+	StoreURL            *string `json:"store_url"`
+	IsActive            *bool   `json:"is_active"`
+}
+
+type GetAppReleasesRequest struct {
+	Platform value.DeviceType `form:"platform"`
+}
+
+type CheckAppVersionRequest struct {
+	Platform value.DeviceType `form:"platform" binding:"required"`
+	Version  string           `form:"version" binding:"required"`
+	DeviceID string           `form:"device_id"`
+}
+
+type GetAppVersionDistributionRequest struct {
+	OrganizationID string `form:"organization_id"`
+}
@@ -0,0 +1,31 @@
+package request
+
+type AttendanceCheckRequest struct {
+	StudentID      string `json:"student_id" binding:"required"`
+	OrganizationID string `json:"organization_id" binding:"required"`
+	DepartmentID   string `json:"department_id"`
+	DeviceID       string `json:"device_id"`
+	Type           string `json:"type" binding:"required"` // check_in | check_out
+}
+
+// ManualAttendanceRequest is used by teachers to record or correct a student's attendance.
+type ManualAttendanceRequest struct {
+	StudentID      string  `json:"student_id" binding:"required"`
+	OrganizationID string  `json:"organization_id" binding:"required"`
+	DepartmentID   string  `json:"department_id"`
+	Date           string  `json:"date" binding:"required"` // YYYY-MM-DD
+	Status         string  `json:"status" binding:"required"`
+	CheckInAt      *string `json:"check_in_at"`  // HH:MM
+	CheckOutAt     *string `json:"check_out_at"` // HH:MM
+	Note           string  `json:"note"`
+}
+
+type UpdateAttendanceSettingRequest struct {
+	OrganizationID        string `json:"organization_id" binding:"required"`
+	Timezone              string `json:"timezone" binding:"required"`
+	StartTime             string `json:"start_time" binding:"required"`
+	LateAfterMinutes      int    `json:"late_after_minutes"`
+	AbsentAfter           string `json:"absent_after" binding:"required"`
+	WorkingDays           []int  `json:"working_days" binding:"required"`
+	NotifyParentOnAbsence bool   `json:"notify_parent_on_absence"`
+}
@@ -0,0 +1,42 @@
+package request
+
+type CreateBookableResourceRequest struct {
+	OrganizationID  string `json:"organization_id" binding:"required"`
+	Name            string `json:"name" binding:"required"`
+	Type            string `json:"type" binding:"required"` // room | teacher | device
+	OwnerID         string `json:"owner_id"`
+	Capacity        int    `json:"capacity"`
+	SlotMinutes     int    `json:"slot_minutes"`
+	ReminderMinutes int    `json:"reminder_minutes"`
+	Timezone        string `json:"timezone"`
+}
+
+type UpdateBookableResourceRequest struct {
+	ID              string `json:"id" binding:"required"`
+	Name            string `json:"name" binding:"required"`
+	Type            string `json:"type" binding:"required"`
+	OwnerID         string `json:"owner_id"`
+	Capacity        int    `json:"capacity"`
+	SlotMinutes     int    `json:"slot_minutes"`
+	ReminderMinutes int    `json:"reminder_minutes"`
+	Timezone        string `json:"timezone"`
+	IsActive        bool   `json:"is_active"`
+}
+
+type BookingAvailabilityRuleRequest struct {
+	Weekday   int    `json:"weekday" binding:"required"`    // 1 = Monday ... 7 = Sunday
+	StartTime string `json:"start_time" binding:"required"` // HH:MM
+	EndTime   string `json:"end_time" binding:"required"`   // HH:MM
+	ValidFrom string `json:"valid_from"`                    // YYYY-MM-DD
+	ValidTo   string `json:"valid_to"`                      // YYYY-MM-DD
+}
+
+type UpdateBookingRulesRequest struct {
+	Rules []BookingAvailabilityRuleRequest `json:"rules"`
+}
+
+type CreateBookingReservationRequest struct {
+	ResourceID string `json:"resource_id" binding:"required"`
+	StartAt    string `json:"start_at" binding:"required"` // RFC3339
+	Note       string `json:"note"`
+}
@@ -0,0 +1,19 @@
+package request
+
+type AddChildGuardianRequest struct {
+	ChildID            string `json:"child_id" binding:"required"`
+	GuardianUserID     string `json:"guardian_user_id" binding:"required"`
+	Relationship       string `json:"relationship" binding:"required"`
+	IsPrimaryContact   bool   `json:"is_primary_contact"`
+	CanPickup          bool   `json:"can_pickup"`
+	CanViewSubmissions bool   `json:"can_view_submissions"`
+}
+
+type UpdateChildGuardianRequest struct {
+	ChildID            string `json:"child_id" binding:"required"`
+	GuardianUserID     string `json:"guardian_user_id" binding:"required"`
+	Relationship       string `json:"relationship" binding:"required"`
+	IsPrimaryContact   bool   `json:"is_primary_contact"`
+	CanPickup          bool   `json:"can_pickup"`
+	CanViewSubmissions bool   `json:"can_view_submissions"`
+}
@@ -0,0 +1,6 @@
+package request
+
+type UpdateChildConsentRequest struct {
+	PhotoConsent       *bool `json:"photo_consent" binding:"required"`
+	DataSharingConsent *bool `json:"data_sharing_consent" binding:"required"`
+}
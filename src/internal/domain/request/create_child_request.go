@@ -3,4 +3,6 @@ package request
 type CreateChildRequest struct {
 	ChildName string `json:"child_name" binding:"required"`
 	Age       int    `json:"age" binding:"required"`
+	// Relationship of the creator to the child, defaults to legal_guardian
+	Relationship string `json:"relationship"`
 }
@@ -0,0 +1,36 @@
+package request
+
+import (
+	"encoding/json"
+	"sen-global-api/internal/domain/value"
+)
+
+type CreateDeviceCommandRequest struct {
+	DeviceIDs []string                `json:"device_ids" binding:"required,min=1"`
+	Type      value.DeviceCommandType `json:"type" binding:"required"`
+	// show_message: {"title": "...", "message": "..."}, capture_diagnostics: {"include_logs": true}
+	Payload json.RawMessage `json:"payload"`
+	// 0 is 24 hours, at most 7 days
+	TTLMinutes int `json:"ttl_minutes"`
+}
+
+// GetDeviceCommandsRequest is sent by the device, its device_id must be the one of its device token when given.
+type GetDeviceCommandsRequest struct {
+	DeviceID string `form:"device_id"`
+}
+
+// AckDeviceCommandRequest is sent by the device after running a command.
+type AckDeviceCommandRequest struct {
+	// DeviceID must be the one of the device token when given
+	DeviceID string `json:"device_id"`
+	// acknowledged or failed
+	Status value.DeviceCommandStatus `json:"status" binding:"required"`
+	Result json.RawMessage           `json:"result"`
+	Error  string                    `json:"error"`
+}
+
+type GetDeviceCommandHistoryRequest struct {
+	Status string `form:"status"`
+	Page   int    `form:"page"`
+	Limit  int    `form:"limit"`
+}
@@ -0,0 +1,43 @@
+package request
+
+import "sen-global-api/internal/domain/value"
+
+type CreateDeviceEnrollmentCodeRequest struct {
+	OrganizationID string `json:"organization_id" binding:"required"`
+	DepartmentID   string `json:"department_id"`
+	Name           string `json:"name"`
+	// number of devices which can enroll with the code
+	Quota int `json:"quota" binding:"required,min=1"`
+	// 0 is 72 hours, at most 90 days
+	ExpiresInHours int `json:"expires_in_hours"`
+}
+
+type GetDeviceEnrollmentCodesRequest struct {
+	OrganizationID string `form:"organization_id" binding:"required"`
+	Page           int    `form:"page"`
+	Limit          int    `form:"limit"`
+}
+
+// EnrollDeviceRequest is sent by a fresh device after scanning the QR code of an organization.
+type EnrollDeviceRequest struct {
+	// the token or the whole QR payload
+	Code       string `json:"code" binding:"required"`
+	DeviceID   string `json:"device_id" binding:"required"`
+	AppVersion string `json:"app_version"`
+}
+
+type SaveDeviceLimitRequest struct {
+	Scope   value.DeviceLimitScope `json:"scope" binding:"required"`
+	ScopeID string                 `json:"scope_id" binding:"required"`
+	// 0 is unlimited
+	MaxDevices *int `json:"max_devices" binding:"required,min=0"`
+}
+
+type GetDeviceLimitsRequest struct {
+	Scope value.DeviceLimitScope `form:"scope"`
+}
+
+type DeleteDeviceLimitRequest struct {
+	Scope   value.DeviceLimitScope `form:"scope" binding:"required"`
+	ScopeID string                 `form:"scope_id" binding:"required"`
+}
@@ -0,0 +1,34 @@
+package request
+
+// DeviceHeartbeatRequest is sent by the devices every interval_seconds of the previous response.
+type DeviceHeartbeatRequest struct {
+	// DeviceID must be the one of the device token when given
+	DeviceID   string `json:"device_id"`
+	UserID     string `json:"user_id"`
+	AppVersion string `json:"app_version"`
+	// 0-100
+	BatteryLevel   *int   `json:"battery_level"`
+	Charging       *bool  `json:"charging"`
+	StorageFreeMB  *int64 `json:"storage_free_mb"`
+	StorageTotalMB *int64 `json:"storage_total_mb"`
+	// wifi, cellular, ethernet or none
+	Network string `json:"network"`
+	// 0-4 bars
+	SignalLevel *int `json:"signal_level"`
+}
+
+type UpdateDeviceAlertSettingRequest struct {
+	OrganizationID      string `json:"organization_id" binding:"required"`
+	Enabled             bool   `json:"enabled"`
+	OfflineAfterMinutes int    `json:"offline_after_minutes" binding:"required"`
+	StartTime           string `json:"start_time" binding:"required"`
+	EndTime             string `json:"end_time" binding:"required"`
+	NotifyPush          bool   `json:"notify_push"`
+	NotifyEmail         bool   `json:"notify_email"`
+}
+
+type GetDeviceOfflineAlertsRequest struct {
+	OrganizationID string `form:"organization_id" binding:"required"`
+	Page           int    `form:"page"`
+	Limit          int    `form:"limit"`
+}
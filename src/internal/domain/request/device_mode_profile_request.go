@@ -0,0 +1,23 @@
+package request
+
+// SaveDeviceModeProfileRequest creates or replaces the profile of a mode, for an organization or the default one.
+type SaveDeviceModeProfileRequest struct {
+	// mode t, mode s, mode p or mode l
+	Mode string `json:"mode" binding:"required"`
+	// empty for the default profile of the mode
+	OrganizationID         string   `json:"organization_id"`
+	Name                   string   `json:"name"`
+	RequireLogin           bool     `json:"require_login"`
+	AllowedRoles           []string `json:"allowed_roles"`
+	AllowedComponentIDs    []string `json:"allowed_component_ids"`
+	MobileQuestionTypes    []string `json:"mobile_question_types"`
+	IdleTimeoutSeconds     int      `json:"idle_timeout_seconds"`
+	AutoLogoutOnIdle       bool     `json:"auto_logout_on_idle"`
+	AutoLogoutAfterMinutes int      `json:"auto_logout_after_minutes"`
+	// HH:MM
+	AutoLogoutAt string `json:"auto_logout_at"`
+}
+
+type GetDeviceModeProfilesRequest struct {
+	OrganizationID string `form:"organization_id"`
+}
@@ -0,0 +1,36 @@
+package request
+
+import "sen-global-api/pkg/featureflag"
+
+type CreateFeatureFlagRequest struct {
+	Key         string           `json:"key" binding:"required"`
+	Description string           `json:"description"`
+	Kind        featureflag.Kind `json:"kind"`
+	Enabled     bool             `json:"enabled"`
+	// multivariate only, a boolean flag serves true / false
+	Variants       []string           `json:"variants"`
+	DefaultVariant string             `json:"default_variant"`
+	OffVariant     string             `json:"off_variant"`
+	Rules          []featureflag.Rule `json:"rules"`
+}
+
+// UpdateFeatureFlagRequest changes the given fields, the key and kind of a flag are fixed.
+type UpdateFeatureFlagRequest struct {
+	Description    *string             `json:"description"`
+	Enabled        *bool               `json:"enabled"`
+	Variants       *[]string           `json:"variants"`
+	DefaultVariant *string             `json:"default_variant"`
+	OffVariant     *string             `json:"off_variant"`
+	Rules          *[]featureflag.Rule `json:"rules"`
+}
+
+type GetFeatureFlagAuditsRequest struct {
+	Key   string `form:"key"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+}
+
+// EvaluateFeatureFlagsRequest previews the flags served to a subject, for the admin.
+type EvaluateFeatureFlagsRequest struct {
+	featureflag.Subject
+}
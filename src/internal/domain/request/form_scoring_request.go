@@ -0,0 +1,10 @@
+package request
+
+import "sen-global-api/internal/domain/entity"
+
+type UpdateFormScoringRequest struct {
+	Questions     []entity.ScoringQuestion         `json:"questions" binding:"required"`
+	Levels        []entity.ScoringLevel            `json:"levels"`
+	SectionLevels map[string][]entity.ScoringLevel `json:"section_levels"`
+	IsActive      *bool                            `json:"is_active"`
+}
@@ -3,4 +3,7 @@ package request
 type GetFormRequest struct {
 	QrCode   string `json:"qr_code" binding:"required"`
 	DeviceID string `json:"device_id" binding:"required"`
+	// grant of /v1/form/verify-password, or the password, for a protected form
+	FormGrant    string `json:"form_grant"`
+	FormPassword string `json:"form_password"`
 }
@@ -0,0 +1,8 @@
+package request
+
+// GetRedirectUrlAnalyticsRequest selects the scans between From and To ("2006-01-02", both included).
+type GetRedirectUrlAnalyticsRequest struct {
+	From           string `form:"from"`
+	To             string `form:"to"`
+	OrganizationID string `form:"organization_id"`
+}
@@ -1,5 +1,14 @@
 package request
 
 type GetRedirectUrlByQRCodeRequest struct {
-	QRCode string `form:"qr_code"`
+	QRCode         string `form:"qr_code"`
+	DeviceID       string `form:"device_id"`
+	OrganizationID string `form:"organization_id"`
+}
+
+type VerifyRedirectUrlPasswordRequest struct {
+	QRCode         string `json:"qr_code" binding:"required"`
+	Password       string `json:"password" binding:"required"`
+	DeviceID       string `json:"device_id"`
+	OrganizationID string `json:"organization_id"`
 }
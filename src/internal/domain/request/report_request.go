@@ -0,0 +1,37 @@
+package request
+
+import "sen-global-api/internal/domain/value"
+
+// ReportPeriodRequest is the period of a report, "YYYY-MM-DD", the last 12 months by default.
+type ReportPeriodRequest struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type GenerateClassReportRequest struct {
+	OrganizationID string `json:"organization_id" binding:"required"`
+	// empty for every student of the organization
+	DepartmentID string `json:"department_id"`
+	ReportPeriodRequest
+}
+
+// CreateReportJobRequest generates in background a student_profile report for every student, or a
+// class_summary report for every class, of the organization.
+type CreateReportJobRequest struct {
+	Type           value.ReportType `json:"type" binding:"required"`
+	OrganizationID string           `json:"organization_id" binding:"required"`
+	// limits the job to one class
+	DepartmentID string `json:"department_id"`
+	ReportPeriodRequest
+}
+
+type GetReportJobsRequest struct {
+	OrganizationID string `form:"organization_id"`
+	Page           int    `form:"page"`
+	Limit          int    `form:"limit"`
+}
+
+type GetReportsRequest struct {
+	Type      value.ReportType `form:"type" binding:"required"`
+	SubjectID string           `form:"subject_id" binding:"required"`
+}
@@ -0,0 +1,16 @@
+package request
+
+type SearchDataLogRequest struct {
+	// Endpoint is the route, e.g. /v1/form/submit, a trailing * matches a prefix
+	Endpoint   string `form:"endpoint"`
+	Method     string `form:"method"`
+	Status     string `form:"status"`
+	StatusCode int    `form:"status_code"`
+	UserID     string `form:"user_id"`
+	RequestID  string `form:"request_id"`
+	// From and To are RFC3339 times
+	From  string `form:"from"`
+	To    string `form:"to"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+}
@@ -11,4 +11,7 @@ type SubmitFormRequest struct {
 	StudentCustomID *string `json:"student_custom_id"`
 	UserCustomID    *string `json:"user_custom_id"`
 	StudentID       string  `json:"student_id"`
+	// grant of /v1/form/verify-password, or the password, for a protected form
+	FormGrant    string `json:"form_grant"`
+	FormPassword string `json:"form_password"`
 }
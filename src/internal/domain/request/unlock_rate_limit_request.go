@@ -0,0 +1,7 @@
+package request
+
+type UnlockRateLimitRequest struct {
+	Group string `json:"group" binding:"required"`
+	Kind  string `json:"kind" binding:"required"`
+	Key   string `json:"key" binding:"required"`
+}
@@ -2,4 +2,20 @@ package request
 
 type UpdateRedirectUrlRequest struct {
 	Password *string `json:"password"`
+	// ValidFrom and ValidUntil are RFC3339 times, an empty string removes the bound
+	ValidFrom  *string `json:"valid_from"`
+	ValidUntil *string `json:"valid_until"`
+	// MaxUses caps the resolved scans, 0 removes the cap
+	MaxUses       *uint64                     `json:"max_uses"`
+	ResetUseCount bool                        `json:"reset_use_count"`
+	Targets       *[]RedirectUrlTargetRequest `json:"targets"`
+}
+
+type RedirectUrlTargetRequest struct {
+	TargetUrl      string `json:"target_url" binding:"required"`
+	Weight         uint   `json:"weight"`
+	Language       string `json:"language"`
+	OrganizationID string `json:"organization_id"`
+	StartTime      string `json:"start_time"`
+	EndTime        string `json:"end_time"`
 }
@@ -10,4 +10,6 @@ type UploadImageRequest struct {
 	FileName  string                `form:"file_name" binding:"required"`
 	ImageName string                `form:"image_name"`
 	Mode      string                `form:"mode" binding:"required"`
+	OwnerID   string                `form:"owner_id"`
+	OwnerRole string                `form:"owner_role"`
 }
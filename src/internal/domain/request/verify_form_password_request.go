@@ -0,0 +1,6 @@
+package request
+
+type VerifyFormPasswordRequest struct {
+	FormID   uint64 `json:"form_id" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
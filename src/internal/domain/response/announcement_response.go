@@ -0,0 +1,55 @@
+package response
+
+import "time"
+
+type AnnouncementTranslationResponse struct {
+	LanguageID uint   `json:"language_id"`
+	Title      string `json:"title"`
+	Body       string `json:"body"`
+}
+
+type AnnouncementTargetResponse struct {
+	TargetType string `json:"target_type"`
+	TargetID   string `json:"target_id"`
+}
+
+type AnnouncementAttachmentResponse struct {
+	Type string `json:"type"`
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	Url  string `json:"url"`
+}
+
+type AnnouncementResponse struct {
+	ID             string                            `json:"id"`
+	OrganizationID string                            `json:"organization_id"`
+	Status         string                            `json:"status"`
+	PublishAt      *time.Time                        `json:"publish_at"`
+	ExpireAt       *time.Time                        `json:"expire_at"`
+	PushedAt       *time.Time                        `json:"pushed_at"`
+	CreatedBy      string                            `json:"created_by"`
+	CreatedAt      time.Time                         `json:"created_at"`
+	Translations   []AnnouncementTranslationResponse `json:"translations"`
+	Targets        []AnnouncementTargetResponse      `json:"targets"`
+	Attachments    []AnnouncementAttachmentResponse  `json:"attachments"`
+	ReadCount      int64                             `json:"read_count"`
+}
+
+// AnnouncementFeedItem is an announcement as shown to its audience, in the app language.
+type AnnouncementFeedItem struct {
+	ID          string                           `json:"id"`
+	LanguageID  uint                             `json:"language_id"`
+	Title       string                           `json:"title"`
+	Body        string                           `json:"body"`
+	PublishAt   *time.Time                       `json:"publish_at"`
+	ExpireAt    *time.Time                       `json:"expire_at"`
+	Attachments []AnnouncementAttachmentResponse `json:"attachments"`
+	IsRead      bool                             `json:"is_read"`
+}
+
+type AnnouncementReadResponse struct {
+	UserID   string    `json:"user_id"`
+	Nickname string    `json:"nickname"`
+	DeviceID string    `json:"device_id"`
+	ReadAt   time.Time `json:"read_at"`
+}
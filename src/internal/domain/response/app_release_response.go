@@ -0,0 +1,31 @@
+package response
+
+import "sen-global-api/internal/domain/value"
+
+type AppVersionCheckResponse struct {
+	Status              value.AppUpdateStatus `json:"status"`
+	CurrentVersion      string                `json:"current_version"`
+	LatestVersion       string                `json:"latest_version,omitempty"`
+	MinSupportedVersion string                `json:"min_supported_version,omitempty"`
+	ReleaseNotes        string                `json:"release_notes,omitempty"`
+	StoreURL            string                `json:"store_url,omitempty"`
+}
+
+type AppVersionCountResponse struct {
+	Platform value.DeviceType      `json:"platform"`
+	Version  string                `json:"version"`
+	Devices  int64                 `json:"devices"`
+	Status   value.AppUpdateStatus `json:"status"`
+}
+
+// AppVersionDistributionResponse is the app versions of the devices of one organization.
+type AppVersionDistributionResponse struct {
+	OrganizationID   string                    `json:"organization_id"`
+	OrganizationName string                    `json:"organization_name"`
+	TotalDevices     int64                     `json:"total_devices"`
+	UpToDate         int64                     `json:"up_to_date"`
+	UpdateAvailable  int64                     `json:"update_available"`
+	UpdateRequired   int64                     `json:"update_required"`
+	Unknown          int64                     `json:"unknown"`
+	Versions         []AppVersionCountResponse `json:"versions"`
+}
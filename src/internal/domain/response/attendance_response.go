@@ -0,0 +1,37 @@
+package response
+
+import "time"
+
+type AttendanceSettingResponse struct {
+	OrganizationID        string `json:"organization_id"`
+	Timezone              string `json:"timezone"`
+	StartTime             string `json:"start_time"`
+	LateAfterMinutes      int    `json:"late_after_minutes"`
+	AbsentAfter           string `json:"absent_after"`
+	WorkingDays           []int  `json:"working_days"`
+	NotifyParentOnAbsence bool   `json:"notify_parent_on_absence"`
+}
+
+type AttendanceRecordResponse struct {
+	StudentID      string     `json:"student_id"`
+	StudentName    string     `json:"student_name"`
+	DepartmentID   string     `json:"department_id"`
+	Date           string     `json:"date"`
+	Status         string     `json:"status"`
+	CheckInAt      *time.Time `json:"check_in_at"`
+	CheckOutAt     *time.Time `json:"check_out_at"`
+	ParentNotified bool       `json:"parent_notified"`
+	Note           string     `json:"note"`
+}
+
+type AttendanceSheetResponse struct {
+	OrganizationID string                     `json:"organization_id"`
+	DepartmentID   string                     `json:"department_id"`
+	Date           string                     `json:"date"`
+	Present        int                        `json:"present"`
+	Late           int                        `json:"late"`
+	Absent         int                        `json:"absent"`
+	Excused        int                        `json:"excused"`
+	NotRecorded    int                        `json:"not_recorded"`
+	Records        []AttendanceRecordResponse `json:"records"`
+}
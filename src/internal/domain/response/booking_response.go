@@ -0,0 +1,51 @@
+package response
+
+import "time"
+
+type BookableResourceResponse struct {
+	ID              string                            `json:"id"`
+	OrganizationID  string                            `json:"organization_id"`
+	Name            string                            `json:"name"`
+	Type            string                            `json:"type"`
+	OwnerID         string                            `json:"owner_id"`
+	Capacity        int                               `json:"capacity"`
+	SlotMinutes     int                               `json:"slot_minutes"`
+	ReminderMinutes int                               `json:"reminder_minutes"`
+	Timezone        string                            `json:"timezone"`
+	IsActive        bool                              `json:"is_active"`
+	Rules           []BookingAvailabilityRuleResponse `json:"rules"`
+}
+
+type BookingAvailabilityRuleResponse struct {
+	Weekday   int    `json:"weekday"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+	ValidFrom string `json:"valid_from"`
+	ValidTo   string `json:"valid_to"`
+}
+
+type BookingSlotResponse struct {
+	StartAt   time.Time `json:"start_at"`
+	EndAt     time.Time `json:"end_at"`
+	Remaining int       `json:"remaining"`
+}
+
+type BookingSlotsResponse struct {
+	ResourceID   string                `json:"resource_id"`
+	ResourceName string                `json:"resource_name"`
+	Timezone     string                `json:"timezone"`
+	Slots        []BookingSlotResponse `json:"slots"`
+}
+
+type BookingReservationResponse struct {
+	ID           string     `json:"id"`
+	ResourceID   string     `json:"resource_id"`
+	UserID       string     `json:"user_id"`
+	SubmissionID uint64     `json:"submission_id"`
+	StartAt      time.Time  `json:"start_at"`
+	EndAt        time.Time  `json:"end_at"`
+	Status       string     `json:"status"`
+	Note         string     `json:"note"`
+	CancelledAt  *time.Time `json:"cancelled_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
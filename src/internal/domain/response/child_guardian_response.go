@@ -0,0 +1,18 @@
+package response
+
+type ChildGuardianResponse struct {
+	ChildID            string `json:"child_id"`
+	GuardianUserID     string `json:"guardian_user_id"`
+	GuardianName       string `json:"guardian_name"`
+	Relationship       string `json:"relationship"`
+	IsPrimaryContact   bool   `json:"is_primary_contact"`
+	CanPickup          bool   `json:"can_pickup"`
+	CanViewSubmissions bool   `json:"can_view_submissions"`
+}
+
+type ChildGuardianPermissionResponse struct {
+	IsGuardian         bool `json:"is_guardian"`
+	IsPrimaryContact   bool `json:"is_primary_contact"`
+	CanPickup          bool `json:"can_pickup"`
+	CanViewSubmissions bool `json:"can_view_submissions"`
+}
@@ -0,0 +1,26 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type ChildConsentResponse struct {
+	ChildID            string     `json:"child_id"`
+	PhotoConsent       bool       `json:"photo_consent"`
+	DataSharingConsent bool       `json:"data_sharing_consent"`
+	UpdatedBy          string     `json:"updated_by"`
+	UpdatedAt          *time.Time `json:"updated_at"`
+}
+
+type ChildDataRequestResponse struct {
+	ID          string          `json:"id"`
+	ChildID     string          `json:"child_id"`
+	RequestedBy string          `json:"requested_by"`
+	Type        string          `json:"type"`
+	Status      string          `json:"status"`
+	Summary     json.RawMessage `json:"summary,omitempty"`
+	Error       string          `json:"error,omitempty"`
+	CompletedAt *time.Time      `json:"completed_at"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
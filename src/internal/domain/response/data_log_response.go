@@ -0,0 +1,8 @@
+package response
+
+import "sen-global-api/internal/domain/entity"
+
+type DataLogListResponse struct {
+	Logs       []entity.DataLog `json:"logs"`
+	Pagination Pagination       `json:"pagination"`
+}
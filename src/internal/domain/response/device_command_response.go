@@ -0,0 +1,8 @@
+package response
+
+import "sen-global-api/internal/domain/entity"
+
+type DeviceCommandListResponse struct {
+	Commands   []entity.DeviceCommand `json:"commands"`
+	Pagination Pagination             `json:"pagination"`
+}
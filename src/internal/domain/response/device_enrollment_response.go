@@ -0,0 +1,43 @@
+package response
+
+import (
+	"sen-global-api/internal/domain/entity"
+
+	"gorm.io/datatypes"
+)
+
+type DeviceEnrollmentCodeResponse struct {
+	entity.DeviceEnrollmentCode
+	Remaining int `json:"remaining"`
+	// signed token, the QR code holds qr_payload
+	Token     string `json:"token"`
+	QRPayload string `json:"qr_payload"`
+}
+
+type DeviceEnrollmentCodeListResponse struct {
+	Codes      []DeviceEnrollmentCodeResponse `json:"codes"`
+	Pagination Pagination                     `json:"pagination"`
+}
+
+type DeviceEnrollmentCodeDetailResponse struct {
+	DeviceEnrollmentCodeResponse
+	Enrollments []entity.DeviceEnrollment `json:"enrollments"`
+}
+
+type EnrolledDeviceMenuResponse struct {
+	ComponentID string `json:"component_id"`
+	Order       int    `json:"order"`
+	IsShow      bool   `json:"is_show"`
+}
+
+// EnrolledDeviceResponse is what the device needs to start in its organization.
+type EnrolledDeviceResponse struct {
+	DeviceID         string `json:"device_id"`
+	DeviceName       string `json:"device_name"`
+	OrganizationID   string `json:"organization_id"`
+	OrganizationName string `json:"organization_name"`
+	DepartmentID     string `json:"department_id"`
+	// device component values of the organization
+	Profile datatypes.JSON               `json:"profile"`
+	Menus   []EnrolledDeviceMenuResponse `json:"menus"`
+}
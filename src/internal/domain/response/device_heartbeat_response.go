@@ -0,0 +1,42 @@
+package response
+
+import (
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/value"
+	"time"
+)
+
+type DeviceHeartbeatResponse struct {
+	IntervalSeconds int `json:"interval_seconds"`
+}
+
+type FleetDeviceResponse struct {
+	DeviceID       string                   `json:"device_id"`
+	DeviceName     string                   `json:"device_name"`
+	DeviceNickName string                   `json:"device_nick_name"`
+	Status         value.DeviceMode         `json:"status"`
+	Connectivity   value.DeviceConnectivity `json:"connectivity"`
+	LastSeenAt     *time.Time               `json:"last_seen_at"`
+	UserID         string                   `json:"user_id"`
+	AppVersion     string                   `json:"app_version"`
+	BatteryLevel   *int                     `json:"battery_level"`
+	Charging       *bool                    `json:"charging"`
+	StorageFreeMB  *int64                   `json:"storage_free_mb"`
+	StorageTotalMB *int64                   `json:"storage_total_mb"`
+	Network        string                   `json:"network"`
+	SignalLevel    *int                     `json:"signal_level"`
+}
+
+// DeviceFleetResponse is the connectivity of the devices of one organization.
+type DeviceFleetResponse struct {
+	OrganizationID string                `json:"organization_id"`
+	Online         int                   `json:"online"`
+	Offline        int                   `json:"offline"`
+	Unknown        int                   `json:"unknown"`
+	Devices        []FleetDeviceResponse `json:"devices"`
+}
+
+type DeviceOfflineAlertListResponse struct {
+	Alerts     []entity.DeviceOfflineAlert `json:"alerts"`
+	Pagination Pagination                  `json:"pagination"`
+}
@@ -0,0 +1,21 @@
+package response
+
+import (
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/pkg/featureflag"
+)
+
+// AppFeatureFlagsResponse is the variant of every flag served to the caller, by key.
+type AppFeatureFlagsResponse struct {
+	Flags map[string]string `json:"flags"`
+}
+
+type FeatureFlagAuditListResponse struct {
+	Audits     []entity.FeatureFlagAudit `json:"audits"`
+	Pagination Pagination                `json:"pagination"`
+}
+
+type FeatureFlagEvaluationResponse struct {
+	Subject     featureflag.Subject      `json:"subject"`
+	Evaluations []featureflag.Evaluation `json:"evaluations"`
+}
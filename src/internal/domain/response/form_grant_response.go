@@ -0,0 +1,8 @@
+package response
+
+import "time"
+
+type FormGrantResponse struct {
+	Grant     string    `json:"grant"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -16,7 +16,7 @@ type CreateFormSucceedResponse struct {
 type GetFormListResponseData struct {
 	ID          uint64    `json:"id"`
 	Spreadsheet string    `json:"spreadsheet_url"`
-	Password    string    `json:"password"`
+	HasPassword bool      `json:"has_password"`
 	Note        string    `json:"note"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
@@ -0,0 +1,21 @@
+package response
+
+import (
+	"sen-global-api/internal/domain/entity"
+	"time"
+)
+
+type FormScoringResponse struct {
+	FormID        uint64                           `json:"form_id"`
+	Questions     []entity.ScoringQuestion         `json:"questions"`
+	Levels        []entity.ScoringLevel            `json:"levels"`
+	SectionLevels map[string][]entity.ScoringLevel `json:"section_levels"`
+	IsActive      bool                             `json:"is_active"`
+	UpdatedAt     time.Time                        `json:"updated_at"`
+}
+
+type SubmissionScoreResponse struct {
+	SubmissionID uint64                  `json:"submission_id"`
+	FormID       uint64                  `json:"form_id"`
+	Score        *entity.SubmissionScore `json:"score"`
+}
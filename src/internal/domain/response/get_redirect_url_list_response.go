@@ -1,16 +1,23 @@
 package response
 
-import "time"
+import (
+	"sen-global-api/internal/domain/entity"
+	"time"
+)
 
 type GetRedirectUrlListResponseData struct {
-	ID           uint64    `json:"id" binding:"required"`
-	QRCode       string    `json:"qr_code" binding:"required"`
-	TargetUrl    string    `json:"target_url" binding:"required"`
-	Password     *string   `json:"password" binding:"required"`
-	Hint         string    `json:"hint" binding:"required"`
-	HashPassword *string   `json:"hash_password" binding:"required"`
-	CreatedAt    time.Time `json:"created_at" binding:"required"`
-	UpdatedAt    time.Time `json:"updated_at" binding:"required"`
+	ID          uint64                      `json:"id" binding:"required"`
+	QRCode      string                      `json:"qr_code" binding:"required"`
+	TargetUrl   string                      `json:"target_url" binding:"required"`
+	HasPassword bool                        `json:"has_password"`
+	Hint        string                      `json:"hint" binding:"required"`
+	ValidFrom   *time.Time                  `json:"valid_from"`
+	ValidUntil  *time.Time                  `json:"valid_until"`
+	MaxUses     *uint64                     `json:"max_uses"`
+	UseCount    uint64                      `json:"use_count"`
+	Targets     []entity.SRedirectUrlTarget `json:"targets,omitempty"`
+	CreatedAt   time.Time                   `json:"created_at" binding:"required"`
+	UpdatedAt   time.Time                   `json:"updated_at" binding:"required"`
 }
 
 type GetRedirectUrlListResponse struct {
@@ -20,6 +20,8 @@ type OrgSettingResponse struct {
 	MessageTopMenu     string                `json:"message_top_menu"`
 	TopMenuPassword    string                `json:"top_menu_password"`
 	Component          *components.Component `json:"component"`
+	// profile of the mode of the device, null when the mode is unrestricted
+	ModeProfile *entity.DeviceModeProfile `json:"mode_profile"`
 }
 
 type GetOrgSettingResponse4Web struct {
@@ -0,0 +1,6 @@
+package response
+
+type QRLoginResponse struct {
+	UserID  string `json:"user_id"`
+	QRLogin string `json:"qr_login"`
+}
@@ -26,9 +26,11 @@ type QuestionListData struct {
 
 type QuestionListResponseData struct {
 	QuestionListData []QuestionListData `json:"questions" binding:"required"`
-	DecryptPassword  string             `json:"decrypt_password"`
-	FormName         string             `json:"form_name" binding:"required"`
-	FormId           uint64             `json:"form_id"`
+	// PasswordRequired asks the app for the form password, checked with POST /v1/form/verify-password
+	// whose grant is then sent as form_grant to get the questions and to submit
+	PasswordRequired bool   `json:"password_required"`
+	FormName         string `json:"form_name" binding:"required"`
+	FormId           uint64 `json:"form_id"`
 }
 
 type QuestionListResponse struct {
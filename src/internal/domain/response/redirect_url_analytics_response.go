@@ -0,0 +1,58 @@
+package response
+
+import "time"
+
+type RedirectUrlStatsResponse struct {
+	RedirectUrlID uint64     `json:"redirect_url_id"`
+	QRCode        string     `json:"qr_code"`
+	TargetUrl     string     `json:"target_url"`
+	TotalScans    int64      `json:"total_scans"`
+	ResolvedScans int64      `json:"resolved_scans"`
+	RefusedScans  int64      `json:"refused_scans"`
+	UniqueDevices int64      `json:"unique_devices"`
+	LastScanAt    *time.Time `json:"last_scan_at"`
+	UseCount      uint64     `json:"use_count"`
+	MaxUses       *uint64    `json:"max_uses"`
+	ValidFrom     *time.Time `json:"valid_from"`
+	ValidUntil    *time.Time `json:"valid_until"`
+	// Status is active, not_started, expired or exhausted at the time of the request
+	Status string `json:"status"`
+}
+
+type RedirectUrlDashboardResponse struct {
+	From        string                     `json:"from"`
+	To          string                     `json:"to"`
+	TotalScans  int64                      `json:"total_scans"`
+	UsedCodes   int                        `json:"used_codes"`
+	UnusedCodes int                        `json:"unused_codes"`
+	Codes       []RedirectUrlStatsResponse `json:"codes"`
+}
+
+type RedirectUrlScanCount struct {
+	Key   string `json:"key"`
+	Total int64  `json:"total"`
+}
+
+type RedirectUrlScanResponse struct {
+	DeviceID       string    `json:"device_id"`
+	OrganizationID string    `json:"organization_id"`
+	UserAgent      string    `json:"user_agent"`
+	Language       string    `json:"language"`
+	ResolvedUrl    string    `json:"resolved_url"`
+	Outcome        string    `json:"outcome"`
+	ScannedAt      time.Time `json:"scanned_at"`
+}
+
+type RedirectUrlAnalyticsResponse struct {
+	RedirectUrlID  uint64                    `json:"redirect_url_id"`
+	QRCode         string                    `json:"qr_code"`
+	From           string                    `json:"from"`
+	To             string                    `json:"to"`
+	ByDay          []RedirectUrlScanCount    `json:"by_day"`
+	ByHour         []RedirectUrlScanCount    `json:"by_hour"`
+	ByTarget       []RedirectUrlScanCount    `json:"by_target"`
+	ByOrganization []RedirectUrlScanCount    `json:"by_organization"`
+	ByLanguage     []RedirectUrlScanCount    `json:"by_language"`
+	ByOutcome      []RedirectUrlScanCount    `json:"by_outcome"`
+	RecentScans    []RedirectUrlScanResponse `json:"recent_scans"`
+}
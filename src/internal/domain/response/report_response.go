@@ -0,0 +1,19 @@
+package response
+
+import "sen-global-api/internal/domain/entity"
+
+type ReportResponse struct {
+	entity.Report
+	// short-lived download url
+	URL string `json:"url"`
+}
+
+type ReportJobResponse struct {
+	entity.ReportJob
+	Reports []entity.Report `json:"reports"`
+}
+
+type ReportJobListResponse struct {
+	Jobs       []entity.ReportJob `json:"jobs"`
+	Pagination Pagination         `json:"pagination"`
+}
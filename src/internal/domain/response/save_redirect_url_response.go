@@ -3,12 +3,12 @@ package response
 import "time"
 
 type SaveRedirectUrlResponseData struct {
-	ID        uint64    `json:"id" binding:"required"`
-	QRCode    string    `json:"qr_code" binding:"required"`
-	TargetUrl string    `json:"target_url" binding:"required"`
-	Password  *string   `json:"password"`
-	CreatedAt time.Time `json:"created_at" binding:"required"`
-	UpdatedAt time.Time `json:"updated_at" binding:"required"`
+	ID          uint64    `json:"id" binding:"required"`
+	QRCode      string    `json:"qr_code" binding:"required"`
+	TargetUrl   string    `json:"target_url" binding:"required"`
+	HasPassword bool      `json:"has_password"`
+	CreatedAt   time.Time `json:"created_at" binding:"required"`
+	UpdatedAt   time.Time `json:"updated_at" binding:"required"`
 }
 
 type SaveRedirectUrlResponse struct {
@@ -1,15 +1,20 @@
 package usecase
 
 import (
+	"context"
 	"fmt"
 	"sen-global-api/internal/data/repository"
 	"sen-global-api/internal/domain/entity"
 	"sen-global-api/internal/domain/request"
 	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/lifecycle"
+
+	log "github.com/sirupsen/logrus"
 )
 
 type AccountsLogUseCase struct {
 	AccountsLogRepository *repository.AccountsLogRepository
+	AttendanceUseCase     *AttendanceUseCase
 }
 
 func (u *AccountsLogUseCase) CreateAccountsLog(req request.CreateAccountsLogRequest) error {
@@ -24,5 +29,18 @@ func (u *AccountsLogUseCase) CreateAccountsLog(req request.CreateAccountsLogRequ
 		DeviceID:       req.DeviceID,
 		OrganizationID: req.OrganizationID,
 	}
-	return u.AccountsLogRepository.Create(accountsLog)
+	if err := u.AccountsLogRepository.Create(accountsLog); err != nil {
+		return err
+	}
+
+	// login cua student tren device duoc tinh la check-in
+	if accountsLog.Type == value.AccountsLogTypeLogin && u.AttendanceUseCase != nil {
+		lifecycle.Default().Go("attendance_check_in", func(context.Context) {
+			if err := u.AttendanceUseCase.CheckInFromDeviceLogin(req.UserID, req.OrganizationID, req.DeviceID); err != nil {
+				log.Error("AccountsLogUseCase: attendance check-in failed: ", err)
+			}
+		})
+	}
+
+	return nil
 }
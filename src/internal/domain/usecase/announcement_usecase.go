@@ -0,0 +1,694 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/consulapi/gateway"
+	"sen-global-api/pkg/lifecycle"
+	"sen-global-api/pkg/messaging"
+	"sen-global-api/pkg/metrics"
+	"sen-global-api/pkg/realtime"
+	"sen-global-api/pkg/uploader"
+	"strings"
+	"time"
+
+	firebase "firebase.google.com/go/v4"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrAnnouncementAccessDenied is returned when the announcement is not addressed to the user or the device.
+var ErrAnnouncementAccessDenied = errors.New("access denied")
+
+type AnnouncementUseCase struct {
+	Repo                *repository.AnnouncementRepository
+	LanguageSettingRepo *repository.LanguageSettingRepository
+	ImageRepo           *repository.ImageRepository
+	PdfRepo             *repository.PdfRepository
+	OrganizationRepo    *repository.OrganizationRepository
+	DeviceRepo          *repository.DeviceRepository
+	UserEntityRepo      *repository.UserEntityRepository
+	UserTokenFCMRepo    *repository.UserTokenFCMRepository
+	DepartmentGateway   gateway.DepartmentGateway
+	FirebaseApp         *firebase.App
+	UploadProvider      uploader.UploadProvider
+}
+
+// ---------- manage ----------
+
+// CanManage tells whether the user is a manager of the organization.
+func (uc *AnnouncementUseCase) CanManage(orgID, userID string) bool {
+	userOrg, err := uc.OrganizationRepo.GetUserOrgInfo(userID, orgID)
+	if err != nil || userOrg == nil {
+		return false
+	}
+	return userOrg.IsManager
+}
+
+// CanManageAnnouncement tells whether the user manages the organization of the announcement.
+func (uc *AnnouncementUseCase) CanManageAnnouncement(id, userID string) bool {
+	announcement, err := uc.Repo.GetByID(id)
+	if err != nil || announcement == nil {
+		return false
+	}
+	return uc.CanManage(announcement.OrganizationID, userID)
+}
+
+func (uc *AnnouncementUseCase) Create(req request.SaveAnnouncementRequest, userID string) (*response.AnnouncementResponse, error) {
+	announcement := &entity.Announcement{
+		OrganizationID: req.OrganizationID,
+		CreatedBy:      userID,
+	}
+	if err := uc.applyRequest(announcement, req); err != nil {
+		return nil, err
+	}
+
+	if err := uc.Repo.Create(announcement); err != nil {
+		return nil, err
+	}
+	uc.pushIfDue(announcement)
+
+	return uc.mapAnnouncement(announcement, 0), nil
+}
+
+func (uc *AnnouncementUseCase) Update(id string, req request.SaveAnnouncementRequest) (*response.AnnouncementResponse, error) {
+	announcement, err := uc.Repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if announcement == nil {
+		return nil, errors.New("announcement not found")
+	}
+	if req.OrganizationID != announcement.OrganizationID {
+		return nil, errors.New("announcement cannot be moved to another organization")
+	}
+
+	if err := uc.applyRequest(announcement, req); err != nil {
+		return nil, err
+	}
+	if err := uc.Repo.Replace(announcement); err != nil {
+		return nil, err
+	}
+	uc.pushIfDue(announcement)
+
+	counts, _ := uc.Repo.CountReads([]string{id})
+	return uc.mapAnnouncement(announcement, counts[id]), nil
+}
+
+func (uc *AnnouncementUseCase) Delete(id string) error {
+	return uc.Repo.Delete(id)
+}
+
+func (uc *AnnouncementUseCase) GetByID(id string) (*response.AnnouncementResponse, error) {
+	announcement, err := uc.Repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if announcement == nil {
+		return nil, errors.New("announcement not found")
+	}
+
+	counts, err := uc.Repo.CountReads([]string{id})
+	if err != nil {
+		return nil, err
+	}
+	return uc.mapAnnouncement(announcement, counts[id]), nil
+}
+
+func (uc *AnnouncementUseCase) GetByOrganization(orgID string) ([]response.AnnouncementResponse, error) {
+	announcements, err := uc.Repo.GetByOrganization(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(announcements))
+	for _, a := range announcements {
+		ids = append(ids, a.ID.String())
+	}
+	counts, err := uc.Repo.CountReads(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]response.AnnouncementResponse, 0, len(announcements))
+	for i := range announcements {
+		res = append(res, *uc.mapAnnouncement(&announcements[i], counts[announcements[i].ID.String()]))
+	}
+	return res, nil
+}
+
+func (uc *AnnouncementUseCase) GetReads(id string) ([]response.AnnouncementReadResponse, error) {
+	announcement, err := uc.Repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if announcement == nil {
+		return nil, errors.New("announcement not found")
+	}
+
+	reads, err := uc.Repo.GetReadsByAnnouncement(id)
+	if err != nil {
+		return nil, err
+	}
+
+	nicknames := make(map[string]string)
+	if users, err := uc.UserEntityRepo.GetAllByOrganizationID(announcement.OrganizationID); err == nil {
+		for _, u := range users {
+			nicknames[u.ID.String()] = u.Nickname
+		}
+	}
+
+	res := make([]response.AnnouncementReadResponse, 0, len(reads))
+	for _, r := range reads {
+		res = append(res, response.AnnouncementReadResponse{
+			UserID:   r.UserID,
+			Nickname: nicknames[r.UserID],
+			DeviceID: r.DeviceID,
+			ReadAt:   r.ReadAt,
+		})
+	}
+	return res, nil
+}
+
+// ---------- audience ----------
+
+// GetFeed returns the active announcements of the organization targeting the user (or the device), in the app language.
+// The device must belong to the organization and be one the user registered or logged in on.
+func (uc *AnnouncementUseCase) GetFeed(ctx *gin.Context, orgID, userID, deviceID string) ([]response.AnnouncementFeedItem, error) {
+	if err := uc.checkReader(orgID, userID, deviceID); err != nil {
+		return nil, err
+	}
+
+	announcements, err := uc.Repo.GetActiveByOrganization(orgID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	audience := uc.newAudience(ctx, userID, deviceID)
+	visible := make([]entity.Announcement, 0, len(announcements))
+	ids := make([]string, 0, len(announcements))
+	for _, a := range announcements {
+		if audience.matches(a) {
+			visible = append(visible, a)
+			ids = append(ids, a.ID.String())
+		}
+	}
+
+	read, err := uc.Repo.GetReadAnnouncementIDs(userID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var appLanguage uint
+	if lang, ok := ctx.Get("app_language"); ok {
+		appLanguage, _ = lang.(uint)
+	}
+
+	res := make([]response.AnnouncementFeedItem, 0, len(visible))
+	for i := range visible {
+		a := &visible[i]
+		item := response.AnnouncementFeedItem{
+			ID:          a.ID.String(),
+			PublishAt:   a.PublishAt,
+			ExpireAt:    a.ExpireAt,
+			Attachments: uc.mapAttachments(a.Attachments),
+			IsRead:      read[a.ID.String()],
+		}
+		if t := pickTranslation(a.Translations, appLanguage); t != nil {
+			item.LanguageID = t.LanguageID
+			item.Title = t.Title
+			item.Body = t.Body
+		}
+		res = append(res, item)
+	}
+	return res, nil
+}
+
+// MarkRead records the read receipt of the user, the announcement must be addressed to the user (or the device).
+func (uc *AnnouncementUseCase) MarkRead(ctx *gin.Context, id, userID string, req request.ReadAnnouncementRequest) error {
+	announcement, err := uc.Repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if announcement == nil {
+		return errors.New("announcement not found")
+	}
+
+	if err := uc.checkReader(announcement.OrganizationID, userID, req.DeviceID); err != nil {
+		return err
+	}
+	if !uc.newAudience(ctx, userID, req.DeviceID).matches(*announcement) {
+		return fmt.Errorf("%w: announcement is not addressed to the user", ErrAnnouncementAccessDenied)
+	}
+
+	announcementID, _ := uuid.Parse(id)
+	return uc.Repo.CreateRead(&entity.AnnouncementRead{
+		AnnouncementID: announcementID,
+		UserID:         userID,
+		DeviceID:       req.DeviceID,
+	})
+}
+
+// checkReader checks the user belongs to the organization, or reads from a device of the organization
+// the user registered or logged in on.
+func (uc *AnnouncementUseCase) checkReader(orgID, userID, deviceID string) error {
+	if deviceID != "" {
+		inOrg, err := uc.DeviceRepo.CheckDeviceExistInOrganization(deviceID, orgID)
+		if err != nil {
+			return err
+		}
+		if !inOrg {
+			return fmt.Errorf("%w: device does not belong to the organization", ErrAnnouncementAccessDenied)
+		}
+		isUser, err := uc.DeviceRepo.IsUserOfDevice(userID, deviceID)
+		if err != nil {
+			return err
+		}
+		if !isUser {
+			return fmt.Errorf("%w: device does not belong to the user", ErrAnnouncementAccessDenied)
+		}
+		return nil
+	}
+
+	userOrg, err := uc.OrganizationRepo.GetUserOrgInfo(userID, orgID)
+	if err != nil {
+		return err
+	}
+	if userOrg == nil || userOrg.UserID == uuid.Nil {
+		return fmt.Errorf("%w: user does not belong to the organization", ErrAnnouncementAccessDenied)
+	}
+	return nil
+}
+
+type announcementAudience struct {
+	uc          *AnnouncementUseCase
+	ctx         *gin.Context
+	userID      string
+	deviceID    string
+	roles       map[string]bool
+	departments map[string]bool
+}
+
+func (uc *AnnouncementUseCase) newAudience(ctx *gin.Context, userID, deviceID string) *announcementAudience {
+	return &announcementAudience{uc: uc, ctx: ctx, userID: userID, deviceID: deviceID}
+}
+
+// matches tells whether the announcement targets the audience, an announcement without target is for everyone.
+// Roles and departments are only loaded when an announcement targets them.
+func (a *announcementAudience) matches(announcement entity.Announcement) bool {
+	if len(announcement.Targets) == 0 {
+		return true
+	}
+
+	for _, t := range announcement.Targets {
+		switch t.TargetType {
+		case value.AnnouncementTargetDevice:
+			if a.deviceID != "" && t.TargetID == a.deviceID {
+				return true
+			}
+		case value.AnnouncementTargetRole:
+			if a.loadRoles()[t.TargetID] {
+				return true
+			}
+		case value.AnnouncementTargetDepartment:
+			if a.loadDepartments()[t.TargetID] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (a *announcementAudience) loadRoles() map[string]bool {
+	if a.roles != nil {
+		return a.roles
+	}
+	a.roles = make(map[string]bool)
+	user, err := a.uc.UserEntityRepo.GetByID(request.GetUserEntityByIDRequest{ID: a.userID})
+	if err != nil || user == nil {
+		return a.roles
+	}
+	for _, role := range user.Roles {
+		a.roles[role.Role.String()] = true
+	}
+	return a.roles
+}
+
+func (a *announcementAudience) loadDepartments() map[string]bool {
+	if a.departments != nil {
+		return a.departments
+	}
+	a.departments = make(map[string]bool)
+	if a.uc.DepartmentGateway == nil {
+		return a.departments
+	}
+	departments, err := a.uc.DepartmentGateway.GetDepartmentsByUser(a.ctx)
+	if err != nil {
+		log.Error("AnnouncementUseCase: get departments by user: ", err)
+		return a.departments
+	}
+	for _, d := range departments {
+		a.departments[d.ID] = true
+	}
+	return a.departments
+}
+
+// ---------- push ----------
+
+// PushDue sends the publish push of the announcements whose publish time has come.
+func (uc *AnnouncementUseCase) PushDue() error {
+	announcements, err := uc.Repo.GetDueForPush(time.Now())
+	if err != nil {
+		log.Error("AnnouncementUseCase.PushDue: ", err)
+		return err
+	}
+	var errs []error
+	for i := range announcements {
+		if err := uc.push(&announcements[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (uc *AnnouncementUseCase) StartPublishScheduler() {
+	c := cron.New(cron.WithSeconds())
+	// chay moi phut de push cac announcement toi gio publish
+	_, err := c.AddFunc("0 * * * * *", func() {
+		var jobErr error
+		track := metrics.TrackJob("announcement_publish")
+		defer func() { track(jobErr) }()
+		jobErr = uc.PushDue()
+	})
+	if err != nil {
+		log.Fatalf("Failed to add PushDue announcement cron job: %v", err)
+	}
+
+	c.Start()
+	lifecycle.Default().RegisterCron("announcement_publish", c)
+}
+
+func (uc *AnnouncementUseCase) pushIfDue(announcement *entity.Announcement) {
+	now := time.Now()
+	if announcement.Status != value.AnnouncementStatusPublished || announcement.PushedAt != nil {
+		return
+	}
+	if announcement.PublishAt != nil && announcement.PublishAt.After(now) {
+		return
+	}
+	if announcement.ExpireAt != nil && !announcement.ExpireAt.After(now) {
+		return
+	}
+	uc.push(announcement)
+}
+
+// push notifies the audience of the announcement: the whole organization when it has no target, else the
+// targeted devices and the users holding a targeted role, on their realtime channel and their FCM tokens.
+// Department members are only known to the department service for the calling user, so department
+// targets are not pushed and see the announcement in their feed.
+func (uc *AnnouncementUseCase) push(announcement *entity.Announcement) error {
+	payload := map[string]interface{}{
+		"announcement_id": announcement.ID.String(),
+		"organization_id": announcement.OrganizationID,
+	}
+	title := ""
+	if t := pickTranslation(announcement.Translations, 0); t != nil {
+		title = t.Title
+		payload["title"] = title
+	}
+
+	channels := make([]string, 0)
+	roles := make([]string, 0)
+	for _, t := range announcement.Targets {
+		switch t.TargetType {
+		case value.AnnouncementTargetDevice:
+			channels = append(channels, realtime.DeviceChannel(t.TargetID))
+		case value.AnnouncementTargetRole:
+			roles = append(roles, t.TargetID)
+		}
+	}
+	if len(announcement.Targets) == 0 {
+		channels = append(channels, realtime.OrganizationChannel(announcement.OrganizationID))
+	}
+
+	var userIDs []string
+	if len(roles) > 0 {
+		var err error
+		userIDs, err = uc.UserEntityRepo.GetUserIDsByOrganizationAndRoles(announcement.OrganizationID, roles)
+		if err != nil {
+			log.Error("AnnouncementUseCase.push: get targeted users: ", err)
+			return err
+		}
+		for _, userID := range userIDs {
+			channels = append(channels, realtime.UserChannel(userID))
+		}
+	}
+
+	for _, channel := range channels {
+		err := realtime.Publish(context.Background(), channel, string(value.RealtimeEventAnnouncement), payload)
+		if err != nil {
+			log.Errorf("AnnouncementUseCase.push %s on %s: %v", announcement.ID, channel, err)
+			return err
+		}
+	}
+	uc.notifyUsers(userIDs, title)
+
+	now := time.Now()
+	if err := uc.Repo.MarkPushed(announcement.ID.String(), now); err != nil {
+		log.Error("AnnouncementUseCase.push: mark pushed: ", err)
+		return err
+	}
+	announcement.PushedAt = &now
+	return nil
+}
+
+// notifyUsers sends the announcement title to every active FCM token of the users.
+func (uc *AnnouncementUseCase) notifyUsers(userIDs []string, title string) {
+	if uc.FirebaseApp == nil || uc.UserTokenFCMRepo == nil {
+		return
+	}
+
+	for _, userID := range userIDs {
+		tokens, err := uc.UserTokenFCMRepo.FindByUserID(userID)
+		if err != nil {
+			log.Error("AnnouncementUseCase.notifyUsers: ", err)
+			continue
+		}
+		for _, token := range tokens {
+			if !token.IsActive || token.FCMToken == "" {
+				continue
+			}
+			err := messaging.SendNotification(uc.FirebaseApp, messaging.NotificationParams{
+				Title:       "Announcement",
+				Message:     title,
+				DeviceToken: token.FCMToken,
+				Type:        value.NotificationType_Announcement,
+			})
+			if err != nil {
+				log.Error("AnnouncementUseCase.notifyUsers: send notification failed: ", err)
+			}
+		}
+	}
+}
+
+// ---------- helpers ----------
+
+func (uc *AnnouncementUseCase) applyRequest(announcement *entity.Announcement, req request.SaveAnnouncementRequest) error {
+	status := value.AnnouncementStatus(req.Status)
+	if req.Status == "" {
+		status = value.AnnouncementStatusDraft
+	}
+	if !status.IsValid() {
+		return fmt.Errorf("invalid status: %s", req.Status)
+	}
+
+	publishAt, err := parseOptionalTime(req.PublishAt)
+	if err != nil {
+		return fmt.Errorf("invalid publish_at: %w", err)
+	}
+	expireAt, err := parseOptionalTime(req.ExpireAt)
+	if err != nil {
+		return fmt.Errorf("invalid expire_at: %w", err)
+	}
+	if expireAt != nil && publishAt != nil && !expireAt.After(*publishAt) {
+		return errors.New("expire_at must be after publish_at")
+	}
+
+	if len(req.Translations) == 0 {
+		return errors.New("at least one translation is required")
+	}
+	translations := make([]entity.AnnouncementTranslation, 0, len(req.Translations))
+	seenLanguages := make(map[uint]bool)
+	for _, t := range req.Translations {
+		if seenLanguages[t.LanguageID] {
+			return fmt.Errorf("language %d is translated twice", t.LanguageID)
+		}
+		seenLanguages[t.LanguageID] = true
+		if _, err := uc.LanguageSettingRepo.GetByID(t.LanguageID); err != nil {
+			return fmt.Errorf("language %d not found", t.LanguageID)
+		}
+		translations = append(translations, entity.AnnouncementTranslation{
+			LanguageID: t.LanguageID,
+			Title:      strings.TrimSpace(t.Title),
+			Body:       t.Body,
+		})
+	}
+
+	targets := make([]entity.AnnouncementTarget, 0, len(req.Targets))
+	for _, t := range req.Targets {
+		targetType := value.AnnouncementTargetType(t.TargetType)
+		if !targetType.IsValid() {
+			return fmt.Errorf("invalid target type: %s", t.TargetType)
+		}
+		switch targetType {
+		case value.AnnouncementTargetRole:
+			if _, err := entity.RoleFromString(t.TargetID); err != nil {
+				return fmt.Errorf("invalid role: %s", t.TargetID)
+			}
+		case value.AnnouncementTargetDevice:
+			inOrg, err := uc.DeviceRepo.CheckDeviceExistInOrganization(t.TargetID, req.OrganizationID)
+			if err != nil {
+				return err
+			}
+			if !inOrg {
+				return fmt.Errorf("device %s does not belong to the organization", t.TargetID)
+			}
+		}
+		targets = append(targets, entity.AnnouncementTarget{
+			TargetType: targetType,
+			TargetID:   t.TargetID,
+		})
+	}
+
+	attachments := make([]entity.AnnouncementAttachment, 0, len(req.Attachments))
+	for i, a := range req.Attachments {
+		attachmentType := value.AnnouncementAttachmentType(a.Type)
+		var name string
+		switch attachmentType {
+		case value.AnnouncementAttachmentImage:
+			img, err := uc.ImageRepo.GetByKey(a.Key)
+			if err != nil {
+				return fmt.Errorf("image %s not found", a.Key)
+			}
+			name = img.ImageName
+		case value.AnnouncementAttachmentPdf:
+			pdf, err := uc.PdfRepo.GetByKey(a.Key)
+			if err != nil {
+				return fmt.Errorf("pdf %s not found", a.Key)
+			}
+			name = pdf.PdfName
+		default:
+			return fmt.Errorf("invalid attachment type: %s", a.Type)
+		}
+		attachments = append(attachments, entity.AnnouncementAttachment{
+			Type:  attachmentType,
+			Key:   a.Key,
+			Name:  name,
+			Order: i,
+		})
+	}
+
+	// doi lich publish thi push lai
+	if !sameTime(announcement.PublishAt, publishAt) || announcement.Status != status {
+		announcement.PushedAt = nil
+	}
+
+	announcement.Status = status
+	announcement.PublishAt = publishAt
+	announcement.ExpireAt = expireAt
+	announcement.Translations = translations
+	announcement.Targets = targets
+	announcement.Attachments = attachments
+	return nil
+}
+
+func (uc *AnnouncementUseCase) mapAnnouncement(a *entity.Announcement, readCount int64) *response.AnnouncementResponse {
+	res := &response.AnnouncementResponse{
+		ID:             a.ID.String(),
+		OrganizationID: a.OrganizationID,
+		Status:         string(a.Status),
+		PublishAt:      a.PublishAt,
+		ExpireAt:       a.ExpireAt,
+		PushedAt:       a.PushedAt,
+		CreatedBy:      a.CreatedBy,
+		CreatedAt:      a.CreatedAt,
+		Translations:   make([]response.AnnouncementTranslationResponse, 0, len(a.Translations)),
+		Targets:        make([]response.AnnouncementTargetResponse, 0, len(a.Targets)),
+		Attachments:    uc.mapAttachments(a.Attachments),
+		ReadCount:      readCount,
+	}
+	for _, t := range a.Translations {
+		res.Translations = append(res.Translations, response.AnnouncementTranslationResponse{
+			LanguageID: t.LanguageID,
+			Title:      t.Title,
+			Body:       t.Body,
+		})
+	}
+	for _, t := range a.Targets {
+		res.Targets = append(res.Targets, response.AnnouncementTargetResponse{
+			TargetType: string(t.TargetType),
+			TargetID:   t.TargetID,
+		})
+	}
+	return res
+}
+
+func (uc *AnnouncementUseCase) mapAttachments(attachments []entity.AnnouncementAttachment) []response.AnnouncementAttachmentResponse {
+	res := make([]response.AnnouncementAttachmentResponse, 0, len(attachments))
+	for _, a := range attachments {
+		item := response.AnnouncementAttachmentResponse{
+			Type: string(a.Type),
+			Key:  a.Key,
+			Name: a.Name,
+		}
+		if uc.UploadProvider != nil {
+			url, err := uc.UploadProvider.GetFileUploaded(context.Background(), a.Key, nil)
+			if err != nil {
+				log.Error("AnnouncementUseCase: get attachment url: ", err)
+			} else if url != nil {
+				item.Url = *url
+			}
+		}
+		res = append(res, item)
+	}
+	return res
+}
+
+// pickTranslation returns the translation in the language, or the first one when the language is missing.
+func pickTranslation(translations []entity.AnnouncementTranslation, languageID uint) *entity.AnnouncementTranslation {
+	if len(translations) == 0 {
+		return nil
+	}
+	for i := range translations {
+		if translations[i].LanguageID == languageID {
+			return &translations[i]
+		}
+	}
+	return &translations[0]
+}
+
+func parseOptionalTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sen-global-api/internal/domain/entity/menu"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/pkg/appcache"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Cache cua cac API app goi lien tuc (menu, setting), invalidate o cac usecase upload tuong ung.
+var (
+	// scope: student id
+	studentMenuCache = appcache.New[*response.GetStudentMenuResponse]("student_menu", 1, 10*time.Minute)
+	// scope: user id, gom menu child/teacher/staff/parent/department nen bi xoa het khi mot menu thay doi
+	sectionMenuCache = appcache.New[[]response.GetMenuSectionResponse]("section_menu", 1, 5*time.Minute)
+	// scope: organization id
+	orgDeviceMenuCache = appcache.New[[]menu.DeviceMenu]("org_device_menu", 1, 10*time.Minute)
+	// scope: device id
+	orgSettingCache = appcache.New[response.OrgSettingResponse]("org_setting", 2, 10*time.Minute)
+	// scope: owner role + owner id
+	languagesConfigCache = appcache.New[*response.LanguagesConfigResponse]("languages_config", 1, 30*time.Minute)
+)
+
+// appLanguageKey is the cache key of the values depending on the language of the app.
+func appLanguageKey(ctx *gin.Context) string {
+	appLanguage, _ := ctx.Get("app_language")
+	return fmt.Sprintf("lang:%v", appLanguage)
+}
+
+// invalidateSectionMenus drops the composed menus of the app after a menu upload.
+func invalidateSectionMenus(ctx context.Context) {
+	sectionMenuCache.InvalidateAll(ctx)
+}
+
+// invalidateAllMenus is used when shared components change (templates, deletion of a component).
+func invalidateAllMenus(ctx context.Context) {
+	studentMenuCache.InvalidateAll(ctx)
+	sectionMenuCache.InvalidateAll(ctx)
+	orgDeviceMenuCache.InvalidateAll(ctx)
+	orgSettingCache.InvalidateAll(ctx)
+}
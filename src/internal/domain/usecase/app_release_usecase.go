@@ -0,0 +1,293 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/semver"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type AppReleaseUseCase struct {
+	Repo       *repository.AppReleaseRepository
+	DeviceRepo *repository.DeviceRepository
+}
+
+func (uc *AppReleaseUseCase) Create(req request.CreateAppReleaseRequest, createdBy string) (*entity.AppRelease, error) {
+	if !req.Platform.IsValid() {
+		return nil, fmt.Errorf("invalid platform %q", req.Platform)
+	}
+	version, err := semver.Parse(req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.Repo.GetByPlatformAndVersion(req.Platform, version.String())
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("release %s %s already exists", req.Platform, version)
+	}
+
+	release := &entity.AppRelease{
+		Platform:          req.Platform,
+		Version:           version.String(),
+		ReleaseNotes:      req.ReleaseNotes,
+		RolloutPercentage: 100,
+		StoreURL:          req.StoreURL,
+		IsActive:          true,
+		CreatedBy:         createdBy,
+	}
+	if req.RolloutPercentage != nil {
+		release.RolloutPercentage = *req.RolloutPercentage
+	}
+	if req.IsActive != nil {
+		release.IsActive = *req.IsActive
+	}
+	if release.MinSupportedVersion, err = normalizeMinSupportedVersion(req.MinSupportedVersion, version); err != nil {
+		return nil, err
+	}
+	if err := validateRollout(release.RolloutPercentage); err != nil {
+		return nil, err
+	}
+
+	if err := uc.Repo.Create(release); err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+func (uc *AppReleaseUseCase) Update(id uint, req request.UpdateAppReleaseRequest) (*entity.AppRelease, error) {
+	release, err := uc.Repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("app release not found")
+		}
+		return nil, err
+	}
+
+	if req.ReleaseNotes != nil {
+		release.ReleaseNotes = *req.ReleaseNotes
+	}
+	if req.MinSupportedVersion != nil {
+		if release.MinSupportedVersion, err = normalizeMinSupportedVersion(*req.MinSupportedVersion, semver.MustParse(release.Version)); err != nil {
+			return nil, err
+		}
+	}
+	if req.RolloutPercentage != nil {
+		if err := validateRollout(*req.RolloutPercentage); err != nil {
+			return nil, err
+		}
+		release.RolloutPercentage = *req.RolloutPercentage
+	}
+	if req.StoreURL != nil {
+		release.StoreURL = *req.StoreURL
+	}
+	if req.IsActive != nil {
+		release.IsActive = *req.IsActive
+	}
+
+	if err := uc.Repo.Save(release); err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+func (uc *AppReleaseUseCase) Delete(id uint) error {
+	return uc.Repo.Delete(id)
+}
+
+// List returns the releases, the newest version first.
+func (uc *AppReleaseUseCase) List(req request.GetAppReleasesRequest) ([]entity.AppRelease, error) {
+	releases, err := uc.Repo.List(req.Platform, false)
+	if err != nil {
+		return nil, err
+	}
+	sortReleases(releases)
+	return releases, nil
+}
+
+// Check tells a device starting the app whether it must or may update.
+// The version reported is recorded for the fleet reports only when the call carries the device token of the device,
+// the check itself needs no token so anyone could otherwise overwrite the version of any device.
+func (uc *AppReleaseUseCase) Check(req request.CheckAppVersionRequest, tokenDeviceID string) (*response.AppVersionCheckResponse, error) {
+	if !req.Platform.IsValid() {
+		return nil, fmt.Errorf("invalid platform %q", req.Platform)
+	}
+
+	releases, err := uc.Repo.List(req.Platform, true)
+	if err != nil {
+		return nil, err
+	}
+	sortReleases(releases)
+
+	if req.DeviceID != "" && req.DeviceID == tokenDeviceID {
+		if err := uc.DeviceRepo.UpdateAppVersion(req.DeviceID, req.Platform, req.Version); err != nil {
+			log.Warnf("AppReleaseUseCase.Check: record version of device %s: %v", req.DeviceID, err)
+		}
+	}
+
+	return evaluateAppVersion(releases, req.Version, req.DeviceID, true), nil
+}
+
+// GetVersionDistribution reports the app versions of the devices of each organization (one organization when given).
+// The status of a version ignores the rollouts: a device behind the latest release counts as update available.
+func (uc *AppReleaseUseCase) GetVersionDistribution(req request.GetAppVersionDistributionRequest) ([]response.AppVersionDistributionResponse, error) {
+	rows, err := uc.Repo.CountVersionsByOrganization(req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	releases, err := uc.Repo.List("", true)
+	if err != nil {
+		return nil, err
+	}
+	sortReleases(releases)
+
+	byPlatform := make(map[value.DeviceType][]entity.AppRelease)
+	for _, release := range releases {
+		byPlatform[release.Platform] = append(byPlatform[release.Platform], release)
+	}
+
+	result := make([]response.AppVersionDistributionResponse, 0)
+	index := make(map[string]int)
+	for _, row := range rows {
+		i, ok := index[row.OrganizationID]
+		if !ok {
+			i = len(result)
+			index[row.OrganizationID] = i
+			result = append(result, response.AppVersionDistributionResponse{
+				OrganizationID:   row.OrganizationID,
+				OrganizationName: row.OrganizationName,
+				Versions:         make([]response.AppVersionCountResponse, 0),
+			})
+		}
+		org := &result[i]
+
+		status := value.AppUpdateStatusUnknown
+		if row.Platform.IsValid() {
+			status = evaluateAppVersion(byPlatform[row.Platform], row.AppVersion, "", false).Status
+		}
+
+		org.TotalDevices += row.Total
+		switch status {
+		case value.AppUpdateStatusOK:
+			org.UpToDate += row.Total
+		case value.AppUpdateStatusAvailable:
+			org.UpdateAvailable += row.Total
+		case value.AppUpdateStatusRequired:
+			org.UpdateRequired += row.Total
+		default:
+			org.Unknown += row.Total
+		}
+		org.Versions = append(org.Versions, response.AppVersionCountResponse{
+			Platform: row.Platform,
+			Version:  row.AppVersion,
+			Devices:  row.Total,
+			Status:   status,
+		})
+	}
+
+	return result, nil
+}
+
+// evaluateAppVersion compares the version of a device with the active releases of its platform, newest first.
+// With withRollout only the releases whose rollout includes the device are considered.
+func evaluateAppVersion(releases []entity.AppRelease, current string, deviceID string, withRollout bool) *response.AppVersionCheckResponse {
+	res := &response.AppVersionCheckResponse{
+		Status:         value.AppUpdateStatusOK,
+		CurrentVersion: current,
+	}
+
+	var latest *entity.AppRelease
+	var minSupported *semver.Version
+	for i := range releases {
+		release := &releases[i]
+		if withRollout && !inRollout(release, deviceID) {
+			continue
+		}
+		if latest == nil {
+			latest = release
+		}
+		if release.MinSupportedVersion == "" {
+			continue
+		}
+		if min, err := semver.Parse(release.MinSupportedVersion); err == nil && (minSupported == nil || minSupported.Less(min)) {
+			minSupported = &min
+		}
+	}
+	if latest == nil {
+		return res
+	}
+
+	res.LatestVersion = latest.Version
+	res.ReleaseNotes = latest.ReleaseNotes
+	res.StoreURL = latest.StoreURL
+	if minSupported != nil {
+		res.MinSupportedVersion = minSupported.String()
+	}
+
+	version, err := semver.Parse(current)
+	switch {
+	case err != nil:
+		res.Status = value.AppUpdateStatusUnknown
+	case minSupported != nil && version.Less(*minSupported):
+		res.Status = value.AppUpdateStatusRequired
+	case version.Less(semver.MustParse(latest.Version)):
+		res.Status = value.AppUpdateStatusAvailable
+	}
+	return res
+}
+
+// inRollout puts each device in a stable bucket 0-99 per release, so raising the percentage only adds devices.
+func inRollout(release *entity.AppRelease, deviceID string) bool {
+	if release.RolloutPercentage >= 100 {
+		return true
+	}
+	if deviceID == "" || release.RolloutPercentage <= 0 {
+		return false
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(string(release.Platform) + ":" + release.Version + ":" + deviceID))
+	return int(h.Sum32()%100) < release.RolloutPercentage
+}
+
+func sortReleases(releases []entity.AppRelease) {
+	sort.SliceStable(releases, func(i, j int) bool {
+		a, errA := semver.Parse(releases[i].Version)
+		b, errB := semver.Parse(releases[j].Version)
+		if errA != nil || errB != nil {
+			return errB != nil && errA == nil
+		}
+		return b.Less(a)
+	})
+}
+
+func normalizeMinSupportedVersion(min string, version semver.Version) (string, error) {
+	if min == "" {
+		return "", nil
+	}
+	parsed, err := semver.Parse(min)
+	if err != nil {
+		return "", err
+	}
+	if version.Less(parsed) {
+		return "", fmt.Errorf("min supported version %s is greater than the release %s", parsed, version)
+	}
+	return parsed.String(), nil
+}
+
+func validateRollout(percentage int) error {
+	if percentage < 0 || percentage > 100 {
+		return errors.New("rollout_percentage must be between 0 and 100")
+	}
+	return nil
+}
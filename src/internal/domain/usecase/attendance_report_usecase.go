@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"bytes"
+	"fmt"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/value"
+	"sort"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const attendanceReportSheet = "Attendance"
+
+var attendanceStatusCode = map[value.AttendanceStatus]string{
+	value.AttendanceStatusPresent: "P",
+	value.AttendanceStatusLate:    "L",
+	value.AttendanceStatusAbsent:  "A",
+	value.AttendanceStatusExcused: "E",
+}
+
+// ExportMonthlyReport builds the XLSX attendance report of an organization for a month (YYYY-MM):
+// one row per student, one column per day (P/L/A/E) and the totals per status.
+func (uc *AttendanceUseCase) ExportMonthlyReport(orgID, month string) ([]byte, string, error) {
+	from, to, err := monthRange(month)
+	if err != nil {
+		return nil, "", err
+	}
+	first, _ := time.Parse(attendanceDateLayout, from)
+	last, _ := time.Parse(attendanceDateLayout, to)
+	days := last.Day()
+
+	students, err := uc.StudentRepo.GetByOrganizationID(orgID)
+	if err != nil {
+		return nil, "", err
+	}
+	sort.Slice(students, func(i, j int) bool {
+		return students[i].StudentName < students[j].StudentName
+	})
+
+	records, err := uc.Repo.GetRecordsByRange(orgID, from, to)
+	if err != nil {
+		return nil, "", err
+	}
+	recordByStudentDay := make(map[string]map[string]*entity.AttendanceRecord)
+	for i := range records {
+		r := &records[i]
+		if recordByStudentDay[r.StudentID] == nil {
+			recordByStudentDay[r.StudentID] = make(map[string]*entity.AttendanceRecord)
+		}
+		recordByStudentDay[r.StudentID][r.Date] = r
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+	if err := f.SetSheetName(f.GetSheetName(0), attendanceReportSheet); err != nil {
+		return nil, "", err
+	}
+
+	headers := []interface{}{"Student ID", "Student name"}
+	for d := 1; d <= days; d++ {
+		headers = append(headers, d)
+	}
+	headers = append(headers, "Present", "Late", "Absent", "Excused")
+	if err := f.SetSheetRow(attendanceReportSheet, "A1", &headers); err != nil {
+		return nil, "", err
+	}
+
+	for i, student := range students {
+		studentID := student.ID.String()
+		row := []interface{}{studentID, student.StudentName}
+		totals := make(map[value.AttendanceStatus]int)
+
+		for d := 0; d < days; d++ {
+			date := first.AddDate(0, 0, d).Format(attendanceDateLayout)
+			record := recordByStudentDay[studentID][date]
+			if record == nil {
+				row = append(row, "")
+				continue
+			}
+			row = append(row, attendanceStatusCode[record.Status])
+			totals[record.Status]++
+		}
+		row = append(row,
+			totals[value.AttendanceStatusPresent],
+			totals[value.AttendanceStatusLate],
+			totals[value.AttendanceStatusAbsent],
+			totals[value.AttendanceStatusExcused],
+		)
+
+		cell, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return nil, "", err
+		}
+		if err := f.SetSheetRow(attendanceReportSheet, cell, &row); err != nil {
+			return nil, "", err
+		}
+	}
+
+	_ = f.SetColWidth(attendanceReportSheet, "A", "A", 38)
+	_ = f.SetColWidth(attendanceReportSheet, "B", "B", 28)
+	_ = f.SetPanes(attendanceReportSheet, &excelize.Panes{
+		Freeze:      true,
+		XSplit:      2,
+		YSplit:      1,
+		TopLeftCell: "C2",
+		ActivePane:  "bottomRight",
+	})
+
+	buf := new(bytes.Buffer)
+	if err := f.Write(buf); err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), fmt.Sprintf("attendance_%s_%s.xlsx", orgID, month), nil
+}
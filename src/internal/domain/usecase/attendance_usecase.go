@@ -0,0 +1,727 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/lifecycle"
+	"sen-global-api/pkg/messaging"
+	"sen-global-api/pkg/metrics"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	firebase "firebase.google.com/go/v4"
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	attendanceDateLayout  = "2006-01-02"
+	attendanceMonthLayout = "2006-01"
+	attendanceClockLayout = "15:04"
+	defaultAttendanceZone = "Asia/Ho_Chi_Minh"
+)
+
+type AttendanceUseCase struct {
+	Repo              *repository.AttendanceRepository
+	StudentRepo       *repository.StudentApplicationRepository
+	TeacherRepo       *repository.TeacherApplicationRepository
+	OrganizationRepo  *repository.OrganizationRepository
+	ChildGuardianRepo *repository.ChildGuardianRepository
+	UserTokenFCMRepo  *repository.UserTokenFCMRepository
+	FirebaseApp       *firebase.App
+}
+
+// ---------- check-in / check-out ----------
+
+// CheckInOut records a check-in or check-out coming from a device QR scan.
+func (uc *AttendanceUseCase) CheckInOut(req request.AttendanceCheckRequest, recordedBy string) (*response.AttendanceRecordResponse, error) {
+	eventType := value.AttendanceEventType(req.Type)
+	if !eventType.IsValid() {
+		return nil, fmt.Errorf("invalid type: %s", req.Type)
+	}
+
+	student, err := uc.getStudentInOrganization(req.StudentID, req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := uc.applyEvent(&entity.AttendanceEvent{
+		OrganizationID: req.OrganizationID,
+		StudentID:      req.StudentID,
+		DepartmentID:   req.DepartmentID,
+		Type:           eventType,
+		Source:         value.AttendanceSourceQRScan,
+		DeviceID:       req.DeviceID,
+		RecordedBy:     recordedBy,
+		OccurredAt:     time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAttendanceRecord(record, student.StudentName), nil
+}
+
+// CheckInFromDeviceLogin turns a device login of a student (logged in with its student or child id) into a check-in.
+// Logins of other users are ignored.
+func (uc *AttendanceUseCase) CheckInFromDeviceLogin(userID, orgID, deviceID string) error {
+	if userID == "" || orgID == "" {
+		return nil
+	}
+
+	var student entity.SStudentFormApplication
+	err := uc.StudentRepo.DB.
+		Where("organization_id = ? AND status = ? AND (id = ? OR child_id = ?)", orgID, value.Approved, userID, userID).
+		Limit(1).
+		Find(&student).Error
+	if err != nil {
+		return err
+	}
+	if student.ID == uuid.Nil {
+		return nil
+	}
+
+	_, err = uc.applyEvent(&entity.AttendanceEvent{
+		OrganizationID: orgID,
+		StudentID:      student.ID.String(),
+		Type:           value.AttendanceEventTypeCheckIn,
+		Source:         value.AttendanceSourceDeviceLogin,
+		DeviceID:       deviceID,
+		RecordedBy:     userID,
+		OccurredAt:     time.Now(),
+	})
+	return err
+}
+
+// ManualRecord lets a teacher set the attendance of a student for a given day, overriding the detected status.
+func (uc *AttendanceUseCase) ManualRecord(req request.ManualAttendanceRequest, teacherID string) (*response.AttendanceRecordResponse, error) {
+	status := value.AttendanceStatus(req.Status)
+	if !status.IsValid() {
+		return nil, fmt.Errorf("invalid status: %s", req.Status)
+	}
+
+	student, err := uc.getStudentInOrganization(req.StudentID, req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	setting, err := uc.getSetting(req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	loc := attendanceLocation(setting)
+
+	day, err := time.ParseInLocation(attendanceDateLayout, req.Date, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date, expected YYYY-MM-DD: %w", err)
+	}
+	checkInAt, err := parseClockOnDay(day, req.CheckInAt)
+	if err != nil {
+		return nil, err
+	}
+	checkOutAt, err := parseClockOnDay(day, req.CheckOutAt)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := uc.Repo.GetRecord(req.StudentID, req.Date)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		record = &entity.AttendanceRecord{
+			OrganizationID: req.OrganizationID,
+			StudentID:      req.StudentID,
+			Date:           req.Date,
+		}
+	}
+
+	record.Status = status
+	record.Note = req.Note
+	record.UpdatedBy = teacherID
+	if req.DepartmentID != "" {
+		record.DepartmentID = req.DepartmentID
+	}
+	if checkInAt != nil {
+		record.CheckInAt = checkInAt
+	}
+	if checkOutAt != nil {
+		record.CheckOutAt = checkOutAt
+	}
+
+	if record.ID == uuid.Nil {
+		err = uc.Repo.CreateRecord(record)
+	} else {
+		err = uc.Repo.UpdateRecord(record)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// keep a trace of the manual entry next to the device events
+	events := []struct {
+		eventType value.AttendanceEventType
+		at        *time.Time
+	}{
+		{value.AttendanceEventTypeCheckIn, checkInAt},
+		{value.AttendanceEventTypeCheckOut, checkOutAt},
+	}
+	for _, e := range events {
+		if e.at == nil {
+			continue
+		}
+		if err := uc.Repo.CreateEvent(&entity.AttendanceEvent{
+			OrganizationID: req.OrganizationID,
+			StudentID:      req.StudentID,
+			DepartmentID:   req.DepartmentID,
+			Type:           e.eventType,
+			Source:         value.AttendanceSourceManual,
+			RecordedBy:     teacherID,
+			Note:           req.Note,
+			OccurredAt:     *e.at,
+		}); err != nil {
+			log.Error("AttendanceUseCase.ManualRecord: create event failed: ", err)
+		}
+	}
+
+	return mapAttendanceRecord(record, student.StudentName), nil
+}
+
+// applyEvent stores the raw event and updates the daily record of the student.
+func (uc *AttendanceUseCase) applyEvent(event *entity.AttendanceEvent) (*entity.AttendanceRecord, error) {
+	setting, err := uc.getSetting(event.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	loc := attendanceLocation(setting)
+	occurredAt := event.OccurredAt.In(loc)
+	date := occurredAt.Format(attendanceDateLayout)
+
+	record, err := uc.Repo.GetRecord(event.StudentID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.Type == value.AttendanceEventTypeCheckOut && (record == nil || record.CheckInAt == nil) {
+		return nil, errors.New("student has not checked in today")
+	}
+
+	if err := uc.Repo.CreateEvent(event); err != nil {
+		return nil, err
+	}
+
+	if record == nil {
+		record = &entity.AttendanceRecord{
+			OrganizationID: event.OrganizationID,
+			DepartmentID:   event.DepartmentID,
+			StudentID:      event.StudentID,
+			Date:           date,
+			Status:         checkInStatus(setting, occurredAt),
+			CheckInAt:      &event.OccurredAt,
+			UpdatedBy:      event.RecordedBy,
+		}
+		return record, uc.Repo.CreateRecord(record)
+	}
+
+	switch event.Type {
+	case value.AttendanceEventTypeCheckIn:
+		// only the first check-in of the day counts, later scans are kept as events only
+		if record.CheckInAt != nil {
+			return record, nil
+		}
+		record.CheckInAt = &event.OccurredAt
+		// a student marked absent who shows up later is late, excused stays excused
+		if record.Status == value.AttendanceStatusAbsent {
+			record.Status = checkInStatus(setting, occurredAt)
+		}
+	case value.AttendanceEventTypeCheckOut:
+		record.CheckOutAt = &event.OccurredAt
+	}
+
+	if event.DepartmentID != "" {
+		record.DepartmentID = event.DepartmentID
+	}
+	record.UpdatedBy = event.RecordedBy
+
+	return record, uc.Repo.UpdateRecord(record)
+}
+
+// ---------- setting ----------
+
+func (uc *AttendanceUseCase) GetSetting(orgID string) (*response.AttendanceSettingResponse, error) {
+	setting, err := uc.getSetting(orgID)
+	if err != nil {
+		return nil, err
+	}
+	return mapAttendanceSetting(setting), nil
+}
+
+func (uc *AttendanceUseCase) UpdateSetting(req request.UpdateAttendanceSettingRequest) (*response.AttendanceSettingResponse, error) {
+	if _, err := time.LoadLocation(req.Timezone); err != nil {
+		return nil, fmt.Errorf("invalid timezone: %s", req.Timezone)
+	}
+	if _, err := time.Parse(attendanceClockLayout, req.StartTime); err != nil {
+		return nil, fmt.Errorf("invalid start_time, expected HH:MM: %s", req.StartTime)
+	}
+	if _, err := time.Parse(attendanceClockLayout, req.AbsentAfter); err != nil {
+		return nil, fmt.Errorf("invalid absent_after, expected HH:MM: %s", req.AbsentAfter)
+	}
+	if req.LateAfterMinutes < 0 {
+		return nil, errors.New("late_after_minutes must not be negative")
+	}
+
+	days := make([]string, 0, len(req.WorkingDays))
+	for _, d := range req.WorkingDays {
+		if d < 1 || d > 7 {
+			return nil, fmt.Errorf("invalid working day: %d", d)
+		}
+		days = append(days, strconv.Itoa(d))
+	}
+
+	setting, err := uc.Repo.GetSettingByOrganizationID(req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if setting == nil {
+		setting = &entity.AttendanceSetting{OrganizationID: req.OrganizationID}
+	}
+
+	setting.Timezone = req.Timezone
+	setting.StartTime = req.StartTime
+	setting.LateAfterMinutes = req.LateAfterMinutes
+	setting.AbsentAfter = req.AbsentAfter
+	setting.WorkingDays = strings.Join(days, ",")
+	setting.NotifyParentOnAbsence = req.NotifyParentOnAbsence
+
+	if err := uc.Repo.SaveSetting(setting); err != nil {
+		return nil, err
+	}
+
+	return mapAttendanceSetting(setting), nil
+}
+
+// getSetting returns the organization setting, or the default school hours when none is configured.
+func (uc *AttendanceUseCase) getSetting(orgID string) (*entity.AttendanceSetting, error) {
+	setting, err := uc.Repo.GetSettingByOrganizationID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	if setting != nil {
+		return setting, nil
+	}
+
+	return defaultAttendanceSetting(orgID), nil
+}
+
+func defaultAttendanceSetting(orgID string) *entity.AttendanceSetting {
+	return &entity.AttendanceSetting{
+		OrganizationID:   orgID,
+		Timezone:         defaultAttendanceZone,
+		StartTime:        "07:30",
+		LateAfterMinutes: 15,
+		AbsentAfter:      "10:00",
+		WorkingDays:      "1,2,3,4,5",
+	}
+}
+
+// ---------- sheets ----------
+
+// GetDailySheet returns the attendance of every approved student of the organization for a day.
+// When departmentID is set only the students last seen in that department are returned.
+func (uc *AttendanceUseCase) GetDailySheet(orgID, date, departmentID string) (*response.AttendanceSheetResponse, error) {
+	if _, err := time.Parse(attendanceDateLayout, date); err != nil {
+		return nil, fmt.Errorf("invalid date, expected YYYY-MM-DD: %w", err)
+	}
+
+	students, err := uc.StudentRepo.GetByOrganizationID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	records, err := uc.Repo.GetRecordsByDate(orgID, date, "")
+	if err != nil {
+		return nil, err
+	}
+	departments, err := uc.Repo.GetLastDepartments(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	recordByStudent := make(map[string]*entity.AttendanceRecord, len(records))
+	for i := range records {
+		recordByStudent[records[i].StudentID] = &records[i]
+	}
+
+	sheet := &response.AttendanceSheetResponse{
+		OrganizationID: orgID,
+		DepartmentID:   departmentID,
+		Date:           date,
+		Records:        make([]response.AttendanceRecordResponse, 0, len(students)),
+	}
+
+	for _, student := range students {
+		studentID := student.ID.String()
+		record := recordByStudent[studentID]
+
+		department := departments[studentID]
+		if record != nil && record.DepartmentID != "" {
+			department = record.DepartmentID
+		}
+		if departmentID != "" && department != departmentID {
+			continue
+		}
+
+		if record == nil {
+			sheet.NotRecorded++
+			sheet.Records = append(sheet.Records, response.AttendanceRecordResponse{
+				StudentID:    studentID,
+				StudentName:  student.StudentName,
+				DepartmentID: department,
+				Date:         date,
+			})
+			continue
+		}
+
+		switch record.Status {
+		case value.AttendanceStatusPresent:
+			sheet.Present++
+		case value.AttendanceStatusLate:
+			sheet.Late++
+		case value.AttendanceStatusAbsent:
+			sheet.Absent++
+		case value.AttendanceStatusExcused:
+			sheet.Excused++
+		}
+		sheet.Records = append(sheet.Records, *mapAttendanceRecord(record, student.StudentName))
+	}
+
+	sort.Slice(sheet.Records, func(i, j int) bool {
+		return sheet.Records[i].StudentName < sheet.Records[j].StudentName
+	})
+
+	return sheet, nil
+}
+
+// GetStudentMonth returns the records of a student for a month (YYYY-MM).
+func (uc *AttendanceUseCase) GetStudentMonth(studentID, month string) ([]response.AttendanceRecordResponse, error) {
+	from, to, err := monthRange(month)
+	if err != nil {
+		return nil, err
+	}
+
+	studentUUID, err := uuid.Parse(studentID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid student id: %w", err)
+	}
+	student, err := uc.StudentRepo.GetByID(studentUUID)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		return nil, errors.New("student not found")
+	}
+
+	records, err := uc.Repo.GetRecordsByStudent(studentID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]response.AttendanceRecordResponse, 0, len(records))
+	for i := range records {
+		res = append(res, *mapAttendanceRecord(&records[i], student.StudentName))
+	}
+	return res, nil
+}
+
+// CanRecord reports whether the user is an approved teacher or a manager of the organization.
+func (uc *AttendanceUseCase) CanRecord(userID, orgID string) bool {
+	if userID == "" || orgID == "" {
+		return false
+	}
+	if userOrg, err := uc.OrganizationRepo.GetUserOrgInfo(userID, orgID); err == nil && userOrg.UserID.String() == userID && userOrg.IsManager {
+		return true
+	}
+	teacher, err := uc.TeacherRepo.GetByUserIDAndOrgID(userID, orgID)
+	return err == nil && teacher != nil
+}
+
+// CanViewStudent reports whether the user is a guardian of the student's child.
+func (uc *AttendanceUseCase) CanViewStudent(studentID, userID string) bool {
+	studentUUID, err := uuid.Parse(studentID)
+	if err != nil {
+		return false
+	}
+	student, err := uc.StudentRepo.GetByID(studentUUID)
+	if err != nil || student == nil {
+		return false
+	}
+	guardian, err := uc.ChildGuardianRepo.GetByChildAndGuardian(student.ChildID.String(), userID)
+	return err == nil && guardian != nil
+}
+
+// ---------- absence detection ----------
+
+// DetectAbsences marks as absent every student without record once the absent threshold of the day has passed,
+// and notifies their guardians when the organization asks for it.
+// Only the organizations which saved their setting are checked, the default school hours are a suggestion.
+func (uc *AttendanceUseCase) DetectAbsences() error {
+	settings, err := uc.Repo.GetAllSettings()
+	if err != nil {
+		log.Error("AttendanceUseCase.DetectAbsences: get settings failed: ", err)
+		return err
+	}
+
+	now := time.Now()
+	var errs []error
+	for i := range settings {
+		if err := uc.detectAbsencesForOrganization(&settings[i], now); err != nil {
+			log.Errorf("AttendanceUseCase.DetectAbsences: organization %s: %v", settings[i].OrganizationID, err)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (uc *AttendanceUseCase) detectAbsencesForOrganization(setting *entity.AttendanceSetting, now time.Time) error {
+	loc := attendanceLocation(setting)
+	localNow := now.In(loc)
+
+	if !isWorkingDay(setting, localNow) {
+		return nil
+	}
+	absentAfter, err := clockOnDay(localNow, setting.AbsentAfter)
+	if err != nil {
+		return err
+	}
+	if localNow.Before(absentAfter) {
+		return nil
+	}
+
+	date := localNow.Format(attendanceDateLayout)
+
+	students, err := uc.StudentRepo.GetByOrganizationID(setting.OrganizationID)
+	if err != nil {
+		return err
+	}
+	records, err := uc.Repo.GetRecordsByDate(setting.OrganizationID, date, "")
+	if err != nil {
+		return err
+	}
+	departments, err := uc.Repo.GetLastDepartments(setting.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	recorded := make(map[string]bool, len(records))
+	for _, record := range records {
+		recorded[record.StudentID] = true
+	}
+
+	for _, student := range students {
+		studentID := student.ID.String()
+		if recorded[studentID] {
+			continue
+		}
+
+		record := &entity.AttendanceRecord{
+			OrganizationID: setting.OrganizationID,
+			DepartmentID:   departments[studentID],
+			StudentID:      studentID,
+			Date:           date,
+			Status:         value.AttendanceStatusAbsent,
+		}
+		if err := uc.Repo.CreateRecord(record); err != nil {
+			log.Errorf("AttendanceUseCase.detectAbsences: student %s: %v", studentID, err)
+			continue
+		}
+
+		if setting.NotifyParentOnAbsence && uc.notifyGuardians(student, date) {
+			record.ParentNotified = true
+			if err := uc.Repo.UpdateRecord(record); err != nil {
+				log.Errorf("AttendanceUseCase.detectAbsences: student %s: %v", studentID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// notifyGuardians pushes an absence notice to every active device of the child's guardians.
+// It returns true when at least one notification was sent.
+func (uc *AttendanceUseCase) notifyGuardians(student entity.SStudentFormApplication, date string) bool {
+	if uc.FirebaseApp == nil {
+		return false
+	}
+
+	guardians, err := uc.ChildGuardianRepo.GetByChildID(student.ChildID.String())
+	if err != nil {
+		log.Error("AttendanceUseCase.notifyGuardians: ", err)
+		return false
+	}
+
+	sent := false
+	for _, guardian := range guardians {
+		tokens, err := uc.UserTokenFCMRepo.FindByUserID(guardian.GuardianUserID)
+		if err != nil {
+			log.Error("AttendanceUseCase.notifyGuardians: ", err)
+			continue
+		}
+
+		for _, token := range tokens {
+			if !token.IsActive || token.FCMToken == "" {
+				continue
+			}
+			err := messaging.SendNotification(uc.FirebaseApp, messaging.NotificationParams{
+				Title:       "Absence notice",
+				Message:     fmt.Sprintf("%s has not checked in on %s", student.StudentName, date),
+				DeviceToken: token.FCMToken,
+				Type:        value.NotificationType_StudentAbsent,
+			})
+			if err != nil {
+				log.Error("AttendanceUseCase.notifyGuardians: send notification failed: ", err)
+				continue
+			}
+			sent = true
+		}
+	}
+
+	return sent
+}
+
+func (uc *AttendanceUseCase) StartAbsenceScheduler() {
+	c := cron.New(cron.WithSeconds())
+	// chay moi 10 phut, moi org tu kiem tra gio absent_after cua minh
+	_, err := c.AddFunc("0 */10 * * * *", func() {
+		log.Println("[CRON] Running DetectAbsences at", time.Now().Format(time.RFC3339))
+		var jobErr error
+		track := metrics.TrackJob("attendance_absences")
+		defer func() { track(jobErr) }()
+		jobErr = uc.DetectAbsences()
+	})
+	if err != nil {
+		log.Fatalf("Failed to add DetectAbsences cron job: %v", err)
+	}
+
+	c.Start()
+	lifecycle.Default().RegisterCron("attendance_absences", c)
+}
+
+// ---------- helpers ----------
+
+func (uc *AttendanceUseCase) getStudentInOrganization(studentID, orgID string) (*entity.SStudentFormApplication, error) {
+	studentUUID, err := uuid.Parse(studentID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid student id: %w", err)
+	}
+
+	student, err := uc.StudentRepo.GetByID(studentUUID)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil || student.Status != value.Approved || student.OrganizationID.String() != orgID {
+		return nil, errors.New("student not found in organization")
+	}
+	return student, nil
+}
+
+func attendanceLocation(setting *entity.AttendanceSetting) *time.Location {
+	if loc, err := time.LoadLocation(setting.Timezone); err == nil {
+		return loc
+	}
+	// tzdata co the khong co tren container, mac dinh UTC+7
+	return time.FixedZone("ICT", 7*60*60)
+}
+
+func checkInStatus(setting *entity.AttendanceSetting, at time.Time) value.AttendanceStatus {
+	start, err := clockOnDay(at, setting.StartTime)
+	if err != nil {
+		return value.AttendanceStatusPresent
+	}
+	if at.After(start.Add(time.Duration(setting.LateAfterMinutes) * time.Minute)) {
+		return value.AttendanceStatusLate
+	}
+	return value.AttendanceStatusPresent
+}
+
+func isWorkingDay(setting *entity.AttendanceSetting, at time.Time) bool {
+	weekday := int(at.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+	for _, d := range strings.Split(setting.WorkingDays, ",") {
+		if strings.TrimSpace(d) == strconv.Itoa(weekday) {
+			return true
+		}
+	}
+	return false
+}
+
+// clockOnDay returns the "HH:MM" time on the same day and location as day.
+func clockOnDay(day time.Time, clock string) (time.Time, error) {
+	t, err := time.Parse(attendanceClockLayout, clock)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time, expected HH:MM: %s", clock)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, day.Location()), nil
+}
+
+func parseClockOnDay(day time.Time, clock *string) (*time.Time, error) {
+	if clock == nil || *clock == "" {
+		return nil, nil
+	}
+	t, err := clockOnDay(day, *clock)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// monthRange returns the first and last date of a "YYYY-MM" month.
+func monthRange(month string) (string, string, error) {
+	first, err := time.Parse(attendanceMonthLayout, month)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid month, expected YYYY-MM: %w", err)
+	}
+	last := first.AddDate(0, 1, -1)
+	return first.Format(attendanceDateLayout), last.Format(attendanceDateLayout), nil
+}
+
+func mapAttendanceSetting(setting *entity.AttendanceSetting) *response.AttendanceSettingResponse {
+	days := make([]int, 0, 7)
+	for _, d := range strings.Split(setting.WorkingDays, ",") {
+		if n, err := strconv.Atoi(strings.TrimSpace(d)); err == nil {
+			days = append(days, n)
+		}
+	}
+
+	return &response.AttendanceSettingResponse{
+		OrganizationID:        setting.OrganizationID,
+		Timezone:              setting.Timezone,
+		StartTime:             setting.StartTime,
+		LateAfterMinutes:      setting.LateAfterMinutes,
+		AbsentAfter:           setting.AbsentAfter,
+		WorkingDays:           days,
+		NotifyParentOnAbsence: setting.NotifyParentOnAbsence,
+	}
+}
+
+func mapAttendanceRecord(record *entity.AttendanceRecord, studentName string) *response.AttendanceRecordResponse {
+	return &response.AttendanceRecordResponse{
+		StudentID:      record.StudentID,
+		StudentName:    studentName,
+		DepartmentID:   record.DepartmentID,
+		Date:           record.Date,
+		Status:         string(record.Status),
+		CheckInAt:      record.CheckInAt,
+		CheckOutAt:     record.CheckOutAt,
+		ParentNotified: record.ParentNotified,
+		Note:           record.Note,
+	}
+}
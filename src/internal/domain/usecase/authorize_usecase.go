@@ -142,7 +142,7 @@ func (receiver AuthorizeUseCase) UserLoginUsecase(req request.UserLoginFromDevic
 	// 	}
 	// }
 
-	err = receiver.VerifyPassword4LoginQr(req.Password, user.Password, loginType)
+	err = receiver.VerifyPassword4LoginQr(req.Password, user, loginType)
 	if err != nil {
 		return nil, errors.New("invalid username or password")
 	}
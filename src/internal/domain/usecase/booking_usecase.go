@@ -0,0 +1,675 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/lifecycle"
+	"sen-global-api/pkg/messaging"
+	"sen-global-api/pkg/metrics"
+	"strings"
+	"time"
+
+	firebase "firebase.google.com/go/v4"
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	bookingMaxRangeDays    = 31
+	bookingReminderHorizon = 24 * time.Hour
+)
+
+var (
+	ErrBookingSlotUnavailable = errors.New("the selected slot is no longer available")
+	ErrBookingResourceClosed  = errors.New("the resource is not open at the selected time")
+)
+
+type BookingUseCase struct {
+	DBConn           *gorm.DB
+	Repo             *repository.BookingRepository
+	QuestionRepo     *repository.QuestionRepository
+	UserTokenFCMRepo *repository.UserTokenFCMRepository
+	FirebaseApp      *firebase.App
+}
+
+type bookingSlot struct {
+	StartAt time.Time
+	EndAt   time.Time
+}
+
+// ---------- resource ----------
+
+func (uc *BookingUseCase) CreateResource(req request.CreateBookableResourceRequest) (*response.BookableResourceResponse, error) {
+	resource := &entity.BookableResource{
+		OrganizationID: req.OrganizationID,
+		IsActive:       true,
+	}
+	if err := applyResourceFields(resource, req.Name, req.Type, req.OwnerID, req.Capacity, req.SlotMinutes, req.ReminderMinutes, req.Timezone); err != nil {
+		return nil, err
+	}
+
+	if err := uc.Repo.CreateResource(resource); err != nil {
+		return nil, err
+	}
+	return mapBookableResource(resource, nil), nil
+}
+
+func (uc *BookingUseCase) UpdateResource(req request.UpdateBookableResourceRequest) (*response.BookableResourceResponse, error) {
+	resource, err := uc.Repo.GetResourceByID(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if resource == nil {
+		return nil, errors.New("resource not found")
+	}
+
+	if err := applyResourceFields(resource, req.Name, req.Type, req.OwnerID, req.Capacity, req.SlotMinutes, req.ReminderMinutes, req.Timezone); err != nil {
+		return nil, err
+	}
+	resource.IsActive = req.IsActive
+
+	if err := uc.Repo.UpdateResource(resource); err != nil {
+		return nil, err
+	}
+
+	rules, err := uc.Repo.GetRulesByResource(resource.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	return mapBookableResource(resource, rules), nil
+}
+
+func (uc *BookingUseCase) DeleteResource(id string) error {
+	return uc.Repo.DeleteResource(id)
+}
+
+func (uc *BookingUseCase) GetResources(orgID string) ([]response.BookableResourceResponse, error) {
+	resources, err := uc.Repo.GetResourcesByOrganization(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]response.BookableResourceResponse, 0, len(resources))
+	for i := range resources {
+		rules, err := uc.Repo.GetRulesByResource(resources[i].ID.String())
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *mapBookableResource(&resources[i], rules))
+	}
+	return res, nil
+}
+
+func (uc *BookingUseCase) GetResource(id string) (*response.BookableResourceResponse, error) {
+	resource, err := uc.Repo.GetResourceByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if resource == nil {
+		return nil, errors.New("resource not found")
+	}
+
+	rules, err := uc.Repo.GetRulesByResource(id)
+	if err != nil {
+		return nil, err
+	}
+	return mapBookableResource(resource, rules), nil
+}
+
+func (uc *BookingUseCase) ReplaceRules(resourceID string, req request.UpdateBookingRulesRequest) (*response.BookableResourceResponse, error) {
+	resource, err := uc.Repo.GetResourceByID(resourceID)
+	if err != nil {
+		return nil, err
+	}
+	if resource == nil {
+		return nil, errors.New("resource not found")
+	}
+
+	rules := make([]entity.BookingAvailabilityRule, 0, len(req.Rules))
+	for _, r := range req.Rules {
+		if r.Weekday < 1 || r.Weekday > 7 {
+			return nil, fmt.Errorf("invalid weekday: %d", r.Weekday)
+		}
+		start, err := time.Parse(attendanceClockLayout, r.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start_time, expected HH:MM: %s", r.StartTime)
+		}
+		end, err := time.Parse(attendanceClockLayout, r.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end_time, expected HH:MM: %s", r.EndTime)
+		}
+		if !end.After(start) {
+			return nil, fmt.Errorf("end_time must be after start_time: %s-%s", r.StartTime, r.EndTime)
+		}
+		for _, d := range []string{r.ValidFrom, r.ValidTo} {
+			if d == "" {
+				continue
+			}
+			if _, err := time.Parse(attendanceDateLayout, d); err != nil {
+				return nil, fmt.Errorf("invalid date, expected YYYY-MM-DD: %s", d)
+			}
+		}
+
+		rules = append(rules, entity.BookingAvailabilityRule{
+			ResourceID: resourceID,
+			Weekday:    r.Weekday,
+			StartTime:  r.StartTime,
+			EndTime:    r.EndTime,
+			ValidFrom:  r.ValidFrom,
+			ValidTo:    r.ValidTo,
+		})
+	}
+
+	if err := uc.Repo.ReplaceRules(resourceID, rules); err != nil {
+		return nil, err
+	}
+
+	saved, err := uc.Repo.GetRulesByResource(resourceID)
+	if err != nil {
+		return nil, err
+	}
+	return mapBookableResource(resource, saved), nil
+}
+
+// ---------- slots ----------
+
+// GetSlots returns the free slots of a resource between two dates (YYYY-MM-DD, inclusive).
+func (uc *BookingUseCase) GetSlots(resourceID, fromDate, toDate string) (*response.BookingSlotsResponse, error) {
+	resource, err := uc.Repo.GetResourceByID(resourceID)
+	if err != nil {
+		return nil, err
+	}
+	if resource == nil || !resource.IsActive {
+		return nil, errors.New("resource not found")
+	}
+
+	loc := bookingLocation(resource)
+	from, err := time.ParseInLocation(attendanceDateLayout, fromDate, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from date, expected YYYY-MM-DD: %w", err)
+	}
+	to, err := time.ParseInLocation(attendanceDateLayout, toDate, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid to date, expected YYYY-MM-DD: %w", err)
+	}
+	if to.Before(from) {
+		return nil, errors.New("to date must not be before from date")
+	}
+	if to.Sub(from) > bookingMaxRangeDays*24*time.Hour {
+		return nil, fmt.Errorf("date range must not exceed %d days", bookingMaxRangeDays)
+	}
+
+	rules, err := uc.Repo.GetRulesByResource(resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	end := to.AddDate(0, 0, 1)
+	reservations, err := uc.Repo.GetConfirmedInRange(resourceID, from, end)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	res := &response.BookingSlotsResponse{
+		ResourceID:   resourceID,
+		ResourceName: resource.Name,
+		Timezone:     loc.String(),
+		Slots:        make([]response.BookingSlotResponse, 0),
+	}
+
+	for day := from; day.Before(end); day = day.AddDate(0, 0, 1) {
+		for _, slot := range generateSlots(resource, rules, day) {
+			if !slot.StartAt.After(now) {
+				continue
+			}
+
+			taken := 0
+			for _, r := range reservations {
+				if r.StartAt.Before(slot.EndAt) && r.EndAt.After(slot.StartAt) {
+					taken++
+				}
+			}
+			if taken >= resource.Capacity {
+				continue
+			}
+
+			res.Slots = append(res.Slots, response.BookingSlotResponse{
+				StartAt:   slot.StartAt,
+				EndAt:     slot.EndAt,
+				Remaining: resource.Capacity - taken,
+			})
+		}
+	}
+
+	return res, nil
+}
+
+// GetSlotsByQuestion returns the free slots of the resource configured on a CalendarBooking question.
+func (uc *BookingUseCase) GetSlotsByQuestion(questionID, fromDate, toDate string) (*response.BookingSlotsResponse, error) {
+	questions, err := uc.QuestionRepo.GetQuestionsByIDs([]string{questionID})
+	if err != nil {
+		return nil, err
+	}
+	if len(questions) == 0 {
+		return nil, errors.New("question not found")
+	}
+
+	resourceID, err := BookingResourceOfQuestion(questions[0])
+	if err != nil {
+		return nil, err
+	}
+	return uc.GetSlots(resourceID, fromDate, toDate)
+}
+
+// BookingResourceOfQuestion reads the resource id stored in the "value" attribute of a CalendarBooking question.
+func BookingResourceOfQuestion(question entity.SQuestion) (string, error) {
+	if question.QuestionType != value.GetStringValue(value.CalendarBooking) {
+		return "", errors.New("question is not a calendar booking question")
+	}
+
+	var att struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(question.Attributes, &att); err != nil || att.Value == "" {
+		return "", errors.New("calendar booking question has no resource configured")
+	}
+	// cac form cu luu text tu do trong "value", khong phai resource id
+	if _, err := uuid.Parse(att.Value); err != nil {
+		return "", errors.New("calendar booking question has no resource configured")
+	}
+	return att.Value, nil
+}
+
+// ---------- reservation ----------
+
+func (uc *BookingUseCase) Reserve(req request.CreateBookingReservationRequest, userID string) (*response.BookingReservationResponse, error) {
+	startAt, err := time.Parse(time.RFC3339, req.StartAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start_at, expected RFC3339: %w", err)
+	}
+
+	reservation, err := uc.reserve(req.ResourceID, userID, "", startAt, req.Note)
+	if err != nil {
+		return nil, err
+	}
+	return mapBookingReservation(reservation), nil
+}
+
+// ReserveForAnswer books the slot picked in a CalendarBooking answer (RFC3339 start time).
+// It returns a nil reservation when there is nothing to book: an empty answer, or a question
+// without a bookable resource (legacy calendar questions), whose answer is saved as is.
+func (uc *BookingUseCase) ReserveForAnswer(question entity.SQuestion, answer string, userID string) (*entity.BookingReservation, error) {
+	if strings.TrimSpace(answer) == "" {
+		return nil, nil
+	}
+	resourceID, err := BookingResourceOfQuestion(question)
+	if err != nil {
+		return nil, nil
+	}
+	resource, err := uc.Repo.GetResourceByID(resourceID)
+	if err != nil {
+		return nil, err
+	}
+	if resource == nil {
+		return nil, nil
+	}
+
+	startAt, err := time.Parse(time.RFC3339, answer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid booking answer for question %s, expected RFC3339 start time", question.Question)
+	}
+
+	return uc.reserve(resourceID, userID, question.ID.String(), startAt, "")
+}
+
+// reserve creates a reservation with the resource row locked, so two concurrent requests
+// on the same resource can never both see a free slot.
+func (uc *BookingUseCase) reserve(resourceID, userID, questionID string, startAt time.Time, note string) (*entity.BookingReservation, error) {
+	if !startAt.After(time.Now()) {
+		return nil, errors.New("cannot book a slot in the past")
+	}
+
+	var reservation *entity.BookingReservation
+	err := uc.DBConn.Transaction(func(tx *gorm.DB) error {
+		repo := uc.Repo.WithTx(tx)
+
+		resource, err := repo.LockResource(resourceID)
+		if err != nil {
+			return err
+		}
+		if resource == nil || !resource.IsActive {
+			return errors.New("resource not found")
+		}
+
+		rules, err := repo.GetRulesByResource(resourceID)
+		if err != nil {
+			return err
+		}
+
+		loc := bookingLocation(resource)
+		local := startAt.In(loc)
+		day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+		var slot *bookingSlot
+		for _, s := range generateSlots(resource, rules, day) {
+			if s.StartAt.Equal(startAt) {
+				slot = &s
+				break
+			}
+		}
+		if slot == nil {
+			return ErrBookingResourceClosed
+		}
+
+		taken, err := repo.CountOverlapping(resourceID, slot.StartAt, slot.EndAt)
+		if err != nil {
+			return err
+		}
+		if taken >= int64(resource.Capacity) {
+			return ErrBookingSlotUnavailable
+		}
+
+		reservation = &entity.BookingReservation{
+			ResourceID: resourceID,
+			UserID:     userID,
+			QuestionID: questionID,
+			StartAt:    slot.StartAt,
+			EndAt:      slot.EndAt,
+			Status:     value.BookingReservationStatusConfirmed,
+			Note:       note,
+		}
+		return repo.CreateReservation(reservation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// Cancel cancels a reservation, only its owner or an admin can do it.
+func (uc *BookingUseCase) Cancel(id, userID string, asAdmin bool) error {
+	reservation, err := uc.Repo.GetReservationByID(id)
+	if err != nil {
+		return err
+	}
+	if reservation == nil {
+		return errors.New("reservation not found")
+	}
+	if !asAdmin && reservation.UserID != userID {
+		return errors.New("access denied")
+	}
+	if reservation.Status == value.BookingReservationStatusCancelled {
+		return nil
+	}
+
+	now := time.Now()
+	reservation.Status = value.BookingReservationStatusCancelled
+	reservation.CancelledAt = &now
+	return uc.Repo.UpdateReservation(reservation)
+}
+
+// CancelAll is used to release the reservations of a submission that could not be saved.
+func (uc *BookingUseCase) CancelAll(reservations []*entity.BookingReservation) {
+	for _, r := range reservations {
+		if err := uc.Cancel(r.ID.String(), "", true); err != nil {
+			log.Error("BookingUseCase.CancelAll: ", err)
+		}
+	}
+}
+
+func (uc *BookingUseCase) AttachSubmission(reservations []*entity.BookingReservation, submissionID uint64) error {
+	ids := make([]string, 0, len(reservations))
+	for _, r := range reservations {
+		ids = append(ids, r.ID.String())
+	}
+	return uc.Repo.AttachSubmission(ids, submissionID)
+}
+
+func (uc *BookingUseCase) GetMyReservations(userID string) ([]response.BookingReservationResponse, error) {
+	reservations, err := uc.Repo.GetUpcomingByUser(userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]response.BookingReservationResponse, 0, len(reservations))
+	for i := range reservations {
+		res = append(res, *mapBookingReservation(&reservations[i]))
+	}
+	return res, nil
+}
+
+func (uc *BookingUseCase) GetReservationsByResource(resourceID, fromDate, toDate string) ([]response.BookingReservationResponse, error) {
+	from, err := time.Parse(attendanceDateLayout, fromDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from date, expected YYYY-MM-DD: %w", err)
+	}
+	to, err := time.Parse(attendanceDateLayout, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid to date, expected YYYY-MM-DD: %w", err)
+	}
+
+	reservations, err := uc.Repo.GetReservationsByResource(resourceID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]response.BookingReservationResponse, 0, len(reservations))
+	for i := range reservations {
+		res = append(res, *mapBookingReservation(&reservations[i]))
+	}
+	return res, nil
+}
+
+// ---------- reminders ----------
+
+// SendReminders notifies users whose reservation starts within the reminder delay of its resource,
+// it returns the errors of the run so the job metric records the failures.
+func (uc *BookingUseCase) SendReminders() error {
+	now := time.Now()
+	reservations, err := uc.Repo.GetDueReminders(now, now.Add(bookingReminderHorizon))
+	if err != nil {
+		log.Error("BookingUseCase.SendReminders: ", err)
+		return err
+	}
+
+	var errs []error
+
+	resources := make(map[string]*entity.BookableResource)
+	for _, reservation := range reservations {
+		resource, ok := resources[reservation.ResourceID]
+		if !ok {
+			resource, err = uc.Repo.GetResourceByID(reservation.ResourceID)
+			if err != nil {
+				log.Error("BookingUseCase.SendReminders: ", err)
+				errs = append(errs, err)
+				continue
+			}
+			resources[reservation.ResourceID] = resource
+		}
+		if resource == nil {
+			continue
+		}
+		if reservation.StartAt.After(now.Add(time.Duration(resource.ReminderMinutes) * time.Minute)) {
+			continue
+		}
+
+		uc.notifyReservation(reservation, resource)
+
+		if err := uc.Repo.MarkReminderSent(reservation.ID.String()); err != nil {
+			log.Error("BookingUseCase.SendReminders: ", err)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (uc *BookingUseCase) notifyReservation(reservation entity.BookingReservation, resource *entity.BookableResource) {
+	if uc.FirebaseApp == nil {
+		return
+	}
+
+	tokens, err := uc.UserTokenFCMRepo.FindByUserID(reservation.UserID)
+	if err != nil {
+		log.Error("BookingUseCase.notifyReservation: ", err)
+		return
+	}
+
+	startAt := reservation.StartAt.In(bookingLocation(resource)).Format("02/01/2006 15:04")
+	for _, token := range tokens {
+		if !token.IsActive || token.FCMToken == "" {
+			continue
+		}
+		err := messaging.SendNotification(uc.FirebaseApp, messaging.NotificationParams{
+			Title:       "Booking reminder",
+			Message:     fmt.Sprintf("%s at %s", resource.Name, startAt),
+			DeviceToken: token.FCMToken,
+			Type:        value.NotificationType_BookingReminder,
+		})
+		if err != nil {
+			log.Error("BookingUseCase.notifyReservation: send notification failed: ", err)
+		}
+	}
+}
+
+func (uc *BookingUseCase) StartReminderScheduler() {
+	c := cron.New(cron.WithSeconds())
+	// chay moi 5 phut
+	_, err := c.AddFunc("0 */5 * * * *", func() {
+		log.Println("[CRON] Running SendReminders at", time.Now().Format(time.RFC3339))
+		var jobErr error
+		track := metrics.TrackJob("booking_reminders")
+		defer func() { track(jobErr) }()
+		jobErr = uc.SendReminders()
+	})
+	if err != nil {
+		log.Fatalf("Failed to add SendReminders cron job: %v", err)
+	}
+
+	c.Start()
+	lifecycle.Default().RegisterCron("booking_reminders", c)
+}
+
+// ---------- helpers ----------
+
+func applyResourceFields(resource *entity.BookableResource, name, resourceType, ownerID string, capacity, slotMinutes, reminderMinutes int, timezone string) error {
+	t := value.BookableResourceType(resourceType)
+	if !t.IsValid() {
+		return fmt.Errorf("invalid resource type: %s", resourceType)
+	}
+	if capacity <= 0 {
+		capacity = 1
+	}
+	if slotMinutes <= 0 {
+		slotMinutes = 30
+	}
+	if reminderMinutes < 0 {
+		return errors.New("reminder_minutes must not be negative")
+	}
+	if timezone == "" {
+		timezone = defaultAttendanceZone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("invalid timezone: %s", timezone)
+	}
+
+	resource.Name = name
+	resource.Type = t
+	resource.OwnerID = ownerID
+	resource.Capacity = capacity
+	resource.SlotMinutes = slotMinutes
+	resource.ReminderMinutes = reminderMinutes
+	resource.Timezone = timezone
+	return nil
+}
+
+func bookingLocation(resource *entity.BookableResource) *time.Location {
+	if loc, err := time.LoadLocation(resource.Timezone); err == nil {
+		return loc
+	}
+	return time.FixedZone("ICT", 7*60*60)
+}
+
+// generateSlots cuts the rules open on the given day (local midnight) into slots of SlotMinutes.
+func generateSlots(resource *entity.BookableResource, rules []entity.BookingAvailabilityRule, day time.Time) []bookingSlot {
+	weekday := int(day.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+	date := day.Format(attendanceDateLayout)
+	length := time.Duration(resource.SlotMinutes) * time.Minute
+
+	slots := make([]bookingSlot, 0)
+	for _, rule := range rules {
+		if rule.Weekday != weekday {
+			continue
+		}
+		if (rule.ValidFrom != "" && date < rule.ValidFrom) || (rule.ValidTo != "" && date > rule.ValidTo) {
+			continue
+		}
+
+		start, err := clockOnDay(day, rule.StartTime)
+		if err != nil {
+			continue
+		}
+		end, err := clockOnDay(day, rule.EndTime)
+		if err != nil {
+			continue
+		}
+
+		for s := start; !s.Add(length).After(end); s = s.Add(length) {
+			slots = append(slots, bookingSlot{StartAt: s, EndAt: s.Add(length)})
+		}
+	}
+	return slots
+}
+
+func mapBookableResource(resource *entity.BookableResource, rules []entity.BookingAvailabilityRule) *response.BookableResourceResponse {
+	res := &response.BookableResourceResponse{
+		ID:              resource.ID.String(),
+		OrganizationID:  resource.OrganizationID,
+		Name:            resource.Name,
+		Type:            string(resource.Type),
+		OwnerID:         resource.OwnerID,
+		Capacity:        resource.Capacity,
+		SlotMinutes:     resource.SlotMinutes,
+		ReminderMinutes: resource.ReminderMinutes,
+		Timezone:        resource.Timezone,
+		IsActive:        resource.IsActive,
+		Rules:           make([]response.BookingAvailabilityRuleResponse, 0, len(rules)),
+	}
+	for _, rule := range rules {
+		res.Rules = append(res.Rules, response.BookingAvailabilityRuleResponse{
+			Weekday:   rule.Weekday,
+			StartTime: rule.StartTime,
+			EndTime:   rule.EndTime,
+			ValidFrom: rule.ValidFrom,
+			ValidTo:   rule.ValidTo,
+		})
+	}
+	return res
+}
+
+func mapBookingReservation(r *entity.BookingReservation) *response.BookingReservationResponse {
+	return &response.BookingReservationResponse{
+		ID:           r.ID.String(),
+		ResourceID:   r.ResourceID,
+		UserID:       r.UserID,
+		SubmissionID: r.SubmissionID,
+		StartAt:      r.StartAt,
+		EndAt:        r.EndAt,
+		Status:       string(r.Status),
+		Note:         r.Note,
+		CancelledAt:  r.CancelledAt,
+		CreatedAt:    r.CreatedAt,
+	}
+}
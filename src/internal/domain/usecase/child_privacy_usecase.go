@@ -0,0 +1,408 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/lifecycle"
+	"sen-global-api/pkg/uploader"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	childExportFolder      = "privacy-exports"
+	childExportURLDuration = 15 * time.Minute
+	childExportHTTPTimeout = 30 * time.Second
+)
+
+var (
+	ErrPhotoConsentNotGranted       = errors.New("photo consent has not been granted for this child")
+	ErrDataSharingConsentNotGranted = errors.New("data sharing consent has not been granted for this child")
+	ErrChildPrivacyAccessDenied     = errors.New("access denied")
+)
+
+type ChildPrivacyUseCase struct {
+	DBConn            *gorm.DB
+	PrivacyRepo       *repository.ChildPrivacyRepository
+	ConsentRepo       *repository.ChildConsentRepository
+	DataRequestRepo   *repository.ChildDataRequestRepository
+	ChildGuardianRepo *repository.ChildGuardianRepository
+	UploadProvider    uploader.UploadProvider
+}
+
+func (uc *ChildPrivacyUseCase) IsGuardian(childID string, userID string) bool {
+	guardian, err := uc.ChildGuardianRepo.GetByChildAndGuardian(childID, userID)
+	return err == nil && guardian != nil
+}
+
+func (uc *ChildPrivacyUseCase) GetConsent(childID string) (*response.ChildConsentResponse, error) {
+	consent, err := uc.ConsentRepo.GetByChildID(childID)
+	if err != nil {
+		return nil, err
+	}
+
+	if consent == nil {
+		return &response.ChildConsentResponse{ChildID: childID}, nil
+	}
+
+	return &response.ChildConsentResponse{
+		ChildID:            consent.ChildID,
+		PhotoConsent:       consent.PhotoConsent,
+		DataSharingConsent: consent.DataSharingConsent,
+		UpdatedBy:          consent.UpdatedBy,
+		UpdatedAt:          &consent.UpdatedAt,
+	}, nil
+}
+
+func (uc *ChildPrivacyUseCase) UpdateConsent(childID string, userID string, req request.UpdateChildConsentRequest) error {
+	if err := uc.checkPrimaryContact(childID, userID); err != nil {
+		return err
+	}
+
+	consent, err := uc.ConsentRepo.GetByChildID(childID)
+	if err != nil {
+		return err
+	}
+
+	if consent == nil {
+		return uc.ConsentRepo.Create(&entity.ChildConsent{
+			ID:                 uuid.New(),
+			ChildID:            childID,
+			PhotoConsent:       *req.PhotoConsent,
+			DataSharingConsent: *req.DataSharingConsent,
+			UpdatedBy:          userID,
+		})
+	}
+
+	consent.PhotoConsent = *req.PhotoConsent
+	consent.DataSharingConsent = *req.DataSharingConsent
+	consent.UpdatedBy = userID
+	return uc.ConsentRepo.Update(consent)
+}
+
+// CheckOwnerPhotoConsent must be called before storing a photo of any owner, the consent of the child applies
+// when the owner is a child whatever the role sent by the client.
+func (uc *ChildPrivacyUseCase) CheckOwnerPhotoConsent(ownerID string, ownerRole string) error {
+	if ownerRole != string(value.OwnerRoleChild) {
+		isChild, err := uc.ConsentRepo.IsChild(ownerID)
+		if err != nil {
+			return err
+		}
+		if !isChild {
+			return nil
+		}
+	}
+	return uc.CheckPhotoConsent(ownerID)
+}
+
+// CheckPhotoConsent must be called before storing any photo of the child.
+func (uc *ChildPrivacyUseCase) CheckPhotoConsent(childID string) error {
+	consent, err := uc.ConsentRepo.GetByChildID(childID)
+	if err != nil {
+		return err
+	}
+	if consent == nil || !consent.PhotoConsent {
+		return ErrPhotoConsentNotGranted
+	}
+	return nil
+}
+
+// CheckDataSharingConsent must be called before storing a submission made for the child.
+func (uc *ChildPrivacyUseCase) CheckDataSharingConsent(childID string) error {
+	consent, err := uc.ConsentRepo.GetByChildID(childID)
+	if err != nil {
+		return err
+	}
+	if consent == nil || !consent.DataSharingConsent {
+		return ErrDataSharingConsentNotGranted
+	}
+	return nil
+}
+
+// RequestExport registers an export request and builds the archive in background.
+// When asAdmin is false the caller must be a guardian allowed to view the child's submissions.
+func (uc *ChildPrivacyUseCase) RequestExport(childID string, userID string, asAdmin bool) (*response.ChildDataRequestResponse, error) {
+	if !asAdmin {
+		guardian, err := uc.ChildGuardianRepo.GetByChildAndGuardian(childID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if guardian == nil || !guardian.CanViewSubmissions {
+			return nil, fmt.Errorf("%w: you are not allowed to export this child's data", ErrChildPrivacyAccessDenied)
+		}
+	}
+
+	dataRequest, err := uc.createRequest(childID, userID, value.ChildDataRequestTypeExport)
+	if err != nil {
+		return nil, err
+	}
+
+	lifecycle.Default().Go("child_data_export", func(context.Context) {
+		uc.runExport(dataRequest.ID.String(), childID)
+	})
+
+	return mapChildDataRequest(dataRequest), nil
+}
+
+// RequestErasure registers an erasure request and runs it in background.
+// When asAdmin is false the caller must be the child's primary contact.
+func (uc *ChildPrivacyUseCase) RequestErasure(childID string, userID string, asAdmin bool) (*response.ChildDataRequestResponse, error) {
+	if !asAdmin {
+		if err := uc.checkPrimaryContact(childID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	dataRequest, err := uc.createRequest(childID, userID, value.ChildDataRequestTypeErasure)
+	if err != nil {
+		return nil, err
+	}
+
+	lifecycle.Default().Go("child_data_erasure", func(context.Context) {
+		uc.runErasure(dataRequest.ID.String(), childID)
+	})
+
+	return mapChildDataRequest(dataRequest), nil
+}
+
+func (uc *ChildPrivacyUseCase) GetRequestsByChild(childID string) ([]response.ChildDataRequestResponse, error) {
+	requests, err := uc.DataRequestRepo.GetByChildID(childID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]response.ChildDataRequestResponse, 0, len(requests))
+	for i := range requests {
+		res = append(res, *mapChildDataRequest(&requests[i]))
+	}
+	return res, nil
+}
+
+func (uc *ChildPrivacyUseCase) GetAllRequests() ([]response.ChildDataRequestResponse, error) {
+	requests, err := uc.DataRequestRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]response.ChildDataRequestResponse, 0, len(requests))
+	for i := range requests {
+		res = append(res, *mapChildDataRequest(&requests[i]))
+	}
+	return res, nil
+}
+
+// GetExportURL returns a short-lived signed url of a finished export archive.
+func (uc *ChildPrivacyUseCase) GetExportURL(requestID string, userID string, asAdmin bool) (*string, error) {
+	dataRequest, err := uc.DataRequestRepo.GetByID(requestID)
+	if err != nil {
+		return nil, err
+	}
+	if dataRequest == nil || dataRequest.Type != value.ChildDataRequestTypeExport {
+		return nil, errors.New("export request not found")
+	}
+	if !asAdmin && dataRequest.RequestedBy != userID {
+		return nil, ErrChildPrivacyAccessDenied
+	}
+	if dataRequest.Status != value.ChildDataRequestStatusDone || dataRequest.ResultKey == "" {
+		return nil, fmt.Errorf("export is not ready, current status: %s", dataRequest.Status)
+	}
+
+	duration := childExportURLDuration
+	return uc.UploadProvider.GetFileUploaded(context.Background(), dataRequest.ResultKey, &duration)
+}
+
+func (uc *ChildPrivacyUseCase) checkPrimaryContact(childID string, userID string) error {
+	guardian, err := uc.ChildGuardianRepo.GetByChildAndGuardian(childID, userID)
+	if err != nil {
+		return err
+	}
+	if guardian == nil || !guardian.IsPrimaryContact {
+		return fmt.Errorf("%w: only the primary contact can manage this child's data", ErrChildPrivacyAccessDenied)
+	}
+	return nil
+}
+
+func (uc *ChildPrivacyUseCase) createRequest(childID string, userID string, requestType value.ChildDataRequestType) (*entity.ChildDataRequest, error) {
+	dataRequest := &entity.ChildDataRequest{
+		ID:          uuid.New(),
+		ChildID:     childID,
+		RequestedBy: userID,
+		Type:        requestType,
+		Status:      value.ChildDataRequestStatusPending,
+	}
+	if err := uc.DataRequestRepo.Create(dataRequest); err != nil {
+		return nil, fmt.Errorf("create data request failed: %w", err)
+	}
+	return dataRequest, nil
+}
+
+func (uc *ChildPrivacyUseCase) runExport(requestID string, childID string) {
+	_ = uc.DataRequestRepo.UpdateStatus(requestID, value.ChildDataRequestStatusProcessing)
+
+	snapshot, err := uc.PrivacyRepo.Collect(childID)
+	if err != nil {
+		uc.failRequest(requestID, fmt.Errorf("collect child data failed: %w", err))
+		return
+	}
+
+	archive, mediaCount, err := uc.buildArchive(snapshot)
+	if err != nil {
+		uc.failRequest(requestID, err)
+		return
+	}
+
+	key := fmt.Sprintf("%s/%s/%s.zip", childExportFolder, childID, requestID)
+	if _, err := uc.UploadProvider.SaveFileUploaded(context.Background(), archive, key, uploader.UploadPrivate); err != nil {
+		uc.failRequest(requestID, fmt.Errorf("upload export archive failed: %w", err))
+		return
+	}
+
+	summary, _ := json.Marshal(map[string]int{
+		"submissions": len(snapshot.Submissions),
+		"answers":     len(snapshot.Answers),
+		"images":      len(snapshot.Images),
+		"media_files": mediaCount,
+		"menus":       len(snapshot.Menus),
+		"logs":        len(snapshot.ValuesAppHistories) + len(snapshot.AccountsLogs),
+	})
+	if err := uc.DataRequestRepo.MarkDone(requestID, key, datatypes.JSON(summary)); err != nil {
+		log.Error("ChildPrivacyUseCase.runExport: " + err.Error())
+	}
+}
+
+// buildArchive writes data.json and every image of the child under media/ into a zip.
+func (uc *ChildPrivacyUseCase) buildArchive(snapshot *repository.ChildDataSnapshot) ([]byte, int, error) {
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return nil, 0, fmt.Errorf("marshal child data failed: %w", err)
+	}
+	w, err := zw.Create("data.json")
+	if err != nil {
+		return nil, 0, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, 0, err
+	}
+
+	client := &http.Client{Timeout: childExportHTTPTimeout}
+	mediaCount := 0
+	for _, img := range snapshot.Images {
+		content, err := uc.downloadFile(client, img.Key)
+		if err != nil {
+			// a missing media file must not block the export, it stays referenced in data.json
+			log.Warnf("ChildPrivacyUseCase.buildArchive: skip media %s: %v", img.Key, err)
+			continue
+		}
+
+		w, err := zw.Create(path.Join("media", path.Base(img.Key)))
+		if err != nil {
+			return nil, 0, err
+		}
+		if _, err := w.Write(content); err != nil {
+			return nil, 0, err
+		}
+		mediaCount++
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, 0, err
+	}
+
+	return buf.Bytes(), mediaCount, nil
+}
+
+func (uc *ChildPrivacyUseCase) downloadFile(client *http.Client, key string) ([]byte, error) {
+	duration := childExportURLDuration
+	url, err := uc.UploadProvider.GetFileUploaded(context.Background(), key, &duration)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Get(*url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+func (uc *ChildPrivacyUseCase) runErasure(requestID string, childID string) {
+	_ = uc.DataRequestRepo.UpdateStatus(requestID, value.ChildDataRequestStatusProcessing)
+
+	snapshot, err := uc.PrivacyRepo.Collect(childID)
+	if err != nil {
+		uc.failRequest(requestID, fmt.Errorf("collect child data failed: %w", err))
+		return
+	}
+
+	var summary map[string]int64
+	err = uc.DBConn.Transaction(func(tx *gorm.DB) error {
+		summary, err = uc.PrivacyRepo.Erase(tx, snapshot, childID)
+		return err
+	})
+	if err != nil {
+		uc.failRequest(requestID, fmt.Errorf("erase child data failed: %w", err))
+		return
+	}
+
+	// files are removed after commit so a rollback never leaves rows pointing to deleted files
+	var filesDeleted int64
+	for _, img := range snapshot.Images {
+		if err := uc.UploadProvider.DeleteFileUploaded(context.Background(), img.Key); err != nil {
+			log.Warnf("ChildPrivacyUseCase.runErasure: delete file %s failed: %v", img.Key, err)
+			continue
+		}
+		filesDeleted++
+	}
+	summary["s3_files"] = filesDeleted
+
+	summaryJSON, _ := json.Marshal(summary)
+	if err := uc.DataRequestRepo.MarkDone(requestID, "", datatypes.JSON(summaryJSON)); err != nil {
+		log.Error("ChildPrivacyUseCase.runErasure: " + err.Error())
+	}
+}
+
+func (uc *ChildPrivacyUseCase) failRequest(requestID string, err error) {
+	log.Error("ChildPrivacyUseCase: request " + requestID + ": " + err.Error())
+	if markErr := uc.DataRequestRepo.MarkFailed(requestID, err.Error()); markErr != nil {
+		log.Error("ChildPrivacyUseCase.failRequest: " + markErr.Error())
+	}
+}
+
+func mapChildDataRequest(r *entity.ChildDataRequest) *response.ChildDataRequestResponse {
+	return &response.ChildDataRequestResponse{
+		ID:          r.ID.String(),
+		ChildID:     r.ChildID,
+		RequestedBy: r.RequestedBy,
+		Type:        string(r.Type),
+		Status:      string(r.Status),
+		Summary:     json.RawMessage(r.Summary),
+		Error:       r.Error,
+		CompletedAt: r.CompletedAt,
+		CreatedAt:   r.CreatedAt,
+	}
+}
@@ -16,6 +16,8 @@ import (
 	"gorm.io/gorm"
 )
 
+var ErrGuardianAccessDenied = errors.New("access denied: only the primary contact can manage guardians")
+
 type ChildUseCase struct {
 	dbConn                   *gorm.DB
 	childRepo                *repository.ChildRepository
@@ -29,6 +31,7 @@ type ChildUseCase struct {
 	languageSettingRepo      *repository.LanguageSettingRepository
 	parentRepo               *repository.ParentRepository
 	parentChildsRepo         *repository.ParentChildsRepository
+	childGuardianRepo        *repository.ChildGuardianRepository
 	profileGateway           gateway.ProfileGateway
 	generateOwnerCodeUseCase GenerateOwnerCodeUseCase
 	departmentGateway        gateway.DepartmentGateway
@@ -47,6 +50,7 @@ func NewChildUseCase(
 	languageSettingRepo *repository.LanguageSettingRepository,
 	parentRepo *repository.ParentRepository,
 	parentChildsRepo *repository.ParentChildsRepository,
+	childGuardianRepo *repository.ChildGuardianRepository,
 	profileGateway gateway.ProfileGateway,
 	gengenerateOwnerCodeUseCase GenerateOwnerCodeUseCase,
 	departmentGateway gateway.DepartmentGateway,
@@ -64,6 +68,7 @@ func NewChildUseCase(
 		languageSettingRepo:      languageSettingRepo,
 		parentRepo:               parentRepo,
 		parentChildsRepo:         parentChildsRepo,
+		childGuardianRepo:        childGuardianRepo,
 		profileGateway:           profileGateway,
 		generateOwnerCodeUseCase: gengenerateOwnerCodeUseCase,
 		departmentGateway:        departmentGateway,
@@ -90,6 +95,14 @@ func (uc *ChildUseCase) CreateChild(req request.CreateChildRequest, ctx *gin.Con
 		return errors.New("invalid user_id type in context")
 	}
 
+	relationship := value.GuardianRelationshipLegalGuardian
+	if req.Relationship != "" {
+		relationship = value.GuardianRelationship(req.Relationship)
+		if !relationship.IsValid() {
+			return fmt.Errorf("invalid relationship: %s", req.Relationship)
+		}
+	}
+
 	// Start Transaction
 	tx := uc.dbConn.Begin()
 	if tx.Error != nil {
@@ -166,6 +179,20 @@ func (uc *ChildUseCase) CreateChild(req request.CreateChildRequest, ctx *gin.Con
 		return fmt.Errorf("create parent-child failed: %w", err)
 	}
 
+	// ---- Create guardian (creator is the primary contact) ----
+	if err := uc.childGuardianRepo.WithTx(tx).Create(&entity.SChildGuardian{
+		ID:                 uuid.New(),
+		ChildID:            childID.String(),
+		GuardianUserID:     userID.String(),
+		Relationship:       relationship,
+		IsPrimaryContact:   true,
+		CanPickup:          true,
+		CanViewSubmissions: true,
+	}); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("create child guardian failed: %w", err)
+	}
+
 	// ---- Commit ----
 	if err := tx.Commit().Error; err != nil {
 		return err
@@ -370,16 +397,193 @@ func (uc *ChildUseCase) GetParentIDByChildID(childID string) (string, error) {
 }
 
 func (uc *ChildUseCase) IsParentOfChild(userID string, childID string) (bool, error) {
-	parentID, err := uc.childRepo.GetParentIDByChildID(childID)
+	guardian, err := uc.childGuardianRepo.GetByChildAndGuardian(childID, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return guardian != nil, nil
+}
+
+// CanViewSubmissions reports whether userID is a guardian of the child allowed to see its submissions
+func (uc *ChildUseCase) CanViewSubmissions(userID string, childID string) (bool, error) {
+	guardian, err := uc.childGuardianRepo.GetByChildAndGuardian(childID, userID)
 	if err != nil {
 		return false, err
 	}
 
-	if parentID == "" {
-		return false, nil
+	return guardian != nil && guardian.CanViewSubmissions, nil
+}
+
+func (uc *ChildUseCase) GetGuardianPermission(childID string, userID string) (*response.ChildGuardianPermissionResponse, error) {
+	guardian, err := uc.childGuardianRepo.GetByChildAndGuardian(childID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if guardian == nil {
+		return &response.ChildGuardianPermissionResponse{}, nil
+	}
+
+	return &response.ChildGuardianPermissionResponse{
+		IsGuardian:         true,
+		IsPrimaryContact:   guardian.IsPrimaryContact,
+		CanPickup:          guardian.CanPickup,
+		CanViewSubmissions: guardian.CanViewSubmissions,
+	}, nil
+}
+
+func (uc *ChildUseCase) GetGuardians(childID string) ([]response.ChildGuardianResponse, error) {
+	guardians, err := uc.childGuardianRepo.GetByChildID(childID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]response.ChildGuardianResponse, 0, len(guardians))
+	for _, g := range guardians {
+		guardianName := ""
+		user, _ := uc.userRepo.GetByID(request.GetUserEntityByIDRequest{ID: g.GuardianUserID})
+		if user != nil {
+			guardianName = user.Nickname
+		}
+
+		res = append(res, response.ChildGuardianResponse{
+			ChildID:            g.ChildID,
+			GuardianUserID:     g.GuardianUserID,
+			GuardianName:       guardianName,
+			Relationship:       string(g.Relationship),
+			IsPrimaryContact:   g.IsPrimaryContact,
+			CanPickup:          g.CanPickup,
+			CanViewSubmissions: g.CanViewSubmissions,
+		})
+	}
+
+	return res, nil
+}
+
+func (uc *ChildUseCase) GetChildIDsByGuardian(userID string) ([]string, error) {
+	guardians, err := uc.childGuardianRepo.GetByGuardianUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	childIDs := make([]string, 0, len(guardians))
+	for _, g := range guardians {
+		childIDs = append(childIDs, g.ChildID)
+	}
+
+	return childIDs, nil
+}
+
+// only the primary contact of a child can manage its guardians
+func (uc *ChildUseCase) checkPrimaryContact(childID string, userID string) error {
+	caller, err := uc.childGuardianRepo.GetByChildAndGuardian(childID, userID)
+	if err != nil {
+		return err
+	}
+	if caller == nil || !caller.IsPrimaryContact {
+		return ErrGuardianAccessDenied
+	}
+	return nil
+}
+
+func (uc *ChildUseCase) AddGuardian(req request.AddChildGuardianRequest, callerUserID string) error {
+	relationship := value.GuardianRelationship(req.Relationship)
+	if !relationship.IsValid() {
+		return fmt.Errorf("invalid relationship: %s", req.Relationship)
+	}
+
+	if err := uc.checkPrimaryContact(req.ChildID, callerUserID); err != nil {
+		return err
+	}
+
+	existing, err := uc.childGuardianRepo.GetByChildAndGuardian(req.ChildID, req.GuardianUserID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return errors.New("user is already a guardian of this child")
+	}
+
+	user, err := uc.userRepo.GetByID(request.GetUserEntityByIDRequest{ID: req.GuardianUserID})
+	if err != nil || user == nil {
+		return errors.New("guardian user not found")
+	}
+
+	return uc.dbConn.Transaction(func(tx *gorm.DB) error {
+		if req.IsPrimaryContact {
+			if err := uc.childGuardianRepo.WithTx(tx).ClearPrimaryContact(req.ChildID); err != nil {
+				return err
+			}
+		}
+
+		return uc.childGuardianRepo.WithTx(tx).Create(&entity.SChildGuardian{
+			ID:                 uuid.New(),
+			ChildID:            req.ChildID,
+			GuardianUserID:     req.GuardianUserID,
+			Relationship:       relationship,
+			IsPrimaryContact:   req.IsPrimaryContact,
+			CanPickup:          req.CanPickup,
+			CanViewSubmissions: req.CanViewSubmissions,
+		})
+	})
+}
+
+func (uc *ChildUseCase) UpdateGuardian(req request.UpdateChildGuardianRequest, callerUserID string) error {
+	relationship := value.GuardianRelationship(req.Relationship)
+	if !relationship.IsValid() {
+		return fmt.Errorf("invalid relationship: %s", req.Relationship)
+	}
+
+	if err := uc.checkPrimaryContact(req.ChildID, callerUserID); err != nil {
+		return err
+	}
+
+	guardian, err := uc.childGuardianRepo.GetByChildAndGuardian(req.ChildID, req.GuardianUserID)
+	if err != nil {
+		return err
+	}
+	if guardian == nil {
+		return errors.New("guardian not found")
+	}
+
+	// a child must always keep a primary contact
+	if guardian.IsPrimaryContact && !req.IsPrimaryContact {
+		return errors.New("cannot unset the primary contact, assign another guardian as primary instead")
+	}
+
+	return uc.dbConn.Transaction(func(tx *gorm.DB) error {
+		if req.IsPrimaryContact && !guardian.IsPrimaryContact {
+			if err := uc.childGuardianRepo.WithTx(tx).ClearPrimaryContact(req.ChildID); err != nil {
+				return err
+			}
+		}
+
+		guardian.Relationship = relationship
+		guardian.IsPrimaryContact = req.IsPrimaryContact
+		guardian.CanPickup = req.CanPickup
+		guardian.CanViewSubmissions = req.CanViewSubmissions
+		return uc.childGuardianRepo.WithTx(tx).Update(guardian)
+	})
+}
+
+func (uc *ChildUseCase) RemoveGuardian(childID string, guardianUserID string, callerUserID string) error {
+	if err := uc.checkPrimaryContact(childID, callerUserID); err != nil {
+		return err
+	}
+
+	guardian, err := uc.childGuardianRepo.GetByChildAndGuardian(childID, guardianUserID)
+	if err != nil {
+		return err
+	}
+	if guardian == nil {
+		return errors.New("guardian not found")
+	}
+	if guardian.IsPrimaryContact {
+		return errors.New("cannot remove the primary contact")
 	}
 
-	return parentID == userID, nil
+	return uc.childGuardianRepo.Delete(childID, guardianUserID)
 }
 
 func (uc *ChildUseCase) GenerateChildCode(ctx *gin.Context) {
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"errors"
+	"sen-global-api/config"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/pkg/lifecycle"
+	"sen-global-api/pkg/metrics"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	dataLogDefaultRetentionDays = 90
+	dataLogDeleteChunk          = 5000
+	// months partitioned ahead of time
+	dataLogPartitionsAhead = 2
+)
+
+type DataLogUseCase struct {
+	Repo   *repository.DataLogRepository
+	Config config.DataLogConfig
+}
+
+func (uc *DataLogUseCase) Search(req request.SearchDataLogRequest) (*response.DataLogListResponse, error) {
+	filter := repository.DataLogFilter{
+		Endpoint:   req.Endpoint,
+		Method:     req.Method,
+		Status:     req.Status,
+		StatusCode: req.StatusCode,
+		UserID:     req.UserID,
+		RequestID:  req.RequestID,
+		Page:       req.Page,
+		Limit:      req.Limit,
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 || filter.Limit > 200 {
+		filter.Limit = 50
+	}
+
+	var err error
+	if filter.From, err = parseOptionalTime(req.From); err != nil {
+		return nil, errors.New("from must be RFC3339")
+	}
+	if filter.To, err = parseOptionalTime(req.To); err != nil {
+		return nil, errors.New("to must be RFC3339")
+	}
+
+	logs, total, err := uc.Repo.Search(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.DataLogListResponse{
+		Logs: logs,
+		Pagination: response.Pagination{
+			Page:      filter.Page,
+			Limit:     filter.Limit,
+			TotalPage: int((total + int64(filter.Limit) - 1) / int64(filter.Limit)),
+			Total:     total,
+		},
+	}, nil
+}
+
+func (uc *DataLogUseCase) retention() time.Duration {
+	days := uc.Config.RetentionDays
+	if days <= 0 {
+		days = dataLogDefaultRetentionDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// Purge removes the logs past the retention, by dropping whole months when the table is partitioned.
+func (uc *DataLogUseCase) Purge() error {
+	now := time.Now()
+	cutoff := now.Add(-uc.retention())
+
+	partitions, err := uc.Repo.Partitions()
+	if err != nil {
+		log.Error("DataLogUseCase.Purge: list partitions: ", err)
+		return err
+	}
+
+	var errs []error
+	if len(partitions) > 0 {
+		if err := uc.Repo.EnsurePartitions(partitions, now, dataLogPartitionsAhead); err != nil {
+			log.Error("DataLogUseCase.Purge: add partitions: ", err)
+			errs = append(errs, err)
+		}
+		dropped, err := uc.Repo.DropPartitionsBefore(partitions, cutoff)
+		if err != nil {
+			log.Error("DataLogUseCase.Purge: drop partitions: ", err)
+			errs = append(errs, err)
+		} else if len(dropped) > 0 {
+			log.Infof("DataLogUseCase.Purge: dropped partitions %v", dropped)
+		}
+	}
+
+	// the rows of the current month partition and of a non partitioned table
+	deleted, err := uc.Repo.DeleteBefore(cutoff, dataLogDeleteChunk)
+	if err != nil {
+		log.Error("DataLogUseCase.Purge: delete logs: ", err)
+		return errors.Join(append(errs, err)...)
+	}
+	if deleted > 0 {
+		log.Infof("DataLogUseCase.Purge: deleted %d logs older than %s", deleted, cutoff.Format(time.RFC3339))
+	}
+	return errors.Join(errs...)
+}
+
+func (uc *DataLogUseCase) StartPurgeScheduler() {
+	c := cron.New(cron.WithSeconds())
+	// chay luc 3h sang moi ngay
+	_, err := c.AddFunc("0 0 3 * * *", func() {
+		var jobErr error
+		track := metrics.TrackJob("data_log_purge")
+		defer func() { track(jobErr) }()
+		jobErr = uc.Purge()
+	})
+	if err != nil {
+		log.Fatalf("Failed to add Purge data log cron job: %v", err)
+	}
+
+	c.Start()
+	lifecycle.Default().RegisterCron("data_log_purge", c)
+}
@@ -0,0 +1,341 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/lifecycle"
+	"sen-global-api/pkg/messaging"
+	"sen-global-api/pkg/metrics"
+	"sen-global-api/pkg/realtime"
+	"strings"
+	"time"
+
+	firebase "firebase.google.com/go/v4"
+	"github.com/robfig/cron/v3"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultDeviceCommandTTL = 24 * time.Hour
+	maxDeviceCommandTTL     = 7 * 24 * time.Hour
+	maxDeviceCommandDevices = 500
+	maxDeviceMessageLength  = 1000
+
+	deviceCommandViaFCM  = "fcm"
+	deviceCommandViaPoll = "poll"
+)
+
+// DeviceCommandUseCase queues the remote commands of the devices. A command is pushed by a FCM data message
+// and by the realtime channel of the device, the device poll returns it too until it is acknowledged.
+type DeviceCommandUseCase struct {
+	Repo        *repository.DeviceCommandRepository
+	DeviceRepo  *repository.DeviceRepository
+	FirebaseApp *firebase.App
+}
+
+// Enqueue queues the command for each device and pushes it right away.
+func (uc *DeviceCommandUseCase) Enqueue(req request.CreateDeviceCommandRequest, createdBy string) ([]entity.DeviceCommand, error) {
+	if !req.Type.IsValid() {
+		return nil, fmt.Errorf("invalid command type %q", req.Type)
+	}
+	if len(req.DeviceIDs) > maxDeviceCommandDevices {
+		return nil, fmt.Errorf("at most %d devices per command", maxDeviceCommandDevices)
+	}
+	payload, err := validateDeviceCommandPayload(req.Type, req.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := defaultDeviceCommandTTL
+	if req.TTLMinutes < 0 {
+		return nil, errors.New("ttl_minutes must be positive")
+	}
+	if req.TTLMinutes > 0 {
+		ttl = time.Duration(req.TTLMinutes) * time.Minute
+	}
+	if ttl > maxDeviceCommandTTL {
+		return nil, errors.New("ttl_minutes must be at most 7 days")
+	}
+
+	now := time.Now()
+	seen := make(map[string]bool, len(req.DeviceIDs))
+	commands := make([]entity.DeviceCommand, 0, len(req.DeviceIDs))
+	for _, deviceID := range req.DeviceIDs {
+		if deviceID == "" || seen[deviceID] {
+			continue
+		}
+		seen[deviceID] = true
+		if _, err := uc.DeviceRepo.GetDeviceByID(deviceID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("device %s not found", deviceID)
+			}
+			return nil, err
+		}
+		commands = append(commands, entity.DeviceCommand{
+			DeviceID:  deviceID,
+			Type:      req.Type,
+			Payload:   payload,
+			Status:    value.DeviceCommandStatusQueued,
+			CreatedBy: createdBy,
+			ExpiresAt: now.Add(ttl),
+		})
+	}
+	if len(commands) == 0 {
+		return nil, errors.New("device_ids is required")
+	}
+
+	if err := uc.Repo.Create(commands); err != nil {
+		return nil, err
+	}
+	for i := range commands {
+		uc.push(&commands[i])
+	}
+	return commands, nil
+}
+
+// validateDeviceCommandPayload returns the payload to store, a JSON object or nil.
+func validateDeviceCommandPayload(commandType value.DeviceCommandType, raw json.RawMessage) (datatypes.JSON, error) {
+	fields := map[string]interface{}{}
+	if trimmed := strings.TrimSpace(string(raw)); trimmed != "" && trimmed != "null" {
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return nil, errors.New("payload must be a JSON object")
+		}
+	}
+
+	switch commandType {
+	case value.DeviceCommandShowMessage:
+		message, _ := fields["message"].(string)
+		if strings.TrimSpace(message) == "" {
+			return nil, errors.New("show_message requires payload.message")
+		}
+		if len([]rune(message)) > maxDeviceMessageLength {
+			return nil, fmt.Errorf("payload.message must be at most %d characters", maxDeviceMessageLength)
+		}
+		if title, ok := fields["title"]; ok {
+			if _, ok := title.(string); !ok {
+				return nil, errors.New("payload.title must be a string")
+			}
+		}
+	case value.DeviceCommandCaptureDiagnostics:
+		if includeLogs, ok := fields["include_logs"]; ok {
+			if _, ok := includeLogs.(bool); !ok {
+				return nil, errors.New("payload.include_logs must be a boolean")
+			}
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	return datatypes.JSON(data), nil
+}
+
+// push sends the command to the device, the device poll still returns it when the push is lost.
+func (uc *DeviceCommandUseCase) push(command *entity.DeviceCommand) {
+	err := realtime.Publish(context.Background(), realtime.DeviceChannel(command.DeviceID), string(value.RealtimeEventDeviceCommand), command)
+	if err != nil {
+		log.Error("publish device command: ", err)
+	}
+
+	if uc.FirebaseApp == nil {
+		return
+	}
+	mobile, err := repository.NewMobileDeviceRepository().FindByDeviceID(command.DeviceID, uc.Repo.DBConn)
+	if err != nil || mobile.FCMToken == "" {
+		// device chua dang ky FCM thi cho device poll
+		return
+	}
+
+	data := map[string]string{
+		"type":       string(value.RealtimeEventDeviceCommand),
+		"command_id": command.ID.String(),
+		"command":    string(command.Type),
+		"expires_at": command.ExpiresAt.Format(time.RFC3339),
+	}
+	if len(command.Payload) > 0 {
+		data["payload"] = string(command.Payload)
+	}
+	if err := messaging.SendData(uc.FirebaseApp, mobile.FCMToken, data); err != nil {
+		return
+	}
+
+	now := time.Now()
+	if err := uc.Repo.MarkDelivered([]string{command.ID.String()}, deviceCommandViaFCM, now); err != nil {
+		log.Error("DeviceCommandUseCase.push: mark delivered: ", err)
+		return
+	}
+	command.Status = value.DeviceCommandStatusDelivered
+	command.DeliveredVia = deviceCommandViaFCM
+	command.DeliveredAt = &now
+}
+
+// Poll returns the pending commands of a device, the device runs each command once even if it is returned
+// again before its acknowledgement.
+func (uc *DeviceCommandUseCase) Poll(deviceID string) ([]entity.DeviceCommand, error) {
+	now := time.Now()
+	commands, err := uc.Repo.GetPending(deviceID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	queued := make([]string, 0, len(commands))
+	for _, command := range commands {
+		if command.Status == value.DeviceCommandStatusQueued {
+			queued = append(queued, command.ID.String())
+		}
+	}
+	if err := uc.Repo.MarkDelivered(queued, deviceCommandViaPoll, now); err != nil {
+		return nil, err
+	}
+	for i := range commands {
+		if commands[i].Status == value.DeviceCommandStatusQueued {
+			commands[i].Status = value.DeviceCommandStatusDelivered
+			commands[i].DeliveredVia = deviceCommandViaPoll
+			commands[i].DeliveredAt = &now
+		}
+	}
+	return commands, nil
+}
+
+// Acknowledge saves the result of a command sent by its device. Sending the same acknowledgement again
+// returns the saved command.
+func (uc *DeviceCommandUseCase) Acknowledge(commandID string, req request.AckDeviceCommandRequest) (*entity.DeviceCommand, error) {
+	if req.Status != value.DeviceCommandStatusAcknowledged && req.Status != value.DeviceCommandStatusFailed {
+		return nil, errors.New("status must be acknowledged or failed")
+	}
+	if req.Status == value.DeviceCommandStatusFailed && strings.TrimSpace(req.Error) == "" {
+		return nil, errors.New("error is required when the command failed")
+	}
+
+	command, err := uc.Repo.GetByID(commandID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("command not found")
+		}
+		return nil, err
+	}
+	if command.DeviceID != req.DeviceID {
+		return nil, errors.New("command not found")
+	}
+	if command.Status == req.Status {
+		return command, nil
+	}
+
+	now := time.Now()
+	if !command.IsPending(now) {
+		return nil, fmt.Errorf("command is %s", commandStatusOf(command, now))
+	}
+
+	var result datatypes.JSON
+	if trimmed := strings.TrimSpace(string(req.Result)); trimmed != "" && trimmed != "null" {
+		if !json.Valid(req.Result) {
+			return nil, errors.New("result must be valid JSON")
+		}
+		result = datatypes.JSON(req.Result)
+	}
+
+	command.Status = req.Status
+	command.Result = result
+	command.Error = req.Error
+	command.AcknowledgedAt = &now
+	if command.DeliveredAt == nil {
+		// nhan qua realtime channel
+		command.DeliveredAt = &now
+	}
+	ok, err := uc.Repo.Complete(command)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("command is not pending anymore")
+	}
+	return command, nil
+}
+
+// commandStatusOf is the status of the command, a pending command past its expiry is expired even before
+// the expiry job.
+func commandStatusOf(command *entity.DeviceCommand, now time.Time) value.DeviceCommandStatus {
+	if command.Status == value.DeviceCommandStatusQueued || command.Status == value.DeviceCommandStatusDelivered {
+		if !now.Before(command.ExpiresAt) {
+			return value.DeviceCommandStatusExpired
+		}
+	}
+	return command.Status
+}
+
+func (uc *DeviceCommandUseCase) GetCommand(commandID string) (*entity.DeviceCommand, error) {
+	command, err := uc.Repo.GetByID(commandID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("command not found")
+		}
+		return nil, err
+	}
+	command.Status = commandStatusOf(command, time.Now())
+	return command, nil
+}
+
+func (uc *DeviceCommandUseCase) GetHistory(deviceID string, req request.GetDeviceCommandHistoryRequest) (*response.DeviceCommandListResponse, error) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 || req.Limit > 200 {
+		req.Limit = 50
+	}
+
+	commands, total, err := uc.Repo.GetHistory(deviceID, req.Status, req.Limit, (req.Page-1)*req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for i := range commands {
+		commands[i].Status = commandStatusOf(&commands[i], now)
+	}
+	return &response.DeviceCommandListResponse{
+		Commands: commands,
+		Pagination: response.Pagination{
+			Page:      req.Page,
+			Limit:     req.Limit,
+			TotalPage: int((total + int64(req.Limit) - 1) / int64(req.Limit)),
+			Total:     total,
+		},
+	}, nil
+}
+
+// StartScheduler expires the commands not acknowledged in time.
+func (uc *DeviceCommandUseCase) StartScheduler() {
+	c := cron.New(cron.WithSeconds())
+	_, err := c.AddFunc("15 */5 * * * *", func() {
+		var jobErr error
+		track := metrics.TrackJob("device_command_expiry")
+		defer func() { track(jobErr) }()
+		expired, err := uc.Repo.ExpireDue(time.Now())
+		if err != nil {
+			jobErr = err
+			log.Error("DeviceCommandUseCase.ExpireDue: ", err)
+			return
+		}
+		if expired > 0 {
+			log.Infof("[CRON] %d device commands expired", expired)
+		}
+	})
+	if err != nil {
+		log.Fatalf("Failed to add device command expiry cron job: %v", err)
+	}
+
+	c.Start()
+	lifecycle.Default().RegisterCron("device_command", c)
+}
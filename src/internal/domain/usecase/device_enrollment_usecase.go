@@ -0,0 +1,361 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/enrollment"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultEnrollmentCodeTTL = 72 * time.Hour
+	maxEnrollmentCodeTTL     = 90 * 24 * time.Hour
+)
+
+var (
+	ErrEnrollmentCodeRevoked   = errors.New("enrollment code has been revoked")
+	ErrEnrollmentQuotaReached  = errors.New("enrollment code has no device left")
+	ErrDeviceEnrolledElsewhere = errors.New("device is enrolled in another organization")
+)
+
+// DeviceEnrollmentUseCase lets the fresh devices join an organization by scanning an enrollment code,
+// and manages the device limits of the organizations and the users.
+type DeviceEnrollmentUseCase struct {
+	DBConn           *gorm.DB
+	Repo             *repository.DeviceEnrollmentRepository
+	DeviceRepo       *repository.DeviceRepository
+	DeviceMenuRepo   *repository.DeviceMenuRepository
+	OrganizationRepo *repository.OrganizationRepository
+}
+
+func (uc *DeviceEnrollmentUseCase) CreateCode(req request.CreateDeviceEnrollmentCodeRequest, createdBy string) (*response.DeviceEnrollmentCodeResponse, error) {
+	if _, err := uc.OrganizationRepo.GetByID(req.OrganizationID); err != nil {
+		return nil, errors.New("organization not found")
+	}
+	if req.ExpiresInHours < 0 {
+		return nil, errors.New("expires_in_hours must be positive")
+	}
+	ttl := defaultEnrollmentCodeTTL
+	if req.ExpiresInHours > 0 {
+		ttl = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+	if ttl > maxEnrollmentCodeTTL {
+		return nil, errors.New("expires_in_hours must be at most 90 days")
+	}
+
+	code := &entity.DeviceEnrollmentCode{
+		OrganizationID: req.OrganizationID,
+		DepartmentID:   req.DepartmentID,
+		Name:           req.Name,
+		Quota:          req.Quota,
+		// the token holds the expiry in seconds
+		ExpiresAt: time.Now().Add(ttl).Truncate(time.Second),
+		CreatedBy: createdBy,
+	}
+	if err := uc.Repo.CreateCode(code); err != nil {
+		return nil, err
+	}
+	res := toDeviceEnrollmentCodeResponse(*code)
+	return &res, nil
+}
+
+func toDeviceEnrollmentCodeResponse(code entity.DeviceEnrollmentCode) response.DeviceEnrollmentCodeResponse {
+	token := enrollment.Sign(code.ID.String(), code.ExpiresAt)
+	remaining := code.Quota - code.UsedCount
+	if remaining < 0 || code.RevokedAt != nil || !time.Now().Before(code.ExpiresAt) {
+		remaining = 0
+	}
+	return response.DeviceEnrollmentCodeResponse{
+		DeviceEnrollmentCode: code,
+		Remaining:            remaining,
+		Token:                token,
+		QRPayload:            enrollment.Payload(token),
+	}
+}
+
+func (uc *DeviceEnrollmentUseCase) GetCodes(req request.GetDeviceEnrollmentCodesRequest) (*response.DeviceEnrollmentCodeListResponse, error) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 || req.Limit > 200 {
+		req.Limit = 50
+	}
+
+	codes, total, err := uc.Repo.GetCodes(req.OrganizationID, req.Limit, (req.Page-1)*req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]response.DeviceEnrollmentCodeResponse, 0, len(codes))
+	for _, code := range codes {
+		res = append(res, toDeviceEnrollmentCodeResponse(code))
+	}
+	return &response.DeviceEnrollmentCodeListResponse{
+		Codes: res,
+		Pagination: response.Pagination{
+			Page:      req.Page,
+			Limit:     req.Limit,
+			TotalPage: int((total + int64(req.Limit) - 1) / int64(req.Limit)),
+			Total:     total,
+		},
+	}, nil
+}
+
+func (uc *DeviceEnrollmentUseCase) GetCode(id string) (*response.DeviceEnrollmentCodeDetailResponse, error) {
+	code, err := uc.Repo.GetCode(id)
+	if err != nil {
+		return nil, err
+	}
+	if code == nil {
+		return nil, errors.New("enrollment code not found")
+	}
+	enrollments, err := uc.Repo.GetEnrollments(id)
+	if err != nil {
+		return nil, err
+	}
+	return &response.DeviceEnrollmentCodeDetailResponse{
+		DeviceEnrollmentCodeResponse: toDeviceEnrollmentCodeResponse(*code),
+		Enrollments:                  enrollments,
+	}, nil
+}
+
+// RevokeCode stops the code, the devices already enrolled stay in the organization.
+func (uc *DeviceEnrollmentUseCase) RevokeCode(id string) (*response.DeviceEnrollmentCodeResponse, error) {
+	code, err := uc.Repo.GetCode(id)
+	if err != nil {
+		return nil, err
+	}
+	if code == nil {
+		return nil, errors.New("enrollment code not found")
+	}
+	if code.RevokedAt == nil {
+		now := time.Now()
+		code.RevokedAt = &now
+		if err := uc.Repo.SaveCode(code); err != nil {
+			return nil, err
+		}
+	}
+	res := toDeviceEnrollmentCodeResponse(*code)
+	return &res, nil
+}
+
+// Enroll adds the device to the organization of the code, with the device profile of the organization and
+// the menus of the department of the code. Enrolling again with the same code does not use its quota.
+// A device of another organization is only enrolled with its own device token, tokenDeviceID.
+func (uc *DeviceEnrollmentUseCase) Enroll(ctx context.Context, req request.EnrollDeviceRequest, tokenDeviceID string) (*response.EnrolledDeviceResponse, error) {
+	codeID, err := enrollment.Verify(req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	var code *entity.DeviceEnrollmentCode
+	var orgDevice *entity.SOrgDevices
+	var profile *entity.SDeviceComponentValues
+	err = uc.DBConn.Transaction(func(tx *gorm.DB) error {
+		repo := uc.Repo.WithTx(tx)
+		deviceRepo := &repository.DeviceRepository{DBConn: tx}
+
+		code, err = repo.LockCode(codeID)
+		if err != nil {
+			return err
+		}
+		if code == nil {
+			return enrollment.ErrInvalidCode
+		}
+		if code.RevokedAt != nil {
+			return ErrEnrollmentCodeRevoked
+		}
+		if !time.Now().Before(code.ExpiresAt) {
+			return enrollment.ErrExpiredCode
+		}
+
+		enrolled, err := repo.GetEnrollment(codeID, req.DeviceID)
+		if err != nil {
+			return err
+		}
+		orgDevice, err = repo.GetOrgDevice(code.OrganizationID, req.DeviceID)
+		if err != nil {
+			return err
+		}
+
+		if orgDevice == nil {
+			// code chi cho phep lay device cua org khac khi co device token cua chinh device do
+			orgIDs, err := deviceRepo.GetOrgIDsByDeviceID(req.DeviceID)
+			if err != nil {
+				return err
+			}
+			if len(orgIDs) > 0 && tokenDeviceID != req.DeviceID {
+				return ErrDeviceEnrolledElsewhere
+			}
+
+			// chi device moi vao org moi tinh vao quota
+			if code.UsedCount >= code.Quota {
+				return ErrEnrollmentQuotaReached
+			}
+			if err := deviceRepo.CheckOrgDeviceLimitation(code.OrganizationID); err != nil {
+				return err
+			}
+			if orgDevice, err = uc.joinOrganization(tx, code.OrganizationID, req); err != nil {
+				return err
+			}
+			if err := repo.IncrementUsed(codeID); err != nil {
+				return err
+			}
+		}
+		if enrolled == nil {
+			if err := repo.CreateEnrollment(&entity.DeviceEnrollment{
+				CodeID:         code.ID,
+				DeviceID:       req.DeviceID,
+				OrganizationID: code.OrganizationID,
+			}); err != nil {
+				return err
+			}
+		}
+
+		profile, err = (&repository.DeviceComponentValuesRepository{DBConn: tx}).GetByOrganization(request.GetDeviceComponentValuesByOrganizationRequest{ID: code.OrganizationID})
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if profile != nil {
+			if err := repo.SetDeviceProfile(req.DeviceID, profile.ID); err != nil {
+				return err
+			}
+		}
+
+		if code.DepartmentID == "" {
+			return nil
+		}
+		departmentMenus, err := (&repository.DepartmentMenuOrganizationRepository{DBConn: tx}).GetAllByDepartmentAndOrg(ctx, code.DepartmentID, code.OrganizationID)
+		if err != nil {
+			return err
+		}
+		menus := make([]entity.SDeviceMenuV2, 0, len(departmentMenus))
+		for _, m := range departmentMenus {
+			menus = append(menus, entity.SDeviceMenuV2{
+				ID:          uuid.New(),
+				DeviceID:    req.DeviceID,
+				ComponentID: m.ComponentID,
+				Order:       m.Order,
+				IsShow:      true,
+				Visible:     true,
+			})
+		}
+		return repo.AddDeviceMenus(req.DeviceID, menus)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// device doi org thi org setting cache theo device khong con dung
+	orgSettingCache.Invalidate(ctx, req.DeviceID)
+
+	return uc.enrolledDevice(code, orgDevice, profile)
+}
+
+// joinOrganization creates the device when it was never registered and adds it to the organization.
+func (uc *DeviceEnrollmentUseCase) joinOrganization(tx *gorm.DB, orgID string, req request.EnrollDeviceRequest) (*entity.SOrgDevices, error) {
+	deviceRepo := &repository.DeviceRepository{DBConn: tx}
+
+	if _, err := deviceRepo.GetDeviceByID(req.DeviceID); err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if _, err := deviceRepo.CreateDevice(request.RegisterDeviceRequest{
+			DeviceUUID: req.DeviceID,
+			InputMode:  string(value.InfoInputTypeBarcode),
+			AppVersion: req.AppVersion,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	org, err := (&repository.OrganizationRepository{DBConn: tx}).GetByID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	max, _ := deviceRepo.GetMaxCreatedIndexByOrgID(orgID)
+	orgDevice := &entity.SOrgDevices{
+		OrganizationID: org.ID,
+		DeviceID:       req.DeviceID,
+		DeviceName:     fmt.Sprintf("%s	Device :[O.D.%d] NICKNAME", org.OrganizationName, max+1),
+	}
+	if err := uc.Repo.WithTx(tx).CreateOrgDevice(orgDevice); err != nil {
+		return nil, err
+	}
+	return orgDevice, nil
+}
+
+func (uc *DeviceEnrollmentUseCase) enrolledDevice(code *entity.DeviceEnrollmentCode, orgDevice *entity.SOrgDevices, profile *entity.SDeviceComponentValues) (*response.EnrolledDeviceResponse, error) {
+	org, err := uc.OrganizationRepo.GetByID(code.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	deviceMenus, err := uc.DeviceMenuRepo.GetByDeviceID(orgDevice.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(deviceMenus, func(i, j int) bool { return deviceMenus[i].Order < deviceMenus[j].Order })
+
+	res := &response.EnrolledDeviceResponse{
+		DeviceID:         orgDevice.DeviceID,
+		DeviceName:       orgDevice.DeviceName,
+		OrganizationID:   code.OrganizationID,
+		OrganizationName: org.OrganizationName,
+		DepartmentID:     code.DepartmentID,
+		Menus:            make([]response.EnrolledDeviceMenuResponse, 0, len(deviceMenus)),
+	}
+	if profile != nil {
+		res.Profile = profile.Setting
+	}
+	for _, m := range deviceMenus {
+		res.Menus = append(res.Menus, response.EnrolledDeviceMenuResponse{
+			ComponentID: m.ComponentID.String(),
+			Order:       m.Order,
+			IsShow:      m.IsShow,
+		})
+	}
+	return res, nil
+}
+
+// ---------- limit ----------
+
+func (uc *DeviceEnrollmentUseCase) GetLimits(req request.GetDeviceLimitsRequest) ([]entity.DeviceLimit, error) {
+	return uc.Repo.GetLimits(req.Scope)
+}
+
+func (uc *DeviceEnrollmentUseCase) SaveLimit(req request.SaveDeviceLimitRequest, updatedBy string) (*entity.DeviceLimit, error) {
+	switch req.Scope {
+	case value.DeviceLimitScopeOrganization:
+		if _, err := uc.OrganizationRepo.GetByID(req.ScopeID); err != nil {
+			return nil, errors.New("organization not found")
+		}
+	case value.DeviceLimitScopeUser:
+	default:
+		return nil, fmt.Errorf("invalid scope %q", req.Scope)
+	}
+
+	limit := &entity.DeviceLimit{
+		Scope:      req.Scope,
+		ScopeID:    req.ScopeID,
+		MaxDevices: *req.MaxDevices,
+		UpdatedBy:  updatedBy,
+	}
+	if err := uc.Repo.SaveLimit(limit); err != nil {
+		return nil, err
+	}
+	return uc.Repo.GetLimit(req.Scope, req.ScopeID)
+}
+
+// DeleteLimit puts the scope back to the default limit.
+func (uc *DeviceEnrollmentUseCase) DeleteLimit(req request.DeleteDeviceLimitRequest) error {
+	return uc.Repo.DeleteLimit(req.Scope, req.ScopeID)
+}
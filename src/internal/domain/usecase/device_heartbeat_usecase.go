@@ -0,0 +1,561 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sen-global-api/config"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/heartbeat"
+	"sen-global-api/pkg/lifecycle"
+	"sen-global-api/pkg/messaging"
+	"sen-global-api/pkg/metrics"
+	"strings"
+	"time"
+
+	firebase "firebase.google.com/go/v4"
+	"github.com/robfig/cron/v3"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultHeartbeatInterval = 60 * time.Second
+	defaultOnlineMissedBeats = 3
+	heartbeatRollupBatch     = 500
+)
+
+var heartbeatNetworks = map[string]bool{"": true, "wifi": true, "cellular": true, "ethernet": true, "none": true}
+
+// DeviceHeartbeatUseCase records the device heartbeats, shows the fleet of an organization and alerts its
+// managers when a device is offline during the school hours.
+type DeviceHeartbeatUseCase struct {
+	Repo             *repository.DeviceHeartbeatRepository
+	DeviceRepo       *repository.DeviceRepository
+	AttendanceRepo   *repository.AttendanceRepository
+	OrganizationRepo *repository.OrganizationRepository
+	UserEntityRepo   *repository.UserEntityRepository
+	UserTokenFCMRepo *repository.UserTokenFCMRepository
+	FirebaseApp      *firebase.App
+	SMTP             config.SMTPConfig
+	Config           config.HeartbeatConfig
+}
+
+func (uc *DeviceHeartbeatUseCase) interval() time.Duration {
+	if uc.Config.IntervalSeconds > 0 {
+		return time.Duration(uc.Config.IntervalSeconds) * time.Second
+	}
+	return defaultHeartbeatInterval
+}
+
+// onlineWindow is how old the last beat of an online device can be.
+func (uc *DeviceHeartbeatUseCase) onlineWindow() time.Duration {
+	missed := uc.Config.OnlineMissedBeats
+	if missed <= 0 {
+		missed = defaultOnlineMissedBeats
+	}
+	return time.Duration(missed) * uc.interval()
+}
+
+// Record stores a heartbeat in Redis, the database only sees the rollup. The device is looked up in the
+// database only when Redis has no beat of it yet.
+func (uc *DeviceHeartbeatUseCase) Record(ctx context.Context, req request.DeviceHeartbeatRequest) (*response.DeviceHeartbeatResponse, error) {
+	if req.BatteryLevel != nil && (*req.BatteryLevel < 0 || *req.BatteryLevel > 100) {
+		return nil, errors.New("battery_level must be between 0 and 100")
+	}
+	if req.SignalLevel != nil && (*req.SignalLevel < 0 || *req.SignalLevel > 4) {
+		return nil, errors.New("signal_level must be between 0 and 4")
+	}
+	if !heartbeatNetworks[req.Network] {
+		return nil, fmt.Errorf("invalid network %q", req.Network)
+	}
+
+	store := heartbeat.Default()
+	known := false
+	if store.Enabled() {
+		last, err := store.Last(ctx, req.DeviceID)
+		if err != nil {
+			log.Warnf("DeviceHeartbeatUseCase.Record: read last beat of %s: %v", req.DeviceID, err)
+		}
+		_, known = last[req.DeviceID]
+	}
+	if !known {
+		if _, err := uc.DeviceRepo.GetDeviceByID(req.DeviceID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("device not found")
+			}
+			return nil, err
+		}
+	}
+
+	beat := heartbeat.Beat{
+		DeviceID:       req.DeviceID,
+		UserID:         req.UserID,
+		AppVersion:     req.AppVersion,
+		BatteryLevel:   req.BatteryLevel,
+		Charging:       req.Charging,
+		StorageFreeMB:  req.StorageFreeMB,
+		StorageTotalMB: req.StorageTotalMB,
+		Network:        req.Network,
+		SignalLevel:    req.SignalLevel,
+		ReceivedAt:     time.Now(),
+	}
+	err := store.Record(ctx, beat)
+	if errors.Is(err, heartbeat.ErrUnavailable) {
+		// khong co Redis (dev) thi ghi thang vao DB
+		err = uc.saveBeats([]heartbeat.Beat{beat})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.DeviceHeartbeatResponse{IntervalSeconds: int(uc.interval() / time.Second)}, nil
+}
+
+// Rollup writes the beats received since the last rollup to the database.
+func (uc *DeviceHeartbeatUseCase) Rollup(ctx context.Context) error {
+	store := heartbeat.Default()
+	for {
+		beats, err := store.PopDirty(ctx, heartbeatRollupBatch)
+		if err != nil {
+			return err
+		}
+		if len(beats) == 0 {
+			return nil
+		}
+		if err := uc.saveBeats(beats); err != nil {
+			return err
+		}
+	}
+}
+
+func (uc *DeviceHeartbeatUseCase) saveBeats(beats []heartbeat.Beat) error {
+	rows := make([]entity.DeviceHeartbeat, 0, len(beats))
+	deviceIDs := make([]string, 0, len(beats))
+	for _, beat := range beats {
+		rows = append(rows, entity.DeviceHeartbeat{
+			DeviceID:       beat.DeviceID,
+			LastSeenAt:     beat.ReceivedAt,
+			UserID:         beat.UserID,
+			AppVersion:     beat.AppVersion,
+			BatteryLevel:   beat.BatteryLevel,
+			Charging:       beat.Charging,
+			StorageFreeMB:  beat.StorageFreeMB,
+			StorageTotalMB: beat.StorageTotalMB,
+			Network:        beat.Network,
+			SignalLevel:    beat.SignalLevel,
+		})
+		deviceIDs = append(deviceIDs, beat.DeviceID)
+	}
+
+	if err := uc.Repo.Upsert(rows); err != nil {
+		return err
+	}
+	if err := uc.Repo.SyncAppVersions(deviceIDs); err != nil {
+		log.Error("DeviceHeartbeatUseCase.saveBeats: sync app versions: ", err)
+	}
+	return uc.Repo.ResolveDeviceAlerts(deviceIDs, time.Now())
+}
+
+// lastBeats returns the last beat of each device: Redis first, the rollup for the devices Redis no longer has.
+func (uc *DeviceHeartbeatUseCase) lastBeats(ctx context.Context, deviceIDs []string) (map[string]heartbeat.Beat, error) {
+	beats, err := heartbeat.Default().Last(ctx, deviceIDs...)
+	if err != nil {
+		log.Warnf("DeviceHeartbeatUseCase.lastBeats: %v", err)
+		beats = make(map[string]heartbeat.Beat)
+	}
+
+	missing := make([]string, 0)
+	for _, id := range deviceIDs {
+		if _, ok := beats[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	rows, err := uc.Repo.GetByDeviceIDs(missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		beats[row.DeviceID] = heartbeat.Beat{
+			DeviceID:       row.DeviceID,
+			UserID:         row.UserID,
+			AppVersion:     row.AppVersion,
+			BatteryLevel:   row.BatteryLevel,
+			Charging:       row.Charging,
+			StorageFreeMB:  row.StorageFreeMB,
+			StorageTotalMB: row.StorageTotalMB,
+			Network:        row.Network,
+			SignalLevel:    row.SignalLevel,
+			ReceivedAt:     row.LastSeenAt,
+		}
+	}
+	return beats, nil
+}
+
+// GetFleet returns the devices of an organization with their connectivity and last heartbeat.
+func (uc *DeviceHeartbeatUseCase) GetFleet(ctx context.Context, orgID string) (*response.DeviceFleetResponse, error) {
+	orgDevices, err := uc.Repo.GetOrgDevices(orgID)
+	if err != nil {
+		return nil, err
+	}
+	deviceIDs := make([]string, 0, len(orgDevices))
+	for _, orgDevice := range orgDevices {
+		deviceIDs = append(deviceIDs, orgDevice.DeviceID)
+	}
+	beats, err := uc.lastBeats(ctx, deviceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &response.DeviceFleetResponse{
+		OrganizationID: orgID,
+		Devices:        make([]response.FleetDeviceResponse, 0, len(orgDevices)),
+	}
+	now := time.Now()
+	for _, orgDevice := range orgDevices {
+		device := response.FleetDeviceResponse{
+			DeviceID:       orgDevice.DeviceID,
+			DeviceName:     orgDevice.DeviceName,
+			DeviceNickName: orgDevice.DeviceNickName,
+			Status:         orgDevice.Device.Status,
+			Connectivity:   value.DeviceConnectivityUnknown,
+			AppVersion:     orgDevice.Device.AppVersion,
+		}
+		if device.DeviceName == "" {
+			device.DeviceName = orgDevice.Device.DeviceName
+		}
+
+		if beat, ok := beats[orgDevice.DeviceID]; ok {
+			lastSeen := beat.ReceivedAt
+			device.LastSeenAt = &lastSeen
+			device.Connectivity = value.DeviceConnectivityOffline
+			if now.Sub(lastSeen) <= uc.onlineWindow() {
+				device.Connectivity = value.DeviceConnectivityOnline
+			}
+			device.UserID = beat.UserID
+			if beat.AppVersion != "" {
+				device.AppVersion = beat.AppVersion
+			}
+			device.BatteryLevel = beat.BatteryLevel
+			device.Charging = beat.Charging
+			device.StorageFreeMB = beat.StorageFreeMB
+			device.StorageTotalMB = beat.StorageTotalMB
+			device.Network = beat.Network
+			device.SignalLevel = beat.SignalLevel
+		}
+
+		switch device.Connectivity {
+		case value.DeviceConnectivityOnline:
+			res.Online++
+		case value.DeviceConnectivityOffline:
+			res.Offline++
+		default:
+			res.Unknown++
+		}
+		res.Devices = append(res.Devices, device)
+	}
+	return res, nil
+}
+
+// IsManager tells whether the user manages the organization.
+func (uc *DeviceHeartbeatUseCase) IsManager(userID string, orgID string) bool {
+	userOrg, err := uc.OrganizationRepo.GetUserOrgInfo(userID, orgID)
+	if err != nil {
+		return false
+	}
+	return userOrg.UserID.String() == userID && userOrg.IsManager
+}
+
+// ---------- offline alerts ----------
+
+// GetAlertSetting returns the organization setting, alerts are disabled when none is configured.
+func (uc *DeviceHeartbeatUseCase) GetAlertSetting(orgID string) (*entity.DeviceAlertSetting, error) {
+	setting, err := uc.Repo.GetAlertSetting(orgID)
+	if err != nil {
+		return nil, err
+	}
+	if setting != nil {
+		return setting, nil
+	}
+	return &entity.DeviceAlertSetting{
+		OrganizationID:      orgID,
+		OfflineAfterMinutes: 15,
+		StartTime:           "07:30",
+		EndTime:             "16:30",
+		NotifyPush:          true,
+	}, nil
+}
+
+func (uc *DeviceHeartbeatUseCase) UpdateAlertSetting(req request.UpdateDeviceAlertSettingRequest) (*entity.DeviceAlertSetting, error) {
+	start, err := time.Parse(attendanceClockLayout, req.StartTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start_time, expected HH:MM: %s", req.StartTime)
+	}
+	end, err := time.Parse(attendanceClockLayout, req.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end_time, expected HH:MM: %s", req.EndTime)
+	}
+	if !start.Before(end) {
+		return nil, errors.New("start_time must be before end_time")
+	}
+	if req.OfflineAfterMinutes < 1 {
+		return nil, errors.New("offline_after_minutes must be at least 1")
+	}
+
+	setting, err := uc.Repo.GetAlertSetting(req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if setting == nil {
+		setting = &entity.DeviceAlertSetting{OrganizationID: req.OrganizationID}
+	}
+	setting.Enabled = req.Enabled
+	setting.OfflineAfterMinutes = req.OfflineAfterMinutes
+	setting.StartTime = req.StartTime
+	setting.EndTime = req.EndTime
+	setting.NotifyPush = req.NotifyPush
+	setting.NotifyEmail = req.NotifyEmail
+
+	if err := uc.Repo.SaveAlertSetting(setting); err != nil {
+		return nil, err
+	}
+	return setting, nil
+}
+
+func (uc *DeviceHeartbeatUseCase) GetAlerts(req request.GetDeviceOfflineAlertsRequest) (*response.DeviceOfflineAlertListResponse, error) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 || req.Limit > 200 {
+		req.Limit = 50
+	}
+
+	alerts, total, err := uc.Repo.GetAlerts(req.OrganizationID, req.Limit, (req.Page-1)*req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return &response.DeviceOfflineAlertListResponse{
+		Alerts: alerts,
+		Pagination: response.Pagination{
+			Page:      req.Page,
+			Limit:     req.Limit,
+			TotalPage: int((total + int64(req.Limit) - 1) / int64(req.Limit)),
+			Total:     total,
+		},
+	}, nil
+}
+
+// DetectOfflineDevices alerts the managers of each organization with alerts enabled of its devices offline
+// during the school hours. A device is alerted once per offline period, the devices that never beat are ignored.
+func (uc *DeviceHeartbeatUseCase) DetectOfflineDevices(ctx context.Context) error {
+	settings, err := uc.Repo.GetEnabledAlertSettings()
+	if err != nil {
+		log.Error("DeviceHeartbeatUseCase.DetectOfflineDevices: get settings failed: ", err)
+		return err
+	}
+
+	var errs []error
+	for i := range settings {
+		if err := uc.detectOfflineForOrganization(ctx, &settings[i], time.Now()); err != nil {
+			log.Errorf("DeviceHeartbeatUseCase.DetectOfflineDevices: organization %s: %v", settings[i].OrganizationID, err)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (uc *DeviceHeartbeatUseCase) detectOfflineForOrganization(ctx context.Context, setting *entity.DeviceAlertSetting, now time.Time) error {
+	// gio hoc theo ngay lam viec va mui gio cua attendance setting
+	schoolDays, err := uc.AttendanceRepo.GetSettingByOrganizationID(setting.OrganizationID)
+	if err != nil {
+		return err
+	}
+	if schoolDays == nil {
+		schoolDays = &entity.AttendanceSetting{Timezone: defaultAttendanceZone, WorkingDays: "1,2,3,4,5"}
+	}
+	localNow := now.In(attendanceLocation(schoolDays))
+	if !isWorkingDay(schoolDays, localNow) {
+		return nil
+	}
+	start, err := clockOnDay(localNow, setting.StartTime)
+	if err != nil {
+		return err
+	}
+	end, err := clockOnDay(localNow, setting.EndTime)
+	if err != nil {
+		return err
+	}
+	offlineAfter := time.Duration(setting.OfflineAfterMinutes) * time.Minute
+	// a device switched on at the start of the day has offlineAfter to beat
+	if localNow.Before(start.Add(offlineAfter)) || localNow.After(end) {
+		return nil
+	}
+
+	orgDevices, err := uc.Repo.GetOrgDevices(setting.OrganizationID)
+	if err != nil {
+		return err
+	}
+	deviceIDs := make([]string, 0, len(orgDevices))
+	for _, orgDevice := range orgDevices {
+		deviceIDs = append(deviceIDs, orgDevice.DeviceID)
+	}
+	beats, err := uc.lastBeats(ctx, deviceIDs)
+	if err != nil {
+		return err
+	}
+	openAlerts, err := uc.Repo.GetOpenAlerts(setting.OrganizationID)
+	if err != nil {
+		return err
+	}
+	alerted := make(map[string]bool, len(openAlerts))
+	for _, alert := range openAlerts {
+		alerted[alert.DeviceID] = true
+	}
+
+	newAlerts := make([]entity.DeviceOfflineAlert, 0)
+	offlineNames := make([]string, 0)
+	backOnline := make([]string, 0)
+	for _, orgDevice := range orgDevices {
+		if orgDevice.Device.Status == value.DeviceModeDeactivated || orgDevice.Device.Status == value.DeviceModeSuspended {
+			continue
+		}
+		beat, ok := beats[orgDevice.DeviceID]
+		if !ok {
+			continue
+		}
+		if now.Sub(beat.ReceivedAt) <= offlineAfter {
+			if alerted[orgDevice.DeviceID] {
+				backOnline = append(backOnline, orgDevice.DeviceID)
+			}
+			continue
+		}
+		if alerted[orgDevice.DeviceID] {
+			continue
+		}
+		newAlerts = append(newAlerts, entity.DeviceOfflineAlert{
+			OrganizationID: setting.OrganizationID,
+			DeviceID:       orgDevice.DeviceID,
+			LastSeenAt:     beat.ReceivedAt,
+			AlertedAt:      now,
+		})
+		offlineNames = append(offlineNames, fleetDeviceName(orgDevice))
+	}
+
+	if err := uc.Repo.ResolveDeviceAlerts(backOnline, now); err != nil {
+		return err
+	}
+	if len(newAlerts) == 0 {
+		return nil
+	}
+	if err := uc.Repo.CreateAlerts(newAlerts); err != nil {
+		return err
+	}
+	uc.notifyManagers(setting, offlineNames)
+	return nil
+}
+
+func (uc *DeviceHeartbeatUseCase) notifyManagers(setting *entity.DeviceAlertSetting, deviceNames []string) {
+	managers, err := uc.OrganizationRepo.GetAllOrgManagerInfo(setting.OrganizationID)
+	if err != nil {
+		log.Error("DeviceHeartbeatUseCase.notifyManagers: ", err)
+		return
+	}
+
+	title := "Device offline"
+	message := fmt.Sprintf("%s has been offline for more than %d minutes", deviceNames[0], setting.OfflineAfterMinutes)
+	if len(deviceNames) > 1 {
+		title = "Devices offline"
+		message = fmt.Sprintf("%d devices have been offline for more than %d minutes: %s",
+			len(deviceNames), setting.OfflineAfterMinutes, strings.Join(deviceNames, ", "))
+	}
+
+	for _, manager := range *managers {
+		userID := manager.UserID.String()
+
+		if setting.NotifyPush && uc.FirebaseApp != nil {
+			tokens, err := uc.UserTokenFCMRepo.FindByUserID(userID)
+			if err != nil {
+				log.Error("DeviceHeartbeatUseCase.notifyManagers: ", err)
+			}
+			for _, token := range tokens {
+				if !token.IsActive || token.FCMToken == "" {
+					continue
+				}
+				err := messaging.SendNotification(uc.FirebaseApp, messaging.NotificationParams{
+					Title:       title,
+					Message:     message,
+					DeviceToken: token.FCMToken,
+					Type:        value.NotificationType_DeviceOffline,
+				})
+				if err != nil {
+					log.Error("DeviceHeartbeatUseCase.notifyManagers: send notification failed: ", err)
+				}
+			}
+		}
+
+		if setting.NotifyEmail && uc.SMTP.Host != "" {
+			user, err := uc.UserEntityRepo.GetByID(request.GetUserEntityByIDRequest{ID: userID})
+			if err != nil || user.Email == "" {
+				continue
+			}
+			if err := sendMail(uc.SMTP, user.Email, title, message); err != nil {
+				log.Error("DeviceHeartbeatUseCase.notifyManagers: send email failed: ", err)
+			}
+		}
+	}
+}
+
+func fleetDeviceName(orgDevice entity.SOrgDevices) string {
+	for _, name := range []string{orgDevice.DeviceNickName, orgDevice.DeviceName, orgDevice.Device.DeviceName} {
+		if name != "" {
+			return name
+		}
+	}
+	return orgDevice.DeviceID
+}
+
+// StartScheduler starts the rollup, and the offline alerts when alerts is set.
+func (uc *DeviceHeartbeatUseCase) StartScheduler(alerts bool) {
+	c := cron.New(cron.WithSeconds())
+	// rollup Redis -> DB moi phut
+	_, err := c.AddFunc("0 * * * * *", func() {
+		var jobErr error
+		track := metrics.TrackJob("device_heartbeat_rollup")
+		defer func() { track(jobErr) }()
+		if jobErr = uc.Rollup(context.Background()); jobErr != nil {
+			log.Error("DeviceHeartbeatUseCase.Rollup: ", jobErr)
+		}
+	})
+	if err != nil {
+		log.Fatalf("Failed to add heartbeat rollup cron job: %v", err)
+	}
+	if alerts {
+		err = uc.addOfflineAlertJob(c)
+	}
+	if err != nil {
+		log.Fatalf("Failed to add DetectOfflineDevices cron job: %v", err)
+	}
+
+	c.Start()
+	lifecycle.Default().RegisterCron("device_heartbeat", c)
+}
+
+func (uc *DeviceHeartbeatUseCase) addOfflineAlertJob(c *cron.Cron) error {
+	// giay 30 de khong chay cung luc voi rollup
+	_, err := c.AddFunc("30 */5 * * * *", func() {
+		// moi instance deu chay cron, chi 1 instance gui canh bao
+		if !heartbeat.Default().AcquireRun(context.Background(), "offline_alerts", 4*time.Minute) {
+			return
+		}
+		log.Println("[CRON] Running DetectOfflineDevices at", time.Now().Format(time.RFC3339))
+		var jobErr error
+		track := metrics.TrackJob("device_offline_alerts")
+		defer func() { track(jobErr) }()
+		jobErr = uc.DetectOfflineDevices(context.Background())
+	})
+	return err
+}
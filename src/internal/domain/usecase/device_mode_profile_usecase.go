@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/appcache"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// scope: organization id ("-" for the devices without organization), key: mode
+var deviceModeProfileCache = appcache.New[*entity.DeviceModeProfile]("device_mode_profile", 1, 10*time.Minute)
+
+// DeviceModeProfileUseCase manages the profiles of the device modes, served to the devices with their settings
+// and checked by middleware.DeviceModeMiddleware on the role endpoints.
+type DeviceModeProfileUseCase struct {
+	Repo             *repository.DeviceModeProfileRepository
+	DeviceRepo       *repository.DeviceRepository
+	OrganizationRepo *repository.OrganizationRepository
+}
+
+func (uc *DeviceModeProfileUseCase) GetProfiles(req request.GetDeviceModeProfilesRequest) ([]entity.DeviceModeProfile, error) {
+	return uc.Repo.GetAll(req.OrganizationID)
+}
+
+func (uc *DeviceModeProfileUseCase) SaveProfile(ctx context.Context, req request.SaveDeviceModeProfileRequest, updatedBy string) (*entity.DeviceModeProfile, error) {
+	mode, err := value.GetDeviceModeFromString(req.Mode)
+	if err != nil || !mode.IsInUse() {
+		return nil, fmt.Errorf("invalid mode %q, expected mode t, mode s, mode p or mode l", req.Mode)
+	}
+	if req.OrganizationID != "" {
+		if _, err := uc.OrganizationRepo.GetByID(req.OrganizationID); err != nil {
+			return nil, errors.New("organization not found")
+		}
+	}
+	if req.IdleTimeoutSeconds < 0 || req.AutoLogoutAfterMinutes < 0 {
+		return nil, errors.New("idle_timeout_seconds and auto_logout_after_minutes must be positive")
+	}
+	if req.AutoLogoutOnIdle && req.IdleTimeoutSeconds == 0 {
+		return nil, errors.New("auto_logout_on_idle requires idle_timeout_seconds")
+	}
+	if req.AutoLogoutAt != "" {
+		if _, err := time.Parse(attendanceClockLayout, req.AutoLogoutAt); err != nil {
+			return nil, fmt.Errorf("invalid auto_logout_at, expected HH:MM: %s", req.AutoLogoutAt)
+		}
+	}
+
+	roles := make([]string, 0, len(req.AllowedRoles))
+	for _, r := range req.AllowedRoles {
+		role, err := entity.RoleFromString(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid role %q", r)
+		}
+		roles = append(roles, role.String())
+	}
+	componentIDs := make([]string, 0, len(req.AllowedComponentIDs))
+	for _, id := range req.AllowedComponentIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, fmt.Errorf("invalid component id %q", id)
+		}
+		componentIDs = append(componentIDs, id)
+	}
+	questionTypes := make([]string, 0, len(req.MobileQuestionTypes))
+	for _, t := range req.MobileQuestionTypes {
+		questionType, err := value.GetQuestionType(t)
+		if err != nil {
+			return nil, fmt.Errorf("invalid question type %q", t)
+		}
+		questionTypes = append(questionTypes, value.GetStringValue(questionType))
+	}
+
+	profile, err := uc.Repo.Get(mode, req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		profile = &entity.DeviceModeProfile{Mode: mode, OrganizationID: req.OrganizationID}
+	}
+	profile.Name = req.Name
+	profile.RequireLogin = req.RequireLogin
+	profile.AllowedRoles = datatypes.JSONType[[]string]{Data: roles}
+	profile.AllowedComponentIDs = datatypes.JSONType[[]string]{Data: componentIDs}
+	profile.MobileQuestionTypes = datatypes.JSONType[[]string]{Data: questionTypes}
+	profile.IdleTimeoutSeconds = req.IdleTimeoutSeconds
+	profile.AutoLogoutOnIdle = req.AutoLogoutOnIdle
+	profile.AutoLogoutAfterMinutes = req.AutoLogoutAfterMinutes
+	profile.AutoLogoutAt = req.AutoLogoutAt
+	profile.UpdatedBy = updatedBy
+	if err := uc.Repo.Save(profile); err != nil {
+		return nil, err
+	}
+
+	invalidateDeviceModeProfiles(ctx)
+	return profile, nil
+}
+
+// DeleteProfile removes the profile, the devices of an organization get the default profile of the mode again.
+func (uc *DeviceModeProfileUseCase) DeleteProfile(ctx context.Context, id string) error {
+	profile, err := uc.Repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if profile == nil {
+		return errors.New("device mode profile not found")
+	}
+	if err := uc.Repo.Delete(id); err != nil {
+		return err
+	}
+
+	invalidateDeviceModeProfiles(ctx)
+	return nil
+}
+
+// invalidateDeviceModeProfiles drops the profiles and the settings of the devices serving them.
+func invalidateDeviceModeProfiles(ctx context.Context) {
+	deviceModeProfileCache.InvalidateAll(ctx)
+	orgSettingCache.InvalidateAll(ctx)
+}
+
+// GetProfile returns the profile of the mode in the organization, else the default one, nil when the mode
+// has no profile.
+func (uc *DeviceModeProfileUseCase) GetProfile(ctx context.Context, mode value.DeviceMode, orgID string) (*entity.DeviceModeProfile, error) {
+	if !mode.IsInUse() {
+		return nil, nil
+	}
+	scope := orgID
+	if scope == "" {
+		scope = "-"
+	}
+	return deviceModeProfileCache.Get(ctx, scope, string(mode), func() (*entity.DeviceModeProfile, error) {
+		if orgID != "" {
+			profile, err := uc.Repo.Get(mode, orgID)
+			if err != nil || profile != nil {
+				return profile, err
+			}
+		}
+		return uc.Repo.Get(mode, "")
+	})
+}
+
+// GetDeviceProfile returns the profile of the current mode of the device in its organization.
+func (uc *DeviceModeProfileUseCase) GetDeviceProfile(ctx context.Context, deviceID string) (*entity.DeviceModeProfile, error) {
+	device, err := uc.DeviceRepo.GetDeviceByID(deviceID)
+	if err != nil {
+		return nil, err
+	}
+	orgID := ""
+	if orgDevice, err := uc.DeviceRepo.GetOrgByDeviceID(deviceID); err == nil {
+		orgID = orgDevice.OrganizationID.String()
+	}
+	return uc.GetProfile(ctx, device.Status, orgID)
+}
+
+// DeviceAllowsRole tells whether the device can call the endpoints of the role in its current mode.
+// An unknown device is not checked.
+func (uc *DeviceModeProfileUseCase) DeviceAllowsRole(ctx context.Context, deviceID string, role entity.Role) (bool, error) {
+	profile, err := uc.GetDeviceProfile(ctx, deviceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return true, nil
+		}
+		return false, err
+	}
+	return profile == nil || profile.AllowsRole(role), nil
+}
@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/pkg/featureflag"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+var featureFlagKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{0,99}$`)
+
+// FeatureFlagUseCase manages the flags stored in the database, it is the featureflag.Provider of the app.
+// Every change is audited and reloads the flags of this instance, the other instances see it after their refresh.
+type FeatureFlagUseCase struct {
+	Repo *repository.FeatureFlagRepository
+}
+
+// Flags implements featureflag.Provider.
+func (uc *FeatureFlagUseCase) Flags(context.Context) ([]featureflag.Flag, error) {
+	rows, err := uc.Repo.List()
+	if err != nil {
+		return nil, err
+	}
+	flags := make([]featureflag.Flag, 0, len(rows))
+	for _, row := range rows {
+		flags = append(flags, row.ToFlag())
+	}
+	return flags, nil
+}
+
+func (uc *FeatureFlagUseCase) List() ([]entity.FeatureFlag, error) {
+	return uc.Repo.List()
+}
+
+func (uc *FeatureFlagUseCase) Get(key string) (*entity.FeatureFlag, error) {
+	flag, err := uc.Repo.GetByKey(key)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("feature flag %s not found", key)
+	}
+	return flag, err
+}
+
+func (uc *FeatureFlagUseCase) Create(req request.CreateFeatureFlagRequest, createdBy string) (*entity.FeatureFlag, error) {
+	if !featureFlagKeyPattern.MatchString(req.Key) {
+		return nil, errors.New("key must be lower case letters, digits, '_', '.' or '-'")
+	}
+	existing, err := uc.Repo.GetByKey(req.Key)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("feature flag %s already exists", req.Key)
+	}
+
+	flag := featureflag.Flag{
+		Key:            req.Key,
+		Kind:           req.Kind,
+		Enabled:        req.Enabled,
+		Variants:       req.Variants,
+		DefaultVariant: req.DefaultVariant,
+		OffVariant:     req.OffVariant,
+		Rules:          req.Rules,
+	}.Normalize()
+	if err := validateFeatureFlag(flag); err != nil {
+		return nil, err
+	}
+
+	row := &entity.FeatureFlag{Key: flag.Key, Description: req.Description, UpdatedBy: createdBy}
+	setFeatureFlag(row, flag)
+	audit, err := newFeatureFlagAudit(flag.Key, entity.FeatureFlagActionCreate, nil, row, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.Repo.Create(row, audit); err != nil {
+		return nil, err
+	}
+	featureflag.Default().Invalidate()
+	return row, nil
+}
+
+func (uc *FeatureFlagUseCase) Update(key string, req request.UpdateFeatureFlagRequest, updatedBy string) (*entity.FeatureFlag, error) {
+	row, err := uc.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	before := *row
+
+	flag := row.ToFlag()
+	if req.Enabled != nil {
+		flag.Enabled = *req.Enabled
+	}
+	if req.Variants != nil && flag.Kind == featureflag.KindMultivariate {
+		flag.Variants = *req.Variants
+	}
+	if req.DefaultVariant != nil {
+		flag.DefaultVariant = *req.DefaultVariant
+	}
+	if req.OffVariant != nil {
+		flag.OffVariant = *req.OffVariant
+	}
+	if req.Rules != nil {
+		flag.Rules = *req.Rules
+	}
+	flag = flag.Normalize()
+	if err := validateFeatureFlag(flag); err != nil {
+		return nil, err
+	}
+
+	if req.Description != nil {
+		row.Description = *req.Description
+	}
+	row.UpdatedBy = updatedBy
+	setFeatureFlag(row, flag)
+	audit, err := newFeatureFlagAudit(key, entity.FeatureFlagActionUpdate, &before, row, updatedBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.Repo.Save(row, audit); err != nil {
+		return nil, err
+	}
+	featureflag.Default().Invalidate()
+	return row, nil
+}
+
+func (uc *FeatureFlagUseCase) Delete(key string, deletedBy string) error {
+	row, err := uc.Get(key)
+	if err != nil {
+		return err
+	}
+	audit, err := newFeatureFlagAudit(key, entity.FeatureFlagActionDelete, row, nil, deletedBy)
+	if err != nil {
+		return err
+	}
+	if err := uc.Repo.Delete(key, audit); err != nil {
+		return err
+	}
+	featureflag.Default().Invalidate()
+	return nil
+}
+
+func (uc *FeatureFlagUseCase) GetAudits(req request.GetFeatureFlagAuditsRequest) (*response.FeatureFlagAuditListResponse, error) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 || req.Limit > 200 {
+		req.Limit = 50
+	}
+
+	audits, total, err := uc.Repo.GetAudits(req.Key, req.Limit, (req.Page-1)*req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return &response.FeatureFlagAuditListResponse{
+		Audits: audits,
+		Pagination: response.Pagination{
+			Page:      req.Page,
+			Limit:     req.Limit,
+			TotalPage: int((total + int64(req.Limit) - 1) / int64(req.Limit)),
+			Total:     total,
+		},
+	}, nil
+}
+
+// GetAppFlags returns the variant of every flag served to the caller of ctx.
+func (uc *FeatureFlagUseCase) GetAppFlags(ctx context.Context) response.AppFeatureFlagsResponse {
+	res := response.AppFeatureFlagsResponse{Flags: make(map[string]string)}
+	for _, evaluation := range featureflag.FromContext(ctx).All(ctx) {
+		res.Flags[evaluation.Key] = evaluation.Variant
+	}
+	return res
+}
+
+// Evaluate previews the flags served to a subject, with the reason of each variant.
+func (uc *FeatureFlagUseCase) Evaluate(ctx context.Context, subject featureflag.Subject) response.FeatureFlagEvaluationResponse {
+	return response.FeatureFlagEvaluationResponse{
+		Subject:     subject,
+		Evaluations: featureflag.Default().EvaluateAll(ctx, subject),
+	}
+}
+
+func validateFeatureFlag(flag featureflag.Flag) error {
+	if err := flag.Validate(); err != nil {
+		return err
+	}
+	for i, rule := range flag.Rules {
+		for _, role := range rule.Roles {
+			if _, err := entity.RoleFromString(role); err != nil {
+				return fmt.Errorf("rule %d: invalid role %q", i, role)
+			}
+		}
+	}
+	return nil
+}
+
+func setFeatureFlag(row *entity.FeatureFlag, flag featureflag.Flag) {
+	row.Kind = flag.Kind
+	row.Enabled = flag.Enabled
+	row.Variants = datatypes.JSONType[[]string]{Data: flag.Variants}
+	row.DefaultVariant = flag.DefaultVariant
+	row.OffVariant = flag.OffVariant
+	row.Rules = datatypes.JSONType[[]featureflag.Rule]{Data: flag.Rules}
+}
+
+func newFeatureFlagAudit(key string, action entity.FeatureFlagAction, before *entity.FeatureFlag, after *entity.FeatureFlag, changedBy string) (*entity.FeatureFlagAudit, error) {
+	audit := &entity.FeatureFlagAudit{FlagKey: key, Action: action, ChangedBy: changedBy}
+	if before != nil {
+		data, err := json.Marshal(before)
+		if err != nil {
+			return nil, err
+		}
+		audit.Before = data
+	}
+	if after != nil {
+		data, err := json.Marshal(after)
+		if err != nil {
+			return nil, err
+		}
+		audit.After = data
+	}
+	return audit, nil
+}
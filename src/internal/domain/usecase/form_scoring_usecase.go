@@ -0,0 +1,341 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/value"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/datatypes"
+)
+
+type FormScoringUseCase struct {
+	Repo           *repository.FormScoringRepository
+	FormRepo       *repository.FormRepository
+	QuestionRepo   *repository.QuestionRepository
+	SubmissionRepo *repository.SubmissionRepository
+}
+
+// scorableQuestionTypes are the question types a rubric can score.
+var scorableQuestionTypes = map[string]bool{
+	value.GetStringValue(value.QuestionScale):          true,
+	value.GetStringValue(value.QuestionSingleChoice):   true,
+	value.GetStringValue(value.QuestionMultipleChoice): true,
+	value.GetStringValue(value.QuestionButtonCount):    true,
+	value.GetStringValue(value.QuestionNumber):         true,
+}
+
+func (uc *FormScoringUseCase) GetScoring(formID uint64) (*response.FormScoringResponse, error) {
+	scoring, err := uc.Repo.GetByFormID(formID)
+	if err != nil {
+		return nil, err
+	}
+	if scoring == nil {
+		return nil, errors.New("form has no scoring")
+	}
+	return mapFormScoring(scoring), nil
+}
+
+func (uc *FormScoringUseCase) UpdateScoring(formID uint64, req request.UpdateFormScoringRequest) (*response.FormScoringResponse, error) {
+	if _, err := uc.FormRepo.GetFormByID(formID); err != nil {
+		return nil, errors.New("form not found")
+	}
+
+	questions, err := uc.QuestionRepo.GetQuestionsByFormID(formID)
+	if err != nil {
+		return nil, err
+	}
+	questionTypes := make(map[string]string, len(questions))
+	for _, q := range questions {
+		questionTypes[q.ID] = q.QuestionType
+	}
+
+	seen := make(map[string]bool, len(req.Questions))
+	for i := range req.Questions {
+		q := &req.Questions[i]
+		questionType, ok := questionTypes[q.QuestionID]
+		if !ok {
+			return nil, fmt.Errorf("question %s does not belong to the form", q.QuestionID)
+		}
+		if !scorableQuestionTypes[questionType] {
+			return nil, fmt.Errorf("question %s of type %s cannot be scored", q.QuestionID, questionType)
+		}
+		if seen[q.QuestionID] {
+			return nil, fmt.Errorf("question %s is scored twice", q.QuestionID)
+		}
+		seen[q.QuestionID] = true
+
+		if q.Weight == 0 {
+			q.Weight = 1
+		}
+		if q.Weight < 0 || q.MaxPoints < 0 {
+			return nil, fmt.Errorf("question %s: weight and max_points must be positive", q.QuestionID)
+		}
+		q.Section = strings.TrimSpace(q.Section)
+	}
+
+	if err := validateScoringLevels(req.Levels); err != nil {
+		return nil, err
+	}
+	for section, levels := range req.SectionLevels {
+		if err := validateScoringLevels(levels); err != nil {
+			return nil, fmt.Errorf("section %s: %w", section, err)
+		}
+	}
+
+	scoring, err := uc.Repo.GetByFormID(formID)
+	if err != nil {
+		return nil, err
+	}
+	if scoring == nil {
+		scoring = &entity.FormScoring{FormID: formID, IsActive: true}
+	}
+	if req.IsActive != nil {
+		scoring.IsActive = *req.IsActive
+	}
+	scoring.Rubric = datatypes.JSONType[entity.ScoringRubric]{Data: entity.ScoringRubric{
+		Questions:     req.Questions,
+		Levels:        req.Levels,
+		SectionLevels: req.SectionLevels,
+	}}
+
+	if err := uc.Repo.Save(scoring); err != nil {
+		return nil, err
+	}
+	return mapFormScoring(scoring), nil
+}
+
+func (uc *FormScoringUseCase) DeleteScoring(formID uint64) error {
+	return uc.Repo.DeleteByFormID(formID)
+}
+
+func (uc *FormScoringUseCase) GetSubmissionScore(submissionID uint64) (*response.SubmissionScoreResponse, error) {
+	submission, err := uc.SubmissionRepo.GetByID(submissionID)
+	if err != nil {
+		return nil, err
+	}
+	if submission == nil {
+		return nil, errors.New("submission not found")
+	}
+
+	res := &response.SubmissionScoreResponse{
+		SubmissionID: submission.ID,
+		FormID:       submission.FormID,
+	}
+	if len(submission.Score) > 0 {
+		var score entity.SubmissionScore
+		if err := json.Unmarshal(submission.Score, &score); err != nil {
+			return nil, err
+		}
+		res.Score = &score
+	}
+	return res, nil
+}
+
+// ScoreSubmission applies the active rubric of the form to the submitted items.
+// It returns nil when the form is not scored.
+func (uc *FormScoringUseCase) ScoreSubmission(formID uint64, items []repository.SubmissionDataItem) (*entity.SubmissionScore, error) {
+	scoring, err := uc.Repo.GetByFormID(formID)
+	if err != nil {
+		return nil, err
+	}
+	if scoring == nil || !scoring.IsActive {
+		return nil, nil
+	}
+
+	// every scored question counts in the max score, answered or not
+	rubric := scoring.Rubric.Data
+	questionIDs := make([]string, 0, len(rubric.Questions))
+	for _, q := range rubric.Questions {
+		questionIDs = append(questionIDs, q.QuestionID)
+	}
+	questions, err := uc.QuestionRepo.GetQuestionsByIDs(questionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	questionTypes := make(map[string]string, len(questions))
+	for _, q := range questions {
+		questionTypes[q.ID.String()] = q.QuestionType
+	}
+	answers := make(map[string]string, len(items))
+	for _, item := range items {
+		answers[item.QuestionID] = item.Answer
+	}
+
+	return computeScore(rubric, questionTypes, answers), nil
+}
+
+func computeScore(rubric entity.ScoringRubric, questionTypes map[string]string, answers map[string]string) *entity.SubmissionScore {
+	score := &entity.SubmissionScore{
+		Sections: make([]entity.SubmissionSectionScore, 0),
+		Items:    make([]entity.SubmissionScoreItem, 0, len(rubric.Questions)),
+	}
+	sectionIndex := make(map[string]int)
+
+	for _, q := range rubric.Questions {
+		weight := q.Weight
+		if weight == 0 {
+			weight = 1
+		}
+
+		points, maxPoints := questionPoints(q, questionTypes[q.QuestionID], answers[q.QuestionID])
+		item := entity.SubmissionScoreItem{
+			QuestionID: q.QuestionID,
+			Section:    q.Section,
+			Points:     roundScore(points * weight),
+			MaxPoints:  roundScore(maxPoints * weight),
+		}
+		score.Items = append(score.Items, item)
+		score.Total += item.Points
+		score.MaxScore += item.MaxPoints
+
+		if q.Section == "" {
+			continue
+		}
+		i, ok := sectionIndex[q.Section]
+		if !ok {
+			i = len(score.Sections)
+			sectionIndex[q.Section] = i
+			score.Sections = append(score.Sections, entity.SubmissionSectionScore{Section: q.Section})
+		}
+		score.Sections[i].Score += item.Points
+		score.Sections[i].MaxScore += item.MaxPoints
+	}
+
+	score.Total = roundScore(score.Total)
+	score.MaxScore = roundScore(score.MaxScore)
+	score.Level = scoringLevel(rubric.Levels, score.Total)
+	for i := range score.Sections {
+		s := &score.Sections[i]
+		s.Score = roundScore(s.Score)
+		s.MaxScore = roundScore(s.MaxScore)
+		s.Level = scoringLevel(rubric.SectionLevels[s.Section], s.Score)
+	}
+
+	return score
+}
+
+// questionPoints returns the unweighted points of an answer and the best points reachable on the question.
+func questionPoints(q entity.ScoringQuestion, questionType, answer string) (float64, float64) {
+	answer = strings.TrimSpace(answer)
+
+	switch questionType {
+	case value.GetStringValue(value.QuestionSingleChoice):
+		return q.Points[answer], choiceMaxPoints(q, false)
+
+	case value.GetStringValue(value.QuestionMultipleChoice):
+		var points float64
+		for _, option := range splitChoiceAnswer(answer) {
+			points += q.Points[option]
+		}
+		return points, choiceMaxPoints(q, true)
+
+	default:
+		// scale, number, button_count: an explicit mapping wins over the numeric value
+		maxPoints := q.MaxPoints
+		if maxPoints == 0 {
+			maxPoints = choiceMaxPoints(q, false)
+		}
+		if p, ok := q.Points[answer]; ok {
+			return p, maxPoints
+		}
+		n, err := strconv.ParseFloat(answer, 64)
+		if err != nil {
+			return 0, maxPoints
+		}
+		if q.MaxPoints > 0 && n > q.MaxPoints {
+			n = q.MaxPoints
+		}
+		return n, maxPoints
+	}
+}
+
+func choiceMaxPoints(q entity.ScoringQuestion, multiple bool) float64 {
+	if q.MaxPoints > 0 {
+		return q.MaxPoints
+	}
+	var best float64
+	for _, p := range q.Points {
+		if multiple {
+			if p > 0 {
+				best += p
+			}
+		} else if p > best {
+			best = p
+		}
+	}
+	return best
+}
+
+// splitChoiceAnswer reads a multiple choice answer sent either as a JSON array or as a comma separated list.
+func splitChoiceAnswer(answer string) []string {
+	if answer == "" {
+		return nil
+	}
+	var options []string
+	if err := json.Unmarshal([]byte(answer), &options); err == nil {
+		return options
+	}
+	parts := strings.Split(answer, ",")
+	options = make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			options = append(options, p)
+		}
+	}
+	return options
+}
+
+func scoringLevel(levels []entity.ScoringLevel, score float64) string {
+	label := ""
+	best := math.Inf(-1)
+	for _, l := range levels {
+		if score >= l.Min && l.Min > best {
+			best = l.Min
+			label = l.Label
+		}
+	}
+	return label
+}
+
+func validateScoringLevels(levels []entity.ScoringLevel) error {
+	mins := make([]float64, 0, len(levels))
+	for _, l := range levels {
+		if strings.TrimSpace(l.Label) == "" {
+			return errors.New("level label is required")
+		}
+		mins = append(mins, l.Min)
+	}
+	sort.Float64s(mins)
+	for i := 1; i < len(mins); i++ {
+		if mins[i] == mins[i-1] {
+			return fmt.Errorf("two levels start at %v", mins[i])
+		}
+	}
+	return nil
+}
+
+func roundScore(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func mapFormScoring(scoring *entity.FormScoring) *response.FormScoringResponse {
+	rubric := scoring.Rubric.Data
+	return &response.FormScoringResponse{
+		FormID:        scoring.FormID,
+		Questions:     rubric.Questions,
+		Levels:        rubric.Levels,
+		SectionLevels: rubric.SectionLevels,
+		IsActive:      scoring.IsActive,
+		UpdatedAt:     scoring.UpdatedAt,
+	}
+}
@@ -21,7 +21,7 @@ func (receiver *GetFormListUseCase) GetFormList(request request.GetFormListReque
 		formList = append(formList, response.GetFormListResponseData{
 			ID:          form.ID,
 			Spreadsheet: form.SpreadsheetUrl,
-			Password:    form.Password,
+			HasPassword: form.PasswordHash != "",
 			Note:        form.Note,
 			CreatedAt:   form.CreatedAt,
 			UpdatedAt:   form.UpdatedAt,
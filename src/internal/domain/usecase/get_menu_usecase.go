@@ -265,25 +265,33 @@ func (receiver *GetMenuUseCase) GetStudentMenu4App(ctx *gin.Context, studentID s
 		return nil, errors.New("student does not belong to parent")
 	}
 
-	studentMenu, err := receiver.StudentMenuUseCase.GetByStudentID(ctx, studentID, true)
+	// quyen da kiem tra o tren, cache chi chua menu cua student
+	studentMenu, err := studentMenuCache.Get(ctx, studentID, appLanguageKey(ctx), func() (*response.GetStudentMenuResponse, error) {
+		studentMenu, err := receiver.StudentMenuUseCase.GetByStudentID(ctx, studentID, true)
+		if err != nil {
+			return nil, err
+		}
+
+		// get menu icon key
+		img, _ := receiver.UserImageUsecase.GetImg4Ownewr(studentID, value.OwnerRoleStudent)
+
+		menuIconKey := ""
+		if img != nil {
+			menuIconKey = img.Key
+		}
+
+		studentMenu.MenuIconKey = menuIconKey
+		return studentMenu, nil
+	})
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get student menu: %w", err)
 	}
 
-	if len(studentMenu.Components) == 0 {
+	if studentMenu == nil || len(studentMenu.Components) == 0 {
 		return nil, errors.New("student menu not found")
 	}
 
-	// get menu icon key
-	img, _ := receiver.UserImageUsecase.GetImg4Ownewr(studentID, value.OwnerRoleStudent)
-
-	menuIconKey := ""
-	if img != nil {
-		menuIconKey = img.Key
-	}
-
-	studentMenu.MenuIconKey = menuIconKey
 	return studentMenu, nil
 }
 
@@ -487,7 +495,9 @@ func (receiver *GetMenuUseCase) GetDeviceMenuByOrg4Web(organizationID string) ([
 
 func (receiver *GetMenuUseCase) GetDeviceMenuByOrg4App(ctx *gin.Context, organizationID string) ([]menu.DeviceMenu, error) {
 	appLanguage, _ := ctx.Get("app_language")
-	return receiver.MenuRepository.GetDeviceMenuByOrgByLanguage(organizationID, appLanguage.(uint))
+	return orgDeviceMenuCache.Get(ctx, organizationID, appLanguageKey(ctx), func() ([]menu.DeviceMenu, error) {
+		return receiver.MenuRepository.GetDeviceMenuByOrgByLanguage(organizationID, appLanguage.(uint))
+	})
 }
 
 func (receiver *GetMenuUseCase) GetCommonMenu(ctx *gin.Context) response.GetCommonMenuResponse {
@@ -511,7 +521,7 @@ func (receiver *GetMenuUseCase) GetCommonMenuByUser(ctx *gin.Context) response.G
 	var componentMenus []response.ComponentCommonMenuByUser
 
 	userID := ctx.GetString("user_id")
-	children, _ := receiver.ChildRepository.GetByParentID(userID)
+	children, _ := receiver.ChildRepository.GetByGuardianUserID(userID)
 	students, _ := receiver.StudentAppRepo.GetByUserIDApproved(userID)
 	teachers, _ := receiver.TeacherRepository.GetByUserIDApproved(userID)
 
@@ -784,9 +794,15 @@ func (receiver *GetMenuUseCase) GetSectionMenu4WebAdmin(ctx *gin.Context) ([]res
 
 func (receiver *GetMenuUseCase) GetSectionMenu4App(context *gin.Context) ([]response.GetMenuSectionResponse, error) {
 	userID := context.GetString("user_id")
+	return sectionMenuCache.Get(context, userID, appLanguageKey(context), func() ([]response.GetMenuSectionResponse, error) {
+		return receiver.getSectionMenu4App(context, userID)
+	})
+}
+
+func (receiver *GetMenuUseCase) getSectionMenu4App(context *gin.Context, userID string) ([]response.GetMenuSectionResponse, error) {
 	var result []response.GetMenuSectionResponse
 	// Lay danh sach child, students, teachers, staffs by userId
-	children, _ := receiver.ChildRepository.GetByParentID(userID)
+	children, _ := receiver.ChildRepository.GetByGuardianUserID(userID)
 	// students, _ := receiver.StudentAppRepo.GetByUserIDApproved(userID)
 	teachers, _ := receiver.TeacherRepository.GetByUserIDApproved(userID)
 	staffs, _ := receiver.StaffApplicationRepo.GetByUserIDApproved(userID)
@@ -111,7 +111,7 @@ func (receiver *GetQuestionsByFormUseCase) GetQuestionByForm(form entity.SForm)
 	return &response.QuestionListResponse{
 		Data: response.QuestionListResponseData{
 			QuestionListData: result,
-			DecryptPassword:  form.Password,
+			PasswordRequired: form.PasswordHash != "",
 			FormName:         form.Name,
 			FormId:           form.ID,
 		},
@@ -166,7 +166,7 @@ func (receiver *GetQuestionsByFormUseCase) GetQuestionsByForm(form entity.SForm)
 	return &response.QuestionListResponse{
 		Data: response.QuestionListResponseData{
 			QuestionListData: result,
-			DecryptPassword:  form.Password,
+			PasswordRequired: form.PasswordHash != "",
 			FormName:         form.Name,
 		},
 	}
@@ -1,14 +1,251 @@
 package usecase
 
 import (
+	"errors"
+	"math/rand"
 	"sen-global-api/internal/data/repository"
 	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/value"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	redirectUrlDateLayout  = "2006-01-02"
+	redirectUrlClockLayout = "15:04"
+	defaultRedirectUrlZone = "Asia/Ho_Chi_Minh"
+)
+
+var (
+	ErrRedirectUrlNotFound   = errors.New("redirect url not found")
+	ErrRedirectUrlNotStarted = errors.New("this QR code is not active yet")
+	ErrRedirectUrlExpired    = errors.New("this QR code has expired")
+	ErrRedirectUrlExhausted  = errors.New("this QR code has reached its usage limit")
 )
 
 type GetRedirectUrlByQRCodeUseCase struct {
 	*repository.RedirectUrlRepository
+	ScanRepo    *repository.RedirectUrlScanRepository
+	DeviceRepo  *repository.DeviceRepository
+	AttemptRepo *repository.PasswordAttemptRepository
+	// AttendanceRepo holds the organization timezone the time-of-day rules are evaluated in.
+	AttendanceRepo *repository.AttendanceRepository
 }
 
 func (receiver *GetRedirectUrlByQRCodeUseCase) GetByQRCode(qrCode string) (*entity.SRedirectUrl, error) {
 	return receiver.RedirectUrlRepository.GetByQRCode(qrCode)
 }
+
+// RedirectUrlScanContext describes who scanned a QR code.
+type RedirectUrlScanContext struct {
+	DeviceID       string
+	OrganizationID string
+	UserAgent      string
+	AcceptLanguage string
+}
+
+// Resolve checks the validity window and the usage cap of the QR code, picks its target and logs the scan.
+// The returned url carries the resolved target in TargetUrl. A password protected code is not resolved:
+// its TargetUrl is left empty and the target is only given by ResolveWithPassword.
+func (receiver *GetRedirectUrlByQRCodeUseCase) Resolve(req request.GetRedirectUrlByQRCodeRequest, scanCtx RedirectUrlScanContext) (*entity.SRedirectUrl, error) {
+	url, err := receiver.RedirectUrlRepository.GetByQRCode(req.QRCode)
+	if err != nil {
+		return nil, err
+	}
+	if url.HashPassword != nil {
+		url.TargetUrl = ""
+		url.Targets = nil
+		return url, nil
+	}
+	return receiver.resolve(url, req, scanCtx)
+}
+
+// ResolveWithPassword resolves a password protected QR code once its password is verified.
+func (receiver *GetRedirectUrlByQRCodeUseCase) ResolveWithPassword(req request.VerifyRedirectUrlPasswordRequest, scanCtx RedirectUrlScanContext) (*entity.SRedirectUrl, error) {
+	url, err := receiver.RedirectUrlRepository.GetByQRCode(req.QRCode)
+	if err != nil {
+		return nil, err
+	}
+	if url.HashPassword != nil {
+		err = checkCodePassword(receiver.AttemptRepo, value.PasswordAttemptScopeRedirectUrl, url.QRCode, *url.HashPassword, req.Password)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return receiver.resolve(url, request.GetRedirectUrlByQRCodeRequest{
+		QRCode:         req.QRCode,
+		DeviceID:       req.DeviceID,
+		OrganizationID: req.OrganizationID,
+	}, scanCtx)
+}
+
+func (receiver *GetRedirectUrlByQRCodeUseCase) resolve(url *entity.SRedirectUrl, req request.GetRedirectUrlByQRCodeRequest, scanCtx RedirectUrlScanContext) (*entity.SRedirectUrl, error) {
+	now := time.Now()
+	scan := &entity.SRedirectUrlScan{
+		RedirectUrlID:  url.ID,
+		QRCode:         url.QRCode,
+		DeviceID:       req.DeviceID,
+		OrganizationID: receiver.scanOrganization(req.DeviceID, req.OrganizationID),
+		UserAgent:      truncate(scanCtx.UserAgent, 512),
+		Language:       primaryLanguage(scanCtx.AcceptLanguage),
+		Outcome:        value.RedirectUrlScanResolved,
+		ScannedAt:      now,
+	}
+	defer receiver.logScan(scan)
+
+	switch redirectUrlStatus(url, now) {
+	case value.RedirectUrlScanNotStarted:
+		scan.Outcome = value.RedirectUrlScanNotStarted
+		return nil, ErrRedirectUrlNotStarted
+	case value.RedirectUrlScanExpired:
+		scan.Outcome = value.RedirectUrlScanExpired
+		return nil, ErrRedirectUrlExpired
+	}
+
+	ok, err := receiver.RedirectUrlRepository.IncrementUseCount(url.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		scan.Outcome = value.RedirectUrlScanExhausted
+		return nil, ErrRedirectUrlExhausted
+	}
+	url.UseCount++
+
+	localNow := now.In(receiver.scanLocation(scan.OrganizationID))
+	if target := pickRedirectUrlTarget(url.Targets, scan.Language, scan.OrganizationID, localNow); target != nil {
+		url.TargetUrl = target.TargetUrl
+		scan.TargetID = &target.ID
+	}
+	scan.ResolvedUrl = url.TargetUrl
+
+	return url, nil
+}
+
+// scanOrganization trusts the given organization only when the device belongs to it.
+func (receiver *GetRedirectUrlByQRCodeUseCase) scanOrganization(deviceID, orgID string) string {
+	if deviceID == "" || receiver.DeviceRepo == nil {
+		return ""
+	}
+	if orgID != "" {
+		if inOrg, err := receiver.DeviceRepo.CheckDeviceExistInOrganization(deviceID, orgID); err == nil && inOrg {
+			return orgID
+		}
+	}
+	orgDevice, err := receiver.DeviceRepo.GetOrgByDeviceID(deviceID)
+	if err != nil || orgDevice == nil {
+		return ""
+	}
+	return orgDevice.OrganizationID.String()
+}
+
+// scanLocation returns the timezone of the scanning organization, Asia/Ho_Chi_Minh when it has none.
+func (receiver *GetRedirectUrlByQRCodeUseCase) scanLocation(orgID string) *time.Location {
+	zone := defaultRedirectUrlZone
+	if orgID != "" && receiver.AttendanceRepo != nil {
+		setting, err := receiver.AttendanceRepo.GetSettingByOrganizationID(orgID)
+		if err != nil {
+			log.Warnf("redirect url: load timezone of organization %s: %v", orgID, err)
+		} else if setting != nil && setting.Timezone != "" {
+			zone = setting.Timezone
+		}
+	}
+	if loc, err := time.LoadLocation(zone); err == nil {
+		return loc
+	}
+	// tzdata co the khong co tren container, mac dinh UTC+7
+	return time.FixedZone("ICT", 7*60*60)
+}
+
+func (receiver *GetRedirectUrlByQRCodeUseCase) logScan(scan *entity.SRedirectUrlScan) {
+	if receiver.ScanRepo == nil {
+		return
+	}
+	if err := receiver.ScanRepo.Create(scan); err != nil {
+		log.Error("GetRedirectUrlByQRCodeUseCase.logScan: ", err)
+	}
+}
+
+// redirectUrlStatus returns the outcome a scan of the url would have now, ignoring the usage cap race.
+func redirectUrlStatus(url *entity.SRedirectUrl, now time.Time) value.RedirectUrlScanOutcome {
+	if url.ValidFrom != nil && now.Before(*url.ValidFrom) {
+		return value.RedirectUrlScanNotStarted
+	}
+	if url.ValidUntil != nil && !now.Before(*url.ValidUntil) {
+		return value.RedirectUrlScanExpired
+	}
+	if url.MaxUses != nil && url.UseCount >= *url.MaxUses {
+		return value.RedirectUrlScanExhausted
+	}
+	return value.RedirectUrlScanResolved
+}
+
+// pickRedirectUrlTarget returns the first rule target matching the scan, else a weighted pick among the targets without rule.
+// now must already be in the organization's timezone.
+func pickRedirectUrlTarget(targets []entity.SRedirectUrlTarget, language, orgID string, now time.Time) *entity.SRedirectUrlTarget {
+	clock := now.Format(redirectUrlClockLayout)
+
+	weighted := make([]*entity.SRedirectUrlTarget, 0, len(targets))
+	var totalWeight uint
+	for i := range targets {
+		t := &targets[i]
+		if !t.HasRule() {
+			if t.Weight > 0 {
+				weighted = append(weighted, t)
+				totalWeight += t.Weight
+			}
+			continue
+		}
+		if t.Language != "" && !strings.EqualFold(t.Language, language) {
+			continue
+		}
+		if t.OrganizationID != "" && t.OrganizationID != orgID {
+			continue
+		}
+		if t.StartTime != "" && !clockInWindow(clock, t.StartTime, t.EndTime) {
+			continue
+		}
+		return t
+	}
+
+	if totalWeight == 0 {
+		return nil
+	}
+	n := uint(rand.Intn(int(totalWeight)))
+	for _, t := range weighted {
+		if n < t.Weight {
+			return t
+		}
+		n -= t.Weight
+	}
+	return nil
+}
+
+// clockInWindow compares "HH:MM" clocks, a window ending before it starts wraps over midnight.
+func clockInWindow(clock, start, end string) bool {
+	if start <= end {
+		return clock >= start && clock < end
+	}
+	return clock >= start || clock < end
+}
+
+// primaryLanguage returns the primary tag of the preferred language of an Accept-Language header, e.g. "vi" for "vi-VN,vi;q=0.9".
+func primaryLanguage(acceptLanguage string) string {
+	first := strings.TrimSpace(strings.Split(acceptLanguage, ",")[0])
+	first = strings.TrimSpace(strings.Split(first, ";")[0])
+	first = strings.Split(first, "-")[0]
+	if first == "*" {
+		return ""
+	}
+	return truncate(strings.ToLower(first), 16)
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max]
+}
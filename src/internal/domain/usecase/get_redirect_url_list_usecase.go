@@ -19,14 +19,17 @@ func (receiver *GetRedirectUrlListUseCase) GetList(req request.GetRedirectUrlLis
 	var urlListResponseData []response.GetRedirectUrlListResponseData
 	for _, url := range redirectUrls {
 		urlListResponseData = append(urlListResponseData, response.GetRedirectUrlListResponseData{
-			ID:           url.ID,
-			QRCode:       url.QRCode,
-			TargetUrl:    url.TargetUrl,
-			Password:     url.Password,
-			Hint:         url.Hint,
-			HashPassword: url.HashPassword,
-			CreatedAt:    url.CreatedAt,
-			UpdatedAt:    url.UpdatedAt,
+			ID:          url.ID,
+			QRCode:      url.QRCode,
+			TargetUrl:   url.TargetUrl,
+			HasPassword: url.HashPassword != nil,
+			Hint:        url.Hint,
+			ValidFrom:   url.ValidFrom,
+			ValidUntil:  url.ValidUntil,
+			MaxUses:     url.MaxUses,
+			UseCount:    url.UseCount,
+			CreatedAt:   url.CreatedAt,
+			UpdatedAt:   url.UpdatedAt,
 		})
 	}
 
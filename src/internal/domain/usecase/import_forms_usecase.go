@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -13,6 +14,7 @@ import (
 	"sen-global-api/internal/domain/request"
 	"sen-global-api/internal/domain/value"
 	"sen-global-api/pkg/job"
+	"sen-global-api/pkg/logger"
 	"sen-global-api/pkg/monitor"
 	"sen-global-api/pkg/sheet"
 	"strconv"
@@ -45,6 +47,23 @@ type ImportFormsUseCase struct {
 	DefaultCronJobIntervalInMinutes uint8
 	TimeMachine                     *job.TimeMachine
 	config.AppConfig
+	ctx context.Context
+}
+
+// WithContext returns a copy of the use case whose sheet calls and logs carry ctx, e.g. the request id of the request.
+func (receiver ImportFormsUseCase) WithContext(ctx context.Context) *ImportFormsUseCase {
+	receiver.ctx = ctx
+	if receiver.SpreadsheetReader != nil {
+		receiver.SpreadsheetReader = receiver.SpreadsheetReader.WithContext(ctx)
+	}
+	if receiver.SpreadsheetWriter != nil {
+		receiver.SpreadsheetWriter = receiver.SpreadsheetWriter.WithContext(ctx)
+	}
+	return &receiver
+}
+
+func (receiver *ImportFormsUseCase) logEntry() *log.Entry {
+	return logger.FromContext(receiver.ctx)
 }
 
 func (receiver *ImportFormsUseCase) SyncForms(req request.ImportFormRequest) error {
@@ -64,7 +83,7 @@ func (receiver *ImportFormsUseCase) SyncForms(req request.ImportFormRequest) err
 	sheets, err := receiver.SpreadsheetReader.GetSheets(spreadsheetID)
 
 	if err != nil {
-		log.Error(err)
+		receiver.logEntry().Error(err)
 		return err
 	}
 
@@ -81,7 +100,7 @@ func (receiver *ImportFormsUseCase) SyncForms(req request.ImportFormRequest) err
 			ReadRange:     sheetName + `!` + receiver.Google.FirstColumn + strconv.Itoa(receiver.Google.FirstRow+2) + `:AC`,
 		})
 		if err != nil {
-			log.Error(err)
+			receiver.logEntry().Error(err)
 			return err
 		}
 		for rowNo, row := range values {
@@ -94,7 +113,7 @@ func (receiver *ImportFormsUseCase) SyncForms(req request.ImportFormRequest) err
 				case value.ImportSpreadsheetStatusDeleted:
 					err = receiver.FormRepository.DeleteFormByNote(row[0].(string))
 					if err != nil {
-						log.Error(err)
+						receiver.logEntry().Error(err)
 					} else {
 						_, err = receiver.SpreadsheetWriter.UpdateRange(sheet.WriteRangeParams{
 							Range:     sheetName + "!O" + strconv.Itoa(rowNo+receiver.Google.FirstRow+2) + ":Q",
@@ -102,14 +121,14 @@ func (receiver *ImportFormsUseCase) SyncForms(req request.ImportFormRequest) err
 							Rows:      [][]interface{}{{"DELETED", time.Now().Format("2006-01-02 15:04:05"), ""}},
 						}, spreadsheetID)
 						if err != nil {
-							log.Debug("Row No: ", rowNo)
-							log.Error(err)
+							receiver.logEntry().Debug("Row No: ", rowNo)
+							receiver.logEntry().Error(err)
 						}
 					}
 				case value.ImportSpreadsheetStatusDeactivate:
 					err = receiver.FormRepository.DeleteFormByNote(row[0].(string))
 					if err != nil {
-						log.Error(err)
+						receiver.logEntry().Error(err)
 					} else {
 						_, err = receiver.SpreadsheetWriter.UpdateRange(sheet.WriteRangeParams{
 							Range:     sheetName + "!O" + strconv.Itoa(rowNo+receiver.Google.FirstRow+2) + ":Q",
@@ -117,8 +136,8 @@ func (receiver *ImportFormsUseCase) SyncForms(req request.ImportFormRequest) err
 							Rows:      [][]interface{}{{"DEACTIVATED", time.Now().Format("2006-01-02 15:04:05"), ""}},
 						}, spreadsheetID)
 						if err != nil {
-							log.Debug("Row No: ", rowNo)
-							log.Error(err)
+							receiver.logEntry().Debug("Row No: ", rowNo)
+							receiver.logEntry().Error(err)
 						}
 					}
 				case value.ImportSpreadsheetStatusPending:
@@ -147,7 +166,7 @@ func (receiver *ImportFormsUseCase) SyncForms(req request.ImportFormRequest) err
 					}
 					reason, importErr := receiver.importForm(row[0].(string), row[1].(string), row[2].(string), tabName)
 					if importErr != nil {
-						log.Error(importErr)
+						receiver.logEntry().Error(importErr)
 						monitor.LogGoogleAPIRequestImportForm()
 						_, err = receiver.SpreadsheetWriter.UpdateRange(sheet.WriteRangeParams{
 							Range:     sheetName + "!O" + strconv.Itoa(rowNo+receiver.Google.FirstRow+2) + ":Q",
@@ -155,8 +174,8 @@ func (receiver *ImportFormsUseCase) SyncForms(req request.ImportFormRequest) err
 							Rows:      [][]interface{}{{"UPLOADED", time.Now().Format("2006-01-02 15:04:05"), reason}},
 						}, spreadsheetID)
 						if err != nil {
-							log.Debug("Row No: ", rowNo)
-							log.Error(err)
+							receiver.logEntry().Debug("Row No: ", rowNo)
+							receiver.logEntry().Error(err)
 						}
 					} else {
 						monitor.LogGoogleAPIRequestImportForm()
@@ -166,8 +185,8 @@ func (receiver *ImportFormsUseCase) SyncForms(req request.ImportFormRequest) err
 							Rows:      [][]interface{}{{"UPLOADED", time.Now().Format("2006-01-02 15:04:05"), reason}},
 						}, spreadsheetID)
 						if err != nil {
-							log.Debug("Row No: ", rowNo)
-							log.Error(err)
+							receiver.logEntry().Debug("Row No: ", rowNo)
+							receiver.logEntry().Error(err)
 						}
 					}
 				}
@@ -225,7 +244,7 @@ func (receiver *ImportFormsUseCase) ImportForms(req request.ImportFormRequest, u
 	sheets, err := receiver.SpreadsheetReader.GetSheets(spreadsheetID)
 
 	if err != nil {
-		log.Error(err)
+		receiver.logEntry().Error(err)
 		return err
 	}
 
@@ -242,7 +261,7 @@ func (receiver *ImportFormsUseCase) ImportForms(req request.ImportFormRequest, u
 			ReadRange:     sheetName + `!` + receiver.Google.FirstColumn + strconv.Itoa(receiver.Google.FirstRow+2) + `:AC`,
 		})
 		if err != nil {
-			log.Error(err)
+			receiver.logEntry().Error(err)
 			return err
 		}
 		for rowNo, row := range values {
@@ -255,7 +274,7 @@ func (receiver *ImportFormsUseCase) ImportForms(req request.ImportFormRequest, u
 				case value.ImportSpreadsheetStatusDeleted:
 					err = receiver.FormRepository.DeleteFormByNote(row[0].(string))
 					if err != nil {
-						log.Error(err)
+						receiver.logEntry().Error(err)
 					} else {
 						_, err = receiver.SpreadsheetWriter.UpdateRange(sheet.WriteRangeParams{
 							Range:     sheetName + "!O" + strconv.Itoa(rowNo+receiver.Google.FirstRow+2) + ":Q",
@@ -263,14 +282,14 @@ func (receiver *ImportFormsUseCase) ImportForms(req request.ImportFormRequest, u
 							Rows:      [][]interface{}{{"DELETED", time.Now().Format("2006-01-02 15:04:05"), ""}},
 						}, spreadsheetID)
 						if err != nil {
-							log.Debug("Row No: ", rowNo)
-							log.Error(err)
+							receiver.logEntry().Debug("Row No: ", rowNo)
+							receiver.logEntry().Error(err)
 						}
 					}
 				case value.ImportSpreadsheetStatusDeactivate:
 					err = receiver.FormRepository.DeleteFormByNote(row[0].(string))
 					if err != nil {
-						log.Error(err)
+						receiver.logEntry().Error(err)
 					} else {
 						_, err = receiver.SpreadsheetWriter.UpdateRange(sheet.WriteRangeParams{
 							Range:     sheetName + "!O" + strconv.Itoa(rowNo+receiver.Google.FirstRow+2) + ":Q",
@@ -278,8 +297,8 @@ func (receiver *ImportFormsUseCase) ImportForms(req request.ImportFormRequest, u
 							Rows:      [][]interface{}{{"DEACTIVATED", time.Now().Format("2006-01-02 15:04:05"), ""}},
 						}, spreadsheetID)
 						if err != nil {
-							log.Debug("Row No: ", rowNo)
-							log.Error(err)
+							receiver.logEntry().Debug("Row No: ", rowNo)
+							receiver.logEntry().Error(err)
 						}
 					}
 				case value.ImportSpreadsheetStatusPending:
@@ -308,7 +327,7 @@ func (receiver *ImportFormsUseCase) ImportForms(req request.ImportFormRequest, u
 					}
 					reason, importErr := receiver.importForm(row[0].(string), row[1].(string), row[2].(string), tabName)
 					if importErr != nil {
-						log.Error(importErr)
+						receiver.logEntry().Error(importErr)
 						monitor.LogGoogleAPIRequestImportForm()
 						_, err = receiver.SpreadsheetWriter.UpdateRange(sheet.WriteRangeParams{
 							Range:     sheetName + "!O" + strconv.Itoa(rowNo+receiver.Google.FirstRow+2) + ":Q",
@@ -316,8 +335,8 @@ func (receiver *ImportFormsUseCase) ImportForms(req request.ImportFormRequest, u
 							Rows:      [][]interface{}{{"UPLOADED", time.Now().Format("2006-01-02 15:04:05"), reason}},
 						}, spreadsheetID)
 						if err != nil {
-							log.Debug("Row No: ", rowNo)
-							log.Error(err)
+							receiver.logEntry().Debug("Row No: ", rowNo)
+							receiver.logEntry().Error(err)
 						}
 					} else {
 						monitor.LogGoogleAPIRequestImportForm()
@@ -327,8 +346,8 @@ func (receiver *ImportFormsUseCase) ImportForms(req request.ImportFormRequest, u
 							Rows:      [][]interface{}{{"UPLOADED", time.Now().Format("2006-01-02 15:04:05"), reason}},
 						}, spreadsheetID)
 						if err != nil {
-							log.Debug("Row No: ", rowNo)
-							log.Error(err)
+							receiver.logEntry().Debug("Row No: ", rowNo)
+							receiver.logEntry().Error(err)
 						}
 					}
 				}
@@ -351,7 +370,7 @@ func (receiver *ImportFormsUseCase) ImportForms(req request.ImportFormRequest, u
 	case FormsUploaderIndexFourth:
 		receiver.TimeMachine.ScheduleSyncForms4(interval)
 	case FormsUploaderIndexFifth:
-		log.Error("FormsUploaderIndexFifth must not sync here")
+		receiver.logEntry().Error("FormsUploaderIndexFifth must not sync here")
 	}
 
 	return nil
@@ -369,7 +388,7 @@ func (receiver *ImportFormsUseCase) importSignUpForms(req request.ImportFormRequ
 		ReadRange:     "Forms" + `!K11:P`,
 	})
 	if err != nil || values == nil {
-		log.Error(err)
+		receiver.logEntry().Error(err)
 		return errors.New("Error reading sign up forms spreadsheet: " + req.SpreadsheetUrl + " - " + err.Error())
 	}
 
@@ -422,7 +441,7 @@ func (receiver *ImportFormsUseCase) importSignUpForm(spreadsheetUrl, note, sheet
 	match := re.FindStringSubmatch(spreadsheetUrl)
 
 	if len(match) < 2 {
-		log.Error("Import Sign Up Form Invalid spreadsheet url: ", spreadsheetUrl)
+		receiver.logEntry().Error("Import Sign Up Form Invalid spreadsheet url: ", spreadsheetUrl)
 		return entity.SForm{}, fmt.Errorf("import sign up form invalid spreadsheet url: %s", spreadsheetUrl)
 	}
 
@@ -433,7 +452,7 @@ func (receiver *ImportFormsUseCase) importSignUpForm(spreadsheetUrl, note, sheet
 		ReadRange:     sheetNameToRead + `!J11` + `:Q`,
 	})
 	if err != nil {
-		log.Error(fmt.Sprintf("Error reading spreadsheet: %s - note : %s", err.Error(), note))
+		receiver.logEntry().Error(fmt.Sprintf("Error reading spreadsheet: %s - note : %s", err.Error(), note))
 		return entity.SForm{}, err
 	}
 
@@ -491,11 +510,11 @@ func (receiver *ImportFormsUseCase) importSignUpForm(spreadsheetUrl, note, sheet
 	})
 
 	if err != nil {
-		log.Error(fmt.Sprintf("Error creating form: %s - note : %s", err.Error(), note))
+		receiver.logEntry().Error(fmt.Sprintf("Error creating form: %s - note : %s", err.Error(), note))
 		return entity.SForm{}, err
 	}
 
-	log.Warning(msg)
+	receiver.logEntry().Warning(msg)
 
 	return *f, nil
 }
@@ -519,7 +538,7 @@ func (receiver *ImportFormsUseCase) importForm(code string, spreadsheetUrl strin
 		ReadRange:     sheetNameToRead + `!I11:Q`,
 	})
 	if err != nil || values == nil {
-		log.Error(err)
+		receiver.logEntry().Error(err)
 		return fmt.Sprintf("Cannot read tab %s from %s ERROR %s", sheetNameToRead, spreadsheetUrl, err.Error()), err
 	}
 
@@ -627,7 +646,7 @@ func (receiver *ImportFormsUseCase) saveQuestions(rawQuestions []parameters.RawQ
 	for i, rawQuestion := range rawQuestions {
 		questionType, err := value.GetQuestionType(rawQuestion.Type)
 		if err != nil {
-			log.Info(fmt.Sprintf("Invalid question type: %s - %v", rawQuestion.Type, rawQuestion))
+			receiver.logEntry().Info(fmt.Sprintf("Invalid question type: %s - %v", rawQuestion.Type, rawQuestion))
 			invalidQuestions = append(invalidQuestions, InvalidQuestionRow{
 				RowNumber: i + 2,
 				Reason:    fmt.Sprintf("Invalid question type: %s - %v", rawQuestion.Type, rawQuestion),
@@ -950,7 +969,7 @@ func (receiver *ImportFormsUseCase) ImportFormsPartially(url string, sheetName s
 		ReadRange:     sheetName + `!` + receiver.Google.FirstColumn + strconv.Itoa(receiver.Google.FirstRow+2) + `:AC`,
 	})
 	if err != nil {
-		log.Error(err)
+		receiver.logEntry().Error(err)
 		return fmt.Errorf("error reading spreadsheet: %w", err)
 	}
 	for rowNo, row := range values {
@@ -963,7 +982,7 @@ func (receiver *ImportFormsUseCase) ImportFormsPartially(url string, sheetName s
 			case value.ImportSpreadsheetStatusDeleted:
 				err = receiver.FormRepository.DeleteFormByNote(row[0].(string))
 				if err != nil {
-					log.Error(err)
+					receiver.logEntry().Error(err)
 				} else {
 					_, err = receiver.SpreadsheetWriter.UpdateRange(sheet.WriteRangeParams{
 						Range:     sheetName + "!O" + strconv.Itoa(rowNo+receiver.Google.FirstRow+2) + ":Q",
@@ -971,14 +990,14 @@ func (receiver *ImportFormsUseCase) ImportFormsPartially(url string, sheetName s
 						Rows:      [][]interface{}{{"DELETED", time.Now().Format("2006-01-02 15:04:05"), ""}},
 					}, spreadsheetID)
 					if err != nil {
-						log.Debug("Row No: ", rowNo)
-						log.Error(err)
+						receiver.logEntry().Debug("Row No: ", rowNo)
+						receiver.logEntry().Error(err)
 					}
 				}
 			case value.ImportSpreadsheetStatusDeactivate:
 				err = receiver.FormRepository.DeleteFormByNote(row[0].(string))
 				if err != nil {
-					log.Error(err)
+					receiver.logEntry().Error(err)
 				} else {
 					_, err = receiver.SpreadsheetWriter.UpdateRange(sheet.WriteRangeParams{
 						Range:     sheetName + "!O" + strconv.Itoa(rowNo+receiver.Google.FirstRow+2) + ":Q",
@@ -986,8 +1005,8 @@ func (receiver *ImportFormsUseCase) ImportFormsPartially(url string, sheetName s
 						Rows:      [][]interface{}{{"DEACTIVATED", time.Now().Format("2006-01-02 15:04:05"), ""}},
 					}, spreadsheetID)
 					if err != nil {
-						log.Debug("Row No: ", rowNo)
-						log.Error(err)
+						receiver.logEntry().Debug("Row No: ", rowNo)
+						receiver.logEntry().Error(err)
 					}
 				}
 			case value.ImportSpreadsheetStatusPending:
@@ -1030,7 +1049,7 @@ func (receiver *ImportFormsUseCase) ImportFormsPartially(url string, sheetName s
 				// }
 				// importErr, reason := receiver.importForm(row[0].(string), row[1].(string), row[2].(string), row[3].(string), submissionType, submissionSheetID, tabName, outputSheetName, syncStrategy)
 				// if importErr != nil {
-				// 	log.Error(importErr)
+				// 	receiver.logEntry().Error(importErr)
 				// 	monitor.LogGoogleAPIRequestImportForm()
 				// 	_, err = receiver.SpreadsheetWriter.UpdateRange(sheet.WriteRangeParams{
 				// 		Range:     sheetName + "!O" + strconv.Itoa(rowNo+receiver.AppConfig.Google.FirstRow+2) + ":Q",
@@ -1038,8 +1057,8 @@ func (receiver *ImportFormsUseCase) ImportFormsPartially(url string, sheetName s
 				// 		Rows:      [][]interface{}{{"UPLOADED", time.Now().Format("2006-01-02 15:04:05"), reason}},
 				// 	}, spreadsheetID)
 				// 	if err != nil {
-				// 		log.Debug("Row No: ", rowNo)
-				// 		log.Error(err)
+				// 		receiver.logEntry().Debug("Row No: ", rowNo)
+				// 		receiver.logEntry().Error(err)
 				// 	}
 				// } else {
 				// 	monitor.LogGoogleAPIRequestImportForm()
@@ -1049,8 +1068,8 @@ func (receiver *ImportFormsUseCase) ImportFormsPartially(url string, sheetName s
 				// 		Rows:      [][]interface{}{{"UPLOADED", time.Now().Format("2006-01-02 15:04:05"), reason}},
 				// 	}, spreadsheetID)
 				// 	if err != nil {
-				// 		log.Debug("Row No: ", rowNo)
-				// 		log.Error(err)
+				// 		receiver.logEntry().Debug("Row No: ", rowNo)
+				// 		receiver.logEntry().Error(err)
 				// 	}
 				// }
 			}
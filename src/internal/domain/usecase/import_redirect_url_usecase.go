@@ -7,6 +7,7 @@ import (
 	"sen-global-api/internal/domain/request"
 	"sen-global-api/pkg/job"
 	"sen-global-api/pkg/monitor"
+	"sen-global-api/pkg/passwordhash"
 	"sen-global-api/pkg/sheet"
 	"strconv"
 	"strings"
@@ -46,7 +47,7 @@ func (receiver *ImportRedirectUrlsUseCase) SyncUrls(req request.ImportRedirectUr
 	}
 	for rowNo, row := range values {
 		if len(row) >= 5 && cap(row) >= 5 {
-			importErr := receiver.RedirectUrlRepository.SaveRedirectUrl(row[1].(string), row[2].(string), row[3].(string), row[4].(string), "", nil)
+			importErr := receiver.RedirectUrlRepository.SaveRedirectUrl(row[1].(string), row[2].(string), row[4].(string), "", importedPasswordHash(row[3].(string), ""))
 			if importErr != nil {
 				log.Error(importErr)
 			} else {
@@ -98,12 +99,11 @@ func (receiver *ImportRedirectUrlsUseCase) Import(req request.ImportRedirectUrls
 			if len(row) > 8 {
 				hint = row[8].(string)
 			}
-			var hashPwd *string
+			sheetHash := ""
 			if len(row) > 9 {
-				hash := row[9].(string)
-				hashPwd = &hash
+				sheetHash = row[9].(string)
 			}
-			importErr := receiver.RedirectUrlRepository.SaveRedirectUrl(row[1].(string), row[3].(string), row[2].(string), row[4].(string), hint, hashPwd)
+			importErr := receiver.RedirectUrlRepository.SaveRedirectUrl(row[1].(string), row[3].(string), row[4].(string), hint, importedPasswordHash(row[2].(string), sheetHash))
 			if importErr != nil {
 				log.Errorf("ROW NO %d: %v", rowNo, importErr)
 			} else {
@@ -152,12 +152,11 @@ func (receiver *ImportRedirectUrlsUseCase) ImportPartially(url string, sheetName
 			if len(row) > 8 {
 				hint = row[8].(string)
 			}
-			var hashPwd *string
+			sheetHash := ""
 			if len(row) > 9 {
-				hash := row[9].(string)
-				hashPwd = &hash
+				sheetHash = row[9].(string)
 			}
-			importErr := receiver.RedirectUrlRepository.SaveRedirectUrl(row[1].(string), row[2].(string), row[3].(string), row[4].(string), hint, hashPwd)
+			importErr := receiver.RedirectUrlRepository.SaveRedirectUrl(row[1].(string), row[2].(string), row[4].(string), hint, importedPasswordHash(row[3].(string), sheetHash))
 			if importErr != nil {
 				log.Error(importErr)
 			} else {
@@ -176,3 +175,24 @@ func (receiver *ImportRedirectUrlsUseCase) ImportPartially(url string, sheetName
 
 	return nil
 }
+
+// importedPasswordHash hashes the password read from the sheet; a sheet already holding a bcrypt hash is taken as is.
+func importedPasswordHash(password string, sheetHash string) *string {
+	password = strings.TrimSpace(password)
+	if password == "" {
+		if passwordhash.IsHash(sheetHash) {
+			return &sheetHash
+		}
+		return nil
+	}
+	if passwordhash.IsHash(password) {
+		return &password
+	}
+
+	hashed, err := passwordhash.Hash(password)
+	if err != nil {
+		log.Error("importedPasswordHash: ", err)
+		return nil
+	}
+	return &hashed
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"sen-global-api/internal/domain/response"
+	"sen-global-api/pkg/lifecycle"
 	"strings"
 	"time"
 
@@ -45,9 +46,10 @@ func BackupDatabase() gin.HandlerFunc {
 			return
 		}
 
-		defer func() {
+		// chay nen, shutdown se doi backup xong trong deadline
+		lifecycle.Default().Go("database_backup", func(context.Context) {
 			Backup()
-		}()
+		})
 
 		c.JSON(200, response.SucceedResponse{
 			Code:    200,
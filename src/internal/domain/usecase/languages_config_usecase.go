@@ -57,6 +57,7 @@ func (uc *LanguagesConfigUsecase) UploadLanguagesConfig(
 		if err := uc.Repo.Update(ctx, existing); err != nil {
 			return err
 		}
+		languagesConfigCache.Invalidate(ctx, languagesConfigScope(ownerID, ownerRole))
 		return nil
 	}
 
@@ -73,6 +74,7 @@ func (uc *LanguagesConfigUsecase) UploadLanguagesConfig(
 	if err := uc.Repo.Create(ctx, newLC); err != nil {
 		return err
 	}
+	languagesConfigCache.Invalidate(ctx, languagesConfigScope(ownerID, ownerRole))
 	return nil
 }
 
@@ -83,21 +85,23 @@ func (uc *LanguagesConfigUsecase) GetLanguagesConfigByID(ctx context.Context, id
 
 // GetLanguagesConfigByOwner - Lấy theo OwnerID & OwnerType
 func (uc *LanguagesConfigUsecase) GetLanguagesConfigByOwner(ctx context.Context, ownerID string, ownerRole value.OwnerRole4LangConfig) (*response.LanguagesConfigResponse, error) {
-
-	lc, err := uc.Repo.GetByOwner(ctx, ownerID, ownerRole)
-	if err != nil {
-		return nil, err
-	}
-	return mapper.ToLanguagesConfigResponse(lc), nil
+	return languagesConfigCache.Get(ctx, languagesConfigScope(ownerID, ownerRole), "config", func() (*response.LanguagesConfigResponse, error) {
+		lc, err := uc.Repo.GetByOwner(ctx, ownerID, ownerRole)
+		if err != nil {
+			return nil, err
+		}
+		return mapper.ToLanguagesConfigResponse(lc), nil
+	})
 }
 
 func (uc *LanguagesConfigUsecase) GetLanguagesConfigByOwnerNoCtx(ownerID string, ownerRole value.OwnerRole4LangConfig) (*response.LanguagesConfigResponse, error) {
-
-	lc, err := uc.Repo.GetByOwnerNoCtx(ownerID, ownerRole)
-	if err != nil {
-		return nil, err
-	}
-	return mapper.ToLanguagesConfigResponse(lc), nil
+	return languagesConfigCache.Get(context.Background(), languagesConfigScope(ownerID, ownerRole), "config", func() (*response.LanguagesConfigResponse, error) {
+		lc, err := uc.Repo.GetByOwnerNoCtx(ownerID, ownerRole)
+		if err != nil {
+			return nil, err
+		}
+		return mapper.ToLanguagesConfigResponse(lc), nil
+	})
 }
 
 // UpdateLanguagesConfig - Cập nhật
@@ -106,12 +110,36 @@ func (uc *LanguagesConfigUsecase) UpdateLanguagesConfig(ctx context.Context, lc
 		return errors.New("ID không được rỗng khi update")
 	}
 	lc.UpdatedAt = time.Now()
-	return uc.Repo.Update(ctx, lc)
+	if err := uc.Repo.Update(ctx, lc); err != nil {
+		return err
+	}
+	uc.invalidateLanguagesConfig(ctx, lc.ID.String())
+	return nil
 }
 
 // DeleteLanguagesConfig - Xoá
 func (uc *LanguagesConfigUsecase) DeleteLanguagesConfig(ctx context.Context, id string) error {
-	return uc.Repo.Delete(ctx, id)
+	// lay owner truoc khi xoa de invalidate cache
+	existing, _ := uc.Repo.GetByID(ctx, id)
+	if err := uc.Repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	if existing != nil {
+		languagesConfigCache.Invalidate(ctx, languagesConfigScope(existing.OwnerID, existing.OwnerRole))
+	}
+	return nil
+}
+
+func (uc *LanguagesConfigUsecase) invalidateLanguagesConfig(ctx context.Context, id string) {
+	lc, err := uc.Repo.GetByID(ctx, id)
+	if err != nil || lc == nil {
+		return
+	}
+	languagesConfigCache.Invalidate(ctx, languagesConfigScope(lc.OwnerID, lc.OwnerRole))
+}
+
+func languagesConfigScope(ownerID string, ownerRole value.OwnerRole4LangConfig) string {
+	return string(ownerRole) + ":" + ownerID
 }
 
 func (uc *LanguagesConfigUsecase) GetStudentStudyLangConfig(ctx context.Context, studentID string) (*response.LanguagesConfigResponse, error) {
@@ -21,6 +21,10 @@ func (receiver *OrgDeviceRegistrationUseCase) RegisterOrgDevice(req request.Regi
 		return err
 	}
 
+	if err := receiver.CheckOrgDeviceLimitation(req.OrgID); err != nil {
+		return err
+	}
+
 	_, err = receiver.RegisteringDeviceForOrg(org, request.RegisterDeviceRequest{
 		DeviceUUID: req.DeviceID,
 		InputMode:  string(value.InfoInputTypeBarcode),
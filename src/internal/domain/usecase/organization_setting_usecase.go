@@ -11,10 +11,10 @@ import (
 	"sen-global-api/internal/domain/mapper"
 	"sen-global-api/internal/domain/request"
 	"sen-global-api/internal/domain/response"
-	"sen-global-api/internal/firebase"
+	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/realtime"
 	"time"
 
-	"cloud.google.com/go/firestore"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
@@ -28,6 +28,7 @@ type OrganizationSettingUsecase struct {
 	OrganizationRepo            *repository.OrganizationRepository
 	OrganizationSettingMenuRepo *repository.OrganizationSettingMenuRepository
 	LanguageSettingRepo         *repository.LanguageSettingRepository
+	DeviceModeProfileUseCase    *DeviceModeProfileUseCase
 }
 
 func NewOrganizationSettingUsecase(repo *repository.OrganizationSettingRepository) *OrganizationSettingUsecase {
@@ -46,12 +47,23 @@ func (u *OrganizationSettingUsecase) GetOrganizationSettingByID(id uint) (*entit
 
 // UpdateOrganizationSetting cập nhật
 func (u *OrganizationSettingUsecase) UpdateOrganizationSetting(setting *entity.OrganizationSetting) error {
-	return u.Repo.Update(setting)
+	if err := u.Repo.Update(setting); err != nil {
+		return err
+	}
+	orgSettingCache.Invalidate(context.Background(), setting.DeviceID)
+	return nil
 }
 
 // DeleteOrganizationSetting xóa theo ID
 func (u *OrganizationSettingUsecase) DeleteOrganizationSetting(id uint) error {
-	return u.Repo.Delete(id)
+	setting, _ := u.Repo.GetByID(id)
+	if err := u.Repo.Delete(id); err != nil {
+		return err
+	}
+	if setting != nil {
+		orgSettingCache.Invalidate(context.Background(), setting.DeviceID)
+	}
+	return nil
 }
 
 // ListOrganizationSettings lấy tất cả
@@ -178,13 +190,16 @@ func (u *OrganizationSettingUsecase) UploadOrgSetting(req request.UploadOrgSetti
 	if err := u.UploadOrganizationSettingMenu(u.Repo.DBConn, orgSetting.ID.String(), req.Component); err != nil {
 		return fmt.Errorf("upload organization setting menu fail: %w", err)
 	}
+	orgSettingCache.Invalidate(context.Background(), req.DeviceID)
 
-	// Push lên Firestore sau khi commit thành công
+	// Push realtime cho device sau khi commit thành công
 	// get setting by device id
-	settingFirestore, _ := u.GetOrgSetting(req.DeviceID)
-	if err := u.pushToFirestore(settingFirestore); err != nil {
-		// Nếu push Firestore fail thì log lại nhưng không rollback DB
-		log.Printf("pushToFirestore error: %v", err)
+	deviceSetting, _ := u.GetOrgSetting(req.DeviceID)
+	// event khong chua mat khau top menu, device doc lai qua API settings
+	deviceSetting.TopMenuPassword = ""
+	if err := realtime.Publish(context.Background(), realtime.DeviceChannel(req.DeviceID), string(value.RealtimeEventSettingsChanged), deviceSetting); err != nil {
+		// Nếu push fail thì log lại nhưng không rollback DB
+		log.Printf("publish settings changed error: %v", err)
 		return err
 	}
 
@@ -209,6 +224,12 @@ func (u *OrganizationSettingUsecase) GetOrgSetting(deviceID string) (response.Or
 }
 
 func (u *OrganizationSettingUsecase) GetOrgSetting4App(ctx *gin.Context, deviceID string) (response.OrgSettingResponse, error) {
+	return orgSettingCache.Get(ctx, deviceID, appLanguageKey(ctx), func() (response.OrgSettingResponse, error) {
+		return u.getOrgSetting4App(ctx, deviceID)
+	})
+}
+
+func (u *OrganizationSettingUsecase) getOrgSetting4App(ctx *gin.Context, deviceID string) (response.OrgSettingResponse, error) {
 	// Lấy thông tin OrgSetting
 	orgSetting, err := u.Repo.GetByDeviceID(deviceID)
 	if err != nil {
@@ -231,6 +252,13 @@ func (u *OrganizationSettingUsecase) GetOrgSetting4App(ctx *gin.Context, deviceI
 	orgInfo, _ := u.OrganizationRepo.GetByID(orgSetting.OrganizationID)
 	resp := mapper.MapOrgSettingToResponse(orgSetting, component, orgInfo.OrganizationName)
 
+	if u.DeviceModeProfileUseCase != nil {
+		resp.ModeProfile, err = u.DeviceModeProfileUseCase.GetDeviceProfile(ctx, deviceID)
+		if err != nil {
+			log.Warnf("OrganizationSettingUsecase: mode profile of device %s: %v", deviceID, err)
+		}
+	}
+
 	return resp, nil
 }
 
@@ -284,34 +312,6 @@ func (u *OrganizationSettingUsecase) GetOrgSetting4Web(deviceID string) (respons
 	return resp, nil
 }
 
-func (uc *OrganizationSettingUsecase) pushToFirestore(setting response.OrgSettingResponse) error {
-	client := firebase.InitFirestoreClient()
-	ctx := context.Background()
-
-	// Build data map
-	data := map[string]interface{}{
-		"device_id":            setting.DeviceID,
-		"is_view_message_box":  setting.IsViewMessageBox,
-		"is_show_message":      setting.IsShowMessage,
-		"message_box":          setting.MessageBox,
-		"is_show_special_btn":  setting.IsShowSpecialBtn,
-		"is_deactive_app":      setting.IsDeactiveApp,
-		"message_deactive_app": setting.MessageDeactiveApp,
-		"is_deactive_top_menu": setting.IsDeactiveTopMenu,
-		"message_top_menu":     setting.MessageTopMenu,
-		"top_menu_password":    setting.TopMenuPassword,
-		"updated_at":           time.Now(),
-	}
-
-	// Upsert theo device_id
-	docID := setting.DeviceID
-	_, err := client.Collection("device_settings").
-		Doc(docID).
-		Set(ctx, data, firestore.MergeAll)
-
-	return err
-}
-
 // UploadOrgSettingNewsDevice uploads organization setting news for device & portal
 func (u *OrganizationSettingUsecase) UploadOrgSettingNewsDevice(req request.UploadOrgSettingDeviceNewsRequest) error {
 	// check exist by org id
@@ -322,7 +322,11 @@ func (u *OrganizationSettingUsecase) UploadOrgSettingNewsDevice(req request.Uplo
 		exist.IsPublishedDevice = req.IsPublishedDevice
 		exist.MessageDeviceNews = req.MessageDeviceNews
 
-		return u.Repo.UpdateSettingNews(exist)
+		if err := u.Repo.UpdateSettingNews(exist); err != nil {
+			return err
+		}
+		publishDeviceNews(req)
+		return nil
 	}
 
 	// Create
@@ -332,7 +336,25 @@ func (u *OrganizationSettingUsecase) UploadOrgSettingNewsDevice(req request.Uplo
 		MessageDeviceNews: req.MessageDeviceNews,
 	}
 
-	return u.Repo.CreateSettingNews(newSetting)
+	if err := u.Repo.CreateSettingNews(newSetting); err != nil {
+		return err
+	}
+	publishDeviceNews(req)
+	return nil
+}
+
+// publishDeviceNews báo cho các device của org khi news được publish
+func publishDeviceNews(req request.UploadOrgSettingDeviceNewsRequest) {
+	if !req.IsPublishedDevice {
+		return
+	}
+	err := realtime.Publish(context.Background(), realtime.OrganizationChannel(req.OrganizationID), string(value.RealtimeEventNews), map[string]interface{}{
+		"organization_id": req.OrganizationID,
+		"message":         req.MessageDeviceNews,
+	})
+	if err != nil {
+		log.Error("publish device news: ", err)
+	}
 }
 
 func (u *OrganizationSettingUsecase) UploadOrgSettingNewsPortal(req request.UploadOrgSettingPortalNewsRequest) error {
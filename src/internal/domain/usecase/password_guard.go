@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"errors"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/passwordhash"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	passwordMaxFailures  = 5
+	passwordLockDuration = 15 * time.Minute
+)
+
+var ErrWrongPassword = errors.New("wrong password")
+
+// PasswordLockedError is returned while a code is locked after too many wrong passwords.
+type PasswordLockedError struct {
+	Until time.Time
+}
+
+func (e *PasswordLockedError) Error() string {
+	return "too many wrong passwords, try again later"
+}
+
+// checkCodePassword verifies the password of a protected code, counting the failures per code.
+func checkCodePassword(attempts *repository.PasswordAttemptRepository, scope value.PasswordAttemptScope, key string, hashed string, password string) error {
+	lockedUntil, err := attempts.GetLockedUntil(string(scope), key)
+	if err != nil {
+		return err
+	}
+	if lockedUntil != nil {
+		return &PasswordLockedError{Until: *lockedUntil}
+	}
+
+	if passwordhash.Verify(hashed, password) {
+		if err := attempts.Reset(string(scope), key); err != nil {
+			log.Error("checkCodePassword: reset attempts: ", err)
+		}
+		return nil
+	}
+
+	lockedUntil, err = attempts.RecordFailure(string(scope), key, passwordMaxFailures, passwordLockDuration)
+	if err != nil {
+		log.Error("checkCodePassword: record failure: ", err)
+	}
+	if lockedUntil != nil {
+		return &PasswordLockedError{Until: *lockedUntil}
+	}
+	return ErrWrongPassword
+}
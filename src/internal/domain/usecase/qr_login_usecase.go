@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/response"
+)
+
+type QRLoginUseCase struct {
+	Repo *repository.QRLoginTokenRepository
+}
+
+// Rotate revokes the printed QR codes of the user and issues a new one.
+func (uc *QRLoginUseCase) Rotate(userID string) (*response.QRLoginResponse, error) {
+	payload, err := uc.Repo.Rotate(userID)
+	if err != nil {
+		return nil, err
+	}
+	return &response.QRLoginResponse{UserID: userID, QRLogin: payload}, nil
+}
+
+// Revoke invalidates the printed QR codes of the user without issuing a new one.
+func (uc *QRLoginUseCase) Revoke(userID string) error {
+	return uc.Repo.Revoke(userID)
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/ratelimit"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type RateLimitUseCase struct {
+	Limiter               *ratelimit.Limiter
+	AccountsLogRepository *repository.AccountsLogRepository
+	UserEntityRepository  *repository.UserEntityRepository
+}
+
+func (uc *RateLimitUseCase) GetLocks(ctx context.Context) ([]ratelimit.Lock, error) {
+	return uc.Limiter.Locks(ctx)
+}
+
+// Unlock lifts a lock set by the rate limiter and audits it.
+func (uc *RateLimitUseCase) Unlock(ctx context.Context, req request.UnlockRateLimitRequest, adminID string) error {
+	if err := uc.Limiter.Unlock(ctx, req.Group, req.Kind, req.Key); err != nil {
+		return err
+	}
+
+	uc.audit(value.AccountsLogTypeUnlock, req.Kind, req.Key,
+		fmt.Sprintf("%s %s=%s unlocked by %s", req.Group, req.Kind, req.Key, adminID))
+	return nil
+}
+
+// AuditLockout records the lockouts in the accounts log, registered on the limiter at startup.
+func (uc *RateLimitUseCase) AuditLockout(_ context.Context, lock ratelimit.Lock) {
+	uc.audit(value.AccountsLogTypeLockout, lock.Kind, lock.Key,
+		fmt.Sprintf("%s %s=%s locked until %s (strike %d)",
+			lock.Group, lock.Kind, lock.Key, lock.Until.UTC().Format(time.RFC3339), lock.Strike))
+}
+
+func (uc *RateLimitUseCase) audit(logType value.AccountsLogType, kind, key, detail string) {
+	accountsLog := &entity.AccountsLog{
+		Type:   logType,
+		Detail: truncate(detail, 512),
+	}
+	switch kind {
+	case ratelimit.KindUsername:
+		user, err := uc.UserEntityRepository.GetByUsername(request.GetUserEntityByUsernameRequest{Username: key})
+		if err == nil {
+			accountsLog.UserID = user.ID.String()
+		}
+	case ratelimit.KindDevice:
+		accountsLog.DeviceID = key
+	}
+
+	if err := uc.AccountsLogRepository.Create(accountsLog); err != nil {
+		log.Error("RateLimitUseCase.audit: " + err.Error())
+	}
+}
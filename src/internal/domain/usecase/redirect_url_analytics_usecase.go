@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"errors"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/value"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	redirectUrlAnalyticsDefaultDays = 30
+	redirectUrlRecentScans          = 50
+)
+
+type RedirectUrlAnalyticsUseCase struct {
+	RedirectUrlRepo *repository.RedirectUrlRepository
+	ScanRepo        *repository.RedirectUrlScanRepository
+}
+
+// GetDashboard returns the scans of every QR code over the period, the most scanned first.
+func (uc *RedirectUrlAnalyticsUseCase) GetDashboard(req request.GetRedirectUrlAnalyticsRequest) (*response.RedirectUrlDashboardResponse, error) {
+	from, to, err := redirectUrlAnalyticsPeriod(req)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := uc.ScanRepo.GetSummaries(from, to, req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	res := &response.RedirectUrlDashboardResponse{
+		From:  from.Format(redirectUrlDateLayout),
+		To:    to.AddDate(0, 0, -1).Format(redirectUrlDateLayout),
+		Codes: make([]response.RedirectUrlStatsResponse, 0, len(summaries)),
+	}
+	for _, s := range summaries {
+		res.TotalScans += s.TotalScans
+		if s.TotalScans > 0 {
+			res.UsedCodes++
+		} else {
+			res.UnusedCodes++
+		}
+
+		status := redirectUrlStatus(&entity.SRedirectUrl{
+			ValidFrom:  s.ValidFrom,
+			ValidUntil: s.ValidUntil,
+			MaxUses:    s.MaxUses,
+			UseCount:   s.UseCount,
+		}, now)
+
+		res.Codes = append(res.Codes, response.RedirectUrlStatsResponse{
+			RedirectUrlID: s.RedirectUrlID,
+			QRCode:        s.QRCode,
+			TargetUrl:     s.TargetUrl,
+			TotalScans:    s.TotalScans,
+			ResolvedScans: s.ResolvedScans,
+			RefusedScans:  s.TotalScans - s.ResolvedScans,
+			UniqueDevices: s.UniqueDevices,
+			LastScanAt:    s.LastScanAt,
+			UseCount:      s.UseCount,
+			MaxUses:       s.MaxUses,
+			ValidFrom:     s.ValidFrom,
+			ValidUntil:    s.ValidUntil,
+			Status:        redirectUrlStatusLabel(status),
+		})
+	}
+	return res, nil
+}
+
+// GetAnalytics breaks the scans of one QR code down by day, hour, target, organization, language and outcome.
+func (uc *RedirectUrlAnalyticsUseCase) GetAnalytics(id uint64, req request.GetRedirectUrlAnalyticsRequest) (*response.RedirectUrlAnalyticsResponse, error) {
+	url, err := uc.RedirectUrlRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRedirectUrlNotFound
+		}
+		return nil, err
+	}
+
+	from, to, err := redirectUrlAnalyticsPeriod(req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &response.RedirectUrlAnalyticsResponse{
+		RedirectUrlID: url.ID,
+		QRCode:        url.QRCode,
+		From:          from.Format(redirectUrlDateLayout),
+		To:            to.AddDate(0, 0, -1).Format(redirectUrlDateLayout),
+	}
+
+	groups := []struct {
+		expr string
+		dest *[]response.RedirectUrlScanCount
+	}{
+		{"DATE_FORMAT(scanned_at, '%Y-%m-%d')", &res.ByDay},
+		{"DATE_FORMAT(scanned_at, '%H')", &res.ByHour},
+		{"resolved_url", &res.ByTarget},
+		{"organization_id", &res.ByOrganization},
+		{"language", &res.ByLanguage},
+		{"outcome", &res.ByOutcome},
+	}
+	for _, g := range groups {
+		buckets, err := uc.ScanRepo.CountBy(url.ID, from, to, g.expr)
+		if err != nil {
+			return nil, err
+		}
+		*g.dest = make([]response.RedirectUrlScanCount, 0, len(buckets))
+		for _, b := range buckets {
+			*g.dest = append(*g.dest, response.RedirectUrlScanCount{Key: b.Key, Total: b.Total})
+		}
+	}
+
+	scans, err := uc.ScanRepo.GetRecent(url.ID, redirectUrlRecentScans)
+	if err != nil {
+		return nil, err
+	}
+	res.RecentScans = make([]response.RedirectUrlScanResponse, 0, len(scans))
+	for _, s := range scans {
+		res.RecentScans = append(res.RecentScans, response.RedirectUrlScanResponse{
+			DeviceID:       s.DeviceID,
+			OrganizationID: s.OrganizationID,
+			UserAgent:      s.UserAgent,
+			Language:       s.Language,
+			ResolvedUrl:    s.ResolvedUrl,
+			Outcome:        string(s.Outcome),
+			ScannedAt:      s.ScannedAt,
+		})
+	}
+	return res, nil
+}
+
+// redirectUrlAnalyticsPeriod returns [from, to) covering the requested days, the last 30 days by default.
+func redirectUrlAnalyticsPeriod(req request.GetRedirectUrlAnalyticsRequest) (time.Time, time.Time, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	to := today.AddDate(0, 0, 1)
+	if req.To != "" {
+		day, err := time.ParseInLocation(redirectUrlDateLayout, req.To, now.Location())
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("invalid to, expected YYYY-MM-DD")
+		}
+		to = day.AddDate(0, 0, 1)
+	}
+
+	from := to.AddDate(0, 0, -redirectUrlAnalyticsDefaultDays)
+	if req.From != "" {
+		day, err := time.ParseInLocation(redirectUrlDateLayout, req.From, now.Location())
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("invalid from, expected YYYY-MM-DD")
+		}
+		from = day
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, errors.New("from must not be after to")
+	}
+	return from, to, nil
+}
+
+func redirectUrlStatusLabel(status value.RedirectUrlScanOutcome) string {
+	if status == value.RedirectUrlScanResolved {
+		return "active"
+	}
+	return string(status)
+}
@@ -0,0 +1,724 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/lifecycle"
+	"sen-global-api/pkg/metrics"
+	"sen-global-api/pkg/report"
+	"sen-global-api/pkg/uploader"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	reportFolder      = "reports"
+	reportURLDuration = 15 * time.Minute
+	// a pending or processing job without progress for reportJobStaleAfter was left by a crash
+	reportJobStaleAfter = 10 * time.Minute
+	// default and max period of the student and class reports
+	defaultReportPeriodMonths = 12
+	maxReportPeriodMonths     = 24
+)
+
+// ReportUseCase renders the submissions, the student profiles and the class summaries to PDF with pkg/report,
+// stores them with the upload provider and runs the batch jobs generating them for a whole organization.
+type ReportUseCase struct {
+	Repo              *repository.ReportRepository
+	SubmissionRepo    *repository.SubmissionRepository
+	StudentRepo       *repository.StudentApplicationRepository
+	TeacherRepo       *repository.TeacherApplicationRepository
+	AttendanceRepo    *repository.AttendanceRepository
+	OrganizationRepo  *repository.OrganizationRepository
+	ChildGuardianRepo *repository.ChildGuardianRepository
+	UploadProvider    uploader.UploadProvider
+}
+
+// ---------- generate ----------
+
+// GenerateSubmissionReport renders a submission. When asAdmin is false the caller must be its sender, a teacher of
+// the organization of its student or a guardian allowed to view the student's submissions.
+func (uc *ReportUseCase) GenerateSubmissionReport(submissionID string, userID string, asAdmin bool) (*response.ReportResponse, error) {
+	id, err := strconv.ParseUint(submissionID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid submission id: %s", submissionID)
+	}
+	submission, err := uc.SubmissionRepo.GetDetailByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if submission == nil {
+		return nil, errors.New("submission not found")
+	}
+
+	var student *entity.SStudentFormApplication
+	if submission.StudentID != "" {
+		if student, err = uc.getStudent(submission.StudentID); err != nil {
+			log.Warnf("ReportUseCase.GenerateSubmissionReport: student %s of submission %d: %v", submission.StudentID, id, err)
+		}
+	}
+	if !asAdmin && submission.UserID != userID && (student == nil || !uc.canViewStudent(student, userID)) {
+		return nil, errors.New("access denied: you are not allowed to view this submission")
+	}
+
+	data := report.SubmissionReport{
+		Header:      report.Header{Title: "Phiếu trả lời", GeneratedAt: time.Now()},
+		FormName:    submission.Form.Name,
+		FormNote:    submission.Form.Note,
+		UserName:    userDisplayName(&submission.User),
+		SubmittedAt: submission.CreatedAt,
+		Score:       reportScore(submission),
+		Items:       reportItems(submission),
+	}
+	if data.FormName == "" {
+		data.FormName = submission.Form.Note
+	}
+	orgID := ""
+	if student != nil {
+		orgID = student.OrganizationID.String()
+		data.StudentName = student.StudentName
+		data.OrganizationName = uc.organizationName(orgID)
+	}
+
+	content, err := report.Render(report.TemplateSubmission, data)
+	if err != nil {
+		return nil, err
+	}
+	fileName := fmt.Sprintf("submission_%d_%s.pdf", id, time.Now().Format("20060102150405"))
+	saved, err := uc.store(value.ReportTypeSubmission, submissionID, orgID, "", fileName, userID, content)
+	if err != nil {
+		return nil, err
+	}
+	return uc.withURL(saved)
+}
+
+// GenerateStudentReport renders the profile of a student with the history of its submissions and its attendance.
+// When asAdmin is false the caller must be a teacher of the student's organization or one of its guardians.
+func (uc *ReportUseCase) GenerateStudentReport(studentID string, req request.ReportPeriodRequest, userID string, asAdmin bool) (*response.ReportResponse, error) {
+	from, to, err := parseReportPeriod(req)
+	if err != nil {
+		return nil, err
+	}
+	student, err := uc.getStudent(studentID)
+	if err != nil {
+		return nil, err
+	}
+	if !asAdmin && !uc.canViewStudent(student, userID) {
+		return nil, errors.New("access denied: you are not allowed to view this student")
+	}
+
+	orgID := student.OrganizationID.String()
+	departments, err := uc.AttendanceRepo.GetLastDepartments(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := uc.studentProfileReport(student, uc.organizationName(orgID), departments[studentID], from, to, "", userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.withURL(saved)
+}
+
+// GenerateClassReport renders one row per student of a class (or of the whole organization).
+// When asAdmin is false the caller must be a teacher of the organization.
+func (uc *ReportUseCase) GenerateClassReport(req request.GenerateClassReportRequest, userID string, asAdmin bool) (*response.ReportResponse, error) {
+	from, to, err := parseReportPeriod(req.ReportPeriodRequest)
+	if err != nil {
+		return nil, err
+	}
+	org, err := uc.OrganizationRepo.GetByID(req.OrganizationID)
+	if err != nil || org == nil {
+		return nil, errors.New("organization not found")
+	}
+	if !asAdmin && !uc.isTeacherOf(req.OrganizationID, userID) {
+		return nil, errors.New("access denied: only the teachers of the organization can view its classes")
+	}
+
+	saved, err := uc.classSummaryReport(org, req.DepartmentID, from, to, "", userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.withURL(saved)
+}
+
+func (uc *ReportUseCase) studentProfileReport(student *entity.SStudentFormApplication, orgName, departmentID string, from, to time.Time, jobID, createdBy string) (*entity.Report, error) {
+	studentID := student.ID.String()
+	records, err := uc.AttendanceRepo.GetRecordsByStudent(studentID, from.Format(attendanceDateLayout), to.Format(attendanceDateLayout))
+	if err != nil {
+		return nil, err
+	}
+	submissions, err := uc.SubmissionRepo.GetByStudentID(studentID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	data := report.StudentProfileReport{
+		Header:       report.Header{Title: "Hồ sơ học sinh", OrganizationName: orgName, GeneratedAt: time.Now()},
+		StudentName:  student.StudentName,
+		CustomID:     student.CustomID,
+		DepartmentID: departmentID,
+		From:         from,
+		To:           to,
+		Submissions:  make([]report.SubmissionEntry, 0, len(submissions)),
+	}
+	for i := range records {
+		countAttendance(&data.Attendance, records[i].Status)
+	}
+	// lich su nhap lieu theo form, nhu cau hoi OutListEntryHistory tren thiet bi
+	history := make(map[uint64]int)
+	for i := range submissions {
+		formName := submissions[i].Form.Name
+		if formName == "" {
+			formName = submissions[i].Form.Note
+		}
+		score := reportScore(&submissions[i])
+		index, ok := history[submissions[i].FormID]
+		if !ok {
+			index = len(data.EntryHistory)
+			history[submissions[i].FormID] = index
+			data.EntryHistory = append(data.EntryHistory, report.EntryHistory{FormName: formName})
+		}
+		data.EntryHistory[index].Entries = append(data.EntryHistory[index].Entries, report.HistoryEntry{
+			SubmittedAt: submissions[i].CreatedAt,
+			Score:       score,
+		})
+		data.Submissions = append(data.Submissions, report.SubmissionEntry{
+			FormName:    formName,
+			SubmittedAt: submissions[i].CreatedAt,
+			Score:       score,
+			Items:       reportItems(&submissions[i]),
+		})
+	}
+
+	content, err := report.Render(report.TemplateStudentProfile, data)
+	if err != nil {
+		return nil, err
+	}
+	fileName := fmt.Sprintf("student_profile_%s_%s_%s.pdf", studentID, from.Format("20060102"), to.Format("20060102"))
+	return uc.store(value.ReportTypeStudentProfile, studentID, student.OrganizationID.String(), jobID, fileName, createdBy, content)
+}
+
+// classSummaryReport takes the class of each student from its last attendance record, like the attendance sheet.
+func (uc *ReportUseCase) classSummaryReport(org *entity.SOrganization, departmentID string, from, to time.Time, jobID, createdBy string) (*entity.Report, error) {
+	orgID := org.ID.String()
+	students, err := uc.StudentRepo.GetByOrganizationID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	departments, err := uc.AttendanceRepo.GetLastDepartments(orgID)
+	if err != nil {
+		return nil, err
+	}
+	records, err := uc.AttendanceRepo.GetRecordsByRange(orgID, from.Format(attendanceDateLayout), to.Format(attendanceDateLayout))
+	if err != nil {
+		return nil, err
+	}
+
+	attendance := make(map[string]*report.AttendanceSummary)
+	for i := range records {
+		summary := attendance[records[i].StudentID]
+		if summary == nil {
+			summary = &report.AttendanceSummary{}
+			attendance[records[i].StudentID] = summary
+		}
+		countAttendance(summary, records[i].Status)
+	}
+
+	studentIDs := make([]string, 0, len(students))
+	inClass := make([]entity.SStudentFormApplication, 0, len(students))
+	for _, student := range students {
+		if departmentID != "" && departments[student.ID.String()] != departmentID {
+			continue
+		}
+		studentIDs = append(studentIDs, student.ID.String())
+		inClass = append(inClass, student)
+	}
+	sort.Slice(inClass, func(i, j int) bool {
+		return inClass[i].StudentName < inClass[j].StudentName
+	})
+
+	stats, err := uc.SubmissionRepo.GetStudentStats(studentIDs, from, to)
+	if err != nil {
+		return nil, err
+	}
+	statByStudent := make(map[string]repository.StudentSubmissionStat, len(stats))
+	for _, stat := range stats {
+		statByStudent[stat.StudentID] = stat
+	}
+
+	data := report.ClassSummaryReport{
+		Header:       report.Header{Title: "Báo cáo lớp học", OrganizationName: org.OrganizationName, GeneratedAt: time.Now()},
+		DepartmentID: departmentID,
+		From:         from,
+		To:           to,
+		Students:     make([]report.ClassStudentRow, 0, len(inClass)),
+	}
+	for _, student := range inClass {
+		studentID := student.ID.String()
+		row := report.ClassStudentRow{
+			StudentName:      student.StudentName,
+			CustomID:         student.CustomID,
+			Submissions:      statByStudent[studentID].Total,
+			LastSubmissionAt: statByStudent[studentID].LastAt,
+		}
+		if summary := attendance[studentID]; summary != nil {
+			row.Attendance = *summary
+			data.Attendance.Present += summary.Present
+			data.Attendance.Late += summary.Late
+			data.Attendance.Absent += summary.Absent
+			data.Attendance.Excused += summary.Excused
+		}
+		data.Students = append(data.Students, row)
+	}
+
+	content, err := report.Render(report.TemplateClassSummary, data)
+	if err != nil {
+		return nil, err
+	}
+	subjectID := departmentID
+	if subjectID == "" {
+		subjectID = orgID
+	}
+	fileName := fmt.Sprintf("class_summary_%s_%s_%s.pdf", subjectID, from.Format("20060102"), to.Format("20060102"))
+	return uc.store(value.ReportTypeClassSummary, subjectID, orgID, jobID, fileName, createdBy, content)
+}
+
+// store uploads the PDF as a private file and records it.
+func (uc *ReportUseCase) store(reportType value.ReportType, subjectID, orgID, jobID, fileName, createdBy string, content []byte) (*entity.Report, error) {
+	saved := &entity.Report{
+		ID:             uuid.New(),
+		Type:           reportType,
+		SubjectID:      subjectID,
+		OrganizationID: orgID,
+		JobID:          jobID,
+		FileName:       fileName,
+		Size:           int64(len(content)),
+		CreatedBy:      createdBy,
+	}
+	saved.Key = fmt.Sprintf("%s/%s/%s.pdf", reportFolder, reportType, saved.ID)
+
+	if _, err := uc.UploadProvider.SaveFileUploaded(context.Background(), content, saved.Key, uploader.UploadPrivate); err != nil {
+		return nil, fmt.Errorf("upload report failed: %w", err)
+	}
+	if err := uc.Repo.Create(saved); err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// ---------- download ----------
+
+// GetReport returns the report with a short-lived download url. When asAdmin is false only its creator can get it.
+func (uc *ReportUseCase) GetReport(reportID string, userID string, asAdmin bool) (*response.ReportResponse, error) {
+	saved, err := uc.Repo.GetByID(reportID)
+	if err != nil {
+		return nil, err
+	}
+	if saved == nil {
+		return nil, errors.New("report not found")
+	}
+	if !asAdmin && saved.CreatedBy != userID {
+		return nil, errors.New("access denied")
+	}
+	return uc.withURL(saved)
+}
+
+func (uc *ReportUseCase) GetReports(req request.GetReportsRequest) ([]entity.Report, error) {
+	if !req.Type.IsValid() {
+		return nil, fmt.Errorf("invalid report type %q", req.Type)
+	}
+	return uc.Repo.GetBySubject(req.Type, req.SubjectID)
+}
+
+func (uc *ReportUseCase) withURL(saved *entity.Report) (*response.ReportResponse, error) {
+	duration := reportURLDuration
+	url, err := uc.UploadProvider.GetFileUploaded(context.Background(), saved.Key, &duration)
+	if err != nil {
+		return nil, err
+	}
+	return &response.ReportResponse{Report: *saved, URL: *url}, nil
+}
+
+// ---------- batch jobs ----------
+
+// CreateJob registers a batch job and runs it in background.
+func (uc *ReportUseCase) CreateJob(req request.CreateReportJobRequest, createdBy string) (*entity.ReportJob, error) {
+	if req.Type != value.ReportTypeStudentProfile && req.Type != value.ReportTypeClassSummary {
+		return nil, errors.New("type must be student_profile or class_summary")
+	}
+	from, to, err := parseReportPeriod(req.ReportPeriodRequest)
+	if err != nil {
+		return nil, err
+	}
+	if org, err := uc.OrganizationRepo.GetByID(req.OrganizationID); err != nil || org == nil {
+		return nil, errors.New("organization not found")
+	}
+
+	job := &entity.ReportJob{
+		Type:           req.Type,
+		OrganizationID: req.OrganizationID,
+		DepartmentID:   req.DepartmentID,
+		FromDate:       from.Format(attendanceDateLayout),
+		ToDate:         to.Format(attendanceDateLayout),
+		Status:         value.ReportJobStatusPending,
+		CreatedBy:      createdBy,
+	}
+	if err := uc.Repo.CreateJob(job); err != nil {
+		return nil, fmt.Errorf("create report job failed: %w", err)
+	}
+
+	jobID := job.ID.String()
+	lifecycle.Default().Go("report_job", func(context.Context) {
+		uc.runJob(jobID)
+	})
+	return job, nil
+}
+
+func (uc *ReportUseCase) GetJobs(req request.GetReportJobsRequest) (*response.ReportJobListResponse, error) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 || req.Limit > 200 {
+		req.Limit = 50
+	}
+
+	jobs, total, err := uc.Repo.GetJobs(req.OrganizationID, req.Limit, (req.Page-1)*req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return &response.ReportJobListResponse{
+		Jobs: jobs,
+		Pagination: response.Pagination{
+			Page:      req.Page,
+			Limit:     req.Limit,
+			TotalPage: int((total + int64(req.Limit) - 1) / int64(req.Limit)),
+			Total:     total,
+		},
+	}, nil
+}
+
+func (uc *ReportUseCase) GetJob(jobID string) (*response.ReportJobResponse, error) {
+	job, err := uc.Repo.GetJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, errors.New("report job not found")
+	}
+	reports, err := uc.Repo.GetByJobID(jobID)
+	if err != nil {
+		return nil, err
+	}
+	return &response.ReportJobResponse{ReportJob: *job, Reports: reports}, nil
+}
+
+// ResumeInterrupted runs again the jobs stopped by a shutdown or a crash, their generated reports are kept.
+func (uc *ReportUseCase) ResumeInterrupted() {
+	staleBefore := time.Now().Add(-reportJobStaleAfter)
+	jobs, err := uc.Repo.GetResumableJobs(staleBefore)
+	if err != nil {
+		log.Error("ReportUseCase.ResumeInterrupted: ", err)
+		return
+	}
+	for _, job := range jobs {
+		if reportJobStopping() {
+			return
+		}
+		// moi instance chi chay tiep job ma no claim duoc
+		claimed, err := uc.Repo.ClaimJob(job.ID.String(), staleBefore)
+		if err != nil {
+			log.Error("ReportUseCase.ResumeInterrupted: claim job: ", err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		uc.runJob(job.ID.String())
+	}
+}
+
+// runJob generates the missing reports of the job, it returns early on shutdown and leaves the job interrupted
+// so the next start resumes it.
+func (uc *ReportUseCase) runJob(jobID string) {
+	var jobErr error
+	track := metrics.TrackJob("report_job")
+	defer func() { track(jobErr) }()
+
+	job, err := uc.Repo.GetJob(jobID)
+	if err != nil || job == nil {
+		jobErr = err
+		log.Errorf("ReportUseCase.runJob: get job %s: %v", jobID, err)
+		return
+	}
+	if job.Status == value.ReportJobStatusDone || job.Status == value.ReportJobStatusFailed {
+		return
+	}
+
+	fail := func(err error) {
+		jobErr = err
+		log.Errorf("ReportUseCase.runJob: job %s: %v", jobID, err)
+		if err := uc.Repo.FinishJob(jobID, value.ReportJobStatusFailed, err.Error()); err != nil {
+			log.Error("ReportUseCase.runJob: finish job: ", err)
+		}
+	}
+
+	from, to, err := parseReportPeriod(request.ReportPeriodRequest{From: job.FromDate, To: job.ToDate})
+	if err != nil {
+		fail(err)
+		return
+	}
+	org, err := uc.OrganizationRepo.GetByID(job.OrganizationID)
+	if err != nil || org == nil {
+		fail(errors.New("organization not found"))
+		return
+	}
+	departments, err := uc.AttendanceRepo.GetLastDepartments(job.OrganizationID)
+	if err != nil {
+		fail(err)
+		return
+	}
+	generated, err := uc.Repo.GetJobSubjectIDs(jobID)
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	// moi subject la 1 report: hoc sinh voi student_profile, lop voi class_summary
+	var subjects []string
+	students := make(map[string]*entity.SStudentFormApplication)
+	switch job.Type {
+	case value.ReportTypeStudentProfile:
+		list, err := uc.StudentRepo.GetByOrganizationID(job.OrganizationID)
+		if err != nil {
+			fail(err)
+			return
+		}
+		for i := range list {
+			studentID := list[i].ID.String()
+			if job.DepartmentID != "" && departments[studentID] != job.DepartmentID {
+				continue
+			}
+			subjects = append(subjects, studentID)
+			students[studentID] = &list[i]
+		}
+	case value.ReportTypeClassSummary:
+		if job.DepartmentID != "" {
+			subjects = []string{job.DepartmentID}
+		} else {
+			seen := make(map[string]bool)
+			for _, departmentID := range departments {
+				if departmentID != "" && !seen[departmentID] {
+					seen[departmentID] = true
+					subjects = append(subjects, departmentID)
+				}
+			}
+			sort.Strings(subjects)
+		}
+	default:
+		fail(fmt.Errorf("unsupported report type %q", job.Type))
+		return
+	}
+
+	done := 0
+	for _, subject := range subjects {
+		if generated[subject] {
+			done++
+		}
+	}
+	if err := uc.Repo.StartJob(jobID, len(subjects), done); err != nil {
+		fail(err)
+		return
+	}
+
+	failed := 0
+	var lastErr error
+	for _, subject := range subjects {
+		if generated[subject] {
+			continue
+		}
+		if reportJobStopping() {
+			log.Infof("ReportUseCase.runJob: job %s interrupted by shutdown", jobID)
+			if err := uc.Repo.InterruptJob(jobID); err != nil {
+				log.Error("ReportUseCase.runJob: interrupt job: ", err)
+			}
+			return
+		}
+
+		var err error
+		switch job.Type {
+		case value.ReportTypeStudentProfile:
+			_, err = uc.studentProfileReport(students[subject], org.OrganizationName, departments[subject], from, to, jobID, job.CreatedBy)
+		case value.ReportTypeClassSummary:
+			_, err = uc.classSummaryReport(org, subject, from, to, jobID, job.CreatedBy)
+		}
+		if err != nil {
+			failed++
+			lastErr = err
+			log.Errorf("ReportUseCase.runJob: job %s, %s %s: %v", jobID, job.Type, subject, err)
+		}
+		if err := uc.Repo.CountJobReport(jobID, err != nil); err != nil {
+			log.Error("ReportUseCase.runJob: count report: ", err)
+		}
+	}
+
+	status, reason := value.ReportJobStatusDone, ""
+	if failed > 0 {
+		reason = fmt.Sprintf("%d reports failed, last error: %v", failed, lastErr)
+		if failed == len(subjects)-done {
+			status = value.ReportJobStatusFailed
+			jobErr = lastErr
+		}
+	}
+	if err := uc.Repo.FinishJob(jobID, status, reason); err != nil {
+		log.Error("ReportUseCase.runJob: finish job: ", err)
+	}
+}
+
+func reportJobStopping() bool {
+	select {
+	case <-lifecycle.Default().Stopping():
+		return true
+	default:
+		return false
+	}
+}
+
+// ---------- helpers ----------
+
+func (uc *ReportUseCase) getStudent(studentID string) (*entity.SStudentFormApplication, error) {
+	studentUUID, err := uuid.Parse(studentID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid student id: %s", studentID)
+	}
+	student, err := uc.StudentRepo.GetByID(studentUUID)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		return nil, errors.New("student not found")
+	}
+	return student, nil
+}
+
+func (uc *ReportUseCase) canViewStudent(student *entity.SStudentFormApplication, userID string) bool {
+	if uc.isTeacherOf(student.OrganizationID.String(), userID) {
+		return true
+	}
+	guardian, err := uc.ChildGuardianRepo.GetByChildAndGuardian(student.ChildID.String(), userID)
+	return err == nil && guardian != nil && guardian.CanViewSubmissions
+}
+
+func (uc *ReportUseCase) isTeacherOf(orgID, userID string) bool {
+	teacher, err := uc.TeacherRepo.GetByUserIDAndOrgID(userID, orgID)
+	return err == nil && teacher != nil
+}
+
+func (uc *ReportUseCase) organizationName(orgID string) string {
+	org, err := uc.OrganizationRepo.GetByID(orgID)
+	if err != nil || org == nil {
+		return ""
+	}
+	return org.OrganizationName
+}
+
+// parseReportPeriod returns the first and the last instant of the period.
+func parseReportPeriod(req request.ReportPeriodRequest) (time.Time, time.Time, error) {
+	now := time.Now()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if req.To != "" {
+		t, err := time.ParseInLocation(attendanceDateLayout, req.To, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to, expected YYYY-MM-DD: %s", req.To)
+		}
+		to = t
+	}
+	from := to.AddDate(0, -defaultReportPeriodMonths, 0)
+	if req.From != "" {
+		t, err := time.ParseInLocation(attendanceDateLayout, req.From, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid from, expected YYYY-MM-DD: %s", req.From)
+		}
+		from = t
+	}
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, errors.New("from must be before to")
+	}
+	if from.Before(to.AddDate(0, -maxReportPeriodMonths, 0)) {
+		return time.Time{}, time.Time{}, fmt.Errorf("the period must be at most %d months", maxReportPeriodMonths)
+	}
+	return from, to.AddDate(0, 0, 1).Add(-time.Second), nil
+}
+
+func countAttendance(summary *report.AttendanceSummary, status value.AttendanceStatus) {
+	switch status {
+	case value.AttendanceStatusPresent:
+		summary.Present++
+	case value.AttendanceStatusLate:
+		summary.Late++
+	case value.AttendanceStatusAbsent:
+		summary.Absent++
+	case value.AttendanceStatusExcused:
+		summary.Excused++
+	}
+}
+
+func reportItems(submission *entity.SSubmission) []report.Item {
+	var data entity.SubmissionData
+	if err := json.Unmarshal(submission.SubmissionData, &data); err != nil {
+		return nil
+	}
+	items := make([]report.Item, 0, len(data.Items))
+	for _, item := range data.Items {
+		if strings.TrimSpace(item.Question) == "" {
+			continue
+		}
+		items = append(items, report.Item{Question: item.Question, Answer: item.Answer})
+	}
+	return items
+}
+
+func reportScore(submission *entity.SSubmission) *report.Score {
+	if len(submission.Score) == 0 {
+		return nil
+	}
+	var score entity.SubmissionScore
+	if err := json.Unmarshal(submission.Score, &score); err != nil || score.MaxScore == 0 {
+		return nil
+	}
+	res := &report.Score{Total: score.Total, MaxScore: score.MaxScore, Level: score.Level}
+	for _, section := range score.Sections {
+		res.Sections = append(res.Sections, report.SectionScore{
+			Section:  section.Section,
+			Score:    section.Score,
+			MaxScore: section.MaxScore,
+			Level:    section.Level,
+		})
+	}
+	return res
+}
+
+func userDisplayName(user *entity.SUserEntity) string {
+	if user == nil {
+		return ""
+	}
+	for _, name := range []string{user.Fullname, user.Nickname, user.Username} {
+		if name != "" {
+			return name
+		}
+	}
+	return ""
+}
@@ -4,6 +4,7 @@ import (
 	"sen-global-api/internal/data/repository"
 	"sen-global-api/internal/domain/entity"
 	"sen-global-api/internal/domain/request"
+	"sen-global-api/pkg/passwordhash"
 )
 
 type SaveRedirectUrlUseCase struct {
@@ -16,7 +17,11 @@ func (receiver *SaveRedirectUrlUseCase) Save(req request.SaveRedirectUrlRequest)
 		TargetUrl: req.TargetUrl,
 	}
 	if req.Password != "" {
-		url.Password = &req.Password
+		hashed, err := passwordhash.Hash(req.Password)
+		if err != nil {
+			return nil, err
+		}
+		url.HashPassword = &hashed
 	}
 	return receiver.RedirectUrlRepository.Save(url)
 }
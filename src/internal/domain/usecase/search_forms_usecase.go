@@ -20,7 +20,7 @@ func (receiver *SearchFormsUseCase) SearchForms(keyword string) ([]response.GetF
 		formList = append(formList, response.GetFormListResponseData{
 			ID:          form.ID,
 			Spreadsheet: form.SpreadsheetUrl,
-			Password:    form.Password,
+			HasPassword: form.PasswordHash != "",
 			Note:        form.Note,
 			CreatedAt:   form.CreatedAt,
 			UpdatedAt:   form.UpdatedAt,
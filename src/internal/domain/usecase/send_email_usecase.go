@@ -26,15 +26,24 @@ func (receiver *SendEmailUseCase) SendEmail(target string, subject string, conte
 		return err
 	}
 
-	smtpServer := receiver.Host
+	err = sendMail(receiver.SMTPConfig, target, subject, content)
+
+	receiver.logHistory(target, subject, device)
+
+	return err
+}
+
+// sendMail sends a plain text email with the SMTP account of the config.
+func sendMail(cfg config.SMTPConfig, target string, subject string, content string) error {
+	smtpServer := cfg.Host
 	auth := smtp.PlainAuth(
 		"",
-		receiver.Username,
-		receiver.Password,
+		cfg.Username,
+		cfg.Password,
 		smtpServer,
 	)
 
-	from := mail.Address{Name: "SENBOX", Address: receiver.Username}
+	from := mail.Address{Name: "SENBOX", Address: cfg.Username}
 	to := mail.Address{Name: target, Address: target}
 	title := subject
 
@@ -53,17 +62,13 @@ func (receiver *SendEmailUseCase) SendEmail(target string, subject string, conte
 
 	// Connect to the server, authenticate, set the sender and recipient,
 	// and send the email all in one step.
-	err = smtp.SendMail(
-		smtpServer+":"+strconv.Itoa(receiver.Port),
+	return smtp.SendMail(
+		smtpServer+":"+strconv.Itoa(cfg.Port),
 		auth,
 		from.Address,
 		[]string{to.Address},
 		[]byte(message),
 	)
-
-	receiver.logHistory(target, subject, device)
-
-	return err
 }
 
 func (receiver *SendEmailUseCase) logHistory(target string, subject string, device entity.SDevice) {
@@ -6,7 +6,10 @@ import (
 	"sen-global-api/internal/domain/entity"
 	"sen-global-api/internal/domain/request"
 	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/lifecycle"
+	"sen-global-api/pkg/metrics"
 	"strconv"
+	"time"
 
 	"firebase.google.com/go/v4/messaging"
 	"github.com/sirupsen/logrus"
@@ -20,9 +23,9 @@ func SetupLogoRefreshIntervalUseCase(req request.SetupLogoRefreshIntervalRequest
 		if err != nil {
 			return err
 		}
-		go func() {
+		lifecycle.Default().Go("logo_refresh_interval_notification", func(context.Context) {
 			announceLogoFreshUpdatedInterval(req.Interval)
-		}()
+		})
 	}
 
 	if req.Title != "" {
@@ -70,7 +73,9 @@ func announceLogoFreshUpdatedInterval(interval uint64) {
 		},
 	}
 
+	start := time.Now()
 	_, err = msgApp.Send(ctx, msg)
+	metrics.ObserveCall(metrics.ServiceFCM, "send", start, err)
 	if err != nil {
 		logrus.Errorf("[ERROR][INFORM LOGO REFRESH INTERVAL] Cannot send notification: %s", err.Error())
 	}
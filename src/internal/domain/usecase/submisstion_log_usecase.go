@@ -89,8 +89,9 @@ func (u *SubmissionLogUseCase) GetSubmissionsFormLogs(
 			deviceID = val
 		}
 
-		var userID string
-		if tokenStr, ok := headers["Authorization"]; ok && strings.HasPrefix(tokenStr, "Bearer ") {
+		// logs cu khong co user_id, Authorization chua bi che
+		userID := result.UserID
+		if tokenStr, ok := headers["Authorization"]; ok && userID == "" && strings.HasPrefix(tokenStr, "Bearer ") {
 			tokenStr = strings.TrimPrefix(tokenStr, "Bearer ")
 
 			claims := jwt.MapClaims{}
@@ -203,8 +204,9 @@ func (u *SubmissionLogUseCase) GetSubmissionsFormLogsBySubmit(
 			customID = val
 		}
 
-		var userID string
-		if tokenStr, ok := headers["Authorization"]; ok && strings.HasPrefix(tokenStr, "Bearer ") {
+		// logs cu khong co user_id, Authorization chua bi che
+		userID := result.UserID
+		if tokenStr, ok := headers["Authorization"]; ok && userID == "" && strings.HasPrefix(tokenStr, "Bearer ") {
 			tokenStr = strings.TrimPrefix(tokenStr, "Bearer ")
 
 			claims := jwt.MapClaims{}
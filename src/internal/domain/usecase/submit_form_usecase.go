@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,12 +10,12 @@ import (
 	"sen-global-api/internal/domain/model"
 	"sen-global-api/internal/domain/request"
 	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/logger"
 	"sen-global-api/pkg/messaging"
 	"sen-global-api/pkg/sheet"
 
 	firebase "firebase.google.com/go/v4"
 	"github.com/google/uuid"
-	log "github.com/sirupsen/logrus"
 	"google.golang.org/api/drive/v3"
 	"gorm.io/gorm"
 )
@@ -36,15 +37,18 @@ type SubmitFormUseCase struct {
 	OutputSpreadsheetID string
 	FirebaseApp         *firebase.App
 	DB                  *gorm.DB
+	BookingUseCase      *BookingUseCase
+	FormScoringUseCase  *FormScoringUseCase
 }
 
-func (receiver *SubmitFormUseCase) AnswerForm(id uint64, req request.SubmitFormRequest) error {
+// AnswerForm stores the submission and returns its score when the form has a rubric, the logs carry the request id of ctx.
+func (receiver *SubmitFormUseCase) AnswerForm(ctx context.Context, id uint64, req request.SubmitFormRequest) (*entity.SubmissionScore, error) {
 	form, err := receiver.GetFormByID(id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return receiver.answerFormSaveToFormOutputSheet(form, req)
+	return receiver.answerFormSaveToFormOutputSheet(ctx, form, req)
 }
 
 func Map[T, U any](ts []T, f func(T) U) []U {
@@ -55,11 +59,11 @@ func Map[T, U any](ts []T, f func(T) U) []U {
 	return us
 }
 
-func (receiver *SubmitFormUseCase) answerFormSaveToFormOutputSheet(form *entity.SForm, req request.SubmitFormRequest) error {
+func (receiver *SubmitFormUseCase) answerFormSaveToFormOutputSheet(ctx context.Context, form *entity.SForm, req request.SubmitFormRequest) (*entity.SubmissionScore, error) {
 	submissionItems := make([]repository.SubmissionDataItem, 0)
 	questions, err := receiver.GetQuestionsByIDs(Map(req.Answers, func(answer request.Answer) string { return answer.QuestionID }))
 	if err != nil {
-		return fmt.Errorf("system cannot find questions for this form: %s", form.Name)
+		return nil, fmt.Errorf("system cannot find questions for this form: %s", form.Name)
 	}
 
 	rememberAnswers := make([]entity.MemoryComponentValue, 0)
@@ -84,6 +88,36 @@ func (receiver *SubmitFormUseCase) answerFormSaveToFormOutputSheet(form *entity.
 		}
 	}
 
+	// CalendarBooking: answer la slot duoc chon, dat cho truoc khi luu submission
+	reservations := make([]*entity.BookingReservation, 0)
+	if receiver.BookingUseCase != nil {
+		for _, answer := range req.Answers {
+			for _, question := range questions {
+				if answer.QuestionID != question.ID.String() || question.QuestionType != value.GetStringValue(value.CalendarBooking) {
+					continue
+				}
+
+				reservation, err := receiver.BookingUseCase.ReserveForAnswer(question, answer.Answer, req.UserID)
+				if err != nil {
+					receiver.BookingUseCase.CancelAll(reservations)
+					return nil, err
+				}
+				if reservation != nil {
+					reservations = append(reservations, reservation)
+				}
+			}
+		}
+	}
+
+	// cham diem theo rubric cua form (neu co)
+	var score *entity.SubmissionScore
+	if receiver.FormScoringUseCase != nil {
+		score, err = receiver.FormScoringUseCase.ScoreSubmission(form.ID, submissionItems)
+		if err != nil {
+			logger.FromContext(ctx).Error("SubmitFormUseCase.answerFormSaveToFormOutputSheet: score submission", err)
+		}
+	}
+
 	submissionData := repository.SubmissionData{
 		Items: submissionItems,
 	}
@@ -95,18 +129,31 @@ func (receiver *SubmitFormUseCase) answerFormSaveToFormOutputSheet(form *entity.
 		StudentCustomID: req.StudentCustomID,
 		UserCustomID:    req.UserCustomID,
 		StudentID:       req.StudentID,
+		Score:           score,
+	}
+	if req.ChildID != nil {
+		createSubmissionParams.ChildID = *req.ChildID
 	}
 	submissionID, err := receiver.CreateSubmission(createSubmissionParams)
 	if err != nil {
-		log.Error("SubmitFormUseCase.answerFormSaveToFormOutputSheet", err)
-		return errors.New("system cannot handle the submission")
+		logger.FromContext(ctx).Error("SubmitFormUseCase.answerFormSaveToFormOutputSheet", err)
+		if receiver.BookingUseCase != nil {
+			receiver.BookingUseCase.CancelAll(reservations)
+		}
+		return nil, errors.New("system cannot handle the submission")
+	}
+
+	if len(reservations) > 0 {
+		if err := receiver.BookingUseCase.AttachSubmission(reservations, submissionID); err != nil {
+			logger.FromContext(ctx).Error("SubmitFormUseCase.answerFormSaveToFormOutputSheet: attach reservations", err)
+		}
 	}
 
 	if len(rememberAnswers) > 0 {
 		err = receiver.QuestionRepository.CreateMemoryComponentValuesDuplicate(rememberAnswers)
 		if err != nil {
-			log.Error("SubmitFormUseCase.answerFormSaveToFormOutputSheet", err)
-			return errors.New("system cannot handle the submission memory component values")
+			logger.FromContext(ctx).Error("SubmitFormUseCase.answerFormSaveToFormOutputSheet", err)
+			return nil, errors.New("system cannot handle the submission memory component values")
 		}
 	}
 
@@ -127,21 +174,21 @@ func (receiver *SubmitFormUseCase) answerFormSaveToFormOutputSheet(form *entity.
 
 		err := receiver.AnswerRepository.Create(answer)
 		if err != nil {
-			log.Error("SubmitFormUseCase.answerFormSaveToFormOutputSheet: create answer", err)
+			logger.FromContext(ctx).Error("SubmitFormUseCase.answerFormSaveToFormOutputSheet: create answer", err)
 		}
 	}
 
 	defer func() {
-		receiver.sendNotification(form)
+		receiver.sendNotification(ctx, form)
 	}()
 
-	return nil
+	return score, nil
 }
 
-func (receiver *SubmitFormUseCase) sendNotification(form *entity.SForm) {
+func (receiver *SubmitFormUseCase) sendNotification(ctx context.Context, form *entity.SForm) {
 	questions, err := receiver.GetQuestionsByFormID(form.ID)
 	if err != nil {
-		log.Error("Form ", form.Note, " has not send notification question")
+		logger.FromContext(ctx).Error("Form ", form.Note, " has not send notification question")
 		return
 	}
 	sendNotificationQuestion := model.FormQuestionItem{}
@@ -151,7 +198,7 @@ func (receiver *SubmitFormUseCase) sendNotification(form *entity.SForm) {
 		}
 	}
 	if sendNotificationQuestion.ID == "" {
-		log.Debug("Form ", form.Note, " has not send notification question")
+		logger.FromContext(ctx).Debug("Form ", form.Note, " has not send notification question")
 		return
 	}
 	type QuestionAttributes struct {
@@ -160,13 +207,13 @@ func (receiver *SubmitFormUseCase) sendNotification(form *entity.SForm) {
 	var att QuestionAttributes
 	err = json.Unmarshal(sendNotificationQuestion.Attributes, &att)
 	if err != nil {
-		log.Error("Can not unmarshal send notification value ", sendNotificationQuestion.Attributes)
+		logger.FromContext(ctx).Error("Can not unmarshal send notification value ", sendNotificationQuestion.Attributes)
 		return
 	}
 
 	md, err := receiver.FindByDeviceID(att.Value, receiver.DB)
 	if err != nil {
-		log.Error("FCM Token could not be found for the device id ", att.Value)
+		logger.FromContext(ctx).Error("FCM Token could not be found for the device id ", att.Value)
 	}
 
 	noti := messaging.NotificationParams{
@@ -177,6 +224,6 @@ func (receiver *SubmitFormUseCase) sendNotification(form *entity.SForm) {
 	}
 	err = messaging.SendNotification(receiver.FirebaseApp, noti)
 	if err != nil {
-		log.Error("Failed to send notification ", err)
+		logger.FromContext(ctx).Error("Failed to send notification ", err)
 	}
 }
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,6 +11,9 @@ import (
 	"sen-global-api/internal/domain/request"
 	"sen-global-api/internal/domain/response"
 	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/lifecycle"
+	"sen-global-api/pkg/metrics"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -20,6 +24,11 @@ import (
 	"gorm.io/datatypes"
 )
 
+// a pending queue without progress for syncQueueStaleAfter was left by a crash
+const syncQueueStaleAfter = 10 * time.Minute
+
+var errNoDataToSync = errors.New("no data to sync")
+
 type SyncDataUsecase struct {
 	SheetService       *sheets.Service
 	SubmissionRepo     *repository.SubmissionRepository
@@ -151,6 +160,7 @@ func (uc *SyncDataUsecase) GetData2Sync(afterCreatedAt time.Time, formNote []str
 		for _, item := range submissionData.Items {
 			answers[item.Question] = item.Answer
 		}
+		addScoreColumns(answers, sub.Score)
 
 		req := CreateFormAnswerRequest{
 			SubmissionID:    sub.ID,
@@ -168,6 +178,27 @@ func (uc *SyncDataUsecase) GetData2Sync(afterCreatedAt time.Time, formNote []str
 	return results, nil
 }
 
+// addScoreColumns exports the submission score next to the answers: total, level and one column per section.
+func addScoreColumns(answers map[string]string, raw datatypes.JSON) {
+	if len(raw) == 0 {
+		return
+	}
+	var score entity.SubmissionScore
+	if err := json.Unmarshal(raw, &score); err != nil {
+		return
+	}
+
+	answers["Score"] = strconv.FormatFloat(score.Total, 'f', -1, 64)
+	answers["Score Max"] = strconv.FormatFloat(score.MaxScore, 'f', -1, 64)
+	answers["Score Level"] = score.Level
+	for _, section := range score.Sections {
+		answers["Score - "+section.Section] = strconv.FormatFloat(section.Score, 'f', -1, 64)
+		if section.Level != "" {
+			answers["Score Level - "+section.Section] = section.Level
+		}
+	}
+}
+
 func (uc *SyncDataUsecase) ExcuteCreateAndSyncFormAnswer(req request.SyncDataRequest) (string, error) {
 	const defaultStartTime = "2025-08-01T00:00:00Z"
 
@@ -210,7 +241,7 @@ func (uc *SyncDataUsecase) ExcuteCreateAndSyncFormAnswer(req request.SyncDataReq
 	}
 
 	if len(dataList) == 0 {
-		return "", errors.New("no data to sync")
+		return "", errNoDataToSync
 	}
 
 	// Chuẩn bị headers
@@ -225,8 +256,8 @@ func (uc *SyncDataUsecase) ExcuteCreateAndSyncFormAnswer(req request.SyncDataReq
 	}
 
 	// Cập nhật thông tin cho SyncQueue (dù là tạo mới hay update)
-	syncQueue.LastSubmissionID = dataList[len(dataList)-1].SubmissionID
-	syncQueue.LastSubmittedAt = dataList[len(dataList)-1].SubmittedAt
+	// LastSubmittedAt chi tang theo tung dong da sync, de resume dung cho neu bi ngat giua chung
+	syncQueue.LastSubmittedAt = afterCreatedAt
 	syncQueue.FormNotes = datatypes.JSON(notesJSON)
 	syncQueue.SheetName = req.SheetName
 	syncQueue.SpreadsheetID = spreadsheetID
@@ -245,31 +276,82 @@ func (uc *SyncDataUsecase) ExcuteCreateAndSyncFormAnswer(req request.SyncDataReq
 		}
 	}
 
-	// Đồng bộ dữ liệu lên Google Sheet ở nền
-	go func(queueID uint64) {
+	// Đồng bộ dữ liệu lên Google Sheet ở nền, shutdown se doi hoac danh dau interrupted
+	queueID := syncQueue.ID
+	lifecycle.Default().Go("sync_form_answers", func(ctx context.Context) {
 		for _, item := range dataList {
+			if ctx.Err() != nil {
+				log.Warnf("[SYNC] QueueID %d interrupted, resumes after %s", queueID, item.SubmittedAt.Format(time.RFC3339))
+				_ = uc.SyncQueueRepo.UpdateStatusByID(queueID, value.SyncQueueStatusInterrupted)
+				return
+			}
+
 			if err := uc.CreateAndSyncFormAnswerv2(item, spreadsheetID, req.SheetName, headers, headerIndex, queueID); err != nil {
 				fmt.Printf("[SYNC ERROR] StudentCustomID %s: %v\n", item.StudentCustomID, err)
-				continue
+			}
+			if err := uc.SyncQueueRepo.UpdateProgress(queueID, item.SubmissionID, item.SubmittedAt); err != nil {
+				log.Errorf("[SYNC ERROR] QueueID %d: save progress: %v", queueID, err)
 			}
 
 			// Nếu đã gọi API 30 lần => nghỉ 1 phút
+			pause := 1 * time.Second
 			if uc.GetCounter() > 40 {
-				time.Sleep(1 * time.Minute)
+				pause = 1 * time.Minute
 				// reset counter
 				atomic.StoreInt64(&uc.counter, 0)
-			} else {
-				time.Sleep(1 * time.Second)
+			}
+			select {
+			case <-time.After(pause):
+			case <-ctx.Done():
 			}
 		}
 
 		// Đánh dấu đã xong
 		_ = uc.SyncQueueRepo.UpdateStatus(queueID, string(value.SyncQueueStatusDone))
-	}(syncQueue.ID)
+	})
 
 	return dataList[len(dataList)-1].SubmittedAt.String(), nil
 }
 
+// ResumeInterrupted restarts the syncs cut by a shutdown or a crash, from their last synced submission.
+func (uc *SyncDataUsecase) ResumeInterrupted() {
+	staleBefore := time.Now().Add(-syncQueueStaleAfter)
+	queues, err := uc.SyncQueueRepo.GetResumable(staleBefore)
+	if err != nil {
+		log.Errorf("[SYNC RESUME] Failed to fetch interrupted queues: %v", err)
+		return
+	}
+
+	for _, queue := range queues {
+		claimed, err := uc.SyncQueueRepo.ClaimForResume(queue.ID, staleBefore)
+		if err != nil || !claimed {
+			continue
+		}
+
+		var formNotesArr []string
+		if err := json.Unmarshal(queue.FormNotes, &formNotesArr); err != nil {
+			log.Errorf("[SYNC RESUME] QueueID %d: invalid FormNotes: %v", queue.ID, err)
+			_ = uc.SyncQueueRepo.UpdateStatusByID(queue.ID, value.SyncQueueStatusFailed)
+			continue
+		}
+
+		log.Infof("[SYNC RESUME] QueueID %d: resume after %s", queue.ID, queue.LastSubmittedAt.Format(time.RFC3339))
+		_, err = uc.ExcuteCreateAndSyncFormAnswer(request.SyncDataRequest{
+			SheetUrl:  queue.SheetUrl,
+			SheetName: queue.SheetName,
+			FormNotes: formNotesArr,
+			IsAuto:    queue.IsAuto,
+		})
+		switch {
+		case errors.Is(err, errNoDataToSync):
+			_ = uc.SyncQueueRepo.UpdateStatusByID(queue.ID, value.SyncQueueStatusDone)
+		case err != nil:
+			log.Errorf("[SYNC RESUME] QueueID %d: %v", queue.ID, err)
+			_ = uc.SyncQueueRepo.UpdateStatusByID(queue.ID, value.SyncQueueStatusFailed)
+		}
+	}
+}
+
 func ExtractSpreadsheetID(sheetUrl string) (string, error) {
 	re := regexp.MustCompile(`\/d\/([a-zA-Z0-9-_]+)`)
 	matches := re.FindStringSubmatch(sheetUrl)
@@ -367,17 +449,19 @@ func (uc *SyncDataUsecase) GetAllSyncQueue() ([]response.SyncQueueResponse, erro
 	return result, nil
 }
 
-func (uc *SyncDataUsecase) AutoSyncFormAnswersDaily() {
+func (uc *SyncDataUsecase) AutoSyncFormAnswersDaily() error {
 	queues, err := uc.SyncQueueRepo.GetAllAutoSync()
 	if err != nil {
 		log.Printf("Failed to fetch auto-sync queues: %v\n", err)
-		return
+		return err
 	}
 
+	var errs []error
 	for _, queue := range queues {
 		var formNotesArr []string
 		if err := json.Unmarshal(queue.FormNotes, &formNotesArr); err != nil {
 			log.Printf("[AUTO SYNC ERROR] Failed to unmarshal FormNotes for QueueID %d: %v", queue.ID, err)
+			errs = append(errs, err)
 			continue
 		}
 
@@ -388,17 +472,20 @@ func (uc *SyncDataUsecase) AutoSyncFormAnswersDaily() {
 			IsAuto:    queue.IsAuto,
 		}
 
-		go func(q entity.SyncQueue, r request.SyncDataRequest) {
-			log.Printf("[AUTO SYNC] Start syncing for Sheet: %s", q.SheetName)
-			_, err := uc.ExcuteCreateAndSyncFormAnswer(r)
-			if err != nil {
-				log.Printf("[AUTO SYNC ERROR] QueueID %d: %v", q.ID, err)
-			}
-		}(queue, req)
+		log.Printf("[AUTO SYNC] Start syncing for Sheet: %s", queue.SheetName)
+		if _, err := uc.ExcuteCreateAndSyncFormAnswer(req); err != nil {
+			log.Printf("[AUTO SYNC ERROR] QueueID %d: %v", queue.ID, err)
+			errs = append(errs, err)
+		}
 
-		time.Sleep(1 * time.Minute)
+		// dang shutdown thi khong bat dau queue moi
+		if !lifecycle.Default().Sleep(1 * time.Minute) {
+			log.Printf("[AUTO SYNC] Stopped by shutdown")
+			break
+		}
 	}
 
+	return errors.Join(errs...)
 }
 
 func (uc *SyncDataUsecase) StartAutoSyncScheduler() {
@@ -406,7 +493,10 @@ func (uc *SyncDataUsecase) StartAutoSyncScheduler() {
 	// chay vao lic 00:00
 	_, err := c.AddFunc("0 0 0 * * *", func() {
 		log.Println("[CRON] Running AutoSyncFormAnswersDaily at", time.Now().Format(time.RFC3339))
-		uc.AutoSyncFormAnswersDaily()
+		var jobErr error
+		track := metrics.TrackJob("sync_form_answers_daily")
+		defer func() { track(jobErr) }()
+		jobErr = uc.AutoSyncFormAnswersDaily()
 	})
 
 	if err != nil {
@@ -423,11 +513,12 @@ func (uc *SyncDataUsecase) StartAutoSyncScheduler() {
 	// }
 
 	c.Start()
+	lifecycle.Default().RegisterCron("sync_form_answers_daily", c)
 }
 
 /////////// AUTO SYNC FORMS ///////////
 
-func (uc *SyncDataUsecase) AutoSyncForm2() {
+func (uc *SyncDataUsecase) AutoSyncForm2() error {
 	log.Debug("Start AutoSyncForm2")
 
 	// Cấu hình import
@@ -441,7 +532,7 @@ func (uc *SyncDataUsecase) AutoSyncForm2() {
 	formSettings, err := uc.SettingRepository.GetFormSettings2()
 	if err != nil {
 		log.Error("AutoSyncForm2 - failed to get form settings: ", err)
-		return
+		return err
 	}
 	log.Debug("FormSettings: ", formSettings)
 
@@ -449,7 +540,7 @@ func (uc *SyncDataUsecase) AutoSyncForm2() {
 	var importSetting ImportSetting
 	if err := json.Unmarshal([]byte(formSettings.Settings), &importSetting); err != nil {
 		log.Error("AutoSyncForm2 - failed to unmarshal settings: ", err)
-		return
+		return err
 	}
 
 	// 3. Gọi usecase import forms
@@ -461,10 +552,11 @@ func (uc *SyncDataUsecase) AutoSyncForm2() {
 
 	if err := uc.ImportFormsUseCase.SyncForms(req); err != nil {
 		log.Error("AutoSyncForm2 - SyncForms failed: ", err)
-		return
+		return err
 	}
 
 	log.Info("AutoSyncForm2 completed successfully at ", time.Now().Format(time.RFC3339))
+	return nil
 }
 
 func (uc *SyncDataUsecase) StartAutoSyncForm2Scheduler() {
@@ -474,7 +566,10 @@ func (uc *SyncDataUsecase) StartAutoSyncForm2Scheduler() {
 	// Job chạy lúc 05:00:00 hằng ngày
 	_, err := c.AddFunc("0 0 5 * * *", func() {
 		log.Println("[CRON] Running AutoSyncForm2 at", time.Now().Format(time.RFC3339))
-		uc.AutoSyncForm2()
+		var jobErr error
+		track := metrics.TrackJob("sync_form2")
+		defer func() { track(jobErr) }()
+		jobErr = uc.AutoSyncForm2()
 	})
 
 	if err != nil {
@@ -482,6 +577,7 @@ func (uc *SyncDataUsecase) StartAutoSyncForm2Scheduler() {
 	}
 
 	c.Start()
+	lifecycle.Default().RegisterCron("sync_form2", c)
 }
 
 func (uc *SyncDataUsecase) GetCounter() int64 {
@@ -1,8 +1,13 @@
 package usecase
 
 import (
+	"context"
 	"sen-global-api/internal/data/repository"
 	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/realtime"
+
+	log "github.com/sirupsen/logrus"
 )
 
 type UpdateDeviceSheetUseCase struct {
@@ -10,9 +15,32 @@ type UpdateDeviceSheetUseCase struct {
 }
 
 func (receiver UpdateDeviceSheetUseCase) DeactivateDevice(deviceID string, req request.DeactivateDeviceRequest) error {
-	return receiver.DeviceRepository.DeactivateDevice(deviceID, req.Message)
+	if err := receiver.DeviceRepository.DeactivateDevice(deviceID, req.Message); err != nil {
+		return err
+	}
+	// setting cua device kem mode profile
+	orgSettingCache.Invalidate(context.Background(), deviceID)
+	publishDeviceStatus(deviceID, value.Inactive, req.Message)
+	return nil
 }
 
 func (receiver UpdateDeviceSheetUseCase) ActivateDevice(deviceID string, req request.ReactivateDeviceRequest) error {
-	return receiver.DeviceRepository.ActivateDevice(deviceID, req.Message)
+	if err := receiver.DeviceRepository.ActivateDevice(deviceID, req.Message); err != nil {
+		return err
+	}
+	// setting cua device kem mode profile
+	orgSettingCache.Invalidate(context.Background(), deviceID)
+	publishDeviceStatus(deviceID, value.Active, req.Message)
+	return nil
+}
+
+func publishDeviceStatus(deviceID string, status value.Status, message string) {
+	err := realtime.Publish(context.Background(), realtime.DeviceChannel(deviceID), string(value.RealtimeEventStatusChanged), map[string]interface{}{
+		"device_id": deviceID,
+		"status":    value.GetRawStatusValue(status),
+		"message":   message,
+	})
+	if err != nil {
+		log.Error("publish device status changed: ", err)
+	}
 }
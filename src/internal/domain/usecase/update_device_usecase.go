@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"sen-global-api/internal/data/repository"
 	"sen-global-api/internal/domain/entity"
 	"sen-global-api/internal/domain/request"
@@ -62,6 +63,8 @@ func (receiver *UpdateDeviceUseCase) UpdateDeviceV2(deviceID string, req request
 			return nil, err
 		}
 		device.Status = value.DeviceMode(*req.Status)
+		// setting cua device kem mode profile
+		defer orgSettingCache.Invalidate(context.Background(), deviceID)
 	}
 	if req.OutputSpreadsheetUrl != nil {
 		// device.SpreadsheetID = *req.OutputSpreadsheetUrl
@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	log "github.com/sirupsen/logrus"
+	"sen-global-api/pkg/passwordhash"
 )
 
 type UpdateFormUseCase struct {
@@ -29,7 +30,15 @@ func (receiver *UpdateFormUseCase) UpdateForm(formID int, request request.Update
 		return nil, err
 	}
 	if request.Password != nil {
-		form.Password = *request.Password
+		// an empty password removes the protection
+		form.PasswordHash = ""
+		if *request.Password != "" {
+			hashed, err := passwordhash.Hash(*request.Password)
+			if err != nil {
+				return nil, err
+			}
+			form.PasswordHash = hashed
+		}
 	}
 
 	rawQuestions, err := receiver.getRawQuestions(form.SpreadsheetID)
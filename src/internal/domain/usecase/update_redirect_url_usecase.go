@@ -1,9 +1,14 @@
 package usecase
 
 import (
+	"errors"
+	"fmt"
 	"sen-global-api/internal/data/repository"
 	"sen-global-api/internal/domain/entity"
 	"sen-global-api/internal/domain/request"
+	"sen-global-api/pkg/passwordhash"
+	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -18,14 +23,95 @@ func (receiver *UpdateRedirectUrlUseCase) Update(id int, req request.UpdateRedir
 		return nil, err
 	}
 	if req.Password != nil {
-		form.Password = req.Password
+		// an empty password removes the protection
+		if *req.Password == "" {
+			form.HashPassword = nil
+		} else {
+			hashed, err := passwordhash.Hash(*req.Password)
+			if err != nil {
+				return nil, err
+			}
+			form.HashPassword = &hashed
+		}
+	}
+	if req.ValidFrom != nil {
+		if form.ValidFrom, err = parseOptionalTime(*req.ValidFrom); err != nil {
+			return nil, fmt.Errorf("invalid valid_from: %w", err)
+		}
+	}
+	if req.ValidUntil != nil {
+		if form.ValidUntil, err = parseOptionalTime(*req.ValidUntil); err != nil {
+			return nil, fmt.Errorf("invalid valid_until: %w", err)
+		}
+	}
+	if form.ValidFrom != nil && form.ValidUntil != nil && !form.ValidUntil.After(*form.ValidFrom) {
+		return nil, errors.New("valid_until must be after valid_from")
+	}
+	if req.MaxUses != nil {
+		if *req.MaxUses == 0 {
+			form.MaxUses = nil
+		} else {
+			form.MaxUses = req.MaxUses
+		}
+	}
+	if req.ResetUseCount {
+		form.UseCount = 0
 	}
 
-	err = receiver.RedirectUrlRepository.Update(form)
+	var targets []entity.SRedirectUrlTarget
+	if req.Targets != nil {
+		if targets, err = buildRedirectUrlTargets(*req.Targets); err != nil {
+			return nil, err
+		}
+	}
 
+	err = receiver.RedirectUrlRepository.Update(form)
 	if err != nil {
 		log.Error(err)
 		return nil, err
 	}
+
+	if req.Targets != nil {
+		if err := receiver.RedirectUrlRepository.ReplaceTargets(form.ID, targets); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		form.Targets = targets
+	}
 	return form, nil
 }
+
+func buildRedirectUrlTargets(reqs []request.RedirectUrlTargetRequest) ([]entity.SRedirectUrlTarget, error) {
+	targets := make([]entity.SRedirectUrlTarget, 0, len(reqs))
+	for i, t := range reqs {
+		if (t.StartTime == "") != (t.EndTime == "") {
+			return nil, fmt.Errorf("target %d: start_time and end_time go together", i)
+		}
+		if t.StartTime != "" {
+			if _, err := time.Parse(redirectUrlClockLayout, t.StartTime); err != nil {
+				return nil, fmt.Errorf("target %d: invalid start_time, expected HH:MM: %s", i, t.StartTime)
+			}
+			if _, err := time.Parse(redirectUrlClockLayout, t.EndTime); err != nil {
+				return nil, fmt.Errorf("target %d: invalid end_time, expected HH:MM: %s", i, t.EndTime)
+			}
+			if t.StartTime == t.EndTime {
+				return nil, fmt.Errorf("target %d: empty time window", i)
+			}
+		}
+
+		target := entity.SRedirectUrlTarget{
+			TargetUrl:      strings.TrimSpace(t.TargetUrl),
+			Weight:         t.Weight,
+			Language:       strings.ToLower(strings.TrimSpace(t.Language)),
+			OrganizationID: t.OrganizationID,
+			StartTime:      t.StartTime,
+			EndTime:        t.EndTime,
+			Order:          i,
+		}
+		if target.Weight == 0 && !target.HasRule() {
+			target.Weight = 1
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
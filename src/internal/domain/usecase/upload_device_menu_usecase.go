@@ -1,9 +1,14 @@
 package usecase
 
 import (
+	"context"
 	"fmt"
 	"sen-global-api/internal/data/repository"
 	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/realtime"
+
+	log "github.com/sirupsen/logrus"
 )
 
 type UploadDeviceMenuUseCase struct {
@@ -35,5 +40,15 @@ func (receiver *UploadDeviceMenuUseCase) Upload(req request.UploadDeviceMenuRequ
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	orgDeviceMenuCache.Invalidate(context.Background(), req.OrganizationID)
+
+	// bao cho cac device cua org tai lai menu
+	err := realtime.Publish(context.Background(), realtime.OrganizationChannel(req.OrganizationID), string(value.RealtimeEventMenuChanged), map[string]interface{}{
+		"organization_id": req.OrganizationID,
+	})
+	if err != nil {
+		log.Error("publish device menu changed: ", err)
+	}
+
 	return nil
 }
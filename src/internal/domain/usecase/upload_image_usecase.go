@@ -24,6 +24,8 @@ import (
 type UploadImageUseCase struct {
 	uploader.UploadProvider
 	*repository.ImageRepository
+	// optional, checks the photo consent when the image belongs to a child
+	ChildPrivacyUseCase *ChildPrivacyUseCase
 }
 
 func getImageDimensions(data []byte) (int, int, error) {
@@ -251,6 +253,13 @@ func (receiver *UploadImageUseCase) UploadImages(
 }
 
 func (uc *UploadImageUseCase) UploadImagev3(data []byte, req request.UploadImageRequest) (*response.ImageResponse, error) {
+	// Ảnh của child chỉ được lưu khi phụ huynh đã đồng ý
+	if uc.ChildPrivacyUseCase != nil {
+		if err := uc.ChildPrivacyUseCase.CheckOwnerPhotoConsent(req.OwnerID, req.OwnerRole); err != nil {
+			return nil, err
+		}
+	}
+
 	// Chuẩn hóa folder, fileName, imageName
 	req.Folder = helper.SanitizeName(req.Folder)
 	req.FileName = helper.SanitizeName(req.FileName)
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sen-global-api/helper"
@@ -335,10 +336,14 @@ func (receiver *UploadSectionMenuUseCase) UploadSectionMenuV2(ctx *gin.Context,
 	}
 
 	rolledBack = true
+	invalidateAllMenus(ctx)
 	return nil
 }
 
 func (receiver *UploadSectionMenuUseCase) DeleteSectionMenu(componentID string) error {
+	// component co the nam trong moi loai menu
+	defer invalidateAllMenus(context.Background())
+
 	// Xóa organization device menu
 	if err := receiver.MenuRepository.DeleteDeviceMenuOrganizationByComponentID(componentID); err != nil {
 		return nil
@@ -549,6 +554,8 @@ func (receiver *UploadSectionMenuUseCase) UploadStudentMenu(ctx *gin.Context, re
 	}
 
 	rolledBack = true
+	studentMenuCache.Invalidate(ctx, req.StudentID)
+	invalidateSectionMenus(ctx)
 	return nil
 }
 
@@ -702,6 +709,7 @@ func (receiver *UploadSectionMenuUseCase) UploadTeacherMenu(ctx *gin.Context, re
 	}
 
 	rolledBack = true
+	invalidateSectionMenus(ctx)
 	return nil
 }
 
@@ -854,6 +862,7 @@ func (receiver *UploadSectionMenuUseCase) UploadStaffMenu(ctx *gin.Context, req
 	}
 
 	rolledBack = true
+	invalidateSectionMenus(ctx)
 	return nil
 }
 
@@ -997,6 +1006,7 @@ func (receiver *UploadSectionMenuUseCase) UploadChildMenu(ctx *gin.Context, req
 	}
 
 	rolledBack = true
+	invalidateSectionMenus(ctx)
 	return nil
 }
 
@@ -1274,6 +1284,7 @@ func (receiver *UploadSectionMenuUseCase) UploadParentMenu(ctx *gin.Context, req
 	}
 
 	rolledBack = true
+	invalidateSectionMenus(ctx)
 	return nil
 }
 
@@ -1418,6 +1429,7 @@ func (receiver *UploadSectionMenuUseCase) UploadTeacherMenuOrganization(ctx *gin
 	}
 
 	rolledBack = true
+	invalidateSectionMenus(ctx)
 	return nil
 }
 
@@ -1564,6 +1576,8 @@ func (receiver *UploadSectionMenuUseCase) UploadStudentMenuOrganization(ctx *gin
 	}
 
 	rolledBack = true
+	studentMenuCache.Invalidate(ctx, req.StudentID)
+	invalidateSectionMenus(ctx)
 	return nil
 }
 
@@ -1939,6 +1953,7 @@ func (receiver *UploadSectionMenuUseCase) UploadDepartmentMenu(ctx *gin.Context,
 	}
 
 	rolledBack = true
+	invalidateSectionMenus(ctx)
 	return nil
 }
 
@@ -2061,6 +2076,7 @@ func (receiver *UploadSectionMenuUseCase) UploadDepartmentMenuOrganization(ctx *
 	}
 
 	rolledBack = true
+	invalidateSectionMenus(ctx)
 	return nil
 }
 
@@ -2366,6 +2382,7 @@ func (receiver *UploadSectionMenuUseCase) UploadOrganizationDeviceMenu(ctx *gin.
 	}
 
 	rolledBack = true
+	orgDeviceMenuCache.Invalidate(ctx, req.OrganizationID)
 	return nil
 }
 
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"errors"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/request"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/formgrant"
+	"strconv"
+)
+
+var ErrFormPasswordRequired = errors.New("this form is protected, verify its password first")
+
+type VerifyFormPasswordUseCase struct {
+	FormRepo    *repository.FormRepository
+	AttemptRepo *repository.PasswordAttemptRepository
+}
+
+// Verify checks the password of a protected form and returns a short-lived grant opening the form,
+// a form without password always passes.
+func (receiver *VerifyFormPasswordUseCase) Verify(req request.VerifyFormPasswordRequest) (*response.FormGrantResponse, error) {
+	form, err := receiver.FormRepo.GetFormByID(req.FormID)
+	if err != nil {
+		return nil, errors.New("form not found")
+	}
+	if form.PasswordHash != "" {
+		key := strconv.FormatUint(form.ID, 10)
+		if err := checkCodePassword(receiver.AttemptRepo, value.PasswordAttemptScopeForm, key, form.PasswordHash, req.Password); err != nil {
+			return nil, err
+		}
+	}
+
+	grant, expiresAt := formgrant.Sign(form.ID)
+	return &response.FormGrantResponse{Grant: grant, ExpiresAt: expiresAt}, nil
+}
+
+// CheckAccess lets through the calls on a protected form holding a valid grant or the password.
+func (receiver *VerifyFormPasswordUseCase) CheckAccess(form entity.SForm, grant string, password string) error {
+	if form.PasswordHash == "" {
+		return nil
+	}
+	if grant != "" && formgrant.Verify(grant, form.ID) == nil {
+		return nil
+	}
+	if password != "" {
+		key := strconv.FormatUint(form.ID, 10)
+		return checkCodePassword(receiver.AttemptRepo, value.PasswordAttemptScopeForm, key, form.PasswordHash, password)
+	}
+	return ErrFormPasswordRequired
+}
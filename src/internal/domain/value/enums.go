@@ -1181,6 +1181,21 @@ const (
 	DeviceTypeANDROID = DeviceType("ANDROID")
 )
 
+func (t DeviceType) IsValid() bool {
+	return t == DeviceTypeIOS || t == DeviceTypeANDROID
+}
+
+// result of the app version check done by the devices on startup
+type AppUpdateStatus string
+
+const (
+	AppUpdateStatusOK        AppUpdateStatus = "ok"
+	AppUpdateStatusAvailable AppUpdateStatus = "update_available"
+	AppUpdateStatusRequired  AppUpdateStatus = "update_required"
+	// the version is unknown or not parseable
+	AppUpdateStatusUnknown AppUpdateStatus = "unknown"
+)
+
 type NotificationType string
 
 const (
@@ -1190,6 +1205,92 @@ const (
 	NotificationType_UserMessageChanged         NotificationType = "user_message_changed"
 	NotificationType_NoteChanged                NotificationType = "note_changed"
 	NotificationType_DeviceStatusChanged        NotificationType = "device_status_changed"
+	NotificationType_StudentAbsent              NotificationType = "student_absent"
+	NotificationType_BookingReminder            NotificationType = "booking_reminder"
+	NotificationType_DeviceOffline              NotificationType = "device_offline"
+	NotificationType_Announcement               NotificationType = "announcement"
+)
+
+// DeviceCommandType is a remote command run by the app of a device.
+type DeviceCommandType string
+
+const (
+	DeviceCommandReloadMenus        DeviceCommandType = "reload_menus"
+	DeviceCommandForceLogout        DeviceCommandType = "force_logout"
+	DeviceCommandClearCache         DeviceCommandType = "clear_cache"
+	DeviceCommandResyncForms        DeviceCommandType = "resync_forms"
+	DeviceCommandShowMessage        DeviceCommandType = "show_message"
+	DeviceCommandCaptureDiagnostics DeviceCommandType = "capture_diagnostics"
+)
+
+func (t DeviceCommandType) IsValid() bool {
+	switch t {
+	case DeviceCommandReloadMenus, DeviceCommandForceLogout, DeviceCommandClearCache,
+		DeviceCommandResyncForms, DeviceCommandShowMessage, DeviceCommandCaptureDiagnostics:
+		return true
+	}
+	return false
+}
+
+type DeviceCommandStatus string
+
+const (
+	DeviceCommandStatusQueued DeviceCommandStatus = "queued"
+	// pushed by FCM or fetched by the device poll, not yet acknowledged
+	DeviceCommandStatusDelivered    DeviceCommandStatus = "delivered"
+	DeviceCommandStatusAcknowledged DeviceCommandStatus = "acknowledged"
+	DeviceCommandStatusFailed       DeviceCommandStatus = "failed"
+	// not acknowledged before its expiry
+	DeviceCommandStatusExpired DeviceCommandStatus = "expired"
+)
+
+// DeviceLimitScope is what a device limit applies to.
+type DeviceLimitScope string
+
+const (
+	// devices registered in the organization
+	DeviceLimitScopeOrganization DeviceLimitScope = "organization"
+	// devices registered by the user
+	DeviceLimitScopeUser DeviceLimitScope = "user"
+)
+
+// ReportType is the kind of a generated PDF report.
+type ReportType string
+
+const (
+	ReportTypeSubmission     ReportType = "submission"
+	ReportTypeStudentProfile ReportType = "student_profile"
+	ReportTypeClassSummary   ReportType = "class_summary"
+)
+
+func (t ReportType) IsValid() bool {
+	switch t {
+	case ReportTypeSubmission, ReportTypeStudentProfile, ReportTypeClassSummary:
+		return true
+	default:
+		return false
+	}
+}
+
+type ReportJobStatus string
+
+const (
+	ReportJobStatusPending    ReportJobStatus = "pending"
+	ReportJobStatusProcessing ReportJobStatus = "processing"
+	ReportJobStatusDone       ReportJobStatus = "done"
+	ReportJobStatusFailed     ReportJobStatus = "failed"
+	// ReportJobStatusInterrupted: stopped by a shutdown, resumed on next start
+	ReportJobStatusInterrupted ReportJobStatus = "interrupted"
+)
+
+// DeviceConnectivity is the state of a device in the fleet view.
+type DeviceConnectivity string
+
+const (
+	DeviceConnectivityOnline  DeviceConnectivity = "online"
+	DeviceConnectivityOffline DeviceConnectivity = "offline"
+	// the device never sent a heartbeat (app without heartbeat)
+	DeviceConnectivityUnknown DeviceConnectivity = "unknown"
 )
 
 type FcmTopics string
@@ -1237,6 +1338,11 @@ const (
 	DeviceModeL           DeviceMode = "mode l"
 )
 
+// IsInUse tells whether the device is used in one of the modes T/S/P/L, the ones with a mode profile.
+func (m DeviceMode) IsInUse() bool {
+	return m == DeviceModeT || m == DeviceModeS || m == DeviceModeP || m == DeviceModeL
+}
+
 type DeviceConditionKey string
 
 const (
@@ -1284,6 +1390,8 @@ const (
 	SyncQueueStatusPending SyncQueueStatus = "pending"
 	SyncQueueStatusDone    SyncQueueStatus = "done"
 	SyncQueueStatusFailed  SyncQueueStatus = "failed"
+	// SyncQueueStatusInterrupted: stopped by a shutdown, resumed from LastSubmittedAt on next start
+	SyncQueueStatusInterrupted SyncQueueStatus = "interrupted"
 )
 
 type LoginType string
@@ -1412,6 +1520,12 @@ const (
 	AccountsLogSetValue3  AccountsLogType = "set-value-3"
 )
 
+// written by the server only, not accepted from the apps
+const (
+	AccountsLogTypeLockout AccountsLogType = "lockout"
+	AccountsLogTypeUnlock  AccountsLogType = "unlock"
+)
+
 func (k AccountsLogType) IsValid() bool {
 	switch k {
 	case AccountsLogTypeLogin,
@@ -1425,5 +1539,213 @@ func (k AccountsLogType) IsValid() bool {
 	}
 }
 
+// child guardian relationship
+type GuardianRelationship string
+
+const (
+	GuardianRelationshipMother        GuardianRelationship = "mother"
+	GuardianRelationshipFather        GuardianRelationship = "father"
+	GuardianRelationshipLegalGuardian GuardianRelationship = "legal_guardian"
+	GuardianRelationshipNanny         GuardianRelationship = "nanny"
+)
+
+func (r GuardianRelationship) IsValid() bool {
+	switch r {
+	case GuardianRelationshipMother,
+		GuardianRelationshipFather,
+		GuardianRelationshipLegalGuardian,
+		GuardianRelationshipNanny:
+		return true
+	default:
+		return false
+	}
+}
+
+// child data request (privacy)
+type ChildDataRequestType string
+
+const (
+	ChildDataRequestTypeExport  ChildDataRequestType = "export"
+	ChildDataRequestTypeErasure ChildDataRequestType = "erasure"
+)
+
+type ChildDataRequestStatus string
+
+const (
+	ChildDataRequestStatusPending    ChildDataRequestStatus = "pending"
+	ChildDataRequestStatusProcessing ChildDataRequestStatus = "processing"
+	ChildDataRequestStatusDone       ChildDataRequestStatus = "done"
+	ChildDataRequestStatusFailed     ChildDataRequestStatus = "failed"
+)
+
+// attendance
+type AttendanceEventType string
+
+const (
+	AttendanceEventTypeCheckIn  AttendanceEventType = "check_in"
+	AttendanceEventTypeCheckOut AttendanceEventType = "check_out"
+)
+
+func (t AttendanceEventType) IsValid() bool {
+	switch t {
+	case AttendanceEventTypeCheckIn,
+		AttendanceEventTypeCheckOut:
+		return true
+	default:
+		return false
+	}
+}
+
+type AttendanceSource string
+
+const (
+	AttendanceSourceQRScan      AttendanceSource = "qr_scan"
+	AttendanceSourceDeviceLogin AttendanceSource = "device_login"
+	AttendanceSourceManual      AttendanceSource = "manual"
+)
+
+func (s AttendanceSource) IsValid() bool {
+	switch s {
+	case AttendanceSourceQRScan,
+		AttendanceSourceDeviceLogin,
+		AttendanceSourceManual:
+		return true
+	default:
+		return false
+	}
+}
+
+type AttendanceStatus string
+
+const (
+	AttendanceStatusPresent AttendanceStatus = "present"
+	AttendanceStatusLate    AttendanceStatus = "late"
+	AttendanceStatusAbsent  AttendanceStatus = "absent"
+	AttendanceStatusExcused AttendanceStatus = "excused"
+)
+
+func (s AttendanceStatus) IsValid() bool {
+	switch s {
+	case AttendanceStatusPresent,
+		AttendanceStatusLate,
+		AttendanceStatusAbsent,
+		AttendanceStatusExcused:
+		return true
+	default:
+		return false
+	}
+}
+
+// calendar booking
+type BookableResourceType string
+
+const (
+	BookableResourceTypeRoom    BookableResourceType = "room"
+	BookableResourceTypeTeacher BookableResourceType = "teacher"
+	BookableResourceTypeDevice  BookableResourceType = "device"
+)
+
+func (t BookableResourceType) IsValid() bool {
+	switch t {
+	case BookableResourceTypeRoom,
+		BookableResourceTypeTeacher,
+		BookableResourceTypeDevice:
+		return true
+	default:
+		return false
+	}
+}
+
+type BookingReservationStatus string
+
+const (
+	BookingReservationStatusConfirmed BookingReservationStatus = "confirmed"
+	BookingReservationStatusCancelled BookingReservationStatus = "cancelled"
+)
+
+// realtime device push
+type RealtimeEventType string
+
+const (
+	RealtimeEventSettingsChanged RealtimeEventType = "settings_changed"
+	RealtimeEventMenuChanged     RealtimeEventType = "menu_changed"
+	RealtimeEventStatusChanged   RealtimeEventType = "status_changed"
+	RealtimeEventNews            RealtimeEventType = "news"
+	RealtimeEventAnnouncement    RealtimeEventType = "announcement"
+	RealtimeEventDeviceCommand   RealtimeEventType = "device_command"
+)
+
+// announcement
+type AnnouncementStatus string
+
+const (
+	AnnouncementStatusDraft     AnnouncementStatus = "draft"
+	AnnouncementStatusPublished AnnouncementStatus = "published"
+)
+
+func (s AnnouncementStatus) IsValid() bool {
+	switch s {
+	case AnnouncementStatusDraft,
+		AnnouncementStatusPublished:
+		return true
+	default:
+		return false
+	}
+}
+
+type AnnouncementTargetType string
+
+const (
+	AnnouncementTargetDepartment AnnouncementTargetType = "department"
+	AnnouncementTargetRole       AnnouncementTargetType = "role"
+	AnnouncementTargetDevice     AnnouncementTargetType = "device"
+)
+
+func (t AnnouncementTargetType) IsValid() bool {
+	switch t {
+	case AnnouncementTargetDepartment,
+		AnnouncementTargetRole,
+		AnnouncementTargetDevice:
+		return true
+	default:
+		return false
+	}
+}
+
+type AnnouncementAttachmentType string
+
+const (
+	AnnouncementAttachmentImage AnnouncementAttachmentType = "image"
+	AnnouncementAttachmentPdf   AnnouncementAttachmentType = "pdf"
+)
+
+func (t AnnouncementAttachmentType) IsValid() bool {
+	switch t {
+	case AnnouncementAttachmentImage,
+		AnnouncementAttachmentPdf:
+		return true
+	default:
+		return false
+	}
+}
+
+// redirect url scan
+type RedirectUrlScanOutcome string
+
+const (
+	RedirectUrlScanResolved   RedirectUrlScanOutcome = "resolved"
+	RedirectUrlScanNotStarted RedirectUrlScanOutcome = "not_started"
+	RedirectUrlScanExpired    RedirectUrlScanOutcome = "expired"
+	RedirectUrlScanExhausted  RedirectUrlScanOutcome = "exhausted"
+)
+
+// password attempt scope
+type PasswordAttemptScope string
+
+const (
+	PasswordAttemptScopeRedirectUrl PasswordAttemptScope = "redirect_url"
+	PasswordAttemptScopeForm        PasswordAttemptScope = "form"
+)
+
 const ProfileCachePrefix = "profile-service:"
 const MainCachePrefix = "main-service:"
@@ -0,0 +1,56 @@
+package firebase
+
+import (
+	"context"
+	"encoding/json"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/realtime"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+)
+
+// DeviceSettingsAdapter mirrors the settings_changed events to the Firestore `device_settings` collection
+// for the devices still listening on Firestore. The events leave out the top menu password, it is read
+// from SettingRepo.
+type DeviceSettingsAdapter struct {
+	SettingRepo *repository.OrganizationSettingRepository
+}
+
+func (a DeviceSettingsAdapter) Push(ctx context.Context, event realtime.Event) error {
+	if event.Type != string(value.RealtimeEventSettingsChanged) || !strings.HasPrefix(event.Channel, realtime.DeviceChannel("")) {
+		return nil
+	}
+
+	var setting response.OrgSettingResponse
+	if err := json.Unmarshal(event.Data, &setting); err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"device_id":            setting.DeviceID,
+		"is_view_message_box":  setting.IsViewMessageBox,
+		"is_show_message":      setting.IsShowMessage,
+		"message_box":          setting.MessageBox,
+		"is_show_special_btn":  setting.IsShowSpecialBtn,
+		"is_deactive_app":      setting.IsDeactiveApp,
+		"message_deactive_app": setting.MessageDeactiveApp,
+		"is_deactive_top_menu": setting.IsDeactiveTopMenu,
+		"message_top_menu":     setting.MessageTopMenu,
+		"updated_at":           time.Now(),
+	}
+	if a.SettingRepo != nil {
+		if orgSetting, err := a.SettingRepo.GetByDeviceID(setting.DeviceID); err == nil {
+			data["top_menu_password"] = orgSetting.TopMenuPassword
+		}
+	}
+
+	// Upsert theo device_id
+	_, err := InitFirestoreClient().Collection("device_settings").
+		Doc(setting.DeviceID).
+		Set(ctx, data, firestore.MergeAll)
+	return err
+}
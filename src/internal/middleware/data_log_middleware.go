@@ -4,18 +4,34 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"math/rand"
+	"net/http"
+	"sen-global-api/internal/data/repository"
 	"sen-global-api/internal/domain/entity"
+	"sen-global-api/pkg/logger"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"gorm.io/datatypes"
-	"gorm.io/gorm"
 )
 
-func GeneralLoggerMiddleware(db *gorm.DB) gin.HandlerFunc {
+const (
+	dataLogRedacted  = "[REDACTED]"
+	dataLogPII       = "[PII]"
+	dataLogTruncated = "[TRUNCATED]"
+)
+
+// GeneralLoggerMiddleware ghi request/response vào bảng DataLog (audit).
+// Secrets và PII được che, payload bị cắt theo config, ghi bất đồng bộ qua DataLogWriter.
+func GeneralLoggerMiddleware(writer *DataLogWriter, sessionRepository repository.SessionRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if writer == nil || rand.Float64() >= writer.sampleRate(c.FullPath()) {
+			c.Next()
+			return
+		}
+
 		// đọc body request
 		bodyBytes, _ := io.ReadAll(c.Request.Body)
 		c.Request.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
@@ -25,66 +41,150 @@ func GeneralLoggerMiddleware(db *gorm.DB) gin.HandlerFunc {
 		for _, p := range c.Params {
 			params[p.Key] = p.Value
 		}
-
-		// convert sang JSON
 		paramsJSON, _ := json.Marshal(params)
 
-		// lấy tất cả headers
-		headers := make(map[string]string)
-		for k, v := range c.Request.Header {
-			// header có thể nhiều value, gộp lại bằng ","
-			headers[k] = strings.Join(v, ",")
-		}
-		headersJSON, _ := json.Marshal(headers)
-
-		// tạo log entry ban đầu
-		logEntry := &entity.DataLog{
-			ID:        uuid.New(),
-			Endpoint:  c.FullPath(),
-			Method:    c.Request.Method,
-			Payload:   datatypes.JSON(bodyBytes),
-			Status:    "PENDING",
-			CreatedAt: time.Now(),
-			Params:    datatypes.JSON(paramsJSON),
-			Headers:   datatypes.JSON(headersJSON),
-		}
-		db.Create(logEntry)
-		c.Set("general_log_id", logEntry.ID)
+		// headers, Authorization/Cookie bị che
+		headersJSON, _ := json.Marshal(logger.RedactHeaders(c.Request.Header))
 
 		// wrap writer để capture response
-		respBody := &bytes.Buffer{}
-		writer := &bodyLogWriter{body: respBody, ResponseWriter: c.Writer}
-		c.Writer = writer
+		respWriter := &bodyLogWriter{body: &bytes.Buffer{}, limit: writer.maxResponse + 1, ResponseWriter: c.Writer}
+		c.Writer = respWriter
 
-		// tiếp tục request
+		start := time.Now()
 		c.Next()
 
-		// update log sau khi handler xong
-		if logID, exists := c.Get("general_log_id"); exists {
-			status := "SUCCESS"
-			var errMsg string
-			if len(c.Errors) > 0 {
-				status = "FAIL"
-				errMsg = c.Errors.String()
+		status := "SUCCESS"
+		var errMsg *string
+		if len(c.Errors) > 0 || c.Writer.Status() >= 400 {
+			status = "FAIL"
+		}
+		if len(c.Errors) > 0 {
+			msg := logger.Redact(c.Errors.String())
+			errMsg = &msg
+		}
+
+		writer.Enqueue(entity.DataLog{
+			ID:           uuid.New(),
+			Endpoint:     c.FullPath(),
+			Method:       c.Request.Method,
+			Payload:      writer.sanitizeJSON(bodyBytes, len(bodyBytes), writer.maxPayload),
+			Response:     writer.sanitizeJSON(respWriter.body.Bytes(), respWriter.size, writer.maxResponse),
+			Params:       datatypes.JSON(paramsJSON),
+			Headers:      datatypes.JSON(headersJSON),
+			Status:       status,
+			StatusCode:   c.Writer.Status(),
+			ErrorMessage: errMsg,
+			UserID:       dataLogUserID(c, sessionRepository),
+			RequestID:    c.GetString(logger.FieldRequestID),
+			LatencyMs:    time.Since(start).Milliseconds(),
+			CreatedAt:    start,
+		})
+	}
+}
+
+// dataLogUserID trả về user của request, kể cả trên các route không qua Secured().
+func dataLogUserID(c *gin.Context, sessionRepository repository.SessionRepository) string {
+	if userID := c.GetString("user_id"); userID != "" {
+		return userID
+	}
+	authorization := c.GetHeader("Authorization")
+	if !strings.HasPrefix(authorization, "Bearer ") {
+		return ""
+	}
+	userID, err := sessionRepository.ExtractUserIDFromToken(strings.TrimPrefix(authorization, "Bearer "))
+	if err != nil || userID == nil {
+		return ""
+	}
+	return *userID
+}
+
+// sanitizeJSON masks the secrets and PII fields of a JSON body and caps its size.
+// Over the cap the nested values are dropped first so the top level fields (qr_code, ...) stay searchable.
+func (w *DataLogWriter) sanitizeJSON(raw []byte, size int, maxBytes int) datatypes.JSON {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil
+	}
+	if size > len(raw) {
+		// only the head of the response was captured
+		summary, _ := json.Marshal(map[string]interface{}{"_truncated": true, "_size": size})
+		return datatypes.JSON(summary)
+	}
+
+	var body interface{}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		// multipart, text, ...: only the size is kept
+		summary, _ := json.Marshal(map[string]interface{}{"_non_json": true, "_size": size})
+		return datatypes.JSON(summary)
+	}
+
+	body = w.redact(body)
+	sanitized, _ := json.Marshal(body)
+	if len(sanitized) <= maxBytes {
+		return datatypes.JSON(sanitized)
+	}
+
+	if object, ok := body.(map[string]interface{}); ok {
+		for k, v := range object {
+			switch v.(type) {
+			case map[string]interface{}, []interface{}:
+				object[k] = dataLogTruncated
 			}
+		}
+		object["_truncated"] = true
+		object["_size"] = size
+		if sanitized, _ = json.Marshal(object); len(sanitized) <= maxBytes {
+			return datatypes.JSON(sanitized)
+		}
+	}
+
+	summary, _ := json.Marshal(map[string]interface{}{"_truncated": true, "_size": size})
+	return datatypes.JSON(summary)
+}
 
-			db.Model(&entity.DataLog{}).
-				Where("id = ?", logID).
-				Updates(map[string]interface{}{
-					"status":        status,
-					"error_message": errMsg,
-					"response":      datatypes.JSON(respBody.Bytes()),
-				})
+func (w *DataLogWriter) redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			switch {
+			case logger.IsSensitive(k):
+				v[k] = dataLogRedacted
+			case w.piiFields[strings.ToLower(k)]:
+				v[k] = dataLogPII
+			default:
+				v[k] = w.redact(item)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = w.redact(item)
 		}
+		return v
+	default:
+		return value
 	}
 }
 
 type bodyLogWriter struct {
 	gin.ResponseWriter
-	body *bytes.Buffer
+	body  *bytes.Buffer
+	limit int
+	size  int
 }
 
-func (w bodyLogWriter) Write(b []byte) (int, error) {
-	w.body.Write(b)                  // copy response vào buffer
+func (w *bodyLogWriter) Write(b []byte) (int, error) {
+	w.size += len(b)
+	// chỉ giữ phần đầu của response, đủ để biết đã bị cắt
+	if room := w.limit - w.body.Len(); room > 0 {
+		if len(b) < room {
+			room = len(b)
+		}
+		w.body.Write(b[:room])
+	}
 	return w.ResponseWriter.Write(b) // ghi response ra client
 }
+
+// Unwrap cho http.ResponseController tới được writer gốc (vd SSE bỏ write deadline).
+func (w *bodyLogWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"context"
+	"sen-global-api/config"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/entity"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	dataLogDefaultBuffer      = 1000
+	dataLogDefaultMaxPayload  = 64 << 10
+	dataLogDefaultMaxResponse = 16 << 10
+	dataLogBatchSize          = 100
+	dataLogFlushInterval      = 2 * time.Second
+)
+
+var dataLogDefaultPIIFields = []string{"email", "phone", "phone_number", "address", "birthday", "date_of_birth", "dob"}
+
+// DataLogWriter saves the request audit logs in the background by batches.
+// When the buffer is full the new logs are dropped rather than slowing the requests down.
+type DataLogWriter struct {
+	repo        *repository.DataLogRepository
+	queue       chan entity.DataLog
+	sampleRates map[string]float64
+	piiFields   map[string]bool
+	maxPayload  int
+	maxResponse int
+	dropped     int64
+}
+
+func NewDataLogWriter(repo *repository.DataLogRepository, cfg config.DataLogConfig) *DataLogWriter {
+	w := &DataLogWriter{
+		repo:        repo,
+		sampleRates: cfg.SampleRates,
+		piiFields:   make(map[string]bool),
+		maxPayload:  cfg.MaxPayloadBytes,
+		maxResponse: cfg.MaxResponseBytes,
+	}
+
+	buffer := cfg.BufferSize
+	if buffer <= 0 {
+		buffer = dataLogDefaultBuffer
+	}
+	w.queue = make(chan entity.DataLog, buffer)
+
+	if w.maxPayload <= 0 {
+		w.maxPayload = dataLogDefaultMaxPayload
+	}
+	if w.maxResponse <= 0 {
+		w.maxResponse = dataLogDefaultMaxResponse
+	}
+
+	fields := cfg.PIIFields
+	if len(fields) == 0 {
+		fields = dataLogDefaultPIIFields
+	}
+	for _, f := range fields {
+		w.piiFields[strings.ToLower(f)] = true
+	}
+	return w
+}
+
+var defaultDataLogWriter *DataLogWriter
+
+// InitDataLog sets the writer used by GeneralLoggerMiddleware, called once at startup.
+func InitDataLog(repo *repository.DataLogRepository, cfg config.DataLogConfig) *DataLogWriter {
+	defaultDataLogWriter = NewDataLogWriter(repo, cfg)
+	return defaultDataLogWriter
+}
+
+// DefaultDataLogWriter returns the writer set at startup, nil before.
+func DefaultDataLogWriter() *DataLogWriter {
+	return defaultDataLogWriter
+}
+
+// sampleRate returns the fraction of the requests of the route to keep.
+func (w *DataLogWriter) sampleRate(route string) float64 {
+	if rate, ok := w.sampleRates[route]; ok {
+		return rate
+	}
+	return 1
+}
+
+// Enqueue hands the log to the background writer without blocking.
+func (w *DataLogWriter) Enqueue(dataLog entity.DataLog) {
+	select {
+	case w.queue <- dataLog:
+	default:
+		if n := atomic.AddInt64(&w.dropped, 1); n%100 == 1 {
+			log.Warnf("DataLogWriter: buffer full, %d logs dropped so far", n)
+		}
+	}
+}
+
+// Depth returns the number of logs waiting to be saved.
+func (w *DataLogWriter) Depth() int {
+	return len(w.queue)
+}
+
+// Run saves the queued logs until ctx is done, then flushes what is left.
+func (w *DataLogWriter) Run(ctx context.Context) {
+	ticker := time.NewTicker(dataLogFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]entity.DataLog, 0, dataLogBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := w.repo.CreateBatch(batch); err != nil {
+			log.Error("DataLogWriter: save logs: " + err.Error())
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				select {
+				case dataLog := <-w.queue:
+					batch = append(batch, dataLog)
+					if len(batch) >= dataLogBatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		case dataLog := <-w.queue:
+			batch = append(batch, dataLog)
+			if len(batch) >= dataLogBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
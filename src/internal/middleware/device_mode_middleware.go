@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/response"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// DeviceRoleChecker tells whether a device can call the endpoints of a role in its current mode.
+type DeviceRoleChecker interface {
+	DeviceAllowsRole(ctx context.Context, deviceID string, role entity.Role) (bool, error)
+}
+
+type DeviceModeMiddleware struct {
+	SessionRepository repository.SessionRepository
+	Checker           DeviceRoleChecker
+	DeviceRepository  *repository.DeviceRepository
+}
+
+// RequireDeviceRole rejects the calls of a device whose mode profile does not allow role, e.g. a device in
+// student mode calling a teacher endpoint. The device comes from a device token, or for a user token from the
+// X-Device-ID header (or device_id) which must be a device of the user. The apps always send one of them, a user
+// token without header is a web call and is not checked. The calls without token are left to the Secured middleware.
+func (m DeviceModeMiddleware) RequireDeviceRole(role entity.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims := featureFlagClaims(m.SessionRepository, c.GetHeader("Authorization"))
+		if claims == nil {
+			c.Next()
+			return
+		}
+
+		deviceIDs, status := m.devicesOfCall(c, claims)
+		if status != 0 {
+			message := "failed to check the device mode"
+			if status == http.StatusForbidden {
+				message = "the device is not a device of the user"
+			}
+			c.AbortWithStatusJSON(status, response.FailedResponse{
+				Code:  status,
+				Error: message,
+			})
+			return
+		}
+
+		allowed := true
+		var err error
+		for _, deviceID := range deviceIDs {
+			allowed, err = m.Checker.DeviceAllowsRole(c.Request.Context(), deviceID, role)
+			if err != nil || !allowed {
+				break
+			}
+		}
+		if err != nil {
+			log.Error("DeviceModeMiddleware.RequireDeviceRole: ", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response.FailedResponse{
+				Code:  http.StatusInternalServerError,
+				Error: "failed to check the device mode",
+			})
+			return
+		}
+		if !allowed {
+			c.AbortWithStatusJSON(http.StatusForbidden, response.FailedResponse{
+				Code:    http.StatusForbidden,
+				Message: "Access Denied",
+				Error:   "not allowed in the mode of the device",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// devicesOfCall returns the devices whose mode applies to the call, or the status to answer.
+func (m DeviceModeMiddleware) devicesOfCall(c *gin.Context, claims map[string]interface{}) ([]string, int) {
+	if tokenDeviceID, ok := claims["device_uuid"].(string); ok && tokenDeviceID != "" {
+		return []string{tokenDeviceID}, 0
+	}
+	userID, _ := claims["user_id"].(string)
+	if userID == "" {
+		return nil, 0
+	}
+
+	if deviceID := firstNonEmpty(c.GetHeader(DeviceIDHeader), c.Query("device_id")); deviceID != "" {
+		// the header is sent by the caller, it must not name the device of another user
+		ok, err := m.DeviceRepository.IsUserOfDevice(userID, deviceID)
+		if err != nil {
+			log.Error("DeviceModeMiddleware.devicesOfCall: ", err)
+			return nil, http.StatusInternalServerError
+		}
+		if !ok {
+			return nil, http.StatusForbidden
+		}
+		return []string{deviceID}, 0
+	}
+
+	return nil, 0
+}
@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/pkg/featureflag"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	OrganizationIDHeader = "X-Organization-ID"
+	DeviceIDHeader       = "X-Device-ID"
+	AppVersionHeader     = "X-App-Version"
+)
+
+// FeatureFlags puts the flag evaluator of the caller in the request context, read by featureflag.Enabled(ctx, key)
+// and featureflag.Variant(ctx, key) in the handlers. The caller is resolved on the first flag read:
+//   - user and roles from the bearer token, the device from a device token or else the X-Device-ID header
+//   - the organization from X-Organization-ID (or organization_id), kept only when the user or device belongs to it;
+//     a device without header gets its own organization
+//   - the app version from X-App-Version (or app_version)
+func FeatureFlags(sessionRepository repository.SessionRepository, deviceRepository *repository.DeviceRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// gin reuses c after the request while the evaluator may be read by a goroutine, copy what resolve needs
+		authorization := c.GetHeader("Authorization")
+		organizationID := firstNonEmpty(c.GetHeader(OrganizationIDHeader), c.Query("organization_id"))
+		deviceID := firstNonEmpty(c.GetHeader(DeviceIDHeader), c.Query("device_id"))
+		appVersion := firstNonEmpty(c.GetHeader(AppVersionHeader), c.Query("app_version"))
+
+		evaluator := featureflag.NewEvaluator(featureflag.Default(), func() featureflag.Subject {
+			subject := featureflag.Subject{AppVersion: appVersion, DeviceID: deviceID, Roles: make([]string, 0)}
+			if claims := featureFlagClaims(sessionRepository, authorization); claims != nil {
+				if userID, ok := claims["user_id"].(string); ok {
+					subject.UserID = userID
+				}
+				if roles, ok := claims["roles"].(string); ok && roles != "" {
+					subject.Roles = strings.Split(roles, ", ")
+				}
+				if tokenDeviceID, ok := claims["device_uuid"].(string); ok {
+					subject.DeviceID = tokenDeviceID
+				}
+			}
+			subject.OrganizationID = featureFlagOrganization(sessionRepository, deviceRepository, subject, organizationID)
+			return subject
+		})
+
+		c.Set(featureflag.ContextKey, evaluator)
+		c.Request = c.Request.WithContext(featureflag.NewContext(c.Request.Context(), evaluator))
+		c.Next()
+	}
+}
+
+func featureFlagClaims(sessionRepository repository.SessionRepository, authorization string) jwt.MapClaims {
+	if !strings.HasPrefix(authorization, "Bearer ") {
+		return nil
+	}
+	token, err := sessionRepository.ValidateToken(strings.TrimPrefix(authorization, "Bearer "))
+	if err != nil {
+		return nil
+	}
+	claims, _ := token.Claims.(jwt.MapClaims)
+	return claims
+}
+
+func featureFlagOrganization(sessionRepository repository.SessionRepository, deviceRepository *repository.DeviceRepository, subject featureflag.Subject, organizationID string) string {
+	switch {
+	case subject.UserID != "" && organizationID != "":
+		// the super admin is in no organization but may preview any of them
+		for _, role := range subject.Roles {
+			if role == entity.SuperAdmin.String() {
+				return organizationID
+			}
+		}
+		userOrg, err := sessionRepository.OrganizationRepository.GetUserOrgInfo(subject.UserID, organizationID)
+		if err != nil || userOrg.UserID.String() != subject.UserID {
+			return ""
+		}
+		return organizationID
+	case subject.DeviceID != "" && organizationID != "":
+		if _, err := deviceRepository.GetOrgDeviceByDeviceIdAndOrgID(organizationID, subject.DeviceID); err != nil {
+			return ""
+		}
+		return organizationID
+	case subject.DeviceID != "":
+		orgDevice, err := deviceRepository.GetOrgByDeviceID(subject.DeviceID)
+		if err != nil {
+			log.Debugf("FeatureFlags: organization of device %s: %v", subject.DeviceID, err)
+			return ""
+		}
+		return orgDevice.OrganizationID.String()
+	}
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
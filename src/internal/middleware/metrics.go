@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"sen-global-api/pkg/metrics"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics đo latency và status code theo route (template của gin, không phải path thật)
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.URL.Path == "/metrics" {
+			c.Next()
+			return
+		}
+
+		done := metrics.TrackInFlight()
+		start := time.Now()
+		c.Next()
+		done()
+
+		route := c.FullPath()
+		if route == "" {
+			// 404, không dùng path thật để tránh bùng nổ label
+			route = "unmatched"
+		}
+		metrics.ObserveHTTP(c.Request.Method, route, strconv.Itoa(c.Writer.Status()), time.Since(start))
+	}
+}
+
+// MetricsToken chỉ cho scraper có "Authorization: Bearer <token>" đọc /metrics
+func MetricsToken(token string) gin.HandlerFunc {
+	expected := []byte("Bearer " + token)
+	return func(c *gin.Context) {
+		if subtle.ConstantTimeCompare([]byte(c.GetHeader("Authorization")), expected) != 1 {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		c.Next()
+	}
+}
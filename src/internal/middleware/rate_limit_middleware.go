@@ -0,0 +1,203 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sen-global-api/config"
+	"sen-global-api/internal/domain/response"
+	"sen-global-api/pkg/ratelimit"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rate limited route groups, their limits can be overridden in the config
+const (
+	RateLimitLogin          = "login"
+	RateLimitRefreshToken   = "refresh_token"
+	RateLimitDeviceRegister = "device_register"
+	RateLimitDeviceReserve  = "device_reserve"
+	RateLimitCodePassword   = "code_password"
+)
+
+const (
+	rateLimitBodyKey   = "rate_limit_body"
+	rateLimitMaxBody   = 1 << 20
+	rateLimitStrikeTTL = 24 * time.Hour
+)
+
+var defaultRateLimitRules = map[string]ratelimit.Rule{
+	// wrong credentials per username, schools share an IP so the IP limit is higher
+	RateLimitLogin:          {Limit: 5, IPLimit: 50, Window: 15 * time.Minute, FailuresOnly: true, Lockout: 15 * time.Minute, MaxLockout: 24 * time.Hour},
+	RateLimitRefreshToken:   {Limit: 60, Window: time.Minute, Lockout: 5 * time.Minute, MaxLockout: time.Hour},
+	RateLimitDeviceRegister: {Limit: 10, IPLimit: 60, Window: time.Minute, Lockout: 5 * time.Minute, MaxLockout: 24 * time.Hour},
+	RateLimitDeviceReserve:  {Limit: 10, IPLimit: 60, Window: time.Minute, Lockout: 5 * time.Minute, MaxLockout: 24 * time.Hour},
+	// wrong passwords of the QR redirects and the forms
+	RateLimitCodePassword: {Limit: 10, IPLimit: 50, Window: 15 * time.Minute, FailuresOnly: true, Lockout: 15 * time.Minute, MaxLockout: 24 * time.Hour},
+}
+
+// RateLimitRules merges the limits of the config into the default ones.
+func RateLimitRules(cfg config.RateLimitConfig) map[string]ratelimit.Rule {
+	rules := make(map[string]ratelimit.Rule, len(defaultRateLimitRules))
+	for group, rule := range defaultRateLimitRules {
+		rule.StrikeTTL = rateLimitStrikeTTL
+		rules[group] = rule
+	}
+
+	for group, override := range cfg.Groups {
+		rule := rules[group]
+		rule.StrikeTTL = rateLimitStrikeTTL
+		if override.Limit > 0 {
+			rule.Limit = override.Limit
+		}
+		if override.IPLimit > 0 {
+			rule.IPLimit = override.IPLimit
+		}
+		if override.WindowSeconds > 0 {
+			rule.Window = time.Duration(override.WindowSeconds) * time.Second
+		}
+		if override.FailuresOnly != nil {
+			rule.FailuresOnly = *override.FailuresOnly
+		}
+		if override.LockoutSeconds > 0 {
+			rule.Lockout = time.Duration(override.LockoutSeconds) * time.Second
+		}
+		if override.MaxLockoutSeconds > 0 {
+			rule.MaxLockout = time.Duration(override.MaxLockoutSeconds) * time.Second
+		}
+		rules[group] = rule
+	}
+	return rules
+}
+
+// RateLimitKey extracts one key of the request, the empty keys are not limited.
+type RateLimitKey struct {
+	Kind  string
+	Value func(context *gin.Context) string
+}
+
+func RateLimitByIP() RateLimitKey {
+	return RateLimitKey{
+		Kind:  ratelimit.KindIP,
+		Value: func(context *gin.Context) string { return context.ClientIP() },
+	}
+}
+
+// RateLimitByBody reads the key from a field of the JSON body, the body stays readable by the handler.
+func RateLimitByBody(kind, field string) RateLimitKey {
+	return RateLimitKey{
+		Kind: kind,
+		Value: func(context *gin.Context) string {
+			v := strings.TrimSpace(jsonBodyField(context, field))
+			if kind == ratelimit.KindUsername {
+				// usernames are matched case-insensitively by MySQL
+				v = strings.ToLower(v)
+			}
+			return v
+		},
+	}
+}
+
+// RateLimit throttles the route group per key and locks the keys going over the limit.
+// A locked key gets 429 with Retry-After until the lock expires or an admin lifts it.
+func RateLimit(limiter *ratelimit.Limiter, group string, keys ...RateLimitKey) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		rule, ok := limiter.Rule(group)
+		if !limiter.Enabled() || !ok {
+			context.Next()
+			return
+		}
+
+		values := make(map[string]string, len(keys))
+		for _, key := range keys {
+			if v := key.Value(context); v != "" {
+				values[key.Kind] = v
+			}
+		}
+
+		for kind, v := range values {
+			if until := limiter.LockedUntil(context, group, kind, v); until != nil {
+				abortRateLimited(context, *until)
+				return
+			}
+		}
+
+		if !rule.FailuresOnly {
+			for kind, v := range values {
+				if until := limiter.Hit(context, group, kind, v); until != nil {
+					abortRateLimited(context, *until)
+					return
+				}
+			}
+			context.Next()
+			return
+		}
+
+		context.Next()
+
+		status := context.Writer.Status()
+		if status < http.StatusBadRequest || status >= http.StatusInternalServerError || status == http.StatusTooManyRequests {
+			return
+		}
+		for kind, v := range values {
+			limiter.Hit(context, group, kind, v)
+		}
+	}
+}
+
+func abortRateLimited(context *gin.Context, until time.Time) {
+	seconds := int(math.Ceil(time.Until(until).Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	context.Header("Retry-After", strconv.Itoa(seconds))
+	context.AbortWithStatusJSON(http.StatusTooManyRequests, response.FailedResponse{
+		Code:    http.StatusTooManyRequests,
+		Message: "Too many attempts, try again later",
+		Error:   fmt.Sprintf("locked until %s", until.UTC().Format(time.RFC3339)),
+	})
+}
+
+// jsonBodyField returns a top level field of the JSON body as a string, the decoded body is cached on the context.
+func jsonBodyField(context *gin.Context, field string) string {
+	var body map[string]interface{}
+	if cached, ok := context.Get(rateLimitBodyKey); ok {
+		body, _ = cached.(map[string]interface{})
+	} else {
+		if context.Request.Body == nil {
+			return ""
+		}
+		original := context.Request.Body
+		raw, err := io.ReadAll(io.LimitReader(original, rateLimitMaxBody))
+		context.Request.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(raw), original), original}
+		if err != nil {
+			return ""
+		}
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		_ = decoder.Decode(&body)
+		context.Set(rateLimitBodyKey, body)
+	}
+
+	v, ok := body[field]
+	if !ok || v == nil {
+		return ""
+	}
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"regexp"
+	"sen-global-api/pkg/logger"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// an incoming id is kept only when it is short and printable, otherwise a new one is generated
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9\-_.:]{1,128}$`)
+
+// RequestID tags the request with the X-Request-ID of the caller or a new one, echoes it in the response
+// and logs one access line per request, except for the health probes.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(logger.RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		c.Set(logger.FieldRequestID, requestID)
+		c.Request = c.Request.WithContext(logger.WithRequestID(c.Request.Context(), requestID))
+		c.Writer.Header().Set(logger.RequestIDHeader, requestID)
+
+		start := time.Now()
+		c.Next()
+
+		if strings.HasPrefix(c.Request.URL.Path, "/health") {
+			return
+		}
+		entry := logger.FromContext(c.Request.Context()).WithFields(log.Fields{
+			"method":     c.Request.Method,
+			"path":       c.Request.URL.Path,
+			"route":      c.FullPath(),
+			"status":     c.Writer.Status(),
+			"latency_ms": time.Since(start).Milliseconds(),
+			"client_ip":  c.ClientIP(),
+		})
+		if len(c.Errors) > 0 {
+			entry = entry.WithField("errors", c.Errors.String())
+		}
+		switch status := c.Writer.Status(); {
+		case status >= 500:
+			entry.Error("request")
+		case status >= 400:
+			entry.Warn("request")
+		default:
+			entry.Info("request")
+		}
+	}
+}
@@ -71,6 +71,48 @@ func (receiver SecuredMiddleware) Secured() gin.HandlerFunc {
 	}
 }
 
+// OptionalDevice sets "device_id" from the device token of the request when there is one,
+// the calls without device token go on, e.g. the app checking its version before login.
+func (receiver SecuredMiddleware) OptionalDevice() gin.HandlerFunc {
+	return func(context *gin.Context) {
+		if deviceID := receiver.deviceOfToken(context.GetHeader("Authorization")); deviceID != "" {
+			context.Set("device_id", deviceID)
+		}
+		context.Next()
+	}
+}
+
+// SecuredDevice requires a device token and sets "device_id" from its device_uuid claim,
+// for the endpoints called by a device before or without a user login.
+func (receiver SecuredMiddleware) SecuredDevice() gin.HandlerFunc {
+	return func(context *gin.Context) {
+		authorizationHeader := context.GetHeader("Authorization")
+		if !strings.HasPrefix(authorizationHeader, "Bearer ") {
+			context.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		deviceID := receiver.deviceOfToken(authorizationHeader)
+		if deviceID == "" {
+			context.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		context.Set("device_id", deviceID)
+		context.Next()
+	}
+}
+
+// deviceOfToken returns the device_uuid claim of a valid device token, "" for a user token or no token.
+func (receiver SecuredMiddleware) deviceOfToken(authorization string) string {
+	if !strings.HasPrefix(authorization, "Bearer ") {
+		return ""
+	}
+	deviceID, err := receiver.SessionRepository.ExtractDeviceIDFromToken(strings.TrimPrefix(authorization, "Bearer "))
+	if err != nil || deviceID == nil {
+		return ""
+	}
+	return *deviceID
+}
+
 func (receiver SecuredMiddleware) ValidateSuperAdminRole() gin.HandlerFunc {
 	return func(context *gin.Context) {
 		authorizationHeader := context.GetHeader("Authorization")
@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"sen-global-api/internal/domain/entity"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// MigrateChildConsents grants the photo and data sharing consents to the children created before the consents
+// existed, their guardians agreed to the terms of the time and keep the uploads and submissions working.
+// A child created later has no consent until its primary contact gives it.
+// It is idempotent: children already holding a consent row are skipped.
+func MigrateChildConsents(db *gorm.DB) error {
+	if err := db.AutoMigrate(&entity.ChildConsent{}); err != nil {
+		return err
+	}
+
+	query := `INSERT IGNORE INTO child_consent
+			(id, child_id, photo_consent, data_sharing_consent, updated_by, created_at, updated_at)
+		SELECT UUID(), c.id, 1, 1, '', NOW(), NOW()
+		FROM s_child c
+		LEFT JOIN child_consent cc ON cc.child_id = c.id
+		WHERE cc.id IS NULL`
+
+	if err := db.Exec(query).Error; err != nil {
+		log.Error("MigrateChildConsents: " + err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package migrations
+
+import (
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/value"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// MigrateChildGuardians consolidates the legacy parent-child links (s_child.parent_id,
+// s_parent_childs and s_user_parent_child) into s_child_guardian.
+// It is idempotent: existing (child_id, guardian_user_id) pairs are kept untouched.
+func MigrateChildGuardians(db *gorm.DB) error {
+	if err := db.AutoMigrate(&entity.SChildGuardian{}); err != nil {
+		return err
+	}
+
+	queries := []string{
+		// creator of a child is its primary contact
+		`INSERT IGNORE INTO s_child_guardian
+			(id, child_id, guardian_user_id, relationship, is_primary_contact, can_pickup, can_view_submissions, created_at, updated_at)
+		SELECT UUID(), c.id, c.parent_id, ?, 1, 1, 1, NOW(), NOW()
+		FROM s_child c
+		WHERE c.parent_id IS NOT NULL AND c.parent_id <> ''`,
+
+		// s_parent_childs stores the s_parent id, resolve it to the user id
+		`INSERT IGNORE INTO s_child_guardian
+			(id, child_id, guardian_user_id, relationship, is_primary_contact, can_pickup, can_view_submissions, created_at, updated_at)
+		SELECT UUID(), pc.child_id, p.user_id, ?, 0, 1, 1, NOW(), NOW()
+		FROM s_parent_childs pc
+		JOIN s_parent p ON p.id = pc.parent_id`,
+
+		// links between user entities
+		`INSERT IGNORE INTO s_child_guardian
+			(id, child_id, guardian_user_id, relationship, is_primary_contact, can_pickup, can_view_submissions, created_at, updated_at)
+		SELECT UUID(), upc.child_id, upc.parent_id, ?, 1, 1, 1, NOW(), NOW()
+		FROM s_user_parent_child upc`,
+	}
+
+	for _, query := range queries {
+		if err := db.Exec(query, value.GuardianRelationshipLegalGuardian).Error; err != nil {
+			log.Error("MigrateChildGuardians: " + err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MigrateChildGuardiansSinglePrimary keeps a single primary contact per child, the backfill of
+// MigrateChildGuardians marked every creator and every s_user_parent_child link as primary.
+// The creator of the child (s_child.parent_id) is kept when it is a primary guardian, otherwise the smallest id.
+func MigrateChildGuardiansSinglePrimary(db *gorm.DB) error {
+	query := `UPDATE s_child_guardian g
+		JOIN (
+			SELECT cg.child_id,
+				COALESCE(MAX(CASE WHEN cg.guardian_user_id = c.parent_id THEN cg.id END), MIN(cg.id)) AS keep_id
+			FROM s_child_guardian cg
+			LEFT JOIN s_child c ON c.id = cg.child_id
+			WHERE cg.is_primary_contact = 1
+			GROUP BY cg.child_id
+			HAVING COUNT(*) > 1
+		) p ON p.child_id = g.child_id
+		SET g.is_primary_contact = 0
+		WHERE g.is_primary_contact = 1 AND g.id <> p.keep_id`
+
+	if err := db.Exec(query).Error; err != nil {
+		log.Error("MigrateChildGuardiansSinglePrimary: " + err.Error())
+		return err
+	}
+
+	return nil
+}
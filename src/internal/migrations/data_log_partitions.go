@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"fmt"
+	"sen-global-api/internal/domain/entity"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// MigrateDataLogPartitions converts data_log to monthly partitions starting at the month of the oldest log,
+// the purge job then drops the months past the retention instead of deleting their rows and adds the coming
+// months. MySQL requires the partition column in the primary key, so the key becomes (id, created_at).
+// It is idempotent: a table already partitioned is left untouched.
+func MigrateDataLogPartitions(db *gorm.DB) error {
+	if err := db.AutoMigrate(&entity.DataLog{}); err != nil {
+		return err
+	}
+
+	var partitions int64
+	if err := db.Raw(`SELECT COUNT(*) FROM information_schema.PARTITIONS
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'data_log' AND PARTITION_NAME IS NOT NULL`).
+		Scan(&partitions).Error; err != nil {
+		return err
+	}
+	if partitions > 0 {
+		return nil
+	}
+
+	var oldest *time.Time
+	if err := db.Raw("SELECT MIN(created_at) FROM data_log").Scan(&oldest).Error; err != nil {
+		return err
+	}
+	now := time.Now()
+	current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	start := current
+	if oldest != nil && oldest.Before(start) {
+		start = time.Date(oldest.Year(), oldest.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	// the partitions are named after their month like the purge job expects, e.g. p202610
+	parts := make([]string, 0)
+	for month := start; !month.After(current.AddDate(0, 1, 0)); month = month.AddDate(0, 1, 0) {
+		parts = append(parts, fmt.Sprintf("PARTITION %s VALUES LESS THAN ('%s')",
+			month.Format("p200601"), month.AddDate(0, 1, 0).Format("2006-01-02")))
+	}
+	parts = append(parts, "PARTITION pmax VALUES LESS THAN (MAXVALUE)")
+
+	query := "ALTER TABLE data_log DROP PRIMARY KEY, ADD PRIMARY KEY (id, created_at)" +
+		" PARTITION BY RANGE COLUMNS(created_at) (" + strings.Join(parts, ", ") + ")"
+	if err := db.Exec(query).Error; err != nil {
+		log.Error("MigrateDataLogPartitions: " + err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package migrations
+
+import (
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/entity/components"
+	"sen-global-api/internal/domain/entity/menu"
+)
+
+// Entities is the list of the models of the schema.
+// The baseline migration creates them, in development mode they are also auto migrated at every boot.
+func Entities() []interface{} {
+	return []interface{}{
+		&entity.SAppKey{},
+		&entity.SQuestion{},
+		&entity.SForm{},
+		&entity.SRedirectUrl{},
+		&entity.SRedirectUrlTarget{},
+		&entity.SRedirectUrlScan{},
+		&entity.SPasswordAttempt{},
+		&entity.SSetting{},
+		&entity.SToDo{},
+		&entity.SSubmission{},
+		&entity.SFormQuestion{},
+		&entity.SMobileDevice{},
+		&entity.SCodeCounting{},
+		&entity.SDevice{},
+		&entity.SOrganization{},
+		&entity.SDeviceComponentValues{},
+		&entity.SRole{},
+		&entity.SUserEntity{},
+		&entity.SQRLoginToken{},
+		&entity.SUserRoles{},
+		&entity.SFunctionClaim{},
+		&entity.SFunctionClaimPermission{},
+		&entity.SUserDevices{},
+		&entity.SImage{},
+		&entity.SVideo{},
+		&entity.SAudio{},
+		&entity.SPdf{},
+		&entity.SUserFCMToken{},
+		&entity.SUserFunctionAuthorize{},
+		&entity.SUserOrg{},
+		&entity.SOrgFormApplication{},
+		&entity.SPreRegister{},
+		&components.Component{},
+		&menu.SuperAdminMenu{},
+		&menu.OrgMenu{},
+		&menu.UserMenu{},
+		&menu.DeviceMenu{},
+		&entity.PublicImage{},
+		&entity.SStaffFormApplication{},
+		&entity.SStudentFormApplication{},
+		&entity.STeacherFormApplication{},
+		&entity.SOrgDevices{},
+		&entity.MemoryComponentValue{},
+		&entity.SUserParentChild{},
+		&entity.SAnswer{},
+		&entity.SRoleOrgSignUp{},
+		&entity.SChild{},
+		&entity.ChildMenu{},
+		&entity.StudentMenu{},
+		&entity.TeacherMenu{},
+		&entity.StaffMenu{},
+		&entity.OrganizationMenuTemplate{},
+		&entity.SyncQueue{},
+		&entity.UserBlockSetting{},
+		&entity.SDeviceMenuV2{},
+		&entity.ParentMenu{},
+		&entity.StudentBlockSetting{},
+		&entity.OrganizationSetting{},
+		&entity.LanguagesConfig{},
+		&entity.OrganizationNewsSetting{},
+		&entity.UserImages{},
+		&entity.AppConfig{},
+		&entity.TeacherMenuOrganization{},
+		&entity.UserDevicesLogin{},
+		&entity.UserSetting{},
+		&entity.DepartmentMenu{},
+		&entity.DepartmentMenuOrganization{},
+		//&entity.ClassroomMenu{},
+		&entity.ValuesAppCurrent{},
+		&entity.AccountsLog{},
+		&entity.SuperAdminEmergencyMenu{},
+		&entity.OrganizationEmergencyMenu{},
+		&entity.LanguageSetting{},
+		&entity.DataLog{},
+		&entity.OrganizationSettingMenu{},
+		&entity.MessageLanguage{},
+		&entity.SParent{},
+		&entity.SParentChilds{},
+		&entity.StudentMenuOrganization{},
+		&entity.ValuesAppHistories{},
+		&entity.SChildGuardian{},
+		&entity.ChildConsent{},
+		&entity.ChildDataRequest{},
+		&entity.AttendanceSetting{},
+		&entity.AttendanceEvent{},
+		&entity.AttendanceRecord{},
+		&entity.BookableResource{},
+		&entity.BookingAvailabilityRule{},
+		&entity.BookingReservation{},
+		&entity.FormScoring{},
+		&entity.Announcement{},
+		&entity.AnnouncementTranslation{},
+		&entity.AnnouncementTarget{},
+		&entity.AnnouncementAttachment{},
+		&entity.AnnouncementRead{},
+		&entity.AppRelease{},
+		&entity.FeatureFlag{},
+		&entity.FeatureFlagAudit{},
+		&entity.DeviceHeartbeat{},
+		&entity.DeviceAlertSetting{},
+		&entity.DeviceOfflineAlert{},
+		&entity.DeviceCommand{},
+		&entity.DeviceEnrollmentCode{},
+		&entity.DeviceEnrollment{},
+		&entity.DeviceLimit{},
+		&entity.DeviceModeProfile{},
+		&entity.Report{},
+		&entity.ReportJob{},
+	}
+}
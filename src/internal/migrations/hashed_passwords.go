@@ -0,0 +1,79 @@
+package migrations
+
+import (
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/pkg/passwordhash"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// MigrateHashedPasswords replaces the plaintext passwords of the QR redirects and the forms by their bcrypt hash.
+// It is idempotent: only rows still holding a plaintext value are touched.
+func MigrateHashedPasswords(db *gorm.DB) error {
+	if err := migrateRedirectUrlPasswords(db); err != nil {
+		log.Error("MigrateHashedPasswords: redirect urls: " + err.Error())
+		return err
+	}
+	if err := migrateFormPasswords(db); err != nil {
+		log.Error("MigrateHashedPasswords: forms: " + err.Error())
+		return err
+	}
+	return nil
+}
+
+func migrateRedirectUrlPasswords(db *gorm.DB) error {
+	// the password column is no longer mapped by SRedirectUrl
+	if !db.Migrator().HasColumn("s_redirect_url", "password") {
+		return nil
+	}
+
+	var rows []struct {
+		ID       uint64
+		Password string
+	}
+	err := db.Table("s_redirect_url").
+		Select("id, password").
+		Where("password IS NOT NULL").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		updates := map[string]interface{}{"password": nil}
+		if row.Password != "" {
+			hashed, err := passwordhash.Hash(row.Password)
+			if err != nil {
+				return err
+			}
+			updates["hash_password"] = hashed
+		}
+		if err := db.Table("s_redirect_url").Where("id = ?", row.ID).Updates(updates).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func migrateFormPasswords(db *gorm.DB) error {
+	var forms []entity.SForm
+	err := db.Select("id, password").Where("password IS NOT NULL AND password <> ''").Find(&forms).Error
+	if err != nil {
+		return err
+	}
+
+	for _, form := range forms {
+		if passwordhash.IsHash(form.PasswordHash) {
+			continue
+		}
+		hashed, err := passwordhash.Hash(form.PasswordHash)
+		if err != nil {
+			return err
+		}
+		if err := db.Model(&entity.SForm{}).Where("id = ?", form.ID).UpdateColumn("password", hashed).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
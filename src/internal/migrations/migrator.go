@@ -0,0 +1,434 @@
+package migrations
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sen-global-api/pkg/mysql"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Migration is one versioned change of the schema or of the data.
+// The versions are timestamps (20060102150405) so migrations written on different branches keep their order.
+//
+// MySQL commits DDL statements implicitly, so a migration is not wrapped in a transaction:
+// it must be safe to run again when it failed half way (IF NOT EXISTS, HasColumn, INSERT IGNORE, ...).
+type Migration struct {
+	Version int64
+	Name    string
+	Up      func(db *gorm.DB) error
+	// Down is nil for the migrations that cannot be reverted
+	Down func(db *gorm.DB) error
+	// Revision is part of the checksum of a Go migration, bump it when the code of Up or Down changes
+	// so the databases that applied the previous code are reported as edited
+	Revision int
+
+	checksum string
+}
+
+// SchemaMigration records an applied migration.
+type SchemaMigration struct {
+	Version     int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name        string    `gorm:"type:varchar(255);not null"`
+	Checksum    string    `gorm:"type:varchar(64);not null"`
+	AppliedAt   time.Time `gorm:"type:datetime(3);not null"`
+	ExecutionMs int64     `gorm:"not null;default:0"`
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type MigrationStatus struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+	// ChecksumMismatch: the migration was edited after it was applied
+	ChecksumMismatch bool `json:"checksum_mismatch,omitempty"`
+	// Missing: applied in the database but unknown to this build
+	Missing bool `json:"missing,omitempty"`
+}
+
+var (
+	ErrChecksumMismatch  = errors.New("applied migrations were edited")
+	ErrPendingMigrations = errors.New("pending migrations")
+)
+
+const migrationLock = "schema_migrations"
+
+//go:embed sql
+var sqlFiles embed.FS
+
+var registry []Migration
+
+// register adds a Go migration, called from init.
+func register(m Migration) {
+	// the code of a Go migration cannot be hashed, its checksum guards the version, the name and the revision.
+	// Revision 0 keeps the checksum of the migrations applied before the revisions existed.
+	parts := []string{"go", strconv.FormatInt(m.Version, 10), m.Name}
+	if m.Revision > 0 {
+		parts = append(parts, strconv.Itoa(m.Revision))
+	}
+	m.checksum = checksum(parts...)
+	registry = append(registry, m)
+}
+
+func checksum(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+var sqlFileName = regexp.MustCompile(`^(\d{14})_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// sqlMigrations loads the migrations of sql/<version>_<name>.up.sql and .down.sql.
+func sqlMigrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(sqlFiles, "sql")
+	if err != nil {
+		return nil, err
+	}
+
+	type files struct {
+		name     string
+		up, down string
+		hasUp    bool
+		hasDown  bool
+	}
+	byVersion := make(map[int64]*files)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		match := sqlFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %s", entry.Name())
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := sqlFiles.ReadFile(path.Join("sql", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		f := byVersion[version]
+		if f == nil {
+			f = &files{name: match[2]}
+			byVersion[version] = f
+		}
+		if f.name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, f.name, match[2])
+		}
+		if match[3] == "up" {
+			f.up, f.hasUp = string(content), true
+		} else {
+			f.down, f.hasDown = string(content), true
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for version, f := range byVersion {
+		if !f.hasUp {
+			return nil, fmt.Errorf("migration %d_%s has no up file", version, f.name)
+		}
+		m := Migration{
+			Version:  version,
+			Name:     f.name,
+			Up:       execSQL(f.up),
+			checksum: checksum("sql", f.up, f.down),
+		}
+		if f.hasDown {
+			m.Down = execSQL(f.down)
+		}
+		migrations = append(migrations, m)
+	}
+	return migrations, nil
+}
+
+// execSQL runs the statements of a file one by one, the driver does not allow multi statements.
+func execSQL(content string) func(db *gorm.DB) error {
+	return func(db *gorm.DB) error {
+		for _, statement := range splitStatements(content) {
+			if err := db.Exec(statement).Error; err != nil {
+				return fmt.Errorf("%w\n%s", err, statement)
+			}
+		}
+		return nil
+	}
+}
+
+// splitStatements splits on the semicolons ending a line, the comment lines are dropped.
+func splitStatements(content string) []string {
+	statements := make([]string, 0)
+	var current strings.Builder
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSuffix(strings.TrimSpace(current.String()), ";"))
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	fromSQL, err := sqlMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := append(append([]Migration(nil), registry...), fromSQL...)
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version == migrations[i-1].Version {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s",
+				migrations[i].Version, migrations[i-1].Name, migrations[i].Name)
+		}
+	}
+
+	// an ALTER of a large table outlasts the default query timeout
+	return &Migrator{db: db.WithContext(mysql.WithoutTimeout(context.Background())), migrations: migrations}, nil
+}
+
+func (m *Migrator) applied(db *gorm.DB) (map[int64]SchemaMigration, error) {
+	if err := db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return nil, err
+	}
+	var rows []SchemaMigration
+	if err := db.Order("version").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[int64]SchemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// withLock runs fn on one connection holding a MySQL named lock, so two instances never migrate together.
+func (m *Migrator) withLock(fn func(db *gorm.DB) error) error {
+	return m.db.Connection(func(conn *gorm.DB) error {
+		var locked int
+		if err := conn.Raw("SELECT GET_LOCK(?, 60)", migrationLock).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if locked != 1 {
+			return errors.New("another instance is running the migrations")
+		}
+		defer conn.Exec("SELECT RELEASE_LOCK(?)", migrationLock)
+		return fn(conn)
+	})
+}
+
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	applied, err := m.applied(m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	known := make(map[int64]bool, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = true
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if row, ok := applied[migration.Version]; ok {
+			appliedAt := row.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			status.ChecksumMismatch = row.Checksum != migration.checksum
+		}
+		statuses = append(statuses, status)
+	}
+	for version, row := range applied {
+		if known[version] {
+			continue
+		}
+		appliedAt := row.AppliedAt
+		statuses = append(statuses, MigrationStatus{Version: version, Name: row.Name, Applied: true, AppliedAt: &appliedAt, Missing: true})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses, nil
+}
+
+func verify(statuses []MigrationStatus) error {
+	edited := make([]string, 0)
+	for _, status := range statuses {
+		if status.ChecksumMismatch {
+			edited = append(edited, fmt.Sprintf("%d_%s", status.Version, status.Name))
+		}
+	}
+	if len(edited) > 0 {
+		return fmt.Errorf("%w: %s, add a new migration instead", ErrChecksumMismatch, strings.Join(edited, ", "))
+	}
+	return nil
+}
+
+// CheckUpToDate fails when a migration is pending or was edited, used at boot when the migrations are not applied automatically.
+func (m *Migrator) CheckUpToDate() error {
+	statuses, err := m.Status()
+	if err != nil {
+		return err
+	}
+	if err := verify(statuses); err != nil {
+		return err
+	}
+	pending := make([]string, 0)
+	for _, status := range statuses {
+		if !status.Applied {
+			pending = append(pending, fmt.Sprintf("%d_%s", status.Version, status.Name))
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %s, run `global-api <config> migrate up`", ErrPendingMigrations, strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+// Up applies the pending migrations in order, at most n of them when n > 0.
+func (m *Migrator) Up(n int) ([]MigrationStatus, error) {
+	done := make([]MigrationStatus, 0)
+	err := m.withLock(func(db *gorm.DB) error {
+		statuses, err := m.Status()
+		if err != nil {
+			return err
+		}
+		if err := verify(statuses); err != nil {
+			return err
+		}
+		applied, err := m.applied(db)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if n > 0 && len(done) >= n {
+				break
+			}
+
+			start := time.Now()
+			log.Infof("migrations: applying %d_%s", migration.Version, migration.Name)
+			if err := migration.Up(db); err != nil {
+				return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			row := SchemaMigration{
+				Version:     migration.Version,
+				Name:        migration.Name,
+				Checksum:    migration.checksum,
+				AppliedAt:   time.Now(),
+				ExecutionMs: time.Since(start).Milliseconds(),
+			}
+			if err := db.Create(&row).Error; err != nil {
+				return err
+			}
+			done = append(done, MigrationStatus{Version: row.Version, Name: row.Name, Applied: true, AppliedAt: &row.AppliedAt})
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down reverts the last n applied migrations, newest first.
+func (m *Migrator) Down(n int) ([]MigrationStatus, error) {
+	if n <= 0 {
+		return nil, errors.New("the number of migrations to revert must be positive")
+	}
+
+	byVersion := make(map[int64]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		byVersion[migration.Version] = migration
+	}
+
+	done := make([]MigrationStatus, 0)
+	err := m.withLock(func(db *gorm.DB) error {
+		var rows []SchemaMigration
+		if err := db.AutoMigrate(&SchemaMigration{}); err != nil {
+			return err
+		}
+		if err := db.Order("version DESC").Limit(n).Find(&rows).Error; err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			migration, ok := byVersion[row.Version]
+			if !ok {
+				return fmt.Errorf("migration %d_%s is not in this build", row.Version, row.Name)
+			}
+			if migration.Down == nil {
+				return fmt.Errorf("migration %d_%s cannot be reverted", row.Version, row.Name)
+			}
+			if row.Checksum != migration.checksum {
+				return fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, row.Version, row.Name)
+			}
+
+			log.Infof("migrations: reverting %d_%s", migration.Version, migration.Name)
+			if err := migration.Down(db); err != nil {
+				return fmt.Errorf("revert %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			if err := db.Delete(&SchemaMigration{}, row.Version).Error; err != nil {
+				return err
+			}
+			done = append(done, MigrationStatus{Version: row.Version, Name: row.Name})
+		}
+		return nil
+	})
+	return done, err
+}
+
+// SQLDir is the folder of the SQL migrations in the source tree.
+func SQLDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "sql")
+}
+
+var migrationName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Create writes empty up and down files for a new SQL migration.
+func Create(dir string, name string, now time.Time) (string, string, error) {
+	name = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), "-", "_"))
+	if !migrationName.MatchString(name) {
+		return "", "", errors.New("the migration name may only hold letters, digits and _")
+	}
+
+	base := filepath.Join(dir, now.UTC().Format("20060102150405")+"_"+name)
+	upPath, downPath := base+".up.sql", base+".down.sql"
+	if err := os.WriteFile(upPath, []byte("-- "+name+"\n"), 0o644); err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(downPath, []byte("-- revert "+name+"\n"), 0o644); err != nil {
+		return "", "", err
+	}
+	return upPath, downPath, nil
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/pkg/qrlogin"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// MigrateQRLoginTokens replaces the legacy QR codes embedding the password hash by a signed login token.
+// It is idempotent: users already holding a token QR code are skipped.
+func MigrateQRLoginTokens(db *gorm.DB) error {
+	if err := db.AutoMigrate(&entity.SQRLoginToken{}); err != nil {
+		return err
+	}
+
+	var users []entity.SUserEntity
+	err := db.Select("id, username").
+		Where("qr_login = '' OR qr_login LIKE ?", qrlogin.PayloadPrefix+":%:$2%").
+		Find(&users).Error
+	if err != nil {
+		log.Error("MigrateQRLoginTokens: " + err.Error())
+		return err
+	}
+
+	for _, user := range users {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			_, err := repository.NewQRLoginTokenRepository(tx).Issue(user.ID, user.Username)
+			return err
+		})
+		if err != nil {
+			log.Error("MigrateQRLoginTokens: " + err.Error())
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// MigrateSubmissionChildIDs backfills s_submission.child_id from the student application of the submission,
+// so the export and erasure of a child's data also cover the submissions made before child_id existed.
+// It is idempotent: submissions already holding a child_id are skipped.
+func MigrateSubmissionChildIDs(db *gorm.DB) error {
+	query := `UPDATE s_submission s
+		JOIN s_student_form_application sa ON sa.id = s.student_id
+		SET s.child_id = sa.child_id
+		WHERE s.child_id = '' AND s.student_id <> ''
+			AND sa.child_id <> '' AND sa.child_id <> '00000000-0000-0000-0000-000000000000'`
+
+	if err := db.Exec(query).Error; err != nil {
+		log.Error("MigrateSubmissionChildIDs: " + err.Error())
+		return err
+	}
+
+	return nil
+}
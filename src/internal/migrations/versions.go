@@ -0,0 +1,184 @@
+package migrations
+
+import (
+	"sen-global-api/internal/domain/entity"
+	"sen-global-api/internal/domain/value"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// The Go migrations, the SQL ones live in sql/.
+// Never edit an applied migration, add a new version instead.
+// A migration that creates tables or columns drops them in Down, its Up creates them even when the baseline already did.
+func init() {
+	register(Migration{
+		Version: 20261019000000,
+		Name:    "baseline",
+		Up: func(db *gorm.DB) error {
+			// creates the schema of a new database, a no-op for the tables created before the versioned migrations
+			return db.AutoMigrate(Entities()...)
+		},
+	})
+	register(Migration{
+		Version: 20261019000001,
+		Name:    "child_guardians",
+		Up:      MigrateChildGuardians,
+		Down: func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&entity.SChildGuardian{})
+		},
+	})
+	register(Migration{
+		Version: 20261019000002,
+		Name:    "hashed_passwords",
+		Up:      MigrateHashedPasswords,
+	})
+	register(Migration{
+		Version: 20261019000003,
+		Name:    "qr_login_tokens",
+		Up:      MigrateQRLoginTokens,
+		Down: func(db *gorm.DB) error {
+			if err := db.Migrator().DropTable(&entity.SQRLoginToken{}); err != nil {
+				return err
+			}
+			// the QR codes point to the dropped tokens, Up issues new ones for the empty qr_login
+			return db.Model(&entity.SUserEntity{}).Where("qr_login <> ''").UpdateColumn("qr_login", "").Error
+		},
+	})
+	register(Migration{
+		Version: 20261019000004,
+		Name:    "app_releases",
+		Up: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&entity.AppRelease{}); err != nil {
+				return err
+			}
+			if !db.Migrator().HasColumn(&entity.SDevice{}, "Platform") {
+				if err := db.Migrator().AddColumn(&entity.SDevice{}, "Platform"); err != nil {
+					return err
+				}
+			}
+			// the platform was only known by the FCM registration
+			return db.Exec(`UPDATE s_device d JOIN s_mobile_device m ON m.device_id = d.id
+				SET d.platform = m.type WHERE d.platform = ''`).Error
+		},
+		Down: func(db *gorm.DB) error {
+			if err := db.Migrator().DropTable(&entity.AppRelease{}); err != nil {
+				return err
+			}
+			if db.Migrator().HasColumn(&entity.SDevice{}, "Platform") {
+				return db.Migrator().DropColumn(&entity.SDevice{}, "Platform")
+			}
+			return nil
+		},
+	})
+
+	register(Migration{
+		Version: 20261019000005,
+		Name:    "feature_flags",
+		Up: func(db *gorm.DB) error {
+			return db.AutoMigrate(&entity.FeatureFlag{}, &entity.FeatureFlagAudit{})
+		},
+		Down: func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&entity.FeatureFlag{}, &entity.FeatureFlagAudit{})
+		},
+	})
+
+	register(Migration{
+		Version: 20261019000006,
+		Name:    "device_heartbeats",
+		Up: func(db *gorm.DB) error {
+			return db.AutoMigrate(&entity.DeviceHeartbeat{}, &entity.DeviceAlertSetting{}, &entity.DeviceOfflineAlert{})
+		},
+		Down: func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&entity.DeviceHeartbeat{}, &entity.DeviceAlertSetting{}, &entity.DeviceOfflineAlert{})
+		},
+	})
+
+	register(Migration{
+		Version: 20261019000007,
+		Name:    "device_commands",
+		Up: func(db *gorm.DB) error {
+			return db.AutoMigrate(&entity.DeviceCommand{})
+		},
+		Down: func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&entity.DeviceCommand{})
+		},
+	})
+
+	register(Migration{
+		Version: 20261019000008,
+		Name:    "device_enrollment",
+		Up: func(db *gorm.DB) error {
+			return db.AutoMigrate(&entity.DeviceEnrollmentCode{}, &entity.DeviceEnrollment{}, &entity.DeviceLimit{})
+		},
+		Down: func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&entity.DeviceEnrollmentCode{}, &entity.DeviceEnrollment{}, &entity.DeviceLimit{})
+		},
+	})
+
+	register(Migration{
+		Version: 20261019000009,
+		Name:    "device_mode_profiles",
+		Up: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&entity.DeviceModeProfile{}); err != nil {
+				return err
+			}
+			// device mode S chi dung cho hoc sinh, cac mode khac chua gioi han cho den khi admin tao profile
+			student := entity.DeviceModeProfile{
+				Mode:                value.DeviceModeS,
+				Name:                "Student",
+				AllowedRoles:        datatypes.JSONType[[]string]{Data: []string{entity.Student.String()}},
+				AllowedComponentIDs: datatypes.JSONType[[]string]{Data: []string{}},
+				MobileQuestionTypes: datatypes.JSONType[[]string]{Data: []string{}},
+			}
+			return db.Where("mode = ? AND organization_id = ''", value.DeviceModeS).FirstOrCreate(&student).Error
+		},
+		Down: func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&entity.DeviceModeProfile{})
+		},
+	})
+
+	register(Migration{
+		Version: 20261019000010,
+		Name:    "reports",
+		Up: func(db *gorm.DB) error {
+			return db.AutoMigrate(&entity.Report{}, &entity.ReportJob{})
+		},
+		Down: func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&entity.Report{}, &entity.ReportJob{})
+		},
+	})
+
+	register(Migration{
+		Version: 20261019000011,
+		Name:    "child_guardians_single_primary",
+		Up:      MigrateChildGuardiansSinglePrimary,
+		// the other guardians stay secondary contacts, nothing to revert
+		Down: func(db *gorm.DB) error { return nil },
+	})
+
+	register(Migration{
+		Version: 20261019000012,
+		Name:    "submission_child_ids",
+		Up:      MigrateSubmissionChildIDs,
+		// the backfilled child ids are the ones of the student, nothing to revert
+		Down: func(db *gorm.DB) error { return nil },
+	})
+
+	register(Migration{
+		Version: 20261019000013,
+		Name:    "child_consents",
+		Up:      MigrateChildConsents,
+		// the guardians may have changed the granted consents since, they are kept
+		Down: func(db *gorm.DB) error { return nil },
+	})
+
+	register(Migration{
+		Version: 20261019000014,
+		Name:    "data_log_partitions",
+		Up:      MigrateDataLogPartitions,
+		Down: func(db *gorm.DB) error {
+			return db.Exec("ALTER TABLE data_log DROP PRIMARY KEY, ADD PRIMARY KEY (id) REMOVE PARTITIONING").Error
+		},
+	})
+}
@@ -1,6 +1,7 @@
 package router
 
 import (
+	"context"
 	"encoding/json"
 	"sen-global-api/config"
 	"sen-global-api/helper"
@@ -12,7 +13,9 @@ import (
 	"sen-global-api/internal/middleware"
 	"sen-global-api/pkg/consulapi/gateway"
 	"sen-global-api/pkg/job"
+	"sen-global-api/pkg/lifecycle"
 	"sen-global-api/pkg/monitor"
+	"sen-global-api/pkg/ratelimit"
 	"sen-global-api/pkg/sheet"
 	"sen-global-api/pkg/uploader"
 	"time"
@@ -97,7 +100,10 @@ func setupAdminRoutes(engine *gin.Engine, dbConn *gorm.DB, config config.AppConf
 				},
 			},
 		}
-		v1.POST("/login", loginController.Login)
+		v1.POST("/login",
+			middleware.RateLimit(ratelimit.Default(), middleware.RateLimitLogin,
+				middleware.RateLimitByIP(), middleware.RateLimitByBody(ratelimit.KindUsername, "username")),
+			loginController.Login)
 
 		form := controller.FormController{
 			SaveFormUseCase: usecase.SaveFormUseCase{
@@ -195,6 +201,10 @@ func setupAdminRoutes(engine *gin.Engine, dbConn *gorm.DB, config config.AppConf
 			},
 			GetRedirectUrlByQRCodeUseCase: nil,
 			ImportRedirectUrlsUseCase:     importUrlsUseCase,
+			RedirectUrlAnalyticsUseCase: &usecase.RedirectUrlAnalyticsUseCase{
+				RedirectUrlRepo: redirectUrlRepository,
+				ScanRepo:        repository.NewRedirectUrlScanRepository(dbConn),
+			},
 		}
 		redirectUrl.POST("/create", secureMiddleware.ValidateSuperAdminRole(), redirectController.CreateRedirectUrl)
 
@@ -204,6 +214,10 @@ func setupAdminRoutes(engine *gin.Engine, dbConn *gorm.DB, config config.AppConf
 
 		redirectUrl.PUT("/:id", secureMiddleware.ValidateSuperAdminRole(), redirectController.UpdateRedirectUrl)
 
+		redirectUrl.GET("/dashboard", secureMiddleware.ValidateSuperAdminRole(), redirectController.GetRedirectUrlDashboard)
+
+		redirectUrl.GET("/:id/analytics", secureMiddleware.ValidateSuperAdminRole(), redirectController.GetRedirectUrlAnalytics)
+
 		redirectUrl.POST("/import", secureMiddleware.ValidateSuperAdminRole(), redirectController.ImportRedirectUrls)
 		//Partially import
 		redirectUrl.POST("/import/partially", middleware.NewSecureAppMiddleware(dbConn).Secure(), redirectController.ImportPartiallyRedirectUrls)
@@ -563,6 +577,7 @@ func setupAdminRoutes(engine *gin.Engine, dbConn *gorm.DB, config config.AppConf
 		&repository.LanguageSettingRepository{DBConn: dbConn},
 		&repository.ParentRepository{DBConn: dbConn},
 		&repository.ParentChildsRepository{DBConn: dbConn},
+		&repository.ChildGuardianRepository{DBConn: dbConn},
 		profileGw,
 		generateOwnerCodeUseCase,
 		nil,
@@ -642,6 +657,15 @@ func setupAdminRoutes(engine *gin.Engine, dbConn *gorm.DB, config config.AppConf
 		},
 	)
 
+	childPrivacyUseCase := &usecase.ChildPrivacyUseCase{
+		DBConn:            dbConn,
+		PrivacyRepo:       &repository.ChildPrivacyRepository{DBConn: dbConn},
+		ConsentRepo:       &repository.ChildConsentRepository{DBConn: dbConn},
+		DataRequestRepo:   &repository.ChildDataRequestRepository{DBConn: dbConn},
+		ChildGuardianRepo: &repository.ChildGuardianRepository{DBConn: dbConn},
+		UploadProvider:    s3Provider,
+	}
+
 	userEntityController := &controller.UserEntityController{
 		ChildUseCase:              childUseCase,
 		StudentApplicationUseCase: studentUseCase,
@@ -666,6 +690,7 @@ func setupAdminRoutes(engine *gin.Engine, dbConn *gorm.DB, config config.AppConf
 			},
 			ProfileGateway: profileGw,
 		},
+		ChildPrivacyUseCase: childPrivacyUseCase,
 		TeacherApplicationUseCase: &usecase.TeacherApplicationUseCase{
 			TeacherRepo: &repository.TeacherApplicationRepository{DBConn: dbConn},
 			GetUserEntityUseCase: &usecase.GetUserEntityUseCase{
@@ -773,6 +798,16 @@ func setupAdminRoutes(engine *gin.Engine, dbConn *gorm.DB, config config.AppConf
 		},
 	}
 
+	childPrivacyController := &controller.ChildPrivacyController{ChildPrivacyUseCase: childPrivacyUseCase}
+	privacy := engine.Group("/v1/admin/privacy", secureMiddleware.ValidateSuperAdminRole())
+	{
+		privacy.GET("/requests", childPrivacyController.GetAllRequests4Admin)
+		privacy.GET("/child/:id/consent", childPrivacyController.GetConsent4Admin)
+		privacy.POST("/child/:id/data-export", childPrivacyController.RequestExport4Admin)
+		privacy.POST("/child/:id/data-erasure", childPrivacyController.RequestErasure4Admin)
+		privacy.GET("/request/:request_id/download", childPrivacyController.DownloadExport4Admin)
+	}
+
 	user := engine.Group("/v1/admin/user", secureMiddleware.Secured())
 	{
 		user.GET("/search", userEntityController.SearchUser4WebAdmin)
@@ -826,6 +861,18 @@ func setupAdminRoutes(engine *gin.Engine, dbConn *gorm.DB, config config.AppConf
 	if !config.IsDevMode() {
 		syncDataUsecase.StartAutoSyncScheduler()
 		syncDataUsecase.StartAutoSyncForm2Scheduler()
+
+		// sync bi ngat boi shutdown/crash chay tiep khi start
+		lifecycle.Default().Register(lifecycle.Hook{
+			Name:  "sync_queue_resume",
+			Phase: lifecycle.PhaseWorker,
+			Start: func(context.Context) error {
+				lifecycle.Default().Go("sync_queue_resume", func(context.Context) {
+					syncDataUsecase.ResumeInterrupted()
+				})
+				return nil
+			},
+		})
 	}
 
 	applicationController := &controller.ApplicationController{
@@ -1164,6 +1211,12 @@ func setupAdminRoutes(engine *gin.Engine, dbConn *gorm.DB, config config.AppConf
 	usecase.TheTimeMachine.SubscribeSyncDevicesExec(executor)
 	usecase.TheTimeMachine.SubscribeSyncToDosExec(executor)
 	usecase.TheTimeMachine.SubscribeGoogleAPIRequestMonitorExec(executor)
+
+	lifecycle.Default().Register(lifecycle.Hook{
+		Name:  "time_machine",
+		Phase: lifecycle.PhaseScheduler,
+		Stop:  usecase.TheTimeMachine.Shutdown,
+	})
 }
 
 type TimeMachineSubscriber struct {
@@ -1173,7 +1226,7 @@ type TimeMachineSubscriber struct {
 	*usecase.ImportToDoListUseCase
 }
 
-func (t *TimeMachineSubscriber) ExecuteSyncUrls() {
+func (t *TimeMachineSubscriber) ExecuteSyncUrls() error {
 	log.Debug("Start sync urls")
 	type ImportSetting struct {
 		SpreadSheetUrl string `json:"spreadsheet_url"`
@@ -1184,12 +1237,14 @@ func (t *TimeMachineSubscriber) ExecuteSyncUrls() {
 	urlSetting, err := t.GetUrlSettings()
 	if err != nil {
 		log.Error(err)
+		return err
 	} else {
 		log.Debug(urlSetting)
 		var importSetting ImportSetting
 		err = json.Unmarshal([]byte(urlSetting.Settings), &importSetting)
 		if err != nil {
 			log.Error(err)
+			return err
 		} else {
 			err = t.SyncUrls(request.ImportRedirectUrlsRequest{
 				SpreadsheetUrl: importSetting.SpreadSheetUrl,
@@ -1198,12 +1253,14 @@ func (t *TimeMachineSubscriber) ExecuteSyncUrls() {
 			})
 			if err != nil {
 				log.Error(err)
+				return err
 			}
 		}
 	}
+	return nil
 }
 
-func (t *TimeMachineSubscriber) ExecuteSyncForms() {
+func (t *TimeMachineSubscriber) ExecuteSyncForms() error {
 	log.Debug("Start sync forms")
 	type ImportSetting struct {
 		SpreadSheetUrl string `json:"spreadsheet_url"`
@@ -1215,13 +1272,14 @@ func (t *TimeMachineSubscriber) ExecuteSyncForms() {
 	formSettings, err := t.GetFormSettings()
 	if err != nil {
 		log.Error(err)
-		return
+		return err
 	} else {
 		log.Debug(formSettings)
 		var importSetting ImportSetting
 		err = json.Unmarshal([]byte(formSettings.Settings), &importSetting)
 		if err != nil {
 			log.Error(err)
+			return err
 		} else {
 			err = t.SyncForms(request.ImportFormRequest{
 				SpreadsheetUrl: importSetting.SpreadSheetUrl,
@@ -1230,9 +1288,11 @@ func (t *TimeMachineSubscriber) ExecuteSyncForms() {
 			})
 			if err != nil {
 				log.Error(err)
+				return err
 			}
 		}
 	}
+	return nil
 }
 
 // func (t *TimeMachineSubscriber) ExecuteSyncForms2() {
@@ -1267,7 +1327,7 @@ func (t *TimeMachineSubscriber) ExecuteSyncForms() {
 // 	}
 // }
 
-func (t *TimeMachineSubscriber) ExecuteSyncForms3() {
+func (t *TimeMachineSubscriber) ExecuteSyncForms3() error {
 	log.Debug("Start sync forms")
 	type ImportSetting struct {
 		SpreadSheetUrl string `json:"spreadsheet_url"`
@@ -1279,13 +1339,14 @@ func (t *TimeMachineSubscriber) ExecuteSyncForms3() {
 	formSettings, err := t.GetFormSettings3()
 	if err != nil {
 		log.Error(err)
-		return
+		return err
 	} else {
 		log.Debug(formSettings)
 		var importSetting ImportSetting
 		err = json.Unmarshal([]byte(formSettings.Settings), &importSetting)
 		if err != nil {
 			log.Error(err)
+			return err
 		} else {
 			err = t.SyncForms(request.ImportFormRequest{
 				SpreadsheetUrl: importSetting.SpreadSheetUrl,
@@ -1294,12 +1355,14 @@ func (t *TimeMachineSubscriber) ExecuteSyncForms3() {
 			})
 			if err != nil {
 				log.Error(err)
+				return err
 			}
 		}
 	}
+	return nil
 }
 
-func (t *TimeMachineSubscriber) ExecuteSyncForms4() {
+func (t *TimeMachineSubscriber) ExecuteSyncForms4() error {
 	log.Debug("Start sync forms")
 	type ImportSetting struct {
 		SpreadSheetUrl string `json:"spreadsheet_url"`
@@ -1311,13 +1374,14 @@ func (t *TimeMachineSubscriber) ExecuteSyncForms4() {
 	formSettings, err := t.GetFormSettings4()
 	if err != nil {
 		log.Error(err)
-		return
+		return err
 	} else {
 		log.Debug(formSettings)
 		var importSetting ImportSetting
 		err = json.Unmarshal([]byte(formSettings.Settings), &importSetting)
 		if err != nil {
 			log.Error(err)
+			return err
 		} else {
 			err = t.SyncForms(request.ImportFormRequest{
 				SpreadsheetUrl: importSetting.SpreadSheetUrl,
@@ -1326,12 +1390,14 @@ func (t *TimeMachineSubscriber) ExecuteSyncForms4() {
 			})
 			if err != nil {
 				log.Error(err)
+				return err
 			}
 		}
 	}
+	return nil
 }
 
-func (t *TimeMachineSubscriber) ExecuteSyncTodos() {
+func (t *TimeMachineSubscriber) ExecuteSyncTodos() error {
 	log.Debug("Start sync devices")
 	type ImportSetting struct {
 		SpreadSheetUrl string `json:"spreadsheet_url"`
@@ -1343,17 +1409,20 @@ func (t *TimeMachineSubscriber) ExecuteSyncTodos() {
 
 	if err != nil {
 		log.Error(err)
+		return err
 	} else {
 		log.Debug(deviceSetting)
 		var importSetting ImportSetting
 		err = json.Unmarshal([]byte(deviceSetting.Settings), &importSetting)
 		if err != nil {
 			log.Error(err)
+			return err
 		} else {
 			var importSetting ImportSetting
 			err = json.Unmarshal([]byte(deviceSetting.Settings), &importSetting)
 			if err != nil {
 				log.Error(err)
+				return err
 			} else {
 				err = t.ImportToDoList(request.ImportFormRequest{
 					SpreadsheetUrl: importSetting.SpreadSheetUrl,
@@ -1362,13 +1431,16 @@ func (t *TimeMachineSubscriber) ExecuteSyncTodos() {
 				})
 				if err != nil {
 					log.Error(err)
+					return err
 				}
 			}
 		}
 	}
+	return nil
 }
 
 // register, import 1 todo, import 1 form, screen button, top button
-func (t *TimeMachineSubscriber) ExecuteGoogleAPIRequestMonitor() {
+func (t *TimeMachineSubscriber) ExecuteGoogleAPIRequestMonitor() error {
 	monitor.ResetGoogleAPIRequestMonitor()
+	return nil
 }
@@ -0,0 +1,82 @@
+package router
+
+import (
+	"sen-global-api/config"
+	"sen-global-api/internal/controller"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/usecase"
+	"sen-global-api/internal/middleware"
+	"sen-global-api/pkg/consulapi/gateway"
+	"sen-global-api/pkg/uploader"
+	"time"
+
+	firebase "firebase.google.com/go/v4"
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/consul/api"
+	"gorm.io/gorm"
+)
+
+func setupAnnouncementRoutes(engine *gin.Engine, dbConn *gorm.DB, appConfig config.AppConfig, fcm *firebase.App, consulClient *api.Client) {
+	sessionRepository := repository.SessionRepository{
+		OrganizationRepository: &repository.OrganizationRepository{DBConn: dbConn},
+		AuthorizeEncryptKey:    appConfig.AuthorizeEncryptKey,
+
+		TokenExpireTimeInHour: time.Duration(appConfig.TokenExpireDurationInHour),
+	}
+	secureMiddleware := middleware.SecuredMiddleware{SessionRepository: sessionRepository}
+
+	provider := uploader.NewS3Provider(
+		appConfig.S3.SenboxFormSubmitBucket.AccessKey,
+		appConfig.S3.SenboxFormSubmitBucket.SecretKey,
+		appConfig.S3.SenboxFormSubmitBucket.BucketName,
+		appConfig.S3.SenboxFormSubmitBucket.Region,
+		appConfig.S3.SenboxFormSubmitBucket.Domain,
+		appConfig.S3.SenboxFormSubmitBucket.CloudfrontKeyGroupID,
+		appConfig.S3.SenboxFormSubmitBucket.CloudfrontKeyPath,
+	)
+
+	announcementUseCase := &usecase.AnnouncementUseCase{
+		Repo:                repository.NewAnnouncementRepository(dbConn),
+		LanguageSettingRepo: &repository.LanguageSettingRepository{DBConn: dbConn},
+		ImageRepo:           &repository.ImageRepository{DBConn: dbConn},
+		PdfRepo:             &repository.PdfRepository{DBConn: dbConn},
+		OrganizationRepo:    &repository.OrganizationRepository{DBConn: dbConn},
+		DeviceRepo:          &repository.DeviceRepository{DBConn: dbConn},
+		UserEntityRepo:      &repository.UserEntityRepository{DBConn: dbConn},
+		UserTokenFCMRepo:    &repository.UserTokenFCMRepository{DBConn: dbConn},
+		DepartmentGateway:   gateway.NewDepartmentGateway("department-service", consulClient),
+		FirebaseApp:         fcm,
+		UploadProvider:      provider,
+	}
+
+	// neu != dev moi chay cron push announcement
+	if !config.IsDevMode() {
+		announcementUseCase.StartPublishScheduler()
+	}
+
+	announcementController := &controller.AnnouncementController{AnnouncementUseCase: announcementUseCase}
+
+	announcement := engine.Group("/v1/announcement", secureMiddleware.Secured())
+	{
+		announcement.GET("/feed", announcementController.GetFeed)
+		announcement.POST("/:id/read", announcementController.MarkRead)
+
+		// organization managers
+		announcement.GET("/manage", announcementController.GetByOrganization)
+		announcement.POST("", announcementController.Create)
+		announcement.GET("/:id", announcementController.GetByID)
+		announcement.PUT("/:id", announcementController.Update)
+		announcement.DELETE("/:id", announcementController.Delete)
+		announcement.GET("/:id/reads", announcementController.GetReads)
+	}
+
+	admin := engine.Group("/v1/admin/announcement", secureMiddleware.ValidateSuperAdminRole())
+	{
+		admin.GET("", announcementController.GetByOrganization4Admin)
+		admin.POST("", announcementController.Create4Admin)
+		admin.GET("/:id", announcementController.GetByID4Admin)
+		admin.PUT("/:id", announcementController.Update4Admin)
+		admin.DELETE("/:id", announcementController.Delete4Admin)
+		admin.GET("/:id/reads", announcementController.GetReads4Admin)
+	}
+}
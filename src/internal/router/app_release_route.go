@@ -0,0 +1,46 @@
+package router
+
+import (
+	"sen-global-api/config"
+	"sen-global-api/internal/controller"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/usecase"
+	"sen-global-api/internal/middleware"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func setupAppReleaseRoutes(engine *gin.Engine, dbConn *gorm.DB, appConfig config.AppConfig) {
+	sessionRepository := repository.SessionRepository{
+		OrganizationRepository: &repository.OrganizationRepository{DBConn: dbConn},
+		AuthorizeEncryptKey:    appConfig.AuthorizeEncryptKey,
+
+		TokenExpireTimeInHour: time.Duration(appConfig.TokenExpireDurationInHour),
+	}
+	secureMiddleware := middleware.SecuredMiddleware{SessionRepository: sessionRepository}
+
+	appReleaseController := &controller.AppReleaseController{
+		AppReleaseUseCase: &usecase.AppReleaseUseCase{
+			Repo:       repository.NewAppReleaseRepository(dbConn),
+			DeviceRepo: &repository.DeviceRepository{DBConn: dbConn},
+		},
+	}
+
+	// khong can token: app phai biet co bat buoc update truoc khi login,
+	// version chi duoc ghi lai khi co device token cua device
+	app := engine.Group("/v1/app/release", secureMiddleware.OptionalDevice())
+	{
+		app.GET("/check", appReleaseController.CheckVersion)
+	}
+
+	admin := engine.Group("/v1/admin/app-release", secureMiddleware.ValidateSuperAdminRole())
+	{
+		admin.GET("", appReleaseController.GetReleases4Admin)
+		admin.POST("", appReleaseController.CreateRelease4Admin)
+		admin.PUT("/:id", appReleaseController.UpdateRelease4Admin)
+		admin.DELETE("/:id", appReleaseController.DeleteRelease4Admin)
+		admin.GET("/distribution", appReleaseController.GetVersionDistribution4Admin)
+	}
+}
@@ -0,0 +1,59 @@
+package router
+
+import (
+	"sen-global-api/config"
+	"sen-global-api/internal/controller"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/usecase"
+	"sen-global-api/internal/middleware"
+	"time"
+
+	firebase "firebase.google.com/go/v4"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func setupAttendanceRoutes(engine *gin.Engine, dbConn *gorm.DB, appConfig config.AppConfig, fcm *firebase.App) {
+	sessionRepository := repository.SessionRepository{
+		OrganizationRepository: &repository.OrganizationRepository{DBConn: dbConn},
+		AuthorizeEncryptKey:    appConfig.AuthorizeEncryptKey,
+
+		TokenExpireTimeInHour: time.Duration(appConfig.TokenExpireDurationInHour),
+	}
+	secureMiddleware := middleware.SecuredMiddleware{SessionRepository: sessionRepository}
+
+	attendanceUseCase := &usecase.AttendanceUseCase{
+		Repo:              &repository.AttendanceRepository{DBConn: dbConn},
+		StudentRepo:       &repository.StudentApplicationRepository{DB: dbConn},
+		TeacherRepo:       &repository.TeacherApplicationRepository{DBConn: dbConn},
+		OrganizationRepo:  &repository.OrganizationRepository{DBConn: dbConn},
+		ChildGuardianRepo: &repository.ChildGuardianRepository{DBConn: dbConn},
+		UserTokenFCMRepo:  &repository.UserTokenFCMRepository{DBConn: dbConn},
+		FirebaseApp:       fcm,
+	}
+
+	// neu != dev moi chay cron danh dau vang mat
+	if !config.IsDevMode() {
+		attendanceUseCase.StartAbsenceScheduler()
+	}
+
+	attendanceController := &controller.AttendanceController{AttendanceUseCase: attendanceUseCase}
+
+	attendance := engine.Group("/v1/attendance", secureMiddleware.Secured())
+	{
+		attendance.POST("/check", attendanceController.CheckInOut)
+		attendance.POST("/manual", attendanceController.ManualRecord)
+		attendance.GET("/sheet", attendanceController.GetDailySheet)
+		attendance.GET("/student/:student_id", attendanceController.GetStudentMonth)
+		attendance.GET("/setting/:organization_id", attendanceController.GetSetting)
+	}
+
+	admin := engine.Group("/v1/admin/attendance", secureMiddleware.ValidateSuperAdminRole())
+	{
+		admin.GET("/sheet", attendanceController.GetDailySheet4Admin)
+		admin.GET("/student/:student_id", attendanceController.GetStudentMonth4Admin)
+		admin.GET("/setting/:organization_id", attendanceController.GetSetting)
+		admin.PUT("/setting", attendanceController.UpdateSetting)
+		admin.GET("/report", attendanceController.ExportMonthlyReport)
+	}
+}
@@ -0,0 +1,62 @@
+package router
+
+import (
+	"sen-global-api/config"
+	"sen-global-api/internal/controller"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/usecase"
+	"sen-global-api/internal/middleware"
+	"time"
+
+	firebase "firebase.google.com/go/v4"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func setupBookingRoutes(engine *gin.Engine, dbConn *gorm.DB, appConfig config.AppConfig, fcm *firebase.App) {
+	sessionRepository := repository.SessionRepository{
+		OrganizationRepository: &repository.OrganizationRepository{DBConn: dbConn},
+		AuthorizeEncryptKey:    appConfig.AuthorizeEncryptKey,
+
+		TokenExpireTimeInHour: time.Duration(appConfig.TokenExpireDurationInHour),
+	}
+	secureMiddleware := middleware.SecuredMiddleware{SessionRepository: sessionRepository}
+
+	bookingUseCase := &usecase.BookingUseCase{
+		DBConn:           dbConn,
+		Repo:             &repository.BookingRepository{DBConn: dbConn},
+		QuestionRepo:     &repository.QuestionRepository{DBConn: dbConn},
+		UserTokenFCMRepo: &repository.UserTokenFCMRepository{DBConn: dbConn},
+		FirebaseApp:      fcm,
+	}
+
+	// neu != dev moi chay cron nhac lich
+	if !config.IsDevMode() {
+		bookingUseCase.StartReminderScheduler()
+	}
+
+	bookingController := &controller.BookingController{BookingUseCase: bookingUseCase}
+
+	booking := engine.Group("/v1/booking", secureMiddleware.Secured())
+	{
+		booking.GET("/resources", bookingController.GetResources)
+		booking.GET("/resource/:id", bookingController.GetResource)
+		booking.GET("/resource/:id/slots", bookingController.GetSlots)
+		booking.GET("/question/:question_id/slots", bookingController.GetSlotsByQuestion)
+
+		booking.POST("/reservation", bookingController.Reserve)
+		booking.GET("/reservations/me", bookingController.GetMyReservations)
+		booking.POST("/reservation/:id/cancel", bookingController.CancelReservation)
+	}
+
+	admin := engine.Group("/v1/admin/booking", secureMiddleware.ValidateSuperAdminRole())
+	{
+		admin.GET("/resources", bookingController.GetResources)
+		admin.POST("/resource", bookingController.CreateResource)
+		admin.PUT("/resource", bookingController.UpdateResource)
+		admin.DELETE("/resource/:id", bookingController.DeleteResource)
+		admin.PUT("/resource/:id/rules", bookingController.ReplaceRules)
+		admin.GET("/resource/:id/reservations", bookingController.GetReservationsByResource)
+		admin.POST("/reservation/:id/cancel", bookingController.CancelReservation4Admin)
+	}
+}
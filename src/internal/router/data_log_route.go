@@ -0,0 +1,40 @@
+package router
+
+import (
+	"sen-global-api/config"
+	"sen-global-api/internal/controller"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/usecase"
+	"sen-global-api/internal/middleware"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func setupDataLogRoutes(engine *gin.Engine, dbConn *gorm.DB, appConfig config.AppConfig) {
+	sessionRepository := repository.SessionRepository{
+		OrganizationRepository: &repository.OrganizationRepository{DBConn: dbConn},
+		AuthorizeEncryptKey:    appConfig.AuthorizeEncryptKey,
+
+		TokenExpireTimeInHour: time.Duration(appConfig.TokenExpireDurationInHour),
+	}
+	secureMiddleware := middleware.SecuredMiddleware{SessionRepository: sessionRepository}
+
+	dataLogUseCase := &usecase.DataLogUseCase{
+		Repo:   repository.NewDataLogRepository(dbConn),
+		Config: appConfig.DataLog,
+	}
+
+	// neu != dev moi chay cron purge data log
+	if !config.IsDevMode() {
+		dataLogUseCase.StartPurgeScheduler()
+	}
+
+	dataLogController := &controller.DataLogController{DataLogUseCase: dataLogUseCase}
+
+	admin := engine.Group("/v1/admin/data-log", secureMiddleware.ValidateSuperAdminRole())
+	{
+		admin.GET("", dataLogController.GetDataLogs4Admin)
+	}
+}
@@ -0,0 +1,49 @@
+package router
+
+import (
+	"sen-global-api/config"
+	"sen-global-api/internal/controller"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/usecase"
+	"sen-global-api/internal/middleware"
+	"time"
+
+	firebase "firebase.google.com/go/v4"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func setupDeviceCommandRoutes(engine *gin.Engine, dbConn *gorm.DB, appConfig config.AppConfig, fcm *firebase.App) {
+	sessionRepository := repository.SessionRepository{
+		OrganizationRepository: &repository.OrganizationRepository{DBConn: dbConn},
+		AuthorizeEncryptKey:    appConfig.AuthorizeEncryptKey,
+
+		TokenExpireTimeInHour: time.Duration(appConfig.TokenExpireDurationInHour),
+	}
+	secureMiddleware := middleware.SecuredMiddleware{SessionRepository: sessionRepository}
+
+	deviceCommandUseCase := &usecase.DeviceCommandUseCase{
+		Repo:        repository.NewDeviceCommandRepository(dbConn),
+		DeviceRepo:  &repository.DeviceRepository{DBConn: dbConn},
+		FirebaseApp: fcm,
+	}
+
+	// lenh het han van bi loai khi poll, cron chi cap nhat trang thai
+	deviceCommandUseCase.StartScheduler()
+
+	deviceCommandController := &controller.DeviceCommandController{DeviceCommandUseCase: deviceCommandUseCase}
+
+	// device chua login van nhan lenh (vd force_logout), xac thuc bang device token cua chinh device
+	device := engine.Group("/v1/device/commands", secureMiddleware.SecuredDevice())
+	{
+		device.GET("", deviceCommandController.Poll)
+		device.POST("/:id/ack", deviceCommandController.Acknowledge)
+	}
+
+	admin := engine.Group("/v1/admin/device-command", secureMiddleware.ValidateSuperAdminRole())
+	{
+		admin.POST("", deviceCommandController.CreateCommand)
+		admin.GET("/device/:device_id", deviceCommandController.GetHistory)
+		admin.GET("/:id", deviceCommandController.GetCommand)
+	}
+}
@@ -0,0 +1,54 @@
+package router
+
+import (
+	"sen-global-api/config"
+	"sen-global-api/internal/controller"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/usecase"
+	"sen-global-api/internal/middleware"
+	"sen-global-api/pkg/ratelimit"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func setupDeviceEnrollmentRoutes(engine *gin.Engine, dbConn *gorm.DB, appConfig config.AppConfig) {
+	sessionRepository := repository.SessionRepository{
+		OrganizationRepository: &repository.OrganizationRepository{DBConn: dbConn},
+		AuthorizeEncryptKey:    appConfig.AuthorizeEncryptKey,
+
+		TokenExpireTimeInHour: time.Duration(appConfig.TokenExpireDurationInHour),
+	}
+	secureMiddleware := middleware.SecuredMiddleware{SessionRepository: sessionRepository}
+
+	deviceEnrollmentController := &controller.DeviceEnrollmentController{
+		DeviceEnrollmentUseCase: &usecase.DeviceEnrollmentUseCase{
+			DBConn:           dbConn,
+			Repo:             repository.NewDeviceEnrollmentRepository(dbConn),
+			DeviceRepo:       &repository.DeviceRepository{DBConn: dbConn},
+			DeviceMenuRepo:   repository.NewDeviceMenuRepository(dbConn),
+			OrganizationRepo: &repository.OrganizationRepository{DBConn: dbConn},
+		},
+	}
+
+	// device moi chua co token, chi co code trong QR; gioi han nhu dang ky device.
+	// device da thuoc org khac phai gui device token cua no
+	engine.POST("/v1/device/enroll", secureMiddleware.OptionalDevice(),
+		middleware.RateLimit(ratelimit.Default(), middleware.RateLimitDeviceRegister,
+			middleware.RateLimitByIP(), middleware.RateLimitByBody(ratelimit.KindDevice, "device_id")),
+		deviceEnrollmentController.Enroll)
+
+	admin := engine.Group("/v1/admin/device-enrollment", secureMiddleware.ValidateSuperAdminRole())
+	{
+		admin.POST("/code", deviceEnrollmentController.CreateCode)
+		admin.GET("/code", deviceEnrollmentController.GetCodes)
+		admin.GET("/code/:id", deviceEnrollmentController.GetCode)
+		admin.PUT("/code/:id/revoke", deviceEnrollmentController.RevokeCode)
+
+		// limit theo org hoac user, mac dinh user 2 device, org khong gioi han
+		admin.GET("/limit", deviceEnrollmentController.GetLimits)
+		admin.PUT("/limit", deviceEnrollmentController.SaveLimit)
+		admin.DELETE("/limit", deviceEnrollmentController.DeleteLimit)
+	}
+}
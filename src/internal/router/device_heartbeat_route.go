@@ -0,0 +1,57 @@
+package router
+
+import (
+	"sen-global-api/config"
+	"sen-global-api/internal/controller"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/usecase"
+	"sen-global-api/internal/middleware"
+	"time"
+
+	firebase "firebase.google.com/go/v4"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func setupDeviceHeartbeatRoutes(engine *gin.Engine, dbConn *gorm.DB, appConfig config.AppConfig, fcm *firebase.App) {
+	sessionRepository := repository.SessionRepository{
+		OrganizationRepository: &repository.OrganizationRepository{DBConn: dbConn},
+		AuthorizeEncryptKey:    appConfig.AuthorizeEncryptKey,
+
+		TokenExpireTimeInHour: time.Duration(appConfig.TokenExpireDurationInHour),
+	}
+	secureMiddleware := middleware.SecuredMiddleware{SessionRepository: sessionRepository}
+
+	deviceHeartbeatUseCase := &usecase.DeviceHeartbeatUseCase{
+		Repo:             repository.NewDeviceHeartbeatRepository(dbConn),
+		DeviceRepo:       &repository.DeviceRepository{DBConn: dbConn},
+		AttendanceRepo:   &repository.AttendanceRepository{DBConn: dbConn},
+		OrganizationRepo: &repository.OrganizationRepository{DBConn: dbConn},
+		UserEntityRepo:   &repository.UserEntityRepository{DBConn: dbConn},
+		UserTokenFCMRepo: &repository.UserTokenFCMRepository{DBConn: dbConn},
+		FirebaseApp:      fcm,
+		SMTP:             appConfig.SMTP,
+		Config:           appConfig.Heartbeat,
+	}
+
+	// rollup luon chay (ca dev) de DB co last seen, canh bao offline chi chay khi != dev
+	deviceHeartbeatUseCase.StartScheduler(!config.IsDevMode())
+
+	deviceHeartbeatController := &controller.DeviceHeartbeatController{DeviceHeartbeatUseCase: deviceHeartbeatUseCase}
+
+	// device chua login van gui heartbeat, xac thuc bang device token cua chinh device
+	engine.POST("/v1/device/heartbeat", secureMiddleware.SecuredDevice(), deviceHeartbeatController.Heartbeat)
+
+	fleet := engine.Group("/v1/device-fleet", secureMiddleware.Secured())
+	{
+		fleet.GET("/:organization_id", deviceHeartbeatController.GetFleet)
+	}
+
+	admin := engine.Group("/v1/admin/device-fleet", secureMiddleware.ValidateSuperAdminRole())
+	{
+		admin.GET("/alert-setting/:organization_id", deviceHeartbeatController.GetAlertSetting)
+		admin.PUT("/alert-setting", deviceHeartbeatController.UpdateAlertSetting)
+		admin.GET("/alerts", deviceHeartbeatController.GetAlerts)
+		admin.GET("/org/:organization_id", deviceHeartbeatController.GetFleet4Admin)
+	}
+}
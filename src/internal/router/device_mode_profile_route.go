@@ -0,0 +1,43 @@
+package router
+
+import (
+	"sen-global-api/config"
+	"sen-global-api/internal/controller"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/usecase"
+	"sen-global-api/internal/middleware"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func setupDeviceModeProfileRoutes(engine *gin.Engine, dbConn *gorm.DB, appConfig config.AppConfig) {
+	sessionRepository := repository.SessionRepository{
+		OrganizationRepository: &repository.OrganizationRepository{DBConn: dbConn},
+		AuthorizeEncryptKey:    appConfig.AuthorizeEncryptKey,
+
+		TokenExpireTimeInHour: time.Duration(appConfig.TokenExpireDurationInHour),
+	}
+	secureMiddleware := middleware.SecuredMiddleware{SessionRepository: sessionRepository}
+
+	deviceModeProfileController := &controller.DeviceModeProfileController{
+		DeviceModeProfileUseCase: newDeviceModeProfileUseCase(dbConn),
+	}
+
+	admin := engine.Group("/v1/admin/device-mode-profile", secureMiddleware.ValidateSuperAdminRole())
+	{
+		admin.GET("", deviceModeProfileController.GetProfiles)
+		admin.PUT("", deviceModeProfileController.SaveProfile)
+		admin.DELETE("/:id", deviceModeProfileController.DeleteProfile)
+		admin.GET("/device/:device_id", deviceModeProfileController.GetDeviceProfile)
+	}
+}
+
+func newDeviceModeProfileUseCase(dbConn *gorm.DB) *usecase.DeviceModeProfileUseCase {
+	return &usecase.DeviceModeProfileUseCase{
+		Repo:             repository.NewDeviceModeProfileRepository(dbConn),
+		DeviceRepo:       &repository.DeviceRepository{DBConn: dbConn},
+		OrganizationRepo: &repository.OrganizationRepository{DBConn: dbConn},
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"sen-global-api/internal/domain/usecase"
 	"sen-global-api/internal/middleware"
 	"sen-global-api/pkg/consulapi/gateway"
+	"sen-global-api/pkg/ratelimit"
 	"sen-global-api/pkg/sheet"
 	"sen-global-api/pkg/uploader"
 	"time"
@@ -85,10 +86,15 @@ func setupDeviceRoutes(engine *gin.Engine, dbConn *gorm.DB, userSpreadsheet *she
 		&repository.LanguageSettingRepository{DBConn: dbConn},
 		&repository.ParentRepository{DBConn: dbConn},
 		&repository.ParentChildsRepository{DBConn: dbConn},
+		&repository.ChildGuardianRepository{DBConn: dbConn},
 		profileGw,
 		generateOwnerCodeUseCase,
 		nil,
 	)
+	formPasswordUseCase := &usecase.VerifyFormPasswordUseCase{
+		FormRepo:    &repository.FormRepository{DBConn: dbConn},
+		AttemptRepo: repository.NewPasswordAttemptRepository(dbConn),
+	}
 	deviceController := &controller.DeviceController{
 		DBConn: dbConn,
 		UpdateDeviceSheetUseCase: &usecase.UpdateDeviceSheetUseCase{
@@ -136,6 +142,15 @@ func setupDeviceRoutes(engine *gin.Engine, dbConn *gorm.DB, userSpreadsheet *she
 			DriveService:           driveService,
 			FirebaseApp:            fcm,
 			DB:                     dbConn,
+			BookingUseCase: &usecase.BookingUseCase{
+				DBConn:       dbConn,
+				Repo:         &repository.BookingRepository{DBConn: dbConn},
+				QuestionRepo: &repository.QuestionRepository{DBConn: dbConn},
+			},
+			FormScoringUseCase: &usecase.FormScoringUseCase{
+				Repo:         repository.NewFormScoringRepository(dbConn),
+				QuestionRepo: &repository.QuestionRepository{DBConn: dbConn},
+			},
 		},
 		RefreshAccessTokenUseCase: &usecase.RefreshAccessTokenUseCase{
 			SessionRepository: &sessionRepository,
@@ -188,6 +203,10 @@ func setupDeviceRoutes(engine *gin.Engine, dbConn *gorm.DB, userSpreadsheet *she
 			DeviceRepo:    deviceRepository,
 			HistoriesRepo: &repository.ValuesAppHistoriesRepository{DBConn: dbConn},
 		},
+		ChildPrivacyUseCase: &usecase.ChildPrivacyUseCase{
+			ConsentRepo: &repository.ChildConsentRepository{DBConn: dbConn},
+		},
+		FormPasswordUseCase: formPasswordUseCase,
 	}
 
 	answerRepo := repository.AnswerRepository{DBConn: dbConn}
@@ -267,16 +286,24 @@ func setupDeviceRoutes(engine *gin.Engine, dbConn *gorm.DB, userSpreadsheet *she
 
 	secureMiddleware := middleware.SecuredMiddleware{SessionRepository: sessionRepository}
 
+	limiter := ratelimit.Default()
+	registerLimit := func(field string) gin.HandlerFunc {
+		return middleware.RateLimit(limiter, middleware.RateLimitDeviceRegister,
+			middleware.RateLimitByIP(), middleware.RateLimitByBody(ratelimit.KindDevice, field))
+	}
+
 	v1 := engine.Group("v1/device")
 	{
 		v1.GET("/:device_id", deviceController.GetDeviceByID)
 		v1.GET("/user/:user_id", deviceController.GetAllDeviceByUserID)
 		v1.GET("/org/:organization_id", deviceController.GetAllDeviceByOrgID)
-		v1.POST("/org", deviceController.RegisterOrgDevice)
+		v1.POST("/org", registerLimit("device_id"), deviceController.RegisterOrgDevice)
 		// Init for first setting
-		v1.POST("/init", secureMiddleware.Secured(), deviceController.InitDeviceV1)
-		v1.POST("/refresh-token", deviceController.RefreshAccessToken)
-		v1.POST("/messaging/fcm/register", deviceController.RegisterFCM)
+		v1.POST("/init", secureMiddleware.Secured(), registerLimit("device_uuid"), deviceController.InitDeviceV1)
+		v1.POST("/refresh-token",
+			middleware.RateLimit(limiter, middleware.RateLimitRefreshToken, middleware.RateLimitByIP()),
+			deviceController.RefreshAccessToken)
+		v1.POST("/messaging/fcm/register", registerLimit("device_id"), deviceController.RegisterFCM)
 		v1.PUT("/note", secureMiddleware.Secured(), deviceController.TakeNote)
 		smtpController := &controller.SMTPController{
 			SendEmailUseCase: &usecase.SendEmailUseCase{
@@ -293,7 +320,10 @@ func setupDeviceRoutes(engine *gin.Engine, dbConn *gorm.DB, userSpreadsheet *she
 
 		v1.GET("/status/:device_id", secureMiddleware.Secured(), deviceController.GetDeviceStatus)
 
-		v1.POST("/reserve", deviceController.Reserve)
+		v1.POST("/reserve",
+			middleware.RateLimit(limiter, middleware.RateLimitDeviceReserve,
+				middleware.RateLimitByIP(), middleware.RateLimitByBody(ratelimit.KindDevice, "device_id")),
+			deviceController.Reserve)
 
 		v1.POST("/discover", deviceController.Discover)
 
@@ -308,26 +338,44 @@ func setupDeviceRoutes(engine *gin.Engine, dbConn *gorm.DB, userSpreadsheet *she
 		v1.POST("/values-current", deviceController.UploadValuesCurrent)
 	}
 
+	formPasswordController := &controller.FormPasswordController{
+		VerifyFormPasswordUseCase: formPasswordUseCase,
+	}
+
 	form := engine.Group("v1/form", secureMiddleware.Secured())
 	{
-		form.POST("/submit", middleware.GeneralLoggerMiddleware(dbConn), deviceController.SubmitForm)
+		form.POST("/submit", middleware.GeneralLoggerMiddleware(middleware.DefaultDataLogWriter(), sessionRepository), deviceController.SubmitForm)
 		form.POST("/submission/last", deviceController.GetLastSubmissionByForm)
 		form.POST("/get-submission-by-condition", deviceController.GetSubmissionByCondition)
 		form.POST("/get-total-nr-submission-by-condition", deviceController.GetTotalNrSubmissionByCondition)
 		form.POST("/get-memory-form", deviceController.GetSubmission4Memories)
+		form.POST("/verify-password",
+			middleware.RateLimit(limiter, middleware.RateLimitCodePassword,
+				middleware.RateLimitByIP(), middleware.RateLimitByBody(ratelimit.KindForm, "form_id")),
+			formPasswordController.VerifyFormPassword)
 		// form.GET("/submission/get-for-memories", deviceController.GetSubmissionChildProfile)
 	}
 
 	redirectUrl := engine.Group("v1/redirect-url")
 	{
 		redirectController := &controller.RedirectUrlController{
-			SaveRedirectUrlUseCase:        nil,
-			GetRedirectUrlListUseCase:     nil,
-			DeleteRedirectUrlUseCase:      nil,
-			UpdateRedirectUrlUseCase:      nil,
-			GetRedirectUrlByQRCodeUseCase: &usecase.GetRedirectUrlByQRCodeUseCase{RedirectUrlRepository: &repository.RedirectUrlRepository{DBConn: dbConn}},
+			SaveRedirectUrlUseCase:    nil,
+			GetRedirectUrlListUseCase: nil,
+			DeleteRedirectUrlUseCase:  nil,
+			UpdateRedirectUrlUseCase:  nil,
+			GetRedirectUrlByQRCodeUseCase: &usecase.GetRedirectUrlByQRCodeUseCase{
+				RedirectUrlRepository: &repository.RedirectUrlRepository{DBConn: dbConn},
+				ScanRepo:              repository.NewRedirectUrlScanRepository(dbConn),
+				DeviceRepo:            &repository.DeviceRepository{DBConn: dbConn},
+				AttemptRepo:           repository.NewPasswordAttemptRepository(dbConn),
+				AttendanceRepo:        &repository.AttendanceRepository{DBConn: dbConn},
+			},
 		}
 		redirectUrl.GET("", redirectController.GetRedirectUrlByQRCode)
+		redirectUrl.POST("/verify",
+			middleware.RateLimit(limiter, middleware.RateLimitCodePassword,
+				middleware.RateLimitByIP(), middleware.RateLimitByBody(ratelimit.KindQRCode, "qr_code")),
+			redirectController.VerifyRedirectUrlPassword)
 	}
 
 	setting := engine.Group("v1/buttons")
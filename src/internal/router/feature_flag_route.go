@@ -0,0 +1,46 @@
+package router
+
+import (
+	"sen-global-api/config"
+	"sen-global-api/internal/controller"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/usecase"
+	"sen-global-api/internal/middleware"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func setupFeatureFlagRoutes(engine *gin.Engine, dbConn *gorm.DB, appConfig config.AppConfig) {
+	sessionRepository := repository.SessionRepository{
+		OrganizationRepository: &repository.OrganizationRepository{DBConn: dbConn},
+		AuthorizeEncryptKey:    appConfig.AuthorizeEncryptKey,
+
+		TokenExpireTimeInHour: time.Duration(appConfig.TokenExpireDurationInHour),
+	}
+	secureMiddleware := middleware.SecuredMiddleware{SessionRepository: sessionRepository}
+
+	featureFlagController := &controller.FeatureFlagController{
+		FeatureFlagUseCase: &usecase.FeatureFlagUseCase{
+			Repo: repository.NewFeatureFlagRepository(dbConn),
+		},
+	}
+
+	// token khong bat buoc: app doc flag ca truoc khi login, subject lay tu middleware.FeatureFlags
+	app := engine.Group("/v1/app")
+	{
+		app.GET("/flags", featureFlagController.GetAppFlags)
+	}
+
+	admin := engine.Group("/v1/admin/feature-flag", secureMiddleware.ValidateSuperAdminRole())
+	{
+		admin.GET("", featureFlagController.GetFlags4Admin)
+		admin.POST("", featureFlagController.CreateFlag4Admin)
+		admin.GET("/audit", featureFlagController.GetAudits4Admin)
+		admin.POST("/evaluate", featureFlagController.Evaluate4Admin)
+		admin.GET("/:key", featureFlagController.GetFlag4Admin)
+		admin.PUT("/:key", featureFlagController.UpdateFlag4Admin)
+		admin.DELETE("/:key", featureFlagController.DeleteFlag4Admin)
+	}
+}
@@ -0,0 +1,40 @@
+package router
+
+import (
+	"sen-global-api/config"
+	"sen-global-api/internal/controller"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/usecase"
+	"sen-global-api/internal/middleware"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func setupFormScoringRoutes(engine *gin.Engine, dbConn *gorm.DB, appConfig config.AppConfig) {
+	sessionRepository := repository.SessionRepository{
+		OrganizationRepository: &repository.OrganizationRepository{DBConn: dbConn},
+		AuthorizeEncryptKey:    appConfig.AuthorizeEncryptKey,
+
+		TokenExpireTimeInHour: time.Duration(appConfig.TokenExpireDurationInHour),
+	}
+	secureMiddleware := middleware.SecuredMiddleware{SessionRepository: sessionRepository}
+
+	formScoringController := &controller.FormScoringController{
+		FormScoringUseCase: &usecase.FormScoringUseCase{
+			Repo:           repository.NewFormScoringRepository(dbConn),
+			FormRepo:       &repository.FormRepository{DBConn: dbConn},
+			QuestionRepo:   &repository.QuestionRepository{DBConn: dbConn},
+			SubmissionRepo: &repository.SubmissionRepository{DBConn: dbConn},
+		},
+	}
+
+	admin := engine.Group("/v1/admin/form", secureMiddleware.ValidateSuperAdminRole())
+	{
+		admin.GET("/:id/scoring", formScoringController.GetScoring)
+		admin.PUT("/:id/scoring", formScoringController.UpdateScoring)
+		admin.DELETE("/:id/scoring", formScoringController.DeleteScoring)
+		admin.GET("/submission/:id/score", formScoringController.GetSubmissionScore)
+	}
+}
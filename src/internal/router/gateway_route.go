@@ -164,6 +164,7 @@ func setupGatewayRoutes(r *gin.Engine, dbConn *gorm.DB, appCfg config.AppConfig,
 		&repository.LanguageSettingRepository{DBConn: dbConn},
 		&repository.ParentRepository{DBConn: dbConn},
 		&repository.ParentChildsRepository{DBConn: dbConn},
+		&repository.ChildGuardianRepository{DBConn: dbConn},
 		profileGw,
 		generateOwnerCodeUseCase,
 		departmentGW,
@@ -329,6 +330,9 @@ func setupGatewayRoutes(r *gin.Engine, dbConn *gorm.DB, appCfg config.AppConfig,
 		child := api.Group("/children")
 		{
 			child.POST("/code/generate", userEntityCtrl.GenerateChildCode)
+			child.GET("/:child_id/guardians", userEntityCtrl.GetChildGuardians4Gateway)
+			child.GET("/:child_id/guardians/:user_id", userEntityCtrl.GetChildGuardianPermission4Gateway)
+			child.GET("/guardian/:user_id", userEntityCtrl.GetChildrenByGuardian4Gateway)
 		}
 
 		// organization
@@ -364,6 +368,9 @@ func setupGatewayRoutes(r *gin.Engine, dbConn *gorm.DB, appCfg config.AppConfig,
 			UploadImageUseCase: &usecase.UploadImageUseCase{
 				ImageRepository: &repository.ImageRepository{DBConn: dbConn},
 				UploadProvider:  s3Provider,
+				ChildPrivacyUseCase: &usecase.ChildPrivacyUseCase{
+					ConsentRepo: &repository.ChildConsentRepository{DBConn: dbConn},
+				},
 			},
 			DeleteImageUseCase: &usecase.DeleteImageUseCase{
 				ImageRepository: &repository.ImageRepository{DBConn: dbConn},
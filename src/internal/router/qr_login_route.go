@@ -0,0 +1,37 @@
+package router
+
+import (
+	"sen-global-api/config"
+	"sen-global-api/internal/controller"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/usecase"
+	"sen-global-api/internal/middleware"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func setupQRLoginRoutes(engine *gin.Engine, dbConn *gorm.DB, appConfig config.AppConfig) {
+	sessionRepository := repository.SessionRepository{
+		OrganizationRepository: &repository.OrganizationRepository{DBConn: dbConn},
+		AuthorizeEncryptKey:    appConfig.AuthorizeEncryptKey,
+
+		TokenExpireTimeInHour: time.Duration(appConfig.TokenExpireDurationInHour),
+	}
+	secureMiddleware := middleware.SecuredMiddleware{SessionRepository: sessionRepository}
+
+	qrLoginController := &controller.QRLoginController{
+		QRLoginUseCase: &usecase.QRLoginUseCase{
+			Repo: repository.NewQRLoginTokenRepository(dbConn),
+		},
+	}
+
+	engine.POST("/v1/user/qr-login/rotate", secureMiddleware.Secured(), qrLoginController.RotateMine)
+
+	admin := engine.Group("/v1/admin/user", secureMiddleware.ValidateSuperAdminRole())
+	{
+		admin.POST("/:id/qr-login/rotate", qrLoginController.Rotate4Admin)
+		admin.DELETE("/:id/qr-login", qrLoginController.Revoke4Admin)
+	}
+}
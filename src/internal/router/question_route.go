@@ -76,11 +76,15 @@ func setupQuestionRoutes(engine *gin.Engine, conn *gorm.DB, config config.AppCon
 		GetDeviceByIDUseCase: usecase.GetDeviceByIDUseCase{
 			DeviceRepository: &repository.DeviceRepository{DBConn: conn, DefaultRequestPageSize: config.DefaultRequestPageSize, DefaultOutputSpreadsheetUrl: config.OutputSpreadsheetUrl},
 		},
+		FormPasswordUseCase: &usecase.VerifyFormPasswordUseCase{
+			FormRepo:    &repository.FormRepository{DBConn: conn},
+			AttemptRepo: repository.NewPasswordAttemptRepository(conn),
+		},
 	}
 
 	form := engine.Group("v1/form")
 	{
-		form.POST("", middleware.GeneralLoggerMiddleware(conn), questionController.GetFormQRCode)
+		form.POST("", middleware.GeneralLoggerMiddleware(middleware.DefaultDataLogWriter(), sessionRepository), questionController.GetFormQRCode)
 	}
 
 	question := engine.Group("v1/question", secureMiddleware.Secured())
@@ -0,0 +1,41 @@
+package router
+
+import (
+	"sen-global-api/config"
+	"sen-global-api/internal/controller"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/usecase"
+	"sen-global-api/internal/middleware"
+	"sen-global-api/pkg/ratelimit"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func setupRateLimitRoutes(engine *gin.Engine, dbConn *gorm.DB, appConfig config.AppConfig) {
+	sessionRepository := repository.SessionRepository{
+		OrganizationRepository: &repository.OrganizationRepository{DBConn: dbConn},
+		AuthorizeEncryptKey:    appConfig.AuthorizeEncryptKey,
+
+		TokenExpireTimeInHour: time.Duration(appConfig.TokenExpireDurationInHour),
+	}
+	secureMiddleware := middleware.SecuredMiddleware{SessionRepository: sessionRepository}
+
+	rateLimitUseCase := &usecase.RateLimitUseCase{
+		Limiter:               ratelimit.Default(),
+		AccountsLogRepository: &repository.AccountsLogRepository{DBConn: dbConn},
+		UserEntityRepository:  &repository.UserEntityRepository{DBConn: dbConn},
+	}
+	ratelimit.Default().OnLockout(rateLimitUseCase.AuditLockout)
+
+	rateLimitController := &controller.RateLimitController{
+		RateLimitUseCase: rateLimitUseCase,
+	}
+
+	admin := engine.Group("/v1/admin/rate-limit", secureMiddleware.ValidateSuperAdminRole())
+	{
+		admin.GET("/locks", rateLimitController.GetLocks4Admin)
+		admin.POST("/unlock", rateLimitController.Unlock4Admin)
+	}
+}
@@ -0,0 +1,30 @@
+package router
+
+import (
+	"sen-global-api/config"
+	"sen-global-api/internal/controller"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/middleware"
+	"sen-global-api/pkg/realtime"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func setupRealtimeRoutes(engine *gin.Engine, dbConn *gorm.DB, appConfig config.AppConfig) {
+	sessionRepository := repository.SessionRepository{
+		OrganizationRepository: &repository.OrganizationRepository{DBConn: dbConn},
+		AuthorizeEncryptKey:    appConfig.AuthorizeEncryptKey,
+
+		TokenExpireTimeInHour: time.Duration(appConfig.TokenExpireDurationInHour),
+	}
+	secureMiddleware := middleware.SecuredMiddleware{SessionRepository: sessionRepository}
+
+	realtimeController := &controller.RealtimeController{
+		DeviceRepository: &repository.DeviceRepository{DBConn: dbConn},
+		Hub:              realtime.Default(),
+	}
+
+	engine.GET("/v1/realtime/stream", secureMiddleware.Secured(), realtimeController.Stream)
+}
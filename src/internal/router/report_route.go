@@ -0,0 +1,81 @@
+package router
+
+import (
+	"context"
+	"sen-global-api/config"
+	"sen-global-api/internal/controller"
+	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/usecase"
+	"sen-global-api/internal/middleware"
+	"sen-global-api/pkg/lifecycle"
+	"sen-global-api/pkg/uploader"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func setupReportRoutes(engine *gin.Engine, dbConn *gorm.DB, appConfig config.AppConfig) {
+	sessionRepository := repository.SessionRepository{
+		OrganizationRepository: &repository.OrganizationRepository{DBConn: dbConn},
+		AuthorizeEncryptKey:    appConfig.AuthorizeEncryptKey,
+
+		TokenExpireTimeInHour: time.Duration(appConfig.TokenExpireDurationInHour),
+	}
+	secureMiddleware := middleware.SecuredMiddleware{SessionRepository: sessionRepository}
+
+	provider := uploader.NewS3Provider(
+		appConfig.S3.SenboxFormSubmitBucket.AccessKey,
+		appConfig.S3.SenboxFormSubmitBucket.SecretKey,
+		appConfig.S3.SenboxFormSubmitBucket.BucketName,
+		appConfig.S3.SenboxFormSubmitBucket.Region,
+		appConfig.S3.SenboxFormSubmitBucket.Domain,
+		appConfig.S3.SenboxFormSubmitBucket.CloudfrontKeyGroupID,
+		appConfig.S3.SenboxFormSubmitBucket.CloudfrontKeyPath,
+	)
+
+	reportUseCase := &usecase.ReportUseCase{
+		Repo:              repository.NewReportRepository(dbConn),
+		SubmissionRepo:    &repository.SubmissionRepository{DBConn: dbConn},
+		StudentRepo:       &repository.StudentApplicationRepository{DB: dbConn},
+		TeacherRepo:       &repository.TeacherApplicationRepository{DBConn: dbConn},
+		AttendanceRepo:    &repository.AttendanceRepository{DBConn: dbConn},
+		OrganizationRepo:  &repository.OrganizationRepository{DBConn: dbConn},
+		ChildGuardianRepo: &repository.ChildGuardianRepository{DBConn: dbConn},
+		UploadProvider:    provider,
+	}
+
+	// job bi ngat boi shutdown/crash chay tiep khi start
+	lifecycle.Default().Register(lifecycle.Hook{
+		Name:  "report_job_resume",
+		Phase: lifecycle.PhaseWorker,
+		Start: func(context.Context) error {
+			lifecycle.Default().Go("report_job_resume", func(context.Context) {
+				reportUseCase.ResumeInterrupted()
+			})
+			return nil
+		},
+	})
+
+	reportController := &controller.ReportController{ReportUseCase: reportUseCase}
+
+	reports := engine.Group("/v1/report", secureMiddleware.Secured())
+	{
+		reports.POST("/submission/:submission_id", reportController.GenerateSubmissionReport)
+		reports.POST("/student/:student_id", reportController.GenerateStudentReport)
+		reports.POST("/class", reportController.GenerateClassReport)
+		reports.GET("/:id/download", reportController.DownloadReport)
+	}
+
+	admin := engine.Group("/v1/admin/report", secureMiddleware.ValidateSuperAdminRole())
+	{
+		admin.GET("", reportController.GetReports)
+		admin.POST("/submission/:submission_id", reportController.GenerateSubmissionReport4Admin)
+		admin.POST("/student/:student_id", reportController.GenerateStudentReport4Admin)
+		admin.POST("/class", reportController.GenerateClassReport4Admin)
+		admin.GET("/:id/download", reportController.DownloadReport4Admin)
+		admin.POST("/job", reportController.CreateJob)
+		admin.GET("/job", reportController.GetJobs)
+		admin.GET("/job/:id", reportController.GetJob)
+	}
+}
@@ -30,4 +30,19 @@ func Route(
 	setupOrganizationRoutes(engine, dbConn, appConfig)
 	setupAppRoutes(engine, dbConn)
 	setupGatewayRoutes(engine, dbConn, appConfig, consulClient, cacheClientRedis)
+	setupAttendanceRoutes(engine, dbConn, appConfig, fcm)
+	setupBookingRoutes(engine, dbConn, appConfig, fcm)
+	setupFormScoringRoutes(engine, dbConn, appConfig)
+	setupRealtimeRoutes(engine, dbConn, appConfig)
+	setupAnnouncementRoutes(engine, dbConn, appConfig, fcm, consulClient)
+	setupQRLoginRoutes(engine, dbConn, appConfig)
+	setupRateLimitRoutes(engine, dbConn, appConfig)
+	setupDataLogRoutes(engine, dbConn, appConfig)
+	setupAppReleaseRoutes(engine, dbConn, appConfig)
+	setupFeatureFlagRoutes(engine, dbConn, appConfig)
+	setupDeviceHeartbeatRoutes(engine, dbConn, appConfig, fcm)
+	setupDeviceCommandRoutes(engine, dbConn, appConfig, fcm)
+	setupDeviceEnrollmentRoutes(engine, dbConn, appConfig)
+	setupDeviceModeProfileRoutes(engine, dbConn, appConfig)
+	setupReportRoutes(engine, dbConn, appConfig)
 }
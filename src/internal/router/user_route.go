@@ -4,9 +4,11 @@ import (
 	"sen-global-api/config"
 	"sen-global-api/internal/controller"
 	"sen-global-api/internal/data/repository"
+	"sen-global-api/internal/domain/entity"
 	"sen-global-api/internal/domain/usecase"
 	"sen-global-api/internal/middleware"
 	"sen-global-api/pkg/consulapi/gateway"
+	"sen-global-api/pkg/ratelimit"
 	"sen-global-api/pkg/uploader"
 	"time"
 
@@ -27,6 +29,14 @@ func setupUserRoutes(engine *gin.Engine, dbConn *gorm.DB, config config.AppConfi
 	}
 	secureMiddleware := middleware.SecuredMiddleware{SessionRepository: sessionRepository}
 
+	// device dang o mode nao thi chi goi duoc API cua role duoc cho phep trong profile cua mode do
+	deviceModeProfileUseCase := newDeviceModeProfileUseCase(dbConn)
+	deviceMode := middleware.DeviceModeMiddleware{
+		SessionRepository: sessionRepository,
+		Checker:           deviceModeProfileUseCase,
+		DeviceRepository:  &repository.DeviceRepository{DBConn: dbConn},
+	}
+
 	provider := uploader.NewS3Provider(
 		config.S3.SenboxFormSubmitBucket.AccessKey,
 		config.S3.SenboxFormSubmitBucket.SecretKey,
@@ -79,6 +89,7 @@ func setupUserRoutes(engine *gin.Engine, dbConn *gorm.DB, config config.AppConfi
 		&repository.LanguageSettingRepository{DBConn: dbConn},
 		&repository.ParentRepository{DBConn: dbConn},
 		&repository.ParentChildsRepository{DBConn: dbConn},
+		&repository.ChildGuardianRepository{DBConn: dbConn},
 		profileGw,
 		generateOwnerCodeUseCase,
 		departmentGW,
@@ -520,6 +531,17 @@ func setupUserRoutes(engine *gin.Engine, dbConn *gorm.DB, config config.AppConfi
 		},
 	}
 
+	childPrivacyController := &controller.ChildPrivacyController{
+		ChildPrivacyUseCase: &usecase.ChildPrivacyUseCase{
+			DBConn:            dbConn,
+			PrivacyRepo:       &repository.ChildPrivacyRepository{DBConn: dbConn},
+			ConsentRepo:       &repository.ChildConsentRepository{DBConn: dbConn},
+			DataRequestRepo:   &repository.ChildDataRequestRepository{DBConn: dbConn},
+			ChildGuardianRepo: &repository.ChildGuardianRepository{DBConn: dbConn},
+			UploadProvider:    provider,
+		},
+	}
+
 	userAccess := engine.Group("v1/")
 	{
 		loginController := &controller.LoginController{DBConn: dbConn,
@@ -543,9 +565,14 @@ func setupUserRoutes(engine *gin.Engine, dbConn *gorm.DB, config config.AppConfi
 				},
 			},
 		}
-		userAccess.POST("/login", loginController.UserLogin)
+		userAccess.POST("/login",
+			middleware.RateLimit(ratelimit.Default(), middleware.RateLimitLogin,
+				middleware.RateLimitByIP(), middleware.RateLimitByBody(ratelimit.KindUsername, "username")),
+			loginController.UserLogin)
 		userAccess.POST("/logout", secureMiddleware.Secured(), logoutController.UserLogout)
-		userAccess.GET("/refresh-token", loginController.RefreshToken)
+		userAccess.GET("/refresh-token",
+			middleware.RateLimit(ratelimit.Default(), middleware.RateLimitRefreshToken, middleware.RateLimitByIP()),
+			loginController.RefreshToken)
 	}
 
 	// block setting
@@ -564,6 +591,10 @@ func setupUserRoutes(engine *gin.Engine, dbConn *gorm.DB, config config.AppConfi
 	accountsLogController := &controller.AccountsLogController{
 		AccountsLogUseCase: &usecase.AccountsLogUseCase{
 			AccountsLogRepository: &repository.AccountsLogRepository{DBConn: dbConn},
+			AttendanceUseCase: &usecase.AttendanceUseCase{
+				Repo:        &repository.AttendanceRepository{DBConn: dbConn},
+				StudentRepo: &repository.StudentApplicationRepository{DB: dbConn},
+			},
 		},
 	}
 
@@ -595,6 +626,16 @@ func setupUserRoutes(engine *gin.Engine, dbConn *gorm.DB, config config.AppConfi
 		user.GET("/role-sign-up", userEntityController.GetAllRoleOrgSignUp)
 		user.GET("/child/:id", secureMiddleware.Secured(), userEntityController.GetChildByID)
 		user.PUT("/child", secureMiddleware.Secured(), userEntityController.UpdateChild)
+		user.GET("/child/:id/guardians", secureMiddleware.Secured(), userEntityController.GetChildGuardians)
+		user.POST("/child/guardian", secureMiddleware.Secured(), userEntityController.AddChildGuardian)
+		user.PUT("/child/guardian", secureMiddleware.Secured(), userEntityController.UpdateChildGuardian)
+		user.DELETE("/child/:id/guardian/:user_id", secureMiddleware.Secured(), userEntityController.RemoveChildGuardian)
+		user.GET("/child/:id/consent", secureMiddleware.Secured(), childPrivacyController.GetConsent)
+		user.PUT("/child/:id/consent", secureMiddleware.Secured(), childPrivacyController.UpdateConsent)
+		user.POST("/child/:id/data-export", secureMiddleware.Secured(), childPrivacyController.RequestExport)
+		user.POST("/child/:id/data-erasure", secureMiddleware.Secured(), childPrivacyController.RequestErasure)
+		user.GET("/child/:id/data-requests", secureMiddleware.Secured(), childPrivacyController.GetRequestsByChild)
+		user.GET("/child/data-request/:request_id/download", secureMiddleware.Secured(), childPrivacyController.DownloadExport)
 
 		block := user.Group("/block")
 		{
@@ -611,7 +652,7 @@ func setupUserRoutes(engine *gin.Engine, dbConn *gorm.DB, config config.AppConfi
 		user.GET("/organization/:organization_id/staffs-teachers", secureMiddleware.Secured(), userEntityController.GetStaffsTeachers4App)
 	}
 
-	teacherApplication := engine.Group("/v1/user/teacher/application")
+	teacherApplication := engine.Group("/v1/user/teacher/application", deviceMode.RequireDeviceRole(entity.Teacher))
 	{
 		// teacherApplication.GET("/", secureMiddleware.Secured(), userEntityController.GetAllTeacherFormApplication)
 		// teacherApplication.GET("/:id", secureMiddleware.Secured(), userEntityController.GetTeacherFormApplicationByID)
@@ -621,7 +662,7 @@ func setupUserRoutes(engine *gin.Engine, dbConn *gorm.DB, config config.AppConfi
 		teacherApplication.POST("/:id/block", secureMiddleware.Secured(), userEntityController.BlockTeacherFormApplication)
 	}
 
-	staffApplication := engine.Group("/v1/user/staff/application")
+	staffApplication := engine.Group("/v1/user/staff/application", deviceMode.RequireDeviceRole(entity.Staff))
 	{
 		// staffApplication.GET("/", secureMiddleware.Secured(), userEntityController.GetAllStaffFormApplication)
 		// staffApplication.GET("/:id", secureMiddleware.Secured(), userEntityController.GetStaffFormApplicationByID)
@@ -684,9 +725,9 @@ func setupUserRoutes(engine *gin.Engine, dbConn *gorm.DB, config config.AppConfi
 		userMenu.GET("/super-admin", menuController.GetSuperAdminMenu)
 		userMenu.GET("/user/super-admin", menuController.GetSuperAdminMenu4App)
 		userMenu.GET("/user/org/:id", menuController.GetOrgMenu4App)
-		userMenu.GET("/student/:id", menuController.GetStudentMenu4App)
-		userMenu.POST("/student/organization", menuController.GetStudentMenuOrganization4App)
-		userMenu.GET("/teacher/:id", menuController.GetTeacherMenu4App)
+		userMenu.GET("/student/:id", deviceMode.RequireDeviceRole(entity.Student), menuController.GetStudentMenu4App)
+		userMenu.POST("/student/organization", deviceMode.RequireDeviceRole(entity.Student), menuController.GetStudentMenuOrganization4App)
+		userMenu.GET("/teacher/:id", deviceMode.RequireDeviceRole(entity.Teacher), menuController.GetTeacherMenu4App)
 		userMenu.GET("/user/:id", menuController.GetUserMenu4App)
 		userMenu.GET("/device/:id", menuController.GetDeviceMenu)
 		userMenu.GET("/user/device/:id", menuController.GetDeviceMenu4App)
@@ -699,7 +740,7 @@ func setupUserRoutes(engine *gin.Engine, dbConn *gorm.DB, config config.AppConfi
 		userMenu.POST("/device", menuController.UploadDeviceMenu)
 		userMenu.GET("/common", menuController.GetCommonMenu)
 		userMenu.GET("/common-by-user", menuController.GetCommonMenuByUser)
-		userMenu.POST("/teacher/organization", menuController.GetTeacherMenuOrganization4App)
+		userMenu.POST("/teacher/organization", deviceMode.RequireDeviceRole(entity.Teacher), menuController.GetTeacherMenuOrganization4App)
 		// department menu org
 		userMenu.GET("/department/device/:device_id/organization/:organization_id", menuController.GetDepartmentMenuOrganization4App)
 		// emergency menu
@@ -725,6 +766,7 @@ func setupUserRoutes(engine *gin.Engine, dbConn *gorm.DB, config config.AppConfi
 			OrganizationRepo:            &repository.OrganizationRepository{DBConn: dbConn},
 			OrganizationSettingMenuRepo: &repository.OrganizationSettingMenuRepository{DBConn: dbConn},
 			LanguageSettingRepo:         &repository.LanguageSettingRepository{DBConn: dbConn},
+			DeviceModeProfileUseCase:    deviceModeProfileUseCase,
 		},
 		GetOrganizationUseCase: &usecase.GetOrganizationUseCase{
 			OrganizationRepository: &repository.OrganizationRepository{DBConn: dbConn},
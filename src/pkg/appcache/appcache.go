@@ -0,0 +1,155 @@
+// Package appcache is a read-through cache in Redis for the data read by every device and app call
+// (menus, settings, ...). Values are stored as JSON under value.MainCachePrefix.
+//
+// Keys are versioned: a cache is split in scopes (a student, an organization, ...) and each scope has a
+// version counter in Redis. Invalidating a scope bumps its counter, so every key of the scope (one per
+// language, ...) is skipped at once and expires with its TTL. InvalidateAll bumps the counter of the cache.
+package appcache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sen-global-api/internal/domain/value"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/singleflight"
+)
+
+// versionTTL keeps the version counters much longer than the values, an expired counter restarts at 0
+// and must not find the values of its first versions again.
+const versionTTL = 30 * 24 * time.Hour
+
+var (
+	client *goredis.Client
+	group  singleflight.Group
+)
+
+// Init sets the Redis client, called once at startup. Without client every Get loads from the source.
+func Init(redisClient *goredis.Client) {
+	client = redisClient
+}
+
+func Enabled() bool {
+	return client != nil
+}
+
+// Cache is a typed cache, declared once per kind of value:
+//
+//	var orgSettingCache = appcache.New[response.OrgSettingResponse]("org_setting", 1, 10*time.Minute)
+//
+// schema is part of the keys, bump it when T changes so the old values are not decoded into the new type.
+type Cache[T any] struct {
+	name   string
+	schema int
+	ttl    time.Duration
+}
+
+func New[T any](name string, schema int, ttl time.Duration) *Cache[T] {
+	return &Cache[T]{name: name, schema: schema, ttl: ttl}
+}
+
+func (c *Cache[T]) prefix() string {
+	return fmt.Sprintf("%scache:%s:s%d", value.MainCachePrefix, c.name, c.schema)
+}
+
+func (c *Cache[T]) versionKey(scope string) string {
+	return c.prefix() + ":" + scope + ":ver"
+}
+
+func (c *Cache[T]) allVersionKey() string {
+	return c.prefix() + ":ver"
+}
+
+// Get returns the value of scope/key, load is called on a miss and its result stored for the TTL.
+// Concurrent misses of the same key in this instance share one load. Redis errors fall back to load.
+func (c *Cache[T]) Get(ctx context.Context, scope string, key string, load func() (T, error)) (T, error) {
+	if client == nil {
+		return load()
+	}
+
+	dataKey, err := c.dataKey(ctx, scope, key)
+	if err != nil {
+		log.Warnf("appcache %s: read versions: %v", c.name, err)
+		return load()
+	}
+
+	raw, err := client.Get(ctx, dataKey).Bytes()
+	if err == nil {
+		var cached T
+		if err := json.Unmarshal(raw, &cached); err == nil {
+			return cached, nil
+		}
+		log.Warnf("appcache %s: decode %s: %v", c.name, dataKey, err)
+	} else if !errors.Is(err, goredis.Nil) {
+		log.Warnf("appcache %s: get %s: %v", c.name, dataKey, err)
+		return load()
+	}
+
+	result, err, _ := group.Do(dataKey, func() (interface{}, error) {
+		loaded, err := load()
+		if err != nil {
+			return loaded, err
+		}
+		if data, err := json.Marshal(loaded); err != nil {
+			log.Warnf("appcache %s: encode: %v", c.name, err)
+		} else if err := client.Set(context.WithoutCancel(ctx), dataKey, data, c.ttl).Err(); err != nil {
+			log.Warnf("appcache %s: set %s: %v", c.name, dataKey, err)
+		}
+		return loaded, nil
+	})
+	if err != nil {
+		var zero T
+		if result != nil {
+			return result.(T), err
+		}
+		return zero, err
+	}
+	return result.(T), nil
+}
+
+func (c *Cache[T]) dataKey(ctx context.Context, scope string, key string) (string, error) {
+	versions, err := client.MGet(ctx, c.allVersionKey(), c.versionKey(scope)).Result()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:g%s:%s:v%s:%s", c.prefix(), version(versions[0]), scope, version(versions[1]), key), nil
+}
+
+func version(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return "0"
+}
+
+// Invalidate drops every key of the scopes.
+func (c *Cache[T]) Invalidate(ctx context.Context, scopes ...string) {
+	if client == nil || len(scopes) == 0 {
+		return
+	}
+	pipe := client.Pipeline()
+	for _, scope := range scopes {
+		pipe.Incr(ctx, c.versionKey(scope))
+		pipe.Expire(ctx, c.versionKey(scope), versionTTL)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Errorf("appcache %s: invalidate %v: %v", c.name, scopes, err)
+	}
+}
+
+// InvalidateAll drops every key of the cache, for the changes shared by many scopes.
+func (c *Cache[T]) InvalidateAll(ctx context.Context) {
+	if client == nil {
+		return
+	}
+	pipe := client.Pipeline()
+	pipe.Incr(ctx, c.allVersionKey())
+	pipe.Expire(ctx, c.allVersionKey(), versionTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Errorf("appcache %s: invalidate all: %v", c.name, err)
+	}
+}
@@ -10,6 +10,10 @@ import (
 
 type Log struct {
 	Level string `env-required:"true" yaml:"log_level"   env:"LOG_LEVEL"`
+	// Format is "json" (default) or "text" for local development
+	Format string `yaml:"format" env:"LOG_FORMAT"`
+	// Packages overrides the level per package, e.g. "internal/router: warn" or "usecase: debug"
+	Packages map[string]string `yaml:"packages"`
 }
 
 type HTTP struct {
@@ -25,6 +29,18 @@ type Database struct {
 	MaxConn     int    `env-required:"true" yaml:"max_conn" env:"DATABASE_MAX_CONN"`
 	MaxIDleConn int    `env-required:"true" yaml:"max_idle_conn" env:"DATABASE_MAX_IDLE_CONN"`
 	MaxLifetime int    `env-required:"true" yaml:"max_lifetime_conn" env:"DATABASE_MAX_LIFETIME_CONN"`
+	// MaxIdleTime closes the connections idle for longer, in seconds, 0 keeps them
+	MaxIdleTime int `yaml:"max_idle_time_conn" env:"DATABASE_MAX_IDLE_TIME_CONN"`
+	// ReplicaHosts are the read replicas (host or host:port), same user, password and database as the primary
+	ReplicaHosts []string `yaml:"replica_hosts" env:"DATABASE_REPLICA_HOSTS" env-separator:","`
+	// QueryTimeoutSeconds bounds a statement without deadline in its context, 30s by default
+	QueryTimeoutSeconds int `yaml:"query_timeout_seconds" env:"DATABASE_QUERY_TIMEOUT_SECONDS"`
+	// LogLevel of the SQL logger: silent, error, warn or info, info in development and warn otherwise
+	LogLevel string `yaml:"log_level" env:"DATABASE_LOG_LEVEL"`
+	// SlowQueryMs logs the statements slower than this as warnings, 1000ms by default
+	SlowQueryMs int `yaml:"slow_query_ms" env:"DATABASE_SLOW_QUERY_MS"`
+	// ConnectRetries is the number of connection attempts at startup, 5 by default
+	ConnectRetries int `yaml:"connect_retries" env:"DATABASE_CONNECT_RETRIES"`
 }
 
 type RedisCacheConfig struct {
@@ -61,6 +77,8 @@ type Config struct {
 	Consul           Consul           `yaml:"consul"`
 	Registry         Registry         `yaml:"registry"`
 	RedisCacheConfig RedisCacheConfig `yaml:"redis_cache"`
+	// MigrateOnBoot applies the pending migrations at boot outside development, otherwise the boot fails on them
+	MigrateOnBoot bool `yaml:"migrate_on_boot" env:"MIGRATE_ON_BOOT"`
 }
 
 func NewConfigFromYAMLFile(yamlFile string) (*Config, error) {
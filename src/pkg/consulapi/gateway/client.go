@@ -2,11 +2,15 @@ package gateway
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"sen-global-api/pkg/consulapi"
+	"sen-global-api/pkg/logger"
+	"sen-global-api/pkg/metrics"
+	"time"
 
 	"github.com/hashicorp/consul/api"
 )
@@ -40,9 +44,11 @@ func NewGatewayClient(serviceName, token string, consulClient *api.Client, httpC
 	}, nil
 }
 
-// Call gọi API tới service khác thông qua Consul discovery
-func (c *GatewayClient) Call(method, path string, body interface{}, headers map[string]string) ([]byte, error) {
+// Call gọi API tới service khác thông qua Consul discovery, X-Request-ID của ctx được forward
+func (c *GatewayClient) Call(ctx context.Context, method, path string, body interface{}, headers map[string]string) ([]byte, error) {
+	start := time.Now()
 	service, err := c.ServiceDiscovery.DiscoverService()
+	metrics.ObserveCall(metrics.ServiceConsul, "discover", start, err)
 	if err != nil {
 		return nil, fmt.Errorf("service discovery failed: %v", err)
 	}
@@ -58,7 +64,7 @@ func (c *GatewayClient) Call(method, path string, body interface{}, headers map[
 
 	url := fmt.Sprintf("http://%s:%d%s", service.ServiceAddress, service.ServicePort, path)
 
-	req, err := http.NewRequest(method, url, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("create request failed: %v", err)
 	}
@@ -71,6 +77,9 @@ func (c *GatewayClient) Call(method, path string, body interface{}, headers map[
 		req.Header.Set("Authorization", "Bearer "+c.Token)
 	}
 
+	// correlation id cho log giữa các service
+	logger.SetRequestIDHeader(ctx, req)
+
 	// thêm custom headers
 	for k, v := range headers {
 		req.Header.Set(k, v)
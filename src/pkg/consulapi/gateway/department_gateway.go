@@ -55,7 +55,7 @@ func (dg *departmentGateway) GetDepartmentsByUser(context *gin.Context) ([]*resp
 		headers["X-App-Language"] = strconv.Itoa(int(lang))
 	}
 
-	resp, err := client.Call("GET", "/api/v1/gateway/departments", nil, headers)
+	resp, err := client.Call(context, "GET", "/api/v1/gateway/departments", nil, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -99,7 +99,7 @@ func (dg *departmentGateway) GetDepartmentsByOrganization(context *gin.Context,
 		headers["X-App-Language"] = strconv.Itoa(int(lang))
 	}
 
-	resp, err := client.Call("GET", fmt.Sprintf("/api/v1/gateway/departments/organization/%s", orgID), nil, headers)
+	resp, err := client.Call(context, "GET", fmt.Sprintf("/api/v1/gateway/departments/organization/%s", orgID), nil, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -150,7 +150,7 @@ func (dg *departmentGateway) AssignParentDepartmentGroup(context *gin.Context, p
 		OrganizationID: organizationID,
 	}
 
-	resp, err := client.Call("POST", "/api/v1/gateway/departments/assign/parent-group", req, headers)
+	resp, err := client.Call(context, "POST", "/api/v1/gateway/departments/assign/parent-group", req, headers)
 	if err != nil {
 		return fmt.Errorf("call gateway assign parent department group fail: %w", err)
 	}
@@ -200,7 +200,7 @@ func (dg *departmentGateway) AssignStudentDepartmentGroup(context *gin.Context,
 		OrganizationID: organizationID,
 	}
 
-	resp, err := client.Call("POST", "/api/v1/gateway/departments/assign/student-group", req, headers)
+	resp, err := client.Call(context, "POST", "/api/v1/gateway/departments/assign/student-group", req, headers)
 	if err != nil {
 		return fmt.Errorf("call gateway assign student department group fail: %w", err)
 	}
@@ -250,7 +250,7 @@ func (dg *departmentGateway) AssignTeacherDepartmentGroup(context *gin.Context,
 		OrganizationID: organizationID,
 	}
 
-	resp, err := client.Call("POST", "/api/v1/gateway/departments/assign/teacher-group", req, headers)
+	resp, err := client.Call(context, "POST", "/api/v1/gateway/departments/assign/teacher-group", req, headers)
 	if err != nil {
 		return fmt.Errorf("call gateway assign teacher department group fail: %w", err)
 	}
@@ -300,7 +300,7 @@ func (dg *departmentGateway) AssignStaffDepartmentGroup(context *gin.Context, st
 		OrganizationID: organizationID,
 	}
 
-	resp, err := client.Call("POST", "/api/v1/gateway/departments/assign/staff-group", req, headers)
+	resp, err := client.Call(context, "POST", "/api/v1/gateway/departments/assign/staff-group", req, headers)
 	if err != nil {
 		return fmt.Errorf("call gateway assign staff department group fail: %w", err)
 	}
@@ -84,7 +84,7 @@ func (pg *profileGateway) GenerateStudentCode(ctx *gin.Context, ownerID string,
 		CreatedIndex: createdIndex,
 	}
 
-	resp, err := client.Call("POST", "/api/v1/gateway/profiles/owner-code/student/generate", req, headers)
+	resp, err := client.Call(ctx, "POST", "/api/v1/gateway/profiles/owner-code/student/generate", req, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -130,7 +130,7 @@ func (pg *profileGateway) GenerateTeacherCode(ctx *gin.Context, ownerID string,
 		CreatedIndex: createdIndex,
 	}
 
-	resp, err := client.Call("POST", "/api/v1/gateway/profiles/owner-code/teacher/generate", req, headers)
+	resp, err := client.Call(ctx, "POST", "/api/v1/gateway/profiles/owner-code/teacher/generate", req, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -176,7 +176,7 @@ func (pg *profileGateway) GenerateStaffCode(ctx *gin.Context, ownerID string, cr
 		CreatedIndex: createdIndex,
 	}
 
-	resp, err := client.Call("POST", "/api/v1/gateway/profiles/owner-code/staff/generate", req, headers)
+	resp, err := client.Call(ctx, "POST", "/api/v1/gateway/profiles/owner-code/staff/generate", req, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -222,7 +222,7 @@ func (pg *profileGateway) GenerateParentCode(ctx *gin.Context, ownerID string, c
 		CreatedIndex: createdIndex,
 	}
 
-	resp, err := client.Call("POST", "/api/v1/gateway/profiles/owner-code/parent/generate", req, headers)
+	resp, err := client.Call(ctx, "POST", "/api/v1/gateway/profiles/owner-code/parent/generate", req, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -258,7 +258,7 @@ func (pg *profileGateway) GenerateUserCode(ctx *gin.Context, ownerID string, cre
 		CreatedIndex: createdIndex,
 	}
 
-	resp, err := client.Call("POST", "/api/v1/gateway/profiles/owner-code/user/generate", req, headers)
+	resp, err := client.Call(ctx, "POST", "/api/v1/gateway/profiles/owner-code/user/generate", req, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -304,7 +304,7 @@ func (pg *profileGateway) GenerateChildCode(ctx *gin.Context, ownerID string, cr
 		CreatedIndex: createdIndex,
 	}
 
-	resp, err := client.Call("POST", "/api/v1/gateway/profiles/owner-code/child/generate", req, headers)
+	resp, err := client.Call(ctx, "POST", "/api/v1/gateway/profiles/owner-code/child/generate", req, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -350,7 +350,7 @@ func (pg *profileGateway) GenerateDeviceCode(ctx *gin.Context, ownerID string, c
 		CreatedIndex: createdIndex,
 	}
 
-	resp, err := client.Call("POST", "/api/v1/gateway/profiles/owner-code/device/generate", req, headers)
+	resp, err := client.Call(ctx, "POST", "/api/v1/gateway/profiles/owner-code/device/generate", req, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -396,7 +396,7 @@ func (pg *profileGateway) GenerateOrganizationCode(ctx *gin.Context, organizatio
 		CreatedIndex: createdIndex,
 	}
 
-	resp, err := client.Call("POST", "/api/v1/gateway/profiles/owner-code/organization/generate", req, headers)
+	resp, err := client.Call(ctx, "POST", "/api/v1/gateway/profiles/owner-code/organization/generate", req, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -444,7 +444,7 @@ func (pg *profileGateway) GetStudentCode(ctx *gin.Context, ownerID string) (stri
 		headers["X-App-Language"] = strconv.Itoa(int(lang))
 	}
 
-	resp, err := client.Call("GET", fmt.Sprintf("/api/v1/gateway/profiles/owner-code/student/%s", ownerID), nil, headers)
+	resp, err := client.Call(ctx, "GET", fmt.Sprintf("/api/v1/gateway/profiles/owner-code/student/%s", ownerID), nil, headers)
 	if err != nil {
 		return "", err
 	}
@@ -493,7 +493,7 @@ func (pg *profileGateway) GetTeacherCode(ctx *gin.Context, ownerID string) (stri
 		headers["X-App-Language"] = strconv.Itoa(int(lang))
 	}
 
-	resp, err := client.Call("GET", fmt.Sprintf("/api/v1/gateway/profiles/owner-code/teacher/%s", ownerID), nil, headers)
+	resp, err := client.Call(ctx, "GET", fmt.Sprintf("/api/v1/gateway/profiles/owner-code/teacher/%s", ownerID), nil, headers)
 	if err != nil {
 		return "", err
 	}
@@ -542,7 +542,7 @@ func (pg *profileGateway) GetStaffCode(ctx *gin.Context, ownerID string) (string
 		headers["X-App-Language"] = strconv.Itoa(int(lang))
 	}
 
-	resp, err := client.Call("GET", fmt.Sprintf("/api/v1/gateway/profiles/owner-code/staff/%s", ownerID), nil, headers)
+	resp, err := client.Call(ctx, "GET", fmt.Sprintf("/api/v1/gateway/profiles/owner-code/staff/%s", ownerID), nil, headers)
 	if err != nil {
 		return "", err
 	}
@@ -588,7 +588,7 @@ func (pg *profileGateway) GetParentCode(ctx *gin.Context, ownerID string) (strin
 	if lang, ok := appLanguage.(uint); ok {
 		headers["X-App-Language"] = strconv.Itoa(int(lang))
 	}
-	resp, err := client.Call("GET", fmt.Sprintf("/api/v1/gateway/profiles/owner-code/parent/%s", ownerID), nil, headers)
+	resp, err := client.Call(ctx, "GET", fmt.Sprintf("/api/v1/gateway/profiles/owner-code/parent/%s", ownerID), nil, headers)
 	if err != nil {
 		return "", err
 	}
@@ -636,7 +636,7 @@ func (pg *profileGateway) GetUserCode(ctx *gin.Context, ownerID string) (string,
 		headers["X-App-Language"] = strconv.Itoa(int(lang))
 	}
 
-	resp, err := client.Call("GET", fmt.Sprintf("/api/v1/gateway/profiles/owner-code/user/%s", ownerID), nil, headers)
+	resp, err := client.Call(ctx, "GET", fmt.Sprintf("/api/v1/gateway/profiles/owner-code/user/%s", ownerID), nil, headers)
 	if err != nil {
 		return "", err
 	}
@@ -684,7 +684,7 @@ func (pg *profileGateway) GetChildCode(ctx *gin.Context, ownerID string) (string
 		headers["X-App-Language"] = strconv.Itoa(int(lang))
 	}
 
-	resp, err := client.Call("GET", fmt.Sprintf("/api/v1/gateway/profiles/owner-code/child/%s", ownerID), nil, headers)
+	resp, err := client.Call(ctx, "GET", fmt.Sprintf("/api/v1/gateway/profiles/owner-code/child/%s", ownerID), nil, headers)
 	if err != nil {
 		return "", err
 	}
@@ -732,7 +732,7 @@ func (pg *profileGateway) GetDeviceCode(ctx *gin.Context, ownerID string) (strin
 		headers["X-App-Language"] = strconv.Itoa(int(lang))
 	}
 
-	resp, err := client.Call("GET", fmt.Sprintf("/api/v1/gateway/profiles/owner-code/device/%s", ownerID), nil, headers)
+	resp, err := client.Call(ctx, "GET", fmt.Sprintf("/api/v1/gateway/profiles/owner-code/device/%s", ownerID), nil, headers)
 	if err != nil {
 		return "", err
 	}
@@ -780,7 +780,7 @@ func (pg *profileGateway) GetOrganizationCode(ctx *gin.Context, organizationID s
 		headers["X-App-Language"] = strconv.Itoa(int(lang))
 	}
 
-	resp, err := client.Call("GET", fmt.Sprintf("/api/v1/gateway/profiles/owner-code/organization/%s", organizationID), nil, headers)
+	resp, err := client.Call(ctx, "GET", fmt.Sprintf("/api/v1/gateway/profiles/owner-code/organization/%s", organizationID), nil, headers)
 	if err != nil {
 		return "", err
 	}
@@ -826,7 +826,7 @@ func (pg *profileGateway) GetStudentProfile(ctx *gin.Context, studentID string)
 		headers["X-App-Language"] = strconv.Itoa(int(lang))
 	}
 
-	resp, err := client.Call("GET", fmt.Sprintf("/api/v1/gateway/profiles/student/%s", studentID), nil, headers)
+	resp, err := client.Call(ctx, "GET", fmt.Sprintf("/api/v1/gateway/profiles/student/%s", studentID), nil, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,43 @@
+// Package enrollment signs the device enrollment codes shown as QR codes by the organizations.
+// A token is "<code id>.<expiry unix>.<signature>", the code id points to the device_enrollment_code row
+// holding the organization, the quota and the revocation.
+package enrollment
+
+import (
+	"errors"
+	"sen-global-api/pkg/signedtoken"
+	"strings"
+	"time"
+)
+
+// PayloadPrefix tells the apps the QR code is an enrollment code, like the QR login codes.
+const PayloadPrefix = "SENBOX.ORG/[DEVICE-ENROLLMENT]"
+
+var (
+	ErrInvalidCode = errors.New("invalid enrollment code")
+	ErrExpiredCode = errors.New("enrollment code has expired")
+)
+
+var signer = signedtoken.New("enrollment")
+
+func Sign(codeID string, expiresAt time.Time) string {
+	return signer.Sign(codeID, "", expiresAt)
+}
+
+// Verify checks the signature and the expiry of a token and returns the code id. The token can be the
+// whole QR payload.
+func Verify(token string) (string, error) {
+	codeID, err := signer.Verify(strings.TrimPrefix(strings.TrimSpace(token), PayloadPrefix+":"), "")
+	switch {
+	case errors.Is(err, signedtoken.ErrExpired):
+		return "", ErrExpiredCode
+	case err != nil:
+		return "", ErrInvalidCode
+	}
+	return codeID, nil
+}
+
+// Payload is the content of the QR code.
+func Payload(token string) string {
+	return PayloadPrefix + ":" + token
+}
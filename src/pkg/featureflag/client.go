@@ -0,0 +1,117 @@
+package featureflag
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultRefresh = 30 * time.Second
+
+// Provider loads every flag: the database in the app, a YAML file in the tests and local runs.
+type Provider interface {
+	Flags(ctx context.Context) ([]Flag, error)
+}
+
+// Client keeps the flags of its provider in memory, reloaded after refresh or Invalidate.
+// A failed reload keeps the previous flags.
+type Client struct {
+	provider Provider
+	refresh  time.Duration
+
+	mu       sync.RWMutex
+	flags    map[string]Flag
+	loadedAt time.Time
+	loadMu   sync.Mutex
+}
+
+var defaultClient = NewClient(nil, 0)
+
+// NewClient returns a client reloading the flags every refresh, 30s when 0. Without provider there is no flag.
+func NewClient(provider Provider, refresh time.Duration) *Client {
+	if refresh <= 0 {
+		refresh = defaultRefresh
+	}
+	return &Client{provider: provider, refresh: refresh, flags: make(map[string]Flag)}
+}
+
+// Init sets the default client, called once at startup.
+func Init(provider Provider, refresh time.Duration) *Client {
+	defaultClient = NewClient(provider, refresh)
+	return defaultClient
+}
+
+func Default() *Client {
+	return defaultClient
+}
+
+// Invalidate reloads the flags on the next evaluation, the other instances see the change after their refresh.
+func (c *Client) Invalidate() {
+	c.mu.Lock()
+	c.loadedAt = time.Time{}
+	c.mu.Unlock()
+}
+
+func (c *Client) snapshot(ctx context.Context) map[string]Flag {
+	c.mu.RLock()
+	flags, fresh := c.flags, time.Since(c.loadedAt) < c.refresh
+	c.mu.RUnlock()
+	if fresh || c.provider == nil {
+		return flags
+	}
+
+	c.loadMu.Lock()
+	defer c.loadMu.Unlock()
+	// loaded by a concurrent call while waiting
+	c.mu.RLock()
+	flags, fresh = c.flags, time.Since(c.loadedAt) < c.refresh
+	c.mu.RUnlock()
+	if fresh {
+		return flags
+	}
+
+	loaded, err := c.provider.Flags(ctx)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// retried after refresh, not on every evaluation
+	c.loadedAt = time.Now()
+	if err != nil {
+		log.Errorf("featureflag: load flags: %v", err)
+		return c.flags
+	}
+	c.flags = make(map[string]Flag, len(loaded))
+	for _, flag := range loaded {
+		flag = flag.Normalize()
+		if err := flag.Validate(); err != nil {
+			log.Warnf("featureflag: skip flag: %v", err)
+			continue
+		}
+		c.flags[flag.Key] = flag
+	}
+	return c.flags
+}
+
+// Evaluate returns the variant of key served to subject, an unknown flag serves "" (false).
+func (c *Client) Evaluate(ctx context.Context, key string, subject Subject) Evaluation {
+	flag, ok := c.snapshot(ctx)[key]
+	if !ok {
+		return Evaluation{Key: key, Reason: ReasonNotFound, Rule: -1}
+	}
+	return Evaluate(flag, subject)
+}
+
+// EvaluateAll returns the variant of every flag served to subject, ordered by key.
+func (c *Client) EvaluateAll(ctx context.Context, subject Subject) []Evaluation {
+	flags := c.snapshot(ctx)
+	result := make([]Evaluation, 0, len(flags))
+	for _, flag := range flags {
+		result = append(result, Evaluate(flag, subject))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
@@ -0,0 +1,97 @@
+package featureflag
+
+import (
+	"context"
+	"sync"
+)
+
+// ContextKey is the gin context key of the Evaluator of a request.
+const ContextKey = "feature_flags"
+
+type evaluatorKey struct{}
+
+// Evaluator evaluates the flags of one request. The subject is resolved on the first evaluation and each
+// flag is evaluated once, so a request sees the same variant even when the flags are reloaded meanwhile.
+type Evaluator struct {
+	client  *Client
+	resolve func() Subject
+
+	once    sync.Once
+	subject Subject
+	mu      sync.Mutex
+	results map[string]Evaluation
+}
+
+// NewEvaluator returns an evaluator of the subject returned by resolve (DB lookups are only done when a flag is read).
+func NewEvaluator(client *Client, resolve func() Subject) *Evaluator {
+	return &Evaluator{client: client, resolve: resolve, results: make(map[string]Evaluation)}
+}
+
+func (e *Evaluator) Subject() Subject {
+	e.once.Do(func() {
+		if e.resolve != nil {
+			e.subject = e.resolve()
+		}
+	})
+	return e.subject
+}
+
+func (e *Evaluator) Evaluate(ctx context.Context, key string) Evaluation {
+	e.mu.Lock()
+	result, ok := e.results[key]
+	e.mu.Unlock()
+	if ok {
+		return result
+	}
+
+	result = e.client.Evaluate(ctx, key, e.Subject())
+	e.mu.Lock()
+	e.results[key] = result
+	e.mu.Unlock()
+	return result
+}
+
+// All returns every flag of the subject, ordered by key.
+func (e *Evaluator) All(ctx context.Context) []Evaluation {
+	all := e.client.EvaluateAll(ctx, e.Subject())
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i, result := range all {
+		if previous, ok := e.results[result.Key]; ok {
+			all[i] = previous
+		} else {
+			e.results[result.Key] = result
+		}
+	}
+	return all
+}
+
+// NewContext returns a copy of ctx carrying the evaluator.
+func NewContext(ctx context.Context, evaluator *Evaluator) context.Context {
+	return context.WithValue(ctx, evaluatorKey{}, evaluator)
+}
+
+// FromContext returns the evaluator of a request context or of a *gin.Context. Without one the flags are
+// evaluated by the default client for an empty subject.
+func FromContext(ctx context.Context) *Evaluator {
+	if ctx != nil {
+		if evaluator, ok := ctx.Value(evaluatorKey{}).(*Evaluator); ok {
+			return evaluator
+		}
+		// *gin.Context only resolves string keys from its own keys
+		if evaluator, ok := ctx.Value(ContextKey).(*Evaluator); ok {
+			return evaluator
+		}
+	}
+	return NewEvaluator(defaultClient, nil)
+}
+
+// Enabled tells whether the boolean flag key is on for the caller of ctx.
+func Enabled(ctx context.Context, key string) bool {
+	return FromContext(ctx).Evaluate(ctx, key).Bool()
+}
+
+// Variant returns the variant of key served to the caller of ctx.
+func Variant(ctx context.Context, key string) string {
+	return FromContext(ctx).Evaluate(ctx, key).Variant
+}
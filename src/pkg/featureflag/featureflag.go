@@ -0,0 +1,215 @@
+// Package featureflag evaluates the feature flags of a caller (an organization, a user with its roles,
+// a device and its app version).
+//
+// A flag serves one of its variants: boolean flags serve "true" or "false", multivariate flags any of
+// their Variants. The rules of an enabled flag are tried in order, the first matching rule serves its
+// variant and DefaultVariant is served when none matches. A disabled flag serves OffVariant to everyone.
+//
+// Flags change what a caller sees, they are not an access control: the routes still check the roles.
+package featureflag
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sen-global-api/pkg/semver"
+	"slices"
+)
+
+type Kind string
+
+const (
+	KindBoolean      Kind = "boolean"
+	KindMultivariate Kind = "multivariate"
+)
+
+// variants of the boolean flags
+const (
+	VariantTrue  = "true"
+	VariantFalse = "false"
+)
+
+// Reason tells why a variant was served.
+type Reason string
+
+const (
+	ReasonOff      Reason = "off"
+	ReasonRule     Reason = "rule"
+	ReasonDefault  Reason = "default"
+	ReasonNotFound Reason = "not_found"
+)
+
+// Rule targets the callers matching every condition given, a list matches when it contains the value
+// of the caller. Percentage keeps a stable share of the matching callers (by device, else user, else
+// organization), the others go on to the next rule.
+type Rule struct {
+	OrganizationIDs []string `json:"organization_ids,omitempty" yaml:"organization_ids"`
+	// Roles are entity.Role names: Teacher, Parent, ...
+	Roles     []string `json:"roles,omitempty" yaml:"roles"`
+	DeviceIDs []string `json:"device_ids,omitempty" yaml:"device_ids"`
+	// MinAppVersion and MaxAppVersion are inclusive, a caller without app version does not match them
+	MinAppVersion string `json:"min_app_version,omitempty" yaml:"min_app_version"`
+	MaxAppVersion string `json:"max_app_version,omitempty" yaml:"max_app_version"`
+	// Percentage 0-100, nil for everyone
+	Percentage *int   `json:"percentage,omitempty" yaml:"percentage"`
+	Variant    string `json:"variant" yaml:"variant"`
+}
+
+type Flag struct {
+	Key            string   `json:"key" yaml:"key"`
+	Kind           Kind     `json:"kind" yaml:"kind"`
+	Enabled        bool     `json:"enabled" yaml:"enabled"`
+	Variants       []string `json:"variants" yaml:"variants"`
+	DefaultVariant string   `json:"default_variant" yaml:"default_variant"`
+	OffVariant     string   `json:"off_variant" yaml:"off_variant"`
+	Rules          []Rule   `json:"rules" yaml:"rules"`
+}
+
+// Subject is the caller a flag is evaluated for, every field is optional.
+type Subject struct {
+	OrganizationID string   `json:"organization_id"`
+	UserID         string   `json:"user_id"`
+	Roles          []string `json:"roles"`
+	DeviceID       string   `json:"device_id"`
+	AppVersion     string   `json:"app_version"`
+}
+
+type Evaluation struct {
+	Key     string `json:"key"`
+	Variant string `json:"variant"`
+	Reason  Reason `json:"reason"`
+	// Rule is the index of the matching rule, -1 when no rule matched
+	Rule int `json:"rule"`
+}
+
+// Bool is true when a boolean flag serves "true".
+func (e Evaluation) Bool() bool {
+	return e.Variant == VariantTrue
+}
+
+// Normalize fills the defaults: a boolean flag has the variants true/false, is off with false and serves
+// false when no rule matches. The off and default variants of a multivariate flag are its first variant.
+func (f Flag) Normalize() Flag {
+	if f.Kind == "" {
+		f.Kind = KindBoolean
+	}
+	if f.Kind == KindBoolean {
+		f.Variants = []string{VariantTrue, VariantFalse}
+		if f.DefaultVariant == "" {
+			f.DefaultVariant = VariantFalse
+		}
+		if f.OffVariant == "" {
+			f.OffVariant = VariantFalse
+		}
+	} else if len(f.Variants) > 0 {
+		if f.DefaultVariant == "" {
+			f.DefaultVariant = f.Variants[0]
+		}
+		if f.OffVariant == "" {
+			f.OffVariant = f.Variants[0]
+		}
+	}
+	if f.Rules == nil {
+		f.Rules = make([]Rule, 0)
+	}
+	return f
+}
+
+// Validate checks a normalized flag.
+func (f Flag) Validate() error {
+	if f.Key == "" {
+		return errors.New("flag key is required")
+	}
+	if f.Kind != KindBoolean && f.Kind != KindMultivariate {
+		return fmt.Errorf("flag %s: invalid kind %q", f.Key, f.Kind)
+	}
+	if len(f.Variants) == 0 {
+		return fmt.Errorf("flag %s: variants are required", f.Key)
+	}
+	if !slices.Contains(f.Variants, f.DefaultVariant) {
+		return fmt.Errorf("flag %s: default variant %q is not a variant", f.Key, f.DefaultVariant)
+	}
+	if !slices.Contains(f.Variants, f.OffVariant) {
+		return fmt.Errorf("flag %s: off variant %q is not a variant", f.Key, f.OffVariant)
+	}
+	for i, rule := range f.Rules {
+		if !slices.Contains(f.Variants, rule.Variant) {
+			return fmt.Errorf("flag %s: rule %d: variant %q is not a variant", f.Key, i, rule.Variant)
+		}
+		if rule.Percentage != nil && (*rule.Percentage < 0 || *rule.Percentage > 100) {
+			return fmt.Errorf("flag %s: rule %d: percentage must be between 0 and 100", f.Key, i)
+		}
+		for _, version := range []string{rule.MinAppVersion, rule.MaxAppVersion} {
+			if version == "" {
+				continue
+			}
+			if _, err := semver.Parse(version); err != nil {
+				return fmt.Errorf("flag %s: rule %d: %w", f.Key, i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Evaluate returns the variant of the flag served to subject.
+func Evaluate(flag Flag, subject Subject) Evaluation {
+	if !flag.Enabled {
+		return Evaluation{Key: flag.Key, Variant: flag.OffVariant, Reason: ReasonOff, Rule: -1}
+	}
+	for i, rule := range flag.Rules {
+		if rule.matches(flag.Key, i, subject) {
+			return Evaluation{Key: flag.Key, Variant: rule.Variant, Reason: ReasonRule, Rule: i}
+		}
+	}
+	return Evaluation{Key: flag.Key, Variant: flag.DefaultVariant, Reason: ReasonDefault, Rule: -1}
+}
+
+func (r Rule) matches(key string, index int, subject Subject) bool {
+	if len(r.OrganizationIDs) > 0 && !slices.Contains(r.OrganizationIDs, subject.OrganizationID) {
+		return false
+	}
+	if len(r.DeviceIDs) > 0 && !slices.Contains(r.DeviceIDs, subject.DeviceID) {
+		return false
+	}
+	if len(r.Roles) > 0 && !slices.ContainsFunc(subject.Roles, func(role string) bool {
+		return slices.Contains(r.Roles, role)
+	}) {
+		return false
+	}
+	if r.MinAppVersion != "" || r.MaxAppVersion != "" {
+		version, err := semver.Parse(subject.AppVersion)
+		if err != nil {
+			return false
+		}
+		if min, err := semver.Parse(r.MinAppVersion); err == nil && version.Less(min) {
+			return false
+		}
+		if max, err := semver.Parse(r.MaxAppVersion); err == nil && max.Less(version) {
+			return false
+		}
+	}
+	if r.Percentage != nil {
+		return inPercentage(fmt.Sprintf("%s:%d", key, index), subject, *r.Percentage)
+	}
+	return true
+}
+
+// inPercentage puts each caller in a stable bucket 0-99 per flag rule, so raising the percentage only adds callers.
+func inPercentage(seed string, subject Subject, percentage int) bool {
+	if percentage >= 100 {
+		return true
+	}
+	id := subject.DeviceID
+	if id == "" {
+		id = subject.UserID
+	}
+	if id == "" {
+		id = subject.OrganizationID
+	}
+	if id == "" || percentage <= 0 {
+		return false
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(seed + ":" + id))
+	return int(h.Sum32()%100) < percentage
+}
@@ -0,0 +1,195 @@
+package featureflag
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func percentage(p int) *int {
+	return &p
+}
+
+func TestEvaluateTargeting(t *testing.T) {
+	flag := Flag{
+		Key:      "home_layout",
+		Kind:     KindMultivariate,
+		Enabled:  true,
+		Variants: []string{"grid", "list", "cards"},
+		Rules: []Rule{
+			{DeviceIDs: []string{"device-beta"}, Variant: "cards"},
+			{OrganizationIDs: []string{"org-1"}, Roles: []string{"Teacher"}, Variant: "list"},
+			{MinAppVersion: "2.3.0", MaxAppVersion: "2.9.9", Variant: "cards"},
+		},
+	}.Normalize()
+	if err := flag.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		subject Subject
+		variant string
+		reason  Reason
+		rule    int
+	}{
+		{"device list", Subject{DeviceID: "device-beta", OrganizationID: "org-1", Roles: []string{"Teacher"}}, "cards", ReasonRule, 0},
+		{"organization and role", Subject{OrganizationID: "org-1", Roles: []string{"Parent", "Teacher"}}, "list", ReasonRule, 1},
+		{"role of another organization", Subject{OrganizationID: "org-2", Roles: []string{"Teacher"}}, "grid", ReasonDefault, -1},
+		{"organization without role", Subject{OrganizationID: "org-1", Roles: []string{"Parent"}}, "grid", ReasonDefault, -1},
+		{"app version in range", Subject{AppVersion: "2.3.0"}, "cards", ReasonRule, 2},
+		{"app version above range", Subject{AppVersion: "3.0.0"}, "grid", ReasonDefault, -1},
+		{"app version below range", Subject{AppVersion: "2.2.9"}, "grid", ReasonDefault, -1},
+		{"no app version", Subject{}, "grid", ReasonDefault, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Evaluate(flag, tt.subject)
+			if got.Variant != tt.variant || got.Reason != tt.reason || got.Rule != tt.rule {
+				t.Errorf("Evaluate() = %+v, want variant %q reason %q rule %d", got, tt.variant, tt.reason, tt.rule)
+			}
+		})
+	}
+}
+
+func TestEvaluateDisabledFlag(t *testing.T) {
+	flag := Flag{
+		Key:   "new_student_menu",
+		Rules: []Rule{{Variant: VariantTrue}},
+	}.Normalize()
+
+	got := Evaluate(flag, Subject{UserID: "user-1"})
+	if got.Bool() || got.Reason != ReasonOff {
+		t.Errorf("Evaluate() = %+v, want the off variant", got)
+	}
+
+	flag.Enabled = true
+	if got := Evaluate(flag, Subject{UserID: "user-1"}); !got.Bool() || got.Reason != ReasonRule {
+		t.Errorf("Evaluate() = %+v, want the rule variant", got)
+	}
+}
+
+func TestPercentageIsDeterministic(t *testing.T) {
+	flag := Flag{
+		Key:     "new_student_menu",
+		Enabled: true,
+		Rules:   []Rule{{Percentage: percentage(20), Variant: VariantTrue}},
+	}.Normalize()
+
+	const devices = 1000
+	served := make(map[string]bool, devices)
+	count := 0
+	for i := 0; i < devices; i++ {
+		subject := Subject{DeviceID: fmt.Sprintf("device-%d", i)}
+		served[subject.DeviceID] = Evaluate(flag, subject).Bool()
+		if served[subject.DeviceID] {
+			count++
+		}
+	}
+	if count < 150 || count > 250 {
+		t.Errorf("20%% rollout served %d of %d devices", count, devices)
+	}
+
+	// the same device always lands in the same bucket
+	for i := 0; i < devices; i++ {
+		subject := Subject{DeviceID: fmt.Sprintf("device-%d", i)}
+		if got := Evaluate(flag, subject).Bool(); got != served[subject.DeviceID] {
+			t.Fatalf("device %s served %v then %v", subject.DeviceID, served[subject.DeviceID], got)
+		}
+	}
+
+	// raising the percentage only adds devices
+	flag.Rules[0].Percentage = percentage(50)
+	for id, before := range served {
+		if before && !Evaluate(flag, Subject{DeviceID: id}).Bool() {
+			t.Fatalf("device %s left the rollout when raised to 50%%", id)
+		}
+	}
+
+	for _, tt := range []struct {
+		percentage int
+		want       bool
+	}{{0, false}, {100, true}} {
+		flag.Rules[0].Percentage = percentage(tt.percentage)
+		if got := Evaluate(flag, Subject{DeviceID: "device-1"}).Bool(); got != tt.want {
+			t.Errorf("%d%% rollout served %v, want %v", tt.percentage, got, tt.want)
+		}
+	}
+
+	// a caller without any id is never in a partial rollout
+	flag.Rules[0].Percentage = percentage(99)
+	if Evaluate(flag, Subject{}).Bool() {
+		t.Error("a caller without id was served a 99% rollout")
+	}
+}
+
+func TestFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flags.yaml")
+	content := `flags:
+  - key: new_student_menu
+    enabled: true
+    rules:
+      - roles: [Teacher]
+        variant: "true"
+  - key: home_layout
+    kind: multivariate
+    enabled: true
+    variants: [grid, list]
+    rules:
+      - organization_ids: ["org-1"]
+        min_app_version: 2.3.0
+        variant: list
+  - key: broken
+    kind: multivariate
+    enabled: true
+    variants: [a]
+    default_variant: b
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	flags, err := NewFileProvider(path).Flags(context.Background())
+	if err != nil {
+		t.Fatalf("Flags() = %v", err)
+	}
+	if len(flags) != 3 {
+		t.Fatalf("Flags() loaded %d flags, want 3", len(flags))
+	}
+
+	client := NewClient(NewFileProvider(path), 0)
+	ctx := context.Background()
+	if !client.Evaluate(ctx, "new_student_menu", Subject{Roles: []string{"Teacher"}}).Bool() {
+		t.Error("new_student_menu is off for a teacher")
+	}
+	if client.Evaluate(ctx, "new_student_menu", Subject{Roles: []string{"Parent"}}).Bool() {
+		t.Error("new_student_menu is on for a parent")
+	}
+	if got := client.Evaluate(ctx, "home_layout", Subject{OrganizationID: "org-1", AppVersion: "2.4.0"}); got.Variant != "list" {
+		t.Errorf("home_layout = %q, want list", got.Variant)
+	}
+	if got := client.Evaluate(ctx, "home_layout", Subject{OrganizationID: "org-1", AppVersion: "2.0.0"}); got.Variant != "grid" {
+		t.Errorf("home_layout = %q, want grid", got.Variant)
+	}
+	// an invalid flag is skipped, not served
+	if got := client.Evaluate(ctx, "broken", Subject{}); got.Reason != ReasonNotFound {
+		t.Errorf("broken = %+v, want not found", got)
+	}
+}
+
+func TestFileProviderErrors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := NewFileProvider(filepath.Join(dir, "missing.yaml")).Flags(context.Background()); err == nil {
+		t.Error("Flags() of a missing file returned no error")
+	}
+
+	path := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(path, []byte("flags: [key: ["), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileProvider(path).Flags(context.Background()); err == nil {
+		t.Error("Flags() of an invalid file returned no error")
+	}
+}
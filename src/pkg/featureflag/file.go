@@ -0,0 +1,50 @@
+package featureflag
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileProvider reads the flags from a YAML file, for the tests and the local runs without database flags.
+// The file is read on every load so an edit is seen after the refresh:
+//
+//	flags:
+//	  - key: new_student_menu
+//	    enabled: true
+//	    rules:
+//	      - roles: [Teacher]
+//	        variant: "true"
+//	      - percentage: 20
+//	        variant: "true"
+//	  - key: home_layout
+//	    kind: multivariate
+//	    enabled: true
+//	    variants: [grid, list]
+//	    rules:
+//	      - organization_ids: ["7f1c..."]
+//	        min_app_version: 2.3.0
+//	        variant: list
+type FileProvider struct {
+	path string
+}
+
+func NewFileProvider(path string) *FileProvider {
+	return &FileProvider{path: path}
+}
+
+func (p *FileProvider) Flags(context.Context) ([]Flag, error) {
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return nil, err
+	}
+	var file struct {
+		Flags []Flag `yaml:"flags"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", p.path, err)
+	}
+	return file.Flags, nil
+}
@@ -0,0 +1,38 @@
+// Package formgrant signs the short-lived grants returned once the password of a protected form is verified.
+// A grant is "<form id>.<expiry unix>.<signature>", it opens the questions and the submission of that form
+// without sending the password again.
+package formgrant
+
+import (
+	"errors"
+	"sen-global-api/pkg/signedtoken"
+	"strconv"
+	"time"
+)
+
+const TTL = 30 * time.Minute
+
+var (
+	ErrInvalidGrant = errors.New("invalid form grant")
+	ErrExpiredGrant = errors.New("form grant has expired")
+)
+
+var signer = signedtoken.New("form")
+
+// Sign issues a grant on the form, valid for TTL.
+func Sign(formID uint64) (string, time.Time) {
+	expiresAt := time.Now().Add(TTL)
+	return signer.Sign(strconv.FormatUint(formID, 10), "", expiresAt), expiresAt
+}
+
+// Verify checks the signature and the expiry of a grant issued on the form.
+func Verify(grant string, formID uint64) error {
+	id, err := signer.Verify(grant, "")
+	switch {
+	case errors.Is(err, signedtoken.ErrExpired):
+		return ErrExpiredGrant
+	case err != nil, id != strconv.FormatUint(formID, 10):
+		return ErrInvalidGrant
+	}
+	return nil
+}
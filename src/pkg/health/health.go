@@ -0,0 +1,155 @@
+// Package health runs the readiness checks of the dependencies (MySQL, Redis, Consul, S3, Google).
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	// StatusWarn is reported when only the non critical checks fail, the instance stays ready
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+)
+
+const (
+	defaultTimeout = 3 * time.Second
+	// the probes of the load balancer and of Consul share the last report for cacheFor
+	cacheFor = 2 * time.Second
+)
+
+// Check verifies one dependency, it must return when ctx is done.
+type Check struct {
+	Name string
+	// Critical checks make the instance not ready when they fail
+	Critical bool
+	Run      func(ctx context.Context) error
+}
+
+type Result struct {
+	Status    Status `json:"status"`
+	Critical  bool   `json:"critical"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status    Status            `json:"status"`
+	Draining  bool              `json:"draining,omitempty"`
+	CheckedAt time.Time         `json:"checked_at"`
+	Checks    map[string]Result `json:"checks"`
+}
+
+// Ready tells whether the instance can receive traffic.
+func (r Report) Ready() bool {
+	return r.Status != StatusFail
+}
+
+type Checker struct {
+	checks   []Check
+	timeout  time.Duration
+	draining atomic.Bool
+
+	mu   sync.Mutex
+	last *Report
+}
+
+func NewChecker(timeout time.Duration, checks ...Check) *Checker {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &Checker{checks: checks, timeout: timeout}
+}
+
+// Add registers a check, called at startup before the first Run.
+func (c *Checker) Add(check Check) {
+	c.checks = append(c.checks, check)
+}
+
+// SetDraining marks the instance not ready, e.g. while the server drains the requests before stopping.
+func (c *Checker) SetDraining() {
+	c.draining.Store(true)
+}
+
+func (c *Checker) Draining() bool {
+	return c.draining.Load()
+}
+
+// Run checks all the dependencies in parallel, each bounded by the checker timeout.
+func (c *Checker) Run(ctx context.Context) Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.last != nil && time.Since(c.last.CheckedAt) < cacheFor {
+		return c.withDraining(*c.last)
+	}
+
+	results := make(map[string]Result, len(c.checks))
+	var resultsMu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range c.checks {
+		wg.Add(1)
+		go func(check Check) {
+			defer wg.Done()
+			result := c.run(ctx, check)
+			resultsMu.Lock()
+			results[check.Name] = result
+			resultsMu.Unlock()
+		}(check)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusPass, CheckedAt: time.Now(), Checks: results}
+	for _, result := range results {
+		if result.Status != StatusFail {
+			continue
+		}
+		if result.Critical {
+			report.Status = StatusFail
+		} else if report.Status == StatusPass {
+			report.Status = StatusWarn
+		}
+	}
+
+	c.last = &report
+	return c.withDraining(report)
+}
+
+func (c *Checker) withDraining(report Report) Report {
+	if c.Draining() {
+		report.Draining = true
+		report.Status = StatusFail
+	}
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check.Run(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		// the check ignored ctx, it is left to finish on its own
+		err = ctx.Err()
+	}
+
+	result := Result{Status: StatusPass, Critical: check.Critical, LatencyMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,148 @@
+// Package heartbeat keeps the last heartbeat of each device in Redis. The devices beat every minute or so,
+// the beats are only written to Redis and the changed devices are collected in a set, rolled up to the
+// database by a periodic job (PopDirty).
+package heartbeat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sen-global-api/internal/domain/value"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// beatTTL keeps the last beat long after the device is offline, the rollup has the older ones.
+const beatTTL = 7 * 24 * time.Hour
+
+var (
+	beatPrefix = value.MainCachePrefix + "heartbeat:device:"
+	dirtyKey   = value.MainCachePrefix + "heartbeat:dirty"
+)
+
+// ErrUnavailable is returned without Redis, the caller then writes the beat to the database itself.
+var ErrUnavailable = errors.New("heartbeat store unavailable")
+
+// Beat is the state reported by a device, the optional fields are nil when the device cannot read them.
+type Beat struct {
+	DeviceID       string    `json:"device_id"`
+	UserID         string    `json:"user_id,omitempty"`
+	AppVersion     string    `json:"app_version,omitempty"`
+	BatteryLevel   *int      `json:"battery_level,omitempty"`
+	Charging       *bool     `json:"charging,omitempty"`
+	StorageFreeMB  *int64    `json:"storage_free_mb,omitempty"`
+	StorageTotalMB *int64    `json:"storage_total_mb,omitempty"`
+	Network        string    `json:"network,omitempty"`
+	SignalLevel    *int      `json:"signal_level,omitempty"`
+	ReceivedAt     time.Time `json:"received_at"`
+}
+
+type Store struct {
+	client *goredis.Client
+}
+
+var defaultStore = &Store{}
+
+// Init sets the Redis client of the default store, called once at startup.
+func Init(client *goredis.Client) *Store {
+	defaultStore = &Store{client: client}
+	return defaultStore
+}
+
+func Default() *Store {
+	return defaultStore
+}
+
+func (s *Store) Enabled() bool {
+	return s.client != nil
+}
+
+// Record stores the beat as the last one of its device and marks the device for the rollup.
+func (s *Store) Record(ctx context.Context, beat Beat) error {
+	if s.client == nil {
+		return ErrUnavailable
+	}
+	data, err := json.Marshal(beat)
+	if err != nil {
+		return err
+	}
+	pipe := s.client.Pipeline()
+	pipe.Set(ctx, beatPrefix+beat.DeviceID, data, beatTTL)
+	pipe.SAdd(ctx, dirtyKey, beat.DeviceID)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// Last returns the last beat of each device found, the devices without beat in Redis are missing from the map.
+func (s *Store) Last(ctx context.Context, deviceIDs ...string) (map[string]Beat, error) {
+	result := make(map[string]Beat, len(deviceIDs))
+	if s.client == nil || len(deviceIDs) == 0 {
+		return result, nil
+	}
+
+	keys := make([]string, len(deviceIDs))
+	for i, id := range deviceIDs {
+		keys[i] = beatPrefix + id
+	}
+	values, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range values {
+		raw, ok := v.(string)
+		if !ok {
+			continue
+		}
+		var beat Beat
+		if err := json.Unmarshal([]byte(raw), &beat); err != nil {
+			continue
+		}
+		result[beat.DeviceID] = beat
+	}
+	return result, nil
+}
+
+// PopDirty removes up to n devices beating since the last rollup and returns their last beat.
+func (s *Store) PopDirty(ctx context.Context, n int) ([]Beat, error) {
+	if s.client == nil {
+		return nil, nil
+	}
+	ids, err := s.client.SPopN(ctx, dirtyKey, int64(n)).Result()
+	if err != nil && !errors.Is(err, goredis.Nil) {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	last, err := s.Last(ctx, ids...)
+	if err != nil {
+		// put them back for the next rollup
+		s.client.SAdd(context.WithoutCancel(ctx), dirtyKey, toInterfaces(ids)...)
+		return nil, err
+	}
+	beats := make([]Beat, 0, len(last))
+	for _, beat := range last {
+		beats = append(beats, beat)
+	}
+	return beats, nil
+}
+
+// AcquireRun tells whether this instance runs the job name for the next ttl, so a job of every instance runs
+// once in the cluster. Without Redis there is a single instance.
+func (s *Store) AcquireRun(ctx context.Context, name string, ttl time.Duration) bool {
+	if s.client == nil {
+		return true
+	}
+	ok, err := s.client.SetNX(ctx, value.MainCachePrefix+"heartbeat:run:"+name, time.Now().Unix(), ttl).Result()
+	return err == nil && ok
+}
+
+func toInterfaces(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
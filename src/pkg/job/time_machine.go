@@ -1,6 +1,9 @@
 package job
 
 import (
+	"context"
+	"errors"
+	"sen-global-api/pkg/metrics"
 	"sync"
 	"time"
 
@@ -57,14 +60,15 @@ type TimeMachine struct {
 	submissionSyncCron          *gocron.Scheduler
 }
 
+// IntervalTaskExecutor runs the interval jobs, the returned error is recorded by the job metric.
 type IntervalTaskExecutor interface {
-	ExecuteSyncForms()
+	ExecuteSyncForms() error
 	// ExecuteSyncForms2()
-	ExecuteSyncForms3()
-	ExecuteSyncForms4()
-	ExecuteSyncUrls()
-	ExecuteSyncTodos()
-	ExecuteGoogleAPIRequestMonitor()
+	ExecuteSyncForms3() error
+	ExecuteSyncForms4() error
+	ExecuteSyncUrls() error
+	ExecuteSyncTodos() error
+	ExecuteGoogleAPIRequestMonitor() error
 }
 
 func (receiver *TimeMachine) Start(formInterval uint64, urlInterval uint64, todoInterval uint64, formInterval2 uint64, formInterval3 uint64, formInterval4 uint64) {
@@ -89,6 +93,27 @@ func (receiver *TimeMachine) Stop() {
 	receiver.submissionSyncCron.Clear()
 }
 
+// Shutdown stops all the schedulers and waits for the running jobs until ctx is done.
+func (receiver *TimeMachine) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		// gocron Stop waits for the running jobs of the scheduler
+		for _, scheduler := range []*gocron.Scheduler{
+			receiver.formCron, receiver.form2Cron, receiver.form3Cron, receiver.form4Cron, receiver.urlCron,
+			receiver.deviceSyncCron, receiver.todoCron, receiver.googleQPIRequestMonitorCron, receiver.submissionSyncCron,
+		} {
+			scheduler.Stop()
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func (receiver *TimeMachine) SubscribeFormsExec(exec IntervalTaskExecutor) {
 	receiver.formExecutors = append(receiver.formExecutors, exec)
 }
@@ -130,8 +155,13 @@ func (receiver *TimeMachine) ScheduleSyncForms(interval uint64) {
 	now := time.Now()
 	startAt := now.Add(time.Duration(interval) * time.Minute)
 	task, err := receiver.formCron.Every(int(interval)).Minutes().StartAt(startAt).Do(func() {
+		var errs []error
+		track := metrics.TrackJob("sync_forms")
+		defer func() { track(errors.Join(errs...)) }()
 		for _, executor := range receiver.formExecutors {
-			executor.ExecuteSyncForms()
+			if err := executor.ExecuteSyncForms(); err != nil {
+				errs = append(errs, err)
+			}
 		}
 	})
 	if err != nil {
@@ -180,8 +210,13 @@ func (receiver *TimeMachine) ScheduleSyncForms3(interval uint64) {
 	now := time.Now()
 	startAt := now.Add(time.Duration(interval) * time.Minute)
 	task, err := receiver.form3Cron.Every(int(interval)).Minutes().StartAt(startAt).Do(func() {
+		var errs []error
+		track := metrics.TrackJob("sync_forms3")
+		defer func() { track(errors.Join(errs...)) }()
 		for _, executor := range receiver.form3Executors {
-			executor.ExecuteSyncForms3()
+			if err := executor.ExecuteSyncForms3(); err != nil {
+				errs = append(errs, err)
+			}
 		}
 	})
 	if err != nil {
@@ -205,8 +240,13 @@ func (receiver *TimeMachine) ScheduleSyncForms4(interval uint64) {
 	now := time.Now()
 	startAt := now.Add(time.Duration(interval) * time.Minute)
 	task, err := receiver.form4Cron.Every(int(interval)).Minutes().StartAt(startAt).Do(func() {
+		var errs []error
+		track := metrics.TrackJob("sync_forms4")
+		defer func() { track(errors.Join(errs...)) }()
 		for _, executor := range receiver.form4Executors {
-			executor.ExecuteSyncForms4()
+			if err := executor.ExecuteSyncForms4(); err != nil {
+				errs = append(errs, err)
+			}
 		}
 	})
 	if err != nil {
@@ -230,8 +270,13 @@ func (receiver *TimeMachine) ScheduleSyncUrls(interval uint64) {
 	now := time.Now()
 	startAt := now.Add(time.Duration(interval) * time.Minute)
 	task, err := receiver.urlCron.Every(int(interval)).Minutes().StartAt(startAt).Do(func() {
+		var errs []error
+		track := metrics.TrackJob("sync_urls")
+		defer func() { track(errors.Join(errs...)) }()
 		for _, executor := range receiver.urlExecutors {
-			executor.ExecuteSyncUrls()
+			if err := executor.ExecuteSyncUrls(); err != nil {
+				errs = append(errs, err)
+			}
 		}
 	})
 	if err != nil {
@@ -255,9 +300,14 @@ func (receiver *TimeMachine) ScheduleSyncToDos(interval uint64) {
 	now := time.Now()
 	startAt := now.Add(time.Duration(interval) * time.Minute)
 	task, err := receiver.todoCron.Every(int(interval)).Minutes().StartAt(startAt).Do(func() {
+		var errs []error
+		track := metrics.TrackJob("sync_todos")
+		defer func() { track(errors.Join(errs...)) }()
 		log.Debug("Sync todos")
 		for _, executor := range receiver.todoExecutors {
-			executor.ExecuteSyncTodos()
+			if err := executor.ExecuteSyncTodos(); err != nil {
+				errs = append(errs, err)
+			}
 		}
 	})
 	if err != nil {
@@ -278,9 +328,14 @@ func (receiver *TimeMachine) ScheduleGoogleAPIRequestMonitor() {
 	now := time.Now()
 	startAt := now.Add(time.Duration(1) * time.Minute)
 	task, err := receiver.googleQPIRequestMonitorCron.Every(1).Minutes().StartAt(startAt).Do(func() {
+		var errs []error
+		track := metrics.TrackJob("google_api_request_monitor")
+		defer func() { track(errors.Join(errs...)) }()
 		log.Debug("Report Google API Request Monitor")
 		for _, executor := range receiver.googleQPIRequestMonitor {
-			executor.ExecuteGoogleAPIRequestMonitor()
+			if err := executor.ExecuteGoogleAPIRequestMonitor(); err != nil {
+				errs = append(errs, err)
+			}
 		}
 	})
 	if err != nil {
@@ -0,0 +1,209 @@
+// Package lifecycle starts and stops the background subsystems (schedulers, workers, buffered writers)
+// in a defined order so a shutdown lets the in-flight work finish or record where it stopped.
+package lifecycle
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+// Phase orders the stop hooks: the schedulers stop first so no new job starts,
+// then the workers finish, then the buffered writers flush what the workers produced.
+type Phase int
+
+const (
+	PhaseScheduler Phase = iota
+	PhaseWorker
+	PhaseFlush
+)
+
+const (
+	defaultDeadline = 30 * time.Second
+	// once the deadline is over the workers are cancelled and get grace to record their progress
+	grace = 5 * time.Second
+)
+
+type Hook struct {
+	Name  string
+	Phase Phase
+	// Start is called by Manager.Start, or right away when the hook is registered after it
+	Start func(ctx context.Context) error
+	// Stop must return when ctx is done
+	Stop func(ctx context.Context) error
+}
+
+type Manager struct {
+	deadline time.Duration
+
+	mu      sync.Mutex
+	hooks   []Hook
+	started bool
+
+	// workers started with Go are cancelled through ctx
+	ctx      context.Context
+	cancel   context.CancelFunc
+	workers  sync.WaitGroup
+	stopping atomic.Bool
+	stopCh   chan struct{}
+}
+
+func New(deadline time.Duration) *Manager {
+	if deadline <= 0 {
+		deadline = defaultDeadline
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{deadline: deadline, ctx: ctx, cancel: cancel, stopCh: make(chan struct{})}
+}
+
+var defaultManager = New(0)
+
+// Init replaces the default manager, called once at startup before the subsystems register.
+func Init(deadline time.Duration) *Manager {
+	defaultManager = New(deadline)
+	return defaultManager
+}
+
+func Default() *Manager {
+	return defaultManager
+}
+
+// Register adds a subsystem, the hooks of a phase stop in the reverse order of registration.
+func (m *Manager) Register(hook Hook) {
+	m.mu.Lock()
+	m.hooks = append(m.hooks, hook)
+	started := m.started
+	m.mu.Unlock()
+
+	if started && hook.Start != nil {
+		if err := hook.Start(m.ctx); err != nil {
+			log.Errorf("lifecycle: start %s: %v", hook.Name, err)
+		}
+	}
+}
+
+// RegisterCron stops the cron scheduler with the other schedulers and waits for its running jobs.
+func (m *Manager) RegisterCron(name string, c *cron.Cron) {
+	m.Register(Hook{
+		Name:  name,
+		Phase: PhaseScheduler,
+		Stop: func(ctx context.Context) error {
+			select {
+			case <-c.Stop().Done():
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+}
+
+// Start runs the start hooks in the order of registration.
+func (m *Manager) Start() {
+	m.mu.Lock()
+	hooks := append([]Hook(nil), m.hooks...)
+	m.started = true
+	m.mu.Unlock()
+
+	for _, hook := range hooks {
+		if hook.Start == nil {
+			continue
+		}
+		if err := hook.Start(m.ctx); err != nil {
+			log.Errorf("lifecycle: start %s: %v", hook.Name, err)
+		}
+	}
+}
+
+// Go runs fn in background and waits for it on Stop. ctx is cancelled when the stop deadline is over,
+// fn must then return quickly and leave its work resumable.
+func (m *Manager) Go(name string, fn func(ctx context.Context)) {
+	m.workers.Add(1)
+	go func() {
+		defer m.workers.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				log.Errorf("lifecycle: worker %s panic: %v", name, r)
+			}
+		}()
+		fn(m.ctx)
+	}()
+}
+
+// Stopping is closed when the shutdown starts, idle workers return on it.
+func (m *Manager) Stopping() <-chan struct{} {
+	return m.stopCh
+}
+
+// Sleep waits d, it returns false early when the shutdown starts.
+func (m *Manager) Sleep(d time.Duration) bool {
+	select {
+	case <-time.After(d):
+		return true
+	case <-m.stopCh:
+		return false
+	}
+}
+
+// Stop stops the subsystems phase by phase within the deadline.
+func (m *Manager) Stop() {
+	if !m.stopping.CompareAndSwap(false, true) {
+		return
+	}
+	close(m.stopCh)
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.deadline)
+	defer cancel()
+
+	m.stopPhase(ctx, PhaseScheduler)
+
+	done := make(chan struct{})
+	go func() {
+		m.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Warn("lifecycle: deadline over, cancelling the workers")
+		m.cancel()
+		select {
+		case <-done:
+		case <-time.After(grace):
+			log.Error("lifecycle: workers still running after cancel")
+		}
+	}
+	m.cancel()
+	m.stopPhase(ctx, PhaseWorker)
+
+	// the flush must run even when the workers used the whole deadline
+	if ctx.Err() != nil {
+		var flushCancel context.CancelFunc
+		ctx, flushCancel = context.WithTimeout(context.Background(), grace)
+		defer flushCancel()
+	}
+	m.stopPhase(ctx, PhaseFlush)
+}
+
+func (m *Manager) stopPhase(ctx context.Context, phase Phase) {
+	m.mu.Lock()
+	hooks := append([]Hook(nil), m.hooks...)
+	m.mu.Unlock()
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hook := hooks[i]
+		if hook.Phase != phase || hook.Stop == nil {
+			continue
+		}
+		start := time.Now()
+		if err := hook.Stop(ctx); err != nil {
+			log.Errorf("lifecycle: stop %s: %v", hook.Name, err)
+			continue
+		}
+		log.Infof("lifecycle: stopped %s in %s", hook.Name, time.Since(start).Round(time.Millisecond))
+	}
+}
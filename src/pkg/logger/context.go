@@ -0,0 +1,73 @@
+package logger
+
+import (
+	"context"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+	// FieldRequestID is the log field and the gin context key holding the request id
+	FieldRequestID = "request_id"
+)
+
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the request id.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request id of a request context or of a *gin.Context, "" when there is none.
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return id
+	}
+	// *gin.Context only resolves string keys from its own keys
+	if id, ok := ctx.Value(FieldRequestID).(string); ok {
+		return id
+	}
+	return ""
+}
+
+// FromContext returns an entry tagged with the request id of ctx.
+func FromContext(ctx context.Context) *log.Entry {
+	entry := log.WithContext(ctx)
+	if id := RequestIDFromContext(ctx); id != "" {
+		entry = entry.WithField(FieldRequestID, id)
+	}
+	return entry
+}
+
+// SetRequestIDHeader forwards the request id of ctx to an outgoing request.
+func SetRequestIDHeader(ctx context.Context, req *http.Request) {
+	if id := RequestIDFromContext(ctx); id != "" && req.Header.Get(RequestIDHeader) == "" {
+		req.Header.Set(RequestIDHeader, id)
+	}
+}
+
+// Transport forwards the request id of the request context on every outgoing call, e.g. to S3 or Google Sheets.
+type Transport struct {
+	Base http.RoundTripper
+}
+
+func NewTransport(base http.RoundTripper) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{Base: base}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if id := RequestIDFromContext(req.Context()); id != "" && req.Header.Get(RequestIDHeader) == "" {
+		// a RoundTripper must not modify the caller's request
+		req = req.Clone(req.Context())
+		req.Header.Set(RequestIDHeader, id)
+	}
+	return t.Base.RoundTrip(req)
+}
@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// formatter applies the per package levels, adds the request id of the entry context and redacts the secrets
+// before handing the entry to the JSON or text formatter.
+type formatter struct {
+	inner         log.Formatter
+	level         log.Level
+	packageLevels map[string]log.Level
+}
+
+func (f *formatter) Format(entry *log.Entry) ([]byte, error) {
+	if entry.Level > f.levelFor(entry) {
+		// an empty line is not written
+		return nil, nil
+	}
+
+	data := make(log.Fields, len(entry.Data)+1)
+	for k, v := range entry.Data {
+		data[k] = redactField(k, v)
+	}
+	if _, ok := data[FieldRequestID]; !ok && entry.Context != nil {
+		if id := RequestIDFromContext(entry.Context); id != "" {
+			data[FieldRequestID] = id
+		}
+	}
+
+	redacted := *entry
+	redacted.Data = data
+	redacted.Message = Redact(entry.Message)
+	return f.inner.Format(&redacted)
+}
+
+// levelFor returns the level of the package logging the entry, the longest configured package wins.
+func (f *formatter) levelFor(entry *log.Entry) log.Level {
+	if len(f.packageLevels) == 0 || entry.Caller == nil {
+		return f.level
+	}
+
+	pkg := callerPackage(entry.Caller.Function)
+	level := f.level
+	best := -1
+	for name, l := range f.packageLevels {
+		if len(name) <= best {
+			continue
+		}
+		if pkg == name || strings.HasPrefix(pkg, name+"/") || strings.HasSuffix(pkg, "/"+name) {
+			level = l
+			best = len(name)
+		}
+	}
+	return level
+}
+
+// callerPackage turns "sen-global-api/internal/domain/usecase.(*X).Y" into "internal/domain/usecase".
+func callerPackage(function string) string {
+	function = strings.TrimPrefix(function, modulePrefix)
+	slash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[slash+1:], "."); dot >= 0 {
+		return function[:slash+1+dot]
+	}
+	return function
+}
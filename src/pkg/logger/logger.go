@@ -1,17 +1,41 @@
 package logger
 
 import (
+	"fmt"
 	"io"
 	"os"
 	"sen-global-api/config"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
+const modulePrefix = "sen-global-api/"
+
 func InitLogger(config *config.AppConfig) error {
-	logLevel := log.InfoLevel
-	log.SetLevel(logLevel)
+	logConfig := config.Config.Log
+
+	level, err := parseLevel(logConfig.Level)
+	if err != nil {
+		return err
+	}
+
+	packageLevels := make(map[string]log.Level, len(logConfig.Packages))
+	mostVerbose := level
+	for pkg, l := range logConfig.Packages {
+		pkgLevel, err := parseLevel(l)
+		if err != nil {
+			return fmt.Errorf("logger: package %s: %w", pkg, err)
+		}
+		packageLevels[strings.Trim(strings.TrimPrefix(pkg, modulePrefix), "/")] = pkgLevel
+		if pkgLevel > mostVerbose {
+			mostVerbose = pkgLevel
+		}
+	}
+	// logrus drops the entries above the global level before any formatting,
+	// the finer per package levels are applied by the formatter
+	log.SetLevel(mostVerbose)
 
 	lumberjackLogger := &lumberjack.Logger{
 		Filename:   "./logs/senbox.log",
@@ -24,12 +48,32 @@ func InitLogger(config *config.AppConfig) error {
 
 	log.SetReportCaller(true)
 
-	log.SetFormatter(&log.TextFormatter{
-		PadLevelText:    true,
-		ForceColors:     true,
-		FullTimestamp:   true,
-		TimestampFormat: "2006-01-02 15:04:05",
+	var inner log.Formatter = &log.JSONFormatter{
+		TimestampFormat: "2006-01-02T15:04:05.000Z07:00",
+		FieldMap: log.FieldMap{
+			log.FieldKeyMsg: "message",
+		},
+	}
+	if strings.EqualFold(logConfig.Format, "text") {
+		inner = &log.TextFormatter{
+			PadLevelText:    true,
+			FullTimestamp:   true,
+			TimestampFormat: "2006-01-02 15:04:05",
+		}
+	}
+
+	log.SetFormatter(&formatter{
+		inner:         inner,
+		level:         level,
+		packageLevels: packageLevels,
 	})
 
 	return nil
 }
+
+func parseLevel(level string) (log.Level, error) {
+	if strings.TrimSpace(level) == "" {
+		return log.InfoLevel, nil
+	}
+	return log.ParseLevel(strings.TrimSpace(level))
+}
@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+var sensitiveKeys = []string{"password", "passwd", "pwd", "token", "authorization", "secret", "api_key", "api-key", "apikey"}
+
+var (
+	bearerPattern = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-._~+/]+=*`)
+	// key=value, key: value and "key":"value" forms
+	secretPattern = regexp.MustCompile(`(?i)("?[a-z_\-]*(?:password|passwd|pwd|token|authorization|secret|api_?key)"?\s*[:=]\s*"?)([^"&,;\s}]+)`)
+)
+
+// IsSensitive reports whether a field or header name holds a secret.
+func IsSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact masks the bearer tokens and the password/token values found in a message.
+func Redact(s string) string {
+	if s == "" {
+		return s
+	}
+	s = bearerPattern.ReplaceAllString(s, "${1}"+redacted)
+	return secretPattern.ReplaceAllString(s, "${1}"+redacted)
+}
+
+// RedactHeaders returns a copy of the headers with the secrets masked.
+func RedactHeaders(headers http.Header) map[string]string {
+	res := make(map[string]string, len(headers))
+	for k, v := range headers {
+		if IsSensitive(k) || strings.EqualFold(k, "Cookie") {
+			res[k] = redacted
+			continue
+		}
+		res[k] = strings.Join(v, ",")
+	}
+	return res
+}
+
+func redactField(key string, value interface{}) interface{} {
+	if IsSensitive(key) {
+		return redacted
+	}
+	switch v := value.(type) {
+	case string:
+		return Redact(v)
+	case error:
+		return Redact(v.Error())
+	default:
+		return value
+	}
+}
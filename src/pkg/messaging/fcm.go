@@ -6,6 +6,8 @@ import (
 	"os"
 	"sen-global-api/config"
 	"sen-global-api/internal/domain/value"
+	"sen-global-api/pkg/metrics"
+	"time"
 
 	firebase "firebase.google.com/go/v4"
 	"firebase.google.com/go/v4/messaging"
@@ -78,10 +80,48 @@ func SendNotification(app *firebase.App, params NotificationParams) error {
 		},
 	}
 
+	start := time.Now()
 	_, err = msgApp.Send(ctx, msg)
+	metrics.ObserveCall(metrics.ServiceFCM, "send", start, err)
 	if err != nil {
 		log.Errorf("FCM failed to send message to device token %s error %s", params.DeviceToken, err.Error())
 	}
 
 	return err
 }
+
+// SendData sends a data only message, handled by the app itself even in background, without notification.
+func SendData(app *firebase.App, deviceToken string, data map[string]string) error {
+	ctx := context.Background()
+	msgApp, err := app.Messaging(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot initialize Messaging App %s", err.Error())
+	}
+
+	msg := &messaging.Message{
+		Data:  data,
+		Token: deviceToken,
+		Android: &messaging.AndroidConfig{
+			Priority: "high",
+		},
+		APNS: &messaging.APNSConfig{
+			Headers: map[string]string{
+				// background push, must be priority 5
+				"apns-priority":  "5",
+				"apns-push-type": "background",
+			},
+			Payload: &messaging.APNSPayload{
+				Aps: &messaging.Aps{ContentAvailable: true},
+			},
+		},
+	}
+
+	start := time.Now()
+	_, err = msgApp.Send(ctx, msg)
+	metrics.ObserveCall(metrics.ServiceFCM, "send_data", start, err)
+	if err != nil {
+		log.Errorf("FCM failed to send data message to device token %s error %s", deviceToken, err.Error())
+	}
+
+	return err
+}
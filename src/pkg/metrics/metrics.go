@@ -0,0 +1,166 @@
+// Package metrics exposes the Prometheus metrics of the service on /metrics.
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "sen_global_api"
+
+// External services observed with ObserveCall
+const (
+	ServiceRedis  = "redis"
+	ServiceConsul = "consul"
+	ServiceSheets = "sheets"
+	ServiceS3     = "s3"
+	ServiceFCM    = "fcm"
+)
+
+const (
+	ResultSuccess = "success"
+	ResultError   = "error"
+)
+
+var (
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of the HTTP requests by route, method and status code.",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"method", "route", "status"})
+
+	httpRequestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "HTTP requests being served.",
+	})
+
+	externalCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "external_calls_total",
+		Help:      "Calls to the external services (redis, consul, sheets, s3, fcm) by operation and result.",
+	}, []string{"service", "operation", "result"})
+
+	externalCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "external_call_duration_seconds",
+		Help:      "Latency of the calls to the external services.",
+		Buckets:   []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"service", "operation"})
+
+	jobRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "job_runs_total",
+		Help:      "Runs of the background jobs by result.",
+	}, []string{"job", "result"})
+
+	jobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "job_run_duration_seconds",
+		Help:      "Duration of the background job runs.",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300, 600, 1800},
+	}, []string{"job"})
+
+	googleAPIRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "google_api_requests_total",
+		Help:      "Google API requests by kind (init device, import todo, import form, screen button, top button).",
+	}, []string{"kind"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		httpRequestDuration,
+		httpRequestsInFlight,
+		externalCalls,
+		externalCallDuration,
+		jobRuns,
+		jobDuration,
+		googleAPIRequests,
+	)
+}
+
+// Handler serves the registered metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// RegisterDB exports the connection pool stats (open, in use, idle, waits, ...) of a database.
+func RegisterDB(name string, db *sql.DB) error {
+	err := prometheus.Register(collectors.NewDBStatsCollector(db, name))
+	var already prometheus.AlreadyRegisteredError
+	if errors.As(err, &already) {
+		return nil
+	}
+	return err
+}
+
+// RegisterQueue exports the current depth of an in-memory queue, read on every scrape.
+func RegisterQueue(name string, depth func() int) error {
+	err := prometheus.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   namespace,
+		Name:        "queue_depth",
+		Help:        "Items waiting in the in-memory queues.",
+		ConstLabels: prometheus.Labels{"queue": name},
+	}, func() float64 {
+		return float64(depth())
+	}))
+	var already prometheus.AlreadyRegisteredError
+	if errors.As(err, &already) {
+		return nil
+	}
+	return err
+}
+
+// ObserveHTTP records a served request, route is the gin route template so the cardinality stays bounded.
+func ObserveHTTP(method, route, status string, duration time.Duration) {
+	httpRequestDuration.WithLabelValues(method, route, status).Observe(duration.Seconds())
+}
+
+// TrackInFlight counts a request being served, the returned func is called when it ends.
+func TrackInFlight() func() {
+	httpRequestsInFlight.Inc()
+	return httpRequestsInFlight.Dec
+}
+
+func result(err error) string {
+	if err != nil {
+		return ResultError
+	}
+	return ResultSuccess
+}
+
+// ObserveCall records a call to an external service started at start.
+func ObserveCall(service, operation string, start time.Time, err error) {
+	externalCalls.WithLabelValues(service, operation, result(err)).Inc()
+	externalCallDuration.WithLabelValues(service, operation).Observe(time.Since(start).Seconds())
+}
+
+// ObserveJob records a run of a background job started at start.
+func ObserveJob(job string, start time.Time, err error) {
+	jobRuns.WithLabelValues(job, result(err)).Inc()
+	jobDuration.WithLabelValues(job).Observe(time.Since(start).Seconds())
+}
+
+// TrackJob times a job run, the returned func records the result of the run:
+//
+//	track := metrics.TrackJob("name")
+//	defer func() { track(jobErr) }()
+func TrackJob(job string) func(err error) {
+	start := time.Now()
+	return func(err error) {
+		ObserveJob(job, start, err)
+	}
+}
+
+// IncGoogleAPIRequest counts a Google API request of the given kind.
+func IncGoogleAPIRequest(kind string) {
+	googleAPIRequests.WithLabelValues(kind).Inc()
+}
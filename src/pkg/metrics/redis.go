@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// RedisHook counts the Redis commands, a missing key (redis.Nil) is not an error.
+type RedisHook struct{}
+
+func (RedisHook) DialHook(next goredis.DialHook) goredis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		start := time.Now()
+		conn, err := next(ctx, network, addr)
+		ObserveCall(ServiceRedis, "dial", start, err)
+		return conn, err
+	}
+}
+
+func (RedisHook) ProcessHook(next goredis.ProcessHook) goredis.ProcessHook {
+	return func(ctx context.Context, cmd goredis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		ObserveCall(ServiceRedis, cmd.Name(), start, redisError(err))
+		return err
+	}
+}
+
+func (RedisHook) ProcessPipelineHook(next goredis.ProcessPipelineHook) goredis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []goredis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		ObserveCall(ServiceRedis, "pipeline", start, redisError(err))
+		return err
+	}
+}
+
+func redisError(err error) error {
+	if errors.Is(err, goredis.Nil) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Transport counts the HTTP calls made to an external service, e.g. Google Sheets or S3.
+// A call is an error when it fails or answers 429 or 5xx.
+type Transport struct {
+	Service string
+	Base    http.RoundTripper
+}
+
+func NewTransport(service string, base http.RoundTripper) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{Service: service, Base: base}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.Base.RoundTrip(req)
+
+	callErr := err
+	if err == nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500) {
+		callErr = fmt.Errorf("status %d", resp.StatusCode)
+	}
+	ObserveCall(t.Service, req.Method, start, callErr)
+	return resp, err
+}
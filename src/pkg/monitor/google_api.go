@@ -1,38 +1,49 @@
 package monitor
 
+import (
+	"sen-global-api/pkg/metrics"
+	"sync/atomic"
+)
+
 // register, import 1 todo, import 1 form, screen button, top button
+// the counters hold the requests of the current minute, the Prometheus counters are never reset
 var (
-	TotalRequestInitDevice      = 0
-	TotalRequestImportToDo      = 0
-	TotalRequestImportForm      = 0
-	TotalRequestGETScreenButton = 0
-	TotalRequestGETTopButton    = 0
+	TotalRequestInitDevice      atomic.Int64
+	TotalRequestImportToDo      atomic.Int64
+	TotalRequestImportForm      atomic.Int64
+	TotalRequestGETScreenButton atomic.Int64
+	TotalRequestGETTopButton    atomic.Int64
 )
 
 func LogGoogleAPIRequestInitDevice() {
-	TotalRequestInitDevice++
+	TotalRequestInitDevice.Add(1)
+	metrics.IncGoogleAPIRequest("init_device")
 }
 
 func LogGoogleAPIRequestImportTodo() {
-	TotalRequestImportToDo++
+	TotalRequestImportToDo.Add(1)
+	metrics.IncGoogleAPIRequest("import_todo")
 }
 
 func LogGoogleAPIRequestImportForm() {
-	TotalRequestImportForm++
+	TotalRequestImportForm.Add(1)
+	metrics.IncGoogleAPIRequest("import_form")
 }
 
 func LogGoogleAPIRequestGETScreenButton() {
-	TotalRequestGETScreenButton++
+	TotalRequestGETScreenButton.Add(1)
+	metrics.IncGoogleAPIRequest("get_screen_button")
 }
 
 func LogGoogleAPIRequestGETTopButton() {
-	TotalRequestGETTopButton++
+	TotalRequestGETTopButton.Add(1)
+	metrics.IncGoogleAPIRequest("get_top_button")
 }
 
 func ResetGoogleAPIRequestMonitor() {
-	TotalRequestInitDevice = 0
-	TotalRequestImportToDo = 0
-	TotalRequestImportForm = 0
-	TotalRequestGETScreenButton = 0
-	TotalRequestGETTopButton = 0
+	TotalRequestInitDevice.Store(0)
+	TotalRequestImportToDo.Store(0)
+	TotalRequestImportForm.Store(0)
+	TotalRequestGETScreenButton.Store(0)
+	TotalRequestGETTopButton.Store(0)
 }
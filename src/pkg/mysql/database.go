@@ -2,37 +2,61 @@ package mysql
 
 import (
 	"fmt"
-	"log"
-	"os"
+	"net"
 	"sen-global-api/config"
+	"sen-global-api/pkg/common"
+	"strings"
 	"time"
 
 	_ "time/tzdata"
 
+	driver "github.com/go-sql-driver/mysql"
 	"github.com/sirupsen/logrus"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"gorm.io/gorm/schema"
+	"gorm.io/plugin/dbresolver"
+)
+
+const (
+	defaultQueryTimeout   = 30 * time.Second
+	defaultSlowQuery      = time.Second
+	defaultConnectRetries = 5
+	maxConnectBackoff     = 30 * time.Second
+
+	// ReplicaResolver is the dbresolver name of the read replicas
+	ReplicaResolver = "replica"
 )
 
 func Establish(appConfig config.AppConfig) (*gorm.DB, error) {
-	return connect(appConfig)
+	retries := appConfig.Config.Database.ConnectRetries
+	if retries <= 0 {
+		retries = defaultConnectRetries
+	}
+
+	// mysql co the chua san sang khi container khoi dong cung luc, thu lai voi backoff thay vi panic
+	backoff := time.Second
+	for attempt := 1; ; attempt++ {
+		db, err := connect(appConfig)
+		if err == nil {
+			return db, nil
+		}
+		if attempt >= retries {
+			return nil, fmt.Errorf("connect to database after %d attempts: %w", attempt, err)
+		}
+
+		logrus.Warnf("Connect to database failed (attempt %d/%d), retry in %s: %v", attempt, retries, backoff, err)
+		time.Sleep(backoff)
+		backoff = min(backoff*2, maxConnectBackoff)
+	}
 }
 
 func connect(appConfig config.AppConfig) (*gorm.DB, error) {
-	fmt.Println("Connect to database.....")
-	var err error
-	var (
-		devHostName = appConfig.Config.Host
-		devDbName   = appConfig.Config.Database.Database
-		devUser     = appConfig.Config.User
-		devDbPort   = appConfig.Config.Database.Port
-		devPassword = appConfig.Config.Password
-	)
+	logrus.Info("Connect to database.....")
+	dbConfig := appConfig.Config.Database
 
-	dsn := devUser + ":" + devPassword + "@tcp(" + devHostName + ":" + devDbPort + ")/" + devDbName + "?charset=utf8mb4&parseTime=True&loc=Local"
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
+	db, err := gorm.Open(mysql.Open(dsn(dbConfig, net.JoinHostPort(dbConfig.Host, dbConfig.Port))), &gorm.Config{
 		PrepareStmt: false,
 		NamingStrategy: schema.NamingStrategy{
 			SingularTable: true,
@@ -40,24 +64,118 @@ func connect(appConfig config.AppConfig) (*gorm.DB, error) {
 		NowFunc: func() time.Time {
 			return time.Now().Local()
 		},
-		Logger: logger.New(
-			log.New(os.Stdout, "\r\n", log.LstdFlags), // io writer
-			logger.Config{
-				SlowThreshold:             time.Second, // Slow SQL threshold
-				LogLevel:                  logger.Info, // Log level
-				IgnoreRecordNotFoundError: true,        // Ignore ErrRecordNotFound error for logger
-				Colorful:                  false,       // Disable color
-			},
-		),
+		Logger: newLogger(dbConfig, appConfig.Config.Env),
 	})
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	//db.LogMode(appConfig.Config.Env == "development")
-	//db.DB().SetMaxIDleConns(appConfig.Config.Database.MaxIDleConn)
-	//db.DB().SetMaxOpenConns(appConfig.Config.Database.MaxConn)
-	//db.DB().SetConnMaxLifetime(time.Duration(appConfig.Config.Database.MaxLifetime))
-	logrus.Info("Established database!")
+
+	queryTimeout := defaultQueryTimeout
+	if dbConfig.QueryTimeoutSeconds > 0 {
+		queryTimeout = time.Duration(dbConfig.QueryTimeoutSeconds) * time.Second
+	}
+	if err := db.Use(&timeoutPlugin{timeout: queryTimeout}); err != nil {
+		return nil, err
+	}
+
+	if len(dbConfig.ReplicaHosts) > 0 {
+		replicas := make([]gorm.Dialector, 0, len(dbConfig.ReplicaHosts))
+		for _, host := range dbConfig.ReplicaHosts {
+			replicas = append(replicas, mysql.Open(dsn(dbConfig, replicaAddr(host, dbConfig.Port))))
+		}
+		// chi cac query goi Replica(db) moi doc tu replica, con lai van doc tu primary
+		resolver := dbresolver.Register(dbresolver.Config{
+			Replicas: replicas,
+			Policy:   dbresolver.RandomPolicy{},
+		}, ReplicaResolver)
+		// ap dung pool cho primary va cac replica
+		resolver.SetMaxOpenConns(dbConfig.MaxConn).
+			SetMaxIdleConns(dbConfig.MaxIDleConn).
+			SetConnMaxLifetime(connMaxLifetime(dbConfig)).
+			SetConnMaxIdleTime(connMaxIdleTime(dbConfig))
+		if err := db.Use(resolver); err != nil {
+			return nil, err
+		}
+	} else {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		sqlDB.SetMaxOpenConns(dbConfig.MaxConn)
+		sqlDB.SetMaxIdleConns(dbConfig.MaxIDleConn)
+		sqlDB.SetConnMaxLifetime(connMaxLifetime(dbConfig))
+		sqlDB.SetConnMaxIdleTime(connMaxIdleTime(dbConfig))
+	}
+
+	logrus.Infof("Established database! (%d replicas)", len(dbConfig.ReplicaHosts))
 
 	return db, nil
 }
+
+// max_lifetime_conn va max_idle_time_conn tinh bang giay
+func connMaxLifetime(dbConfig common.Database) time.Duration {
+	return time.Duration(dbConfig.MaxLifetime) * time.Second
+}
+
+func connMaxIdleTime(dbConfig common.Database) time.Duration {
+	return time.Duration(dbConfig.MaxIdleTime) * time.Second
+}
+
+func dsn(dbConfig common.Database, addr string) string {
+	cfg := driver.NewConfig()
+	cfg.User = dbConfig.User
+	cfg.Passwd = dbConfig.Password
+	cfg.Net = "tcp"
+	cfg.Addr = addr
+	cfg.DBName = dbConfig.Database
+	cfg.ParseTime = true
+	cfg.Loc = time.Local
+	cfg.Params = map[string]string{"charset": "utf8mb4"}
+	cfg.Timeout = 10 * time.Second
+	return cfg.FormatDSN()
+}
+
+func replicaAddr(host string, defaultPort string) string {
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host
+	}
+	return net.JoinHostPort(host, defaultPort)
+}
+
+func newLogger(dbConfig common.Database, env common.Environment) logger.Interface {
+	level := logger.Warn
+	if env == common.ModeDevelopment {
+		level = logger.Info
+	}
+	switch strings.ToLower(dbConfig.LogLevel) {
+	case "silent":
+		level = logger.Silent
+	case "error":
+		level = logger.Error
+	case "warn":
+		level = logger.Warn
+	case "info":
+		level = logger.Info
+	}
+
+	slow := defaultSlowQuery
+	if dbConfig.SlowQueryMs > 0 {
+		slow = time.Duration(dbConfig.SlowQueryMs) * time.Millisecond
+	}
+
+	return logger.New(
+		logrus.WithField("component", "sql"),
+		logger.Config{
+			SlowThreshold:             slow,
+			LogLevel:                  level,
+			IgnoreRecordNotFoundError: true,
+			Colorful:                  false,
+		},
+	)
+}
+
+// Replica routes the reads of the query to the read replicas when configured, to the primary otherwise.
+// Only for the heavy reads that accept a small replication lag (menus, search, analytics).
+func Replica(db *gorm.DB) *gorm.DB {
+	return db.Clauses(dbresolver.Use(ReplicaResolver))
+}
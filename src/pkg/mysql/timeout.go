@@ -0,0 +1,96 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	timeoutCancelKey = "mysql:timeout_cancel"
+	timeoutParentKey = "mysql:timeout_parent"
+)
+
+type noTimeoutKey struct{}
+
+// WithoutTimeout marks ctx so its statements keep no default timeout, for the migrations and the maintenance
+// jobs whose DDL on a large table can run for minutes. A deadline set on ctx still applies.
+func WithoutTimeout(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noTimeoutKey{}, true)
+}
+
+// timeoutPlugin gives every statement without deadline in its context a default timeout,
+// so a slow query cannot hold a connection of the pool forever. WithoutTimeout opts out.
+// Row/Rows are left out: the caller reads the rows after the callbacks returned.
+type timeoutPlugin struct {
+	timeout time.Duration
+}
+
+func (p *timeoutPlugin) Name() string {
+	return "mysql:timeout"
+}
+
+func (p *timeoutPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+
+	if err := callbacks.Create().Before("gorm:create").Register("mysql:timeout_before", p.before); err != nil {
+		return err
+	}
+	if err := callbacks.Create().After("gorm:after_create").Register("mysql:timeout_after", p.after); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("mysql:timeout_before", p.before); err != nil {
+		return err
+	}
+	if err := callbacks.Query().After("gorm:after_query").Register("mysql:timeout_after", p.after); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("mysql:timeout_before", p.before); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:after_update").Register("mysql:timeout_after", p.after); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("mysql:timeout_before", p.before); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("gorm:after_delete").Register("mysql:timeout_after", p.after); err != nil {
+		return err
+	}
+	if err := callbacks.Raw().Before("gorm:raw").Register("mysql:timeout_before", p.before); err != nil {
+		return err
+	}
+	return callbacks.Raw().After("gorm:raw").Register("mysql:timeout_after", p.after)
+}
+
+func (p *timeoutPlugin) before(db *gorm.DB) {
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return
+	}
+	if skip, _ := ctx.Value(noTimeoutKey{}).(bool); skip {
+		return
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, p.timeout)
+	db.Statement.Context = timeoutCtx
+	db.Statement.Settings.Store(timeoutCancelKey, cancel)
+	db.Statement.Settings.Store(timeoutParentKey, ctx)
+}
+
+func (p *timeoutPlugin) after(db *gorm.DB) {
+	cancel, ok := db.Statement.Settings.LoadAndDelete(timeoutCancelKey)
+	if !ok {
+		return
+	}
+	cancel.(context.CancelFunc)()
+
+	// a chained query (Count then Find) reuses the statement, it must not keep the cancelled context
+	if parent, ok := db.Statement.Settings.LoadAndDelete(timeoutParentKey); ok {
+		db.Statement.Context = parent.(context.Context)
+	}
+}
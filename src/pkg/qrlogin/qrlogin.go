@@ -0,0 +1,63 @@
+// Package qrlogin signs the login tokens printed in the user QR codes.
+// A token is "<token id>.<expiry unix>.<signature>"; the signature binds the token to its user,
+// the token id points to the s_qr_login_token row used to revoke it.
+package qrlogin
+
+import (
+	"errors"
+	"fmt"
+	"sen-global-api/pkg/signedtoken"
+	"time"
+)
+
+// PayloadPrefix is kept from the legacy "username:password" QR codes so the apps parse both the same way.
+const PayloadPrefix = "SENBOX.ORG/[USERNAME-PASSWORD]"
+
+const defaultTTL = 365 * 24 * time.Hour
+
+var (
+	ErrInvalidToken = errors.New("invalid QR login token")
+	ErrExpiredToken = errors.New("QR login token has expired")
+)
+
+var (
+	signer = signedtoken.New("qrlogin")
+	// legacySigner verifies the tokens printed before the purpose was signed, they stay valid until they expire
+	legacySigner = signedtoken.New("")
+	ttl          = defaultTTL
+)
+
+// Init sets the lifetime of the new tokens, called once at startup.
+func Init(tokenTTL time.Duration) {
+	if tokenTTL > 0 {
+		ttl = tokenTTL
+	}
+}
+
+func TTL() time.Duration {
+	return ttl
+}
+
+func Sign(tokenID, userID string, expiresAt time.Time) string {
+	return signer.Sign(tokenID, userID, expiresAt)
+}
+
+// Verify checks the signature and the expiry of a token issued to the user and returns the token id.
+func Verify(token, userID string) (string, error) {
+	tokenID, err := signer.Verify(token, userID)
+	if errors.Is(err, signedtoken.ErrInvalid) {
+		tokenID, err = legacySigner.Verify(token, userID)
+	}
+	switch {
+	case errors.Is(err, signedtoken.ErrExpired):
+		return "", ErrExpiredToken
+	case err != nil:
+		return "", ErrInvalidToken
+	}
+	return tokenID, nil
+}
+
+// Payload is the content of the QR code.
+func Payload(username, token string) string {
+	return fmt.Sprintf("%s:%s:%s", PayloadPrefix, username, token)
+}
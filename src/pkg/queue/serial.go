@@ -1,14 +1,25 @@
 package queue
 
+import (
+	"context"
+	"sen-global-api/pkg/lifecycle"
+)
+
+// New runs the functions sent on the queue one after another.
+// On shutdown the function being run finishes, the worker then stops waiting for new ones.
 func New() chan func() {
 	var queue = make(chan func())
 
-	go func() {
+	lifecycle.Default().Go("serial_queue", func(ctx context.Context) {
 		for {
-			nextFunction := <-queue
-			nextFunction()
+			select {
+			case nextFunction := <-queue:
+				nextFunction()
+			case <-lifecycle.Default().Stopping():
+				return
+			}
 		}
-	}()
+	})
 
 	return queue
 }
@@ -0,0 +1,231 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	keyPrefix    = "ratelimit:"
+	windowPrefix = keyPrefix + "window:"
+	lockPrefix   = keyPrefix + "lock:"
+	strikePrefix = keyPrefix + "strike:"
+)
+
+// key kinds
+const (
+	KindIP       = "ip"
+	KindUsername = "username"
+	KindDevice   = "device"
+	KindQRCode   = "qr_code"
+	KindForm     = "form"
+)
+
+// Rule is the limit applied to every key of a route group.
+type Rule struct {
+	// Limit hits are allowed per sliding Window, IPLimit overrides it for the IP keys
+	Limit   int
+	IPLimit int
+	Window  time.Duration
+	// FailuresOnly counts the rejected requests only, e.g. the wrong passwords
+	FailuresOnly bool
+	// Lockout is the first lock duration, doubled on each new lockout until MaxLockout
+	Lockout    time.Duration
+	MaxLockout time.Duration
+	// StrikeTTL is how long past lockouts are remembered for the progression
+	StrikeTTL time.Duration
+}
+
+// Lock describes a locked key.
+type Lock struct {
+	Group  string    `json:"group"`
+	Kind   string    `json:"kind"`
+	Key    string    `json:"key"`
+	Until  time.Time `json:"until"`
+	Strike int64     `json:"strike"`
+}
+
+// LockoutHandler is notified each time a key gets locked, e.g. to audit it.
+type LockoutHandler func(ctx context.Context, lock Lock)
+
+// slidingWindow drops the hits older than the window, records the new one and returns the hit count.
+var slidingWindow = goredis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], 0, now - window)
+redis.call('ZADD', KEYS[1], now, ARGV[3])
+redis.call('PEXPIRE', KEYS[1], window)
+return redis.call('ZCARD', KEYS[1])
+`)
+
+type Limiter struct {
+	client    *goredis.Client
+	rules     map[string]Rule
+	onLockout []LockoutHandler
+}
+
+// NewLimiter creates a limiter. Without Redis client every request is allowed.
+func NewLimiter(client *goredis.Client, rules map[string]Rule) *Limiter {
+	return &Limiter{client: client, rules: rules}
+}
+
+var defaultLimiter = NewLimiter(nil, nil)
+
+// Init replaces the default limiter, called once at startup.
+func Init(client *goredis.Client, rules map[string]Rule) *Limiter {
+	defaultLimiter = NewLimiter(client, rules)
+	return defaultLimiter
+}
+
+func Default() *Limiter {
+	return defaultLimiter
+}
+
+// OnLockout registers a handler called on each new lockout.
+func (l *Limiter) OnLockout(handler LockoutHandler) {
+	l.onLockout = append(l.onLockout, handler)
+}
+
+func (l *Limiter) Enabled() bool {
+	return l.client != nil
+}
+
+func (l *Limiter) Rule(group string) (Rule, bool) {
+	rule, ok := l.rules[group]
+	return rule, ok && rule.Limit > 0 && rule.Window > 0
+}
+
+func subject(group, kind, key string) string {
+	return group + ":" + kind + ":" + key
+}
+
+// LockedUntil returns the end of the current lock of the key, nil when it is not locked.
+// Redis errors are logged and let the request through.
+func (l *Limiter) LockedUntil(ctx context.Context, group, kind, key string) *time.Time {
+	if l.client == nil {
+		return nil
+	}
+	ttl, err := l.client.PTTL(ctx, lockPrefix+subject(group, kind, key)).Result()
+	if err != nil {
+		log.Errorf("ratelimit: read lock %s: %v", subject(group, kind, key), err)
+		return nil
+	}
+	if ttl <= 0 {
+		return nil
+	}
+	until := time.Now().Add(ttl)
+	return &until
+}
+
+// Hit records a hit for the key and locks it when the rule of the group is exceeded.
+// It returns the end of the lock when the key is locked by this hit.
+func (l *Limiter) Hit(ctx context.Context, group, kind, key string) *time.Time {
+	rule, ok := l.Rule(group)
+	if l.client == nil || !ok {
+		return nil
+	}
+
+	s := subject(group, kind, key)
+	now := time.Now()
+	member := strconv.FormatInt(now.UnixNano(), 10)
+	count, err := slidingWindow.Run(ctx, l.client, []string{windowPrefix + s},
+		now.UnixMilli(), rule.Window.Milliseconds(), member).Int64()
+	if err != nil {
+		log.Errorf("ratelimit: hit %s: %v", s, err)
+		return nil
+	}
+	limit := rule.Limit
+	if kind == KindIP && rule.IPLimit > 0 {
+		limit = rule.IPLimit
+	}
+	if count <= int64(limit) {
+		return nil
+	}
+
+	return l.lock(ctx, rule, group, kind, key, now)
+}
+
+func (l *Limiter) lock(ctx context.Context, rule Rule, group, kind, key string, now time.Time) *time.Time {
+	s := subject(group, kind, key)
+
+	strike, err := l.client.Incr(ctx, strikePrefix+s).Result()
+	if err != nil {
+		log.Errorf("ratelimit: strike %s: %v", s, err)
+		strike = 1
+	}
+	l.client.Expire(ctx, strikePrefix+s, rule.StrikeTTL)
+
+	duration := lockDuration(rule, strike)
+	if err := l.client.Set(ctx, lockPrefix+s, strike, duration).Err(); err != nil {
+		log.Errorf("ratelimit: lock %s: %v", s, err)
+		return nil
+	}
+	// the window starts again after the lock
+	l.client.Del(ctx, windowPrefix+s)
+
+	until := now.Add(duration)
+	lock := Lock{Group: group, Kind: kind, Key: key, Until: until, Strike: strike}
+	for _, handler := range l.onLockout {
+		handler(ctx, lock)
+	}
+	return &until
+}
+
+func lockDuration(rule Rule, strike int64) time.Duration {
+	duration := rule.Lockout
+	for i := int64(1); i < strike && duration < rule.MaxLockout; i++ {
+		duration *= 2
+	}
+	if rule.MaxLockout > 0 && duration > rule.MaxLockout {
+		duration = rule.MaxLockout
+	}
+	return duration
+}
+
+// Unlock lifts the lock of the key and forgets its past lockouts.
+func (l *Limiter) Unlock(ctx context.Context, group, kind, key string) error {
+	if l.client == nil {
+		return nil
+	}
+	s := subject(group, kind, key)
+	return l.client.Del(ctx, lockPrefix+s, strikePrefix+s, windowPrefix+s).Err()
+}
+
+// Locks returns the keys currently locked.
+func (l *Limiter) Locks(ctx context.Context) ([]Lock, error) {
+	locks := make([]Lock, 0)
+	if l.client == nil {
+		return locks, nil
+	}
+
+	iter := l.client.Scan(ctx, 0, lockPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		redisKey := iter.Val()
+		parts := strings.SplitN(strings.TrimPrefix(redisKey, lockPrefix), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		ttl, err := l.client.PTTL(ctx, redisKey).Result()
+		if err != nil || ttl <= 0 {
+			continue
+		}
+		strike, _ := l.client.Get(ctx, redisKey).Int64()
+		locks = append(locks, Lock{
+			Group:  parts[0],
+			Kind:   parts[1],
+			Key:    parts[2],
+			Until:  time.Now().Add(ttl),
+			Strike: strike,
+		})
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("ratelimit: list locks: %w", err)
+	}
+	return locks, nil
+}
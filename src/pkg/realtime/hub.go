@@ -0,0 +1,302 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// pubSubChannel carries every event to every API replica, each replica delivers to its own subscribers.
+	pubSubChannel = "realtime:events"
+	streamPrefix  = "realtime:stream:"
+
+	// replay window kept per channel
+	streamMaxLen = 200
+	streamTTL    = 24 * time.Hour
+
+	subscriptionBuffer = 32
+)
+
+// Event is a typed message delivered to the subscribers of a channel.
+// ID is the Redis stream id ("<ms>-<seq>") and is used as the SSE Last-Event-ID.
+type Event struct {
+	ID        string          `json:"id"`
+	Channel   string          `json:"channel"`
+	Type      string          `json:"type"`
+	Data      json.RawMessage `json:"data"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// Adapter receives every published event, e.g. to mirror it to a legacy side-channel.
+type Adapter interface {
+	Push(ctx context.Context, event Event) error
+}
+
+func DeviceChannel(deviceID string) string {
+	return "device:" + deviceID
+}
+
+func OrganizationChannel(orgID string) string {
+	return "org:" + orgID
+}
+
+func UserChannel(userID string) string {
+	return "user:" + userID
+}
+
+type Subscription struct {
+	C        chan Event
+	channels map[string]bool
+}
+
+type Hub struct {
+	client   *goredis.Client
+	adapters []Adapter
+
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+
+	// used for the event ids when Redis is not configured
+	localSeq int64
+}
+
+// NewHub creates a hub. Without Redis client the events only reach the subscribers of this process and cannot be replayed.
+func NewHub(client *goredis.Client, adapters ...Adapter) *Hub {
+	return &Hub{
+		client:   client,
+		adapters: adapters,
+		subs:     make(map[*Subscription]struct{}),
+	}
+}
+
+var defaultHub = NewHub(nil)
+
+// Init replaces the default hub, called once at startup.
+func Init(client *goredis.Client, adapters ...Adapter) *Hub {
+	defaultHub = NewHub(client, adapters...)
+	return defaultHub
+}
+
+func Default() *Hub {
+	return defaultHub
+}
+
+// Publish sends an event on the default hub.
+func Publish(ctx context.Context, channel, eventType string, data interface{}) error {
+	return defaultHub.Publish(ctx, channel, eventType, data)
+}
+
+func (h *Hub) Publish(ctx context.Context, channel, eventType string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	event := Event{
+		Channel:   channel,
+		Type:      eventType,
+		Data:      payload,
+		CreatedAt: time.Now(),
+	}
+
+	for _, adapter := range h.adapters {
+		if err := adapter.Push(ctx, event); err != nil {
+			log.Errorf("realtime: adapter push %s on %s: %v", eventType, channel, err)
+		}
+	}
+
+	if h.client == nil {
+		seq := atomic.AddInt64(&h.localSeq, 1)
+		event.ID = fmt.Sprintf("%d-%d", event.CreatedAt.UnixMilli(), seq)
+		h.dispatch(event)
+		return nil
+	}
+
+	// log the event for replay, the stream id becomes the event id
+	stream := streamPrefix + channel
+	id, err := h.client.XAdd(ctx, &goredis.XAddArgs{
+		Stream: stream,
+		MaxLen: streamMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{"type": eventType, "data": string(payload)},
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("realtime: log event: %w", err)
+	}
+	h.client.Expire(ctx, stream, streamTTL)
+	event.ID = id
+
+	message, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if err := h.client.Publish(ctx, pubSubChannel, message).Err(); err != nil {
+		return fmt.Errorf("realtime: publish event: %w", err)
+	}
+	return nil
+}
+
+// Run forwards the events published by every replica to the local subscribers until ctx is done.
+func (h *Hub) Run(ctx context.Context) {
+	if h.client == nil {
+		return
+	}
+
+	pubsub := h.client.Subscribe(ctx, pubSubChannel)
+	defer pubsub.Close()
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			var event Event
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				log.Errorf("realtime: invalid event: %v", err)
+				continue
+			}
+			h.dispatch(event)
+		}
+	}
+}
+
+func (h *Hub) Subscribe(channels ...string) *Subscription {
+	sub := &Subscription{
+		C:        make(chan Event, subscriptionBuffer),
+		channels: make(map[string]bool, len(channels)),
+	}
+	for _, c := range channels {
+		sub.channels[c] = true
+	}
+
+	h.mu.Lock()
+	h.subs[sub] = struct{}{}
+	h.mu.Unlock()
+	return sub
+}
+
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	delete(h.subs, sub)
+	h.mu.Unlock()
+}
+
+// Pending returns the events waiting in the subscriber buffers.
+func (h *Hub) Pending() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	pending := 0
+	for sub := range h.subs {
+		pending += len(sub.C)
+	}
+	return pending
+}
+
+func (h *Hub) dispatch(event Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.subs {
+		if !sub.channels[event.Channel] {
+			continue
+		}
+		select {
+		case sub.C <- event:
+		default:
+			// slow client, it will catch up with Last-Event-ID on reconnect
+			log.Warnf("realtime: subscriber buffer full, drop event %s on %s", event.ID, event.Channel)
+		}
+	}
+}
+
+// Replay returns the events of the channels published after lastEventID, oldest first.
+func (h *Hub) Replay(ctx context.Context, lastEventID string, channels ...string) ([]Event, error) {
+	if h.client == nil || lastEventID == "" {
+		return nil, nil
+	}
+
+	events := make([]Event, 0)
+	for _, channel := range channels {
+		messages, err := h.client.XRange(ctx, streamPrefix+channel, lastEventID, "+").Result()
+		if err != nil {
+			return nil, err
+		}
+		for _, msg := range messages {
+			if msg.ID == lastEventID {
+				continue
+			}
+			eventType, _ := msg.Values["type"].(string)
+			data, _ := msg.Values["data"].(string)
+			events = append(events, Event{
+				ID:        msg.ID,
+				Channel:   channel,
+				Type:      eventType,
+				Data:      json.RawMessage(data),
+				CreatedAt: time.UnixMilli(streamIDMillis(msg.ID)),
+			})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return compareStreamIDs(events[i].ID, events[j].ID) < 0
+	})
+	return events, nil
+}
+
+func streamIDMillis(id string) int64 {
+	ms, _ := splitStreamID(id)
+	return ms
+}
+
+func compareStreamIDs(a, b string) int {
+	am, as := splitStreamID(a)
+	bm, bs := splitStreamID(b)
+	switch {
+	case am != bm:
+		if am < bm {
+			return -1
+		}
+		return 1
+	case as < bs:
+		return -1
+	case as > bs:
+		return 1
+	}
+	return 0
+}
+
+// IsEventID reports whether id is a stream id ("<ms>" or "<ms>-<seq>"), Replay only accepts those.
+func IsEventID(id string) bool {
+	parts := strings.SplitN(id, "-", 2)
+	for _, part := range parts {
+		if _, err := strconv.ParseUint(part, 10, 64); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func splitStreamID(id string) (int64, int64) {
+	parts := strings.SplitN(id, "-", 2)
+	ms, _ := strconv.ParseInt(parts[0], 10, 64)
+	var seq int64
+	if len(parts) == 2 {
+		seq, _ = strconv.ParseInt(parts[1], 10, 64)
+	}
+	return ms, seq
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"sen-global-api/config"
+	"sen-global-api/pkg/metrics"
 
 	goredis "github.com/redis/go-redis/v9"
 )
@@ -22,6 +23,7 @@ func ConnectRedis(appConfig *config.AppConfig) {
 		Password: cfg.Password,
 		DB:       cfg.DB,
 	})
+	RedisClient.AddHook(metrics.RedisHook{})
 
 	// test kết nối
 	if err := RedisClient.Ping(Ctx).Err(); err != nil {
@@ -39,6 +41,7 @@ func InitRedisCache(appConfig *config.AppConfig) *goredis.Client {
 		Password: cfg.Password,
 		DB:       cfg.DB,
 	})
+	client.AddHook(metrics.RedisHook{})
 
 	// test connection
 	if err := client.Ping(Ctx).Err(); err != nil {
@@ -0,0 +1,107 @@
+package report
+
+import "time"
+
+// Template names, the data of each template is the struct of the same name below.
+const (
+	TemplateSubmission     = "submission.html"
+	TemplateStudentProfile = "student_profile.html"
+	TemplateClassSummary   = "class_summary.html"
+)
+
+// Header is printed on the top of every report.
+type Header struct {
+	Title            string
+	OrganizationName string
+	GeneratedAt      time.Time
+}
+
+type Item struct {
+	Question string
+	Answer   string
+}
+
+type SectionScore struct {
+	Section  string
+	Score    float64
+	MaxScore float64
+	Level    string
+}
+
+type Score struct {
+	Total    float64
+	MaxScore float64
+	Level    string
+	Sections []SectionScore
+}
+
+type AttendanceSummary struct {
+	Present int
+	Late    int
+	Absent  int
+	Excused int
+}
+
+func (a AttendanceSummary) Days() int {
+	return a.Present + a.Late + a.Absent + a.Excused
+}
+
+type SubmissionReport struct {
+	Header
+	FormName    string
+	FormNote    string
+	UserName    string
+	StudentName string
+	SubmittedAt time.Time
+	Score       *Score
+	Items       []Item
+}
+
+type SubmissionEntry struct {
+	FormName    string
+	SubmittedAt time.Time
+	Score       *Score
+	Items       []Item
+}
+
+type HistoryEntry struct {
+	SubmittedAt time.Time
+	Score       *Score
+}
+
+// EntryHistory is the list of entries of one form, what its OutListEntryHistory question shows on the device.
+type EntryHistory struct {
+	FormName string
+	Entries  []HistoryEntry
+}
+
+// StudentProfileReport is the profile of a student with the history of its submissions over a period.
+type StudentProfileReport struct {
+	Header
+	StudentName  string
+	CustomID     string
+	DepartmentID string
+	From         time.Time
+	To           time.Time
+	Attendance   AttendanceSummary
+	EntryHistory []EntryHistory
+	Submissions  []SubmissionEntry
+}
+
+type ClassStudentRow struct {
+	StudentName      string
+	CustomID         string
+	Attendance       AttendanceSummary
+	Submissions      int
+	LastSubmissionAt time.Time
+}
+
+// ClassSummaryReport is one row per student of a class (department) over a period.
+type ClassSummaryReport struct {
+	Header
+	DepartmentID string
+	From         time.Time
+	To           time.Time
+	Attendance   AttendanceSummary
+	Students     []ClassStudentRow
+}
@@ -0,0 +1,442 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// The renderer only knows the tags used by the templates: title, h1-h3, p, div, ul/li, br, hr, b/strong, span.muted
+// and table (tr, th, td with width="NN%" on the first row and align="right|center").
+
+const (
+	fontFamily   = "dejavu"
+	baseFontSize = 10
+	tableFont    = 9
+	cellPadding  = 1.5
+	// pt -> mm with the line spacing
+	lineFactor = 0.3528 * 1.4
+)
+
+type run struct {
+	text  string
+	bold  bool
+	muted bool
+}
+
+type renderer struct {
+	pdf   *fpdf.Fpdf
+	runs  []run
+	bold  int
+	muted int
+}
+
+func renderHTML(page []byte, f *fontSet) ([]byte, error) {
+	doc, err := html.Parse(bytes.NewReader(page))
+	if err != nil {
+		return nil, fmt.Errorf("report: parse html: %w", err)
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes(fontFamily, "", f.regular)
+	pdf.AddUTF8FontFromBytes(fontFamily, "B", f.bold)
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont(fontFamily, "", 8)
+		pdf.SetTextColor(120, 120, 120)
+		pdf.CellFormat(0, 5, fmt.Sprintf("%d/{nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	r := &renderer{pdf: pdf}
+	r.walk(doc)
+	r.flush(baseFontSize)
+
+	if err := pdf.Error(); err != nil {
+		return nil, fmt.Errorf("report: render pdf: %w", err)
+	}
+	var out bytes.Buffer
+	if err := pdf.Output(&out); err != nil {
+		return nil, fmt.Errorf("report: render pdf: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+func (r *renderer) walk(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		r.text(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		r.children(n)
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Title:
+		r.pdf.SetTitle(textContent(n), true)
+	case atom.Head:
+		r.children(n)
+	case atom.Script, atom.Style:
+	case atom.H1:
+		r.block(n, 18, true, 4, "")
+	case atom.H2:
+		r.block(n, 14, true, 3, "")
+	case atom.H3:
+		r.block(n, 11, true, 2, "")
+	case atom.P, atom.Div, atom.Section:
+		r.block(n, baseFontSize, false, 2, "")
+	case atom.Ul:
+		r.flush(baseFontSize)
+		left, top, right, _ := r.pdf.GetMargins()
+		r.pdf.SetLeftMargin(left + 5)
+		r.pdf.SetX(left + 5)
+		r.children(n)
+		r.pdf.SetMargins(left, top, right)
+		r.pdf.SetX(left)
+		r.pdf.Ln(1)
+	case atom.Li:
+		r.block(n, baseFontSize, false, 0.5, "• ")
+	case atom.Br:
+		r.runs = append(r.runs, run{text: "\n"})
+	case atom.Hr:
+		r.flush(baseFontSize)
+		left, _, right, _ := r.pdf.GetMargins()
+		width, _ := r.pdf.GetPageSize()
+		y := r.pdf.GetY() + 1
+		r.pdf.SetDrawColor(180, 180, 180)
+		r.pdf.Line(left, y, width-right, y)
+		r.pdf.SetY(y + 2)
+	case atom.B, atom.Strong:
+		r.bold++
+		r.children(n)
+		r.bold--
+	case atom.Span:
+		muted := hasClass(n, "muted")
+		if muted {
+			r.muted++
+		}
+		r.children(n)
+		if muted {
+			r.muted--
+		}
+	case atom.Table:
+		r.flush(baseFontSize)
+		r.table(n)
+	default:
+		r.children(n)
+	}
+}
+
+func (r *renderer) children(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		r.walk(c)
+	}
+}
+
+// block draws the element as its own paragraph, the text before it is flushed first.
+func (r *renderer) block(n *html.Node, size float64, bold bool, spaceAfter float64, prefix string) {
+	r.flush(baseFontSize)
+	if prefix != "" {
+		r.runs = append(r.runs, run{text: prefix})
+	}
+
+	muted := hasClass(n, "muted")
+	if bold {
+		r.bold++
+	}
+	if muted {
+		r.muted++
+	}
+	r.children(n)
+	r.flush(size)
+	if muted {
+		r.muted--
+	}
+	if bold {
+		r.bold--
+	}
+	r.pdf.Ln(spaceAfter)
+}
+
+func (r *renderer) text(data string) {
+	collapsed := strings.Join(strings.Fields(data), " ")
+	if collapsed == "" {
+		if data != "" && len(r.runs) > 0 {
+			collapsed = " "
+		} else {
+			return
+		}
+	} else {
+		if startsWithSpace(data) && len(r.runs) > 0 {
+			collapsed = " " + collapsed
+		}
+		if endsWithSpace(data) {
+			collapsed += " "
+		}
+	}
+	r.runs = append(r.runs, run{text: collapsed, bold: r.bold > 0, muted: r.muted > 0})
+}
+
+// flush writes the pending inline texts as one paragraph.
+func (r *renderer) flush(size float64) {
+	runs := r.runs
+	r.runs = nil
+
+	first, last := 0, len(runs)-1
+	for first <= last && strings.TrimSpace(runs[first].text) == "" {
+		first++
+	}
+	for last >= first && strings.TrimSpace(runs[last].text) == "" {
+		last--
+	}
+	if first > last {
+		return
+	}
+	runs = runs[first : last+1]
+	runs[0].text = strings.TrimLeft(runs[0].text, " ")
+	runs[len(runs)-1].text = strings.TrimRight(runs[len(runs)-1].text, " ")
+
+	height := size * lineFactor
+	for _, rn := range runs {
+		style := ""
+		if rn.bold {
+			style = "B"
+		}
+		fontSize := size
+		if rn.muted {
+			fontSize = size - 1
+			r.pdf.SetTextColor(110, 110, 110)
+		} else {
+			r.pdf.SetTextColor(0, 0, 0)
+		}
+		r.pdf.SetFont(fontFamily, style, fontSize)
+		r.pdf.Write(height, rn.text)
+	}
+	r.pdf.Ln(height)
+}
+
+type tableCell struct {
+	text   string
+	header bool
+	align  string
+	width  float64
+}
+
+// table draws the rows with borders, the header rows are repeated on each new page.
+func (r *renderer) table(n *html.Node) {
+	rows := make([][]tableCell, 0)
+	for _, tr := range findAll(n, atom.Tr) {
+		row := make([]tableCell, 0)
+		for c := tr.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode || (c.DataAtom != atom.Td && c.DataAtom != atom.Th) {
+				continue
+			}
+			cell := tableCell{text: textContent(c), header: c.DataAtom == atom.Th, align: "L"}
+			switch attr(c, "align") {
+			case "right":
+				cell.align = "R"
+			case "center":
+				cell.align = "C"
+			}
+			if w := strings.TrimSuffix(attr(c, "width"), "%"); w != "" {
+				cell.width, _ = strconv.ParseFloat(w, 64)
+			}
+			row = append(row, cell)
+		}
+		if len(row) > 0 {
+			rows = append(rows, row)
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	widths := columnWidths(r.pdf, rows)
+	left, _, _, bottom := r.pdf.GetMargins()
+	_, pageHeight := r.pdf.GetPageSize()
+	height := tableFont * lineFactor
+
+	headers := make([][]tableCell, 0)
+	for _, row := range rows {
+		if isHeaderRow(row) {
+			headers = append(headers, row)
+		} else {
+			break
+		}
+	}
+
+	auto, margin := r.pdf.GetAutoPageBreak()
+	r.pdf.SetAutoPageBreak(false, margin)
+	for i, row := range rows {
+		rowHeight := r.rowHeight(row, widths, height)
+		if r.pdf.GetY()+rowHeight > pageHeight-bottom {
+			r.pdf.AddPage()
+			if i >= len(headers) {
+				for _, header := range headers {
+					r.drawRow(header, widths, height, r.rowHeight(header, widths, height), left)
+				}
+			}
+		}
+		r.drawRow(row, widths, height, rowHeight, left)
+	}
+	r.pdf.SetAutoPageBreak(auto, margin)
+	r.pdf.SetTextColor(0, 0, 0)
+	r.pdf.Ln(3)
+}
+
+func (r *renderer) rowHeight(row []tableCell, widths []float64, lineHeight float64) float64 {
+	lines := 1
+	for i, cell := range row {
+		r.setCellFont(cell)
+		if n := len(r.pdf.SplitText(cell.text, widths[i]-2*cellPadding)); n > lines {
+			lines = n
+		}
+	}
+	return float64(lines)*lineHeight + 2*cellPadding
+}
+
+func (r *renderer) drawRow(row []tableCell, widths []float64, lineHeight, rowHeight, left float64) {
+	y := r.pdf.GetY()
+	x := left
+	r.pdf.SetDrawColor(180, 180, 180)
+	for i, width := range widths {
+		cell := tableCell{align: "L"}
+		if i < len(row) {
+			cell = row[i]
+		}
+		if cell.header {
+			r.pdf.SetFillColor(235, 235, 235)
+			r.pdf.Rect(x, y, width, rowHeight, "FD")
+		} else {
+			r.pdf.Rect(x, y, width, rowHeight, "D")
+		}
+
+		r.setCellFont(cell)
+		r.pdf.SetTextColor(0, 0, 0)
+		for j, line := range r.pdf.SplitText(cell.text, width-2*cellPadding) {
+			r.pdf.SetXY(x+cellPadding, y+cellPadding+float64(j)*lineHeight)
+			r.pdf.CellFormat(width-2*cellPadding, lineHeight, line, "", 0, cell.align, false, 0, "")
+		}
+		x += width
+	}
+	r.pdf.SetXY(left, y+rowHeight)
+}
+
+func (r *renderer) setCellFont(cell tableCell) {
+	if cell.header {
+		r.pdf.SetFont(fontFamily, "B", tableFont)
+	} else {
+		r.pdf.SetFont(fontFamily, "", tableFont)
+	}
+}
+
+// columnWidths uses the percentages of the first row, the other columns share the rest of the page.
+func columnWidths(pdf *fpdf.Fpdf, rows [][]tableCell) []float64 {
+	columns := 0
+	for _, row := range rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+	left, _, right, _ := pdf.GetMargins()
+	pageWidth, _ := pdf.GetPageSize()
+	usable := pageWidth - left - right
+
+	widths := make([]float64, columns)
+	rest, free := usable, 0
+	for i := range widths {
+		if i < len(rows[0]) && rows[0][i].width > 0 {
+			widths[i] = usable * rows[0][i].width / 100
+			rest -= widths[i]
+		} else {
+			free++
+		}
+	}
+	for i := range widths {
+		if widths[i] == 0 && free > 0 {
+			widths[i] = rest / float64(free)
+		}
+	}
+	return widths
+}
+
+func isHeaderRow(row []tableCell) bool {
+	for _, cell := range row {
+		if !cell.header {
+			return false
+		}
+	}
+	return true
+}
+
+func textContent(n *html.Node) string {
+	var sb strings.Builder
+	var collect func(*html.Node)
+	collect = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			sb.WriteString(n.Data)
+			sb.WriteString(" ")
+		}
+		if n.Type == html.ElementNode && n.DataAtom == atom.Br {
+			sb.WriteString("\n")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			collect(c)
+		}
+	}
+	collect(n)
+
+	lines := strings.Split(sb.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func findAll(n *html.Node, a atom.Atom) []*html.Node {
+	nodes := make([]*html.Node, 0)
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.DataAtom == a {
+			nodes = append(nodes, c)
+			continue
+		}
+		nodes = append(nodes, findAll(c, a)...)
+	}
+	return nodes
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return strings.TrimSpace(a.Val)
+		}
+	}
+	return ""
+}
+
+func hasClass(n *html.Node, class string) bool {
+	for _, c := range strings.Fields(attr(n, "class")) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+func startsWithSpace(s string) bool {
+	return s != "" && strings.TrimLeft(s, " \t\r\n") != s
+}
+
+func endsWithSpace(s string) bool {
+	return s != "" && strings.TrimRight(s, " \t\r\n") != s
+}
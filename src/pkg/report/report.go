@@ -0,0 +1,112 @@
+// Package report renders the server-side reports to PDF. A report is a html/template of the templates folder,
+// the HTML it produces is drawn by a small renderer (see html.go) with the DejaVu fonts so the Vietnamese
+// texts print correctly.
+package report
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultFontDir holds DejaVuSans.ttf and DejaVuSans-Bold.ttf, relative to the working directory
+	DefaultFontDir = "config/fonts"
+
+	regularFontFile = "DejaVuSans.ttf"
+	boldFontFile    = "DejaVuSans-Bold.ttf"
+)
+
+var ErrNotInitialized = errors.New("report engine is not initialized")
+
+//go:embed templates
+var templateFS embed.FS
+
+var (
+	mu        sync.RWMutex
+	fonts     *fontSet
+	templates *template.Template
+)
+
+type fontSet struct {
+	regular []byte
+	bold    []byte
+}
+
+var funcs = template.FuncMap{
+	"date": func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format("02/01/2006")
+	},
+	"datetime": func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format("02/01/2006 15:04")
+	},
+	"percent": func(part, total int) string {
+		if total == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%.0f%%", float64(part)*100/float64(total))
+	},
+	"inc": func(i int) int {
+		return i + 1
+	},
+	"add": func(a, b int) int {
+		return a + b
+	},
+	"number": func(f float64) string {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	},
+}
+
+// Init loads the fonts of fontDir (DefaultFontDir when empty) and parses the templates, called once at startup.
+func Init(fontDir string) error {
+	if fontDir == "" {
+		fontDir = DefaultFontDir
+	}
+	regular, err := os.ReadFile(filepath.Join(fontDir, regularFontFile))
+	if err != nil {
+		return fmt.Errorf("report: load font: %w", err)
+	}
+	bold, err := os.ReadFile(filepath.Join(fontDir, boldFontFile))
+	if err != nil {
+		return fmt.Errorf("report: load font: %w", err)
+	}
+	tmpl, err := template.New("report").Funcs(funcs).ParseFS(templateFS, "templates/*.html")
+	if err != nil {
+		return fmt.Errorf("report: parse templates: %w", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	fonts = &fontSet{regular: regular, bold: bold}
+	templates = tmpl
+	return nil
+}
+
+// Render executes the template name (e.g. "submission.html") with data and returns the PDF.
+func Render(name string, data interface{}) ([]byte, error) {
+	mu.RLock()
+	f, tmpl := fonts, templates
+	mu.RUnlock()
+	if f == nil || tmpl == nil {
+		return nil, ErrNotInitialized
+	}
+
+	var page bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&page, name, data); err != nil {
+		return nil, fmt.Errorf("report: execute %s: %w", name, err)
+	}
+	return renderHTML(page.Bytes(), f)
+}
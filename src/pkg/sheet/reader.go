@@ -1,6 +1,7 @@
 package sheet
 
 import (
+	"context"
 	"errors"
 	"strconv"
 
@@ -10,6 +11,20 @@ import (
 
 type Reader struct {
 	sheetsService *sheets.Service
+	ctx           context.Context
+}
+
+// WithContext returns a reader whose calls carry ctx, e.g. to forward the request id of the request.
+func (receiver Reader) WithContext(ctx context.Context) *Reader {
+	receiver.ctx = ctx
+	return &receiver
+}
+
+func (receiver Reader) requestContext() context.Context {
+	if receiver.ctx == nil {
+		return context.Background()
+	}
+	return receiver.ctx
 }
 
 // / ReadSpecificRangeParams is the params for reading a specific range of a spreadsheet
@@ -26,6 +41,7 @@ type ReadSpecificRangeParams struct {
 func (receiver Reader) Get(params ReadSpecificRangeParams) ([][]interface{}, error) {
 	resp, err := receiver.sheetsService.Spreadsheets.Values.Get(params.SpreadsheetID, params.ReadRange).
 		ValueRenderOption("FORMATTED_VALUE").
+		Context(receiver.requestContext()).
 		Do()
 	if err != nil {
 		log.Error("Unable to retrieve data from sheet: ", err)
@@ -47,6 +63,7 @@ func (receiver Reader) GetFirstRow(params ReadSpecificRangeParams) ([][]interfac
 	resp, err := receiver.sheetsService.Spreadsheets.Values.Get(params.SpreadsheetID, params.ReadRange).
 		MajorDimension("COLUMNS").
 		ValueRenderOption("FORMATTED_VALUE").
+		Context(receiver.requestContext()).
 		Do()
 	if err != nil {
 		log.Error("Unable to retrieve data from sheet:", err)
@@ -66,6 +83,7 @@ func (receiver Reader) FindFirstRow(params ReadSpecificRangeParams, deviceID str
 		MajorDimension: "ROWS",
 		Values:         [][]interface{}{{"=MATCH(\"" + deviceID + "\", Devices!L:L, 0)"}},
 	}).ValueInputOption("USER_ENTERED").
+		Context(receiver.requestContext()).
 		Do()
 	if err != nil {
 		log.Error("Unable to retrieve data from sheet:", err)
@@ -73,7 +91,7 @@ func (receiver Reader) FindFirstRow(params ReadSpecificRangeParams, deviceID str
 	}
 
 	updatedRows, err := receiver.sheetsService.Spreadsheets.Values.Get(params.SpreadsheetID, "LOOKUP_SHEET!A1").MajorDimension("COLUMNS").
-		ValueRenderOption("FORMATTED_VALUE").Do()
+		ValueRenderOption("FORMATTED_VALUE").Context(receiver.requestContext()).Do()
 	if err != nil {
 		log.Error("Unable to retrieve data from sheet:", err)
 		return 0, err
@@ -94,7 +112,7 @@ func (receiver Reader) FindFirstRow(params ReadSpecificRangeParams, deviceID str
 }
 
 func (receiver Reader) GetSheets(spreadsheetID string) ([]string, error) {
-	resp, err := receiver.sheetsService.Spreadsheets.Get(spreadsheetID).Do()
+	resp, err := receiver.sheetsService.Spreadsheets.Get(spreadsheetID).Context(receiver.requestContext()).Do()
 	if err != nil {
 		log.Error("Unable to retrieve data from sheet:", err)
 		return nil, err
@@ -114,7 +132,7 @@ type SingleSheet struct {
 }
 
 func (receiver Reader) GetAllSheets(spreadsheetID string) ([]SingleSheet, error) {
-	resp, err := receiver.sheetsService.Spreadsheets.Get(spreadsheetID).Do()
+	resp, err := receiver.sheetsService.Spreadsheets.Get(spreadsheetID).Context(receiver.requestContext()).Do()
 	if err != nil {
 		log.Error("Unable to retrieve data from sheet:", err)
 		return nil, err
@@ -2,16 +2,36 @@ package sheet
 
 import (
 	"context"
+	"errors"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/sheets/v4"
 	"os"
 	"sen-global-api/config"
+	"sen-global-api/pkg/logger"
+	"sen-global-api/pkg/metrics"
 )
 
 type Spreadsheet struct {
-	Reader *Reader
-	Writer *Writer
+	Reader      *Reader
+	Writer      *Writer
+	tokenSource oauth2.TokenSource
+}
+
+// CheckCredentials checks that the service account can still get an access token from Google.
+func (s *Spreadsheet) CheckCredentials(ctx context.Context) error {
+	if s.tokenSource == nil {
+		return errors.New("spreadsheet has no credentials")
+	}
+	token, err := s.tokenSource.Token()
+	if err != nil {
+		return err
+	}
+	if !token.Valid() {
+		return errors.New("google access token is invalid")
+	}
+	return ctx.Err()
 }
 
 func NewUserSpreadsheet(config config.AppConfig, contex context.Context) (*Spreadsheet, error) {
@@ -27,14 +47,16 @@ func NewUserSpreadsheet(config config.AppConfig, contex context.Context) (*Sprea
 		return nil, err
 	}
 	client := jwtConfig.Client(contex)
+	client.Transport = logger.NewTransport(metrics.NewTransport(metrics.ServiceSheets, client.Transport))
 	sheetsService, err := sheets.New(client)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Spreadsheet{
-		Reader: &Reader{sheetsService: sheetsService},
-		Writer: &Writer{sheetsService: sheetsService},
+		Reader:      &Reader{sheetsService: sheetsService},
+		Writer:      &Writer{sheetsService: sheetsService},
+		tokenSource: oauth2.ReuseTokenSource(nil, jwtConfig.TokenSource(contex)),
 	}, nil
 }
 
@@ -51,13 +73,15 @@ func NewUploaderSpreadsheet(config config.AppConfig, contex context.Context) (*S
 		return nil, err
 	}
 	client := jwtConfig.Client(contex)
+	client.Transport = logger.NewTransport(metrics.NewTransport(metrics.ServiceSheets, client.Transport))
 	sheetsService, err := sheets.New(client)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Spreadsheet{
-		Reader: &Reader{sheetsService: sheetsService},
-		Writer: &Writer{sheetsService: sheetsService},
+		Reader:      &Reader{sheetsService: sheetsService},
+		Writer:      &Writer{sheetsService: sheetsService},
+		tokenSource: oauth2.ReuseTokenSource(nil, jwtConfig.TokenSource(contex)),
 	}, nil
 }
@@ -9,6 +9,20 @@ import (
 
 type Writer struct {
 	sheetsService *sheets.Service
+	ctx           context.Context
+}
+
+// WithContext returns a writer whose calls carry ctx, e.g. to forward the request id of the request.
+func (receiver Writer) WithContext(ctx context.Context) *Writer {
+	receiver.ctx = ctx
+	return &receiver
+}
+
+func (receiver Writer) requestContext() context.Context {
+	if receiver.ctx == nil {
+		return context.Background()
+	}
+	return receiver.ctx
 }
 
 type WriteRangeParams struct {
@@ -34,7 +48,7 @@ func (receiver Writer) WriteRanges(params WriteRangeParams, spreadsheetID string
 		Range:          params.Range,
 		Values:         params.Rows,
 	}
-	resp, err := receiver.sheetsService.Spreadsheets.Values.Append(spreadsheetID, params.Range, updateValues).ValueInputOption("RAW").Do()
+	resp, err := receiver.sheetsService.Spreadsheets.Values.Append(spreadsheetID, params.Range, updateValues).ValueInputOption("RAW").Context(receiver.requestContext()).Do()
 	if err != nil {
 		log.Error("Unable to append data from sheet: ", err)
 		return nil, err
@@ -49,7 +63,7 @@ func (receiver Writer) WriteRangesAsUserEntered(params WriteRangeParams, spreads
 		Range:          params.Range,
 		Values:         params.Rows,
 	}
-	resp, err := receiver.sheetsService.Spreadsheets.Values.Append(spreadsheetID, params.Range, updateValues).ValueInputOption("USER_ENTERED").Do()
+	resp, err := receiver.sheetsService.Spreadsheets.Values.Append(spreadsheetID, params.Range, updateValues).ValueInputOption("USER_ENTERED").Context(receiver.requestContext()).Do()
 	if err != nil {
 		log.Error("Unable to append data from sheet: ", err)
 		return nil, err
@@ -64,7 +78,7 @@ func (receiver Writer) UpdateRange(params WriteRangeParams, spreadsheetID string
 		Range:          params.Range,
 		Values:         params.Rows,
 	}
-	resp, err := receiver.sheetsService.Spreadsheets.Values.Update(spreadsheetID, params.Range, updateValues).ValueInputOption("RAW").Do()
+	resp, err := receiver.sheetsService.Spreadsheets.Values.Update(spreadsheetID, params.Range, updateValues).ValueInputOption("RAW").Context(receiver.requestContext()).Do()
 	if err != nil {
 		log.Error("Unable to retrieve data from sheet: ", err)
 		return nil, err
@@ -98,7 +112,7 @@ func (receiver Writer) UpdateRanges(spreadsheetID string, params []WriteRangePar
 	//	})
 	//}
 
-	_, err := receiver.sheetsService.Spreadsheets.BatchUpdate(spreadsheetID, rbb).Do()
+	_, err := receiver.sheetsService.Spreadsheets.BatchUpdate(spreadsheetID, rbb).Context(receiver.requestContext()).Do()
 	if err != nil {
 		return err
 	}
@@ -119,7 +133,7 @@ func (receiver Writer) CreateSheet(sheetName string, spreadsheetID string) error
 		Requests: []*sheets.Request{&req},
 	}
 
-	_, err := receiver.sheetsService.Spreadsheets.BatchUpdate(spreadsheetID, rbb).Do()
+	_, err := receiver.sheetsService.Spreadsheets.BatchUpdate(spreadsheetID, rbb).Context(receiver.requestContext()).Do()
 	if err != nil {
 		return err
 	}
@@ -134,7 +148,7 @@ func (receiver Writer) AppendSheet(params AppendParams, spreadsheetID string) (*
 		Values:         params.Rows,
 	}
 
-	resp, err := receiver.sheetsService.Spreadsheets.Values.Append(spreadsheetID, params.SheetName, data).ValueInputOption("RAW").Do()
+	resp, err := receiver.sheetsService.Spreadsheets.Values.Append(spreadsheetID, params.SheetName, data).ValueInputOption("RAW").Context(receiver.requestContext()).Do()
 	if err != nil {
 		return nil, err
 	}
@@ -145,6 +159,7 @@ func (receiver Writer) AppendSheet(params AppendParams, spreadsheetID string) (*
 func (receiver Writer) ClearRange(params ClearRangeParams) (*sheets.ClearValuesResponse, error) {
 	resp, err := receiver.sheetsService.Spreadsheets.Values.
 		Clear(params.SpreadsheetID, params.Range, &sheets.ClearValuesRequest{}).
+		Context(receiver.requestContext()).
 		Do()
 
 	if err != nil {
@@ -166,7 +181,7 @@ func (receiver Writer) CopySingleSheet(params CopySingleSheetParam) error {
 	}
 	copiedSheet, err := receiver.sheetsService.Spreadsheets.Sheets.
 		CopyTo(params.FromSpreadsheetID, params.SingleSheet.ID, copyRequest).
-		Context(context.Background()).
+		Context(receiver.requestContext()).
 		Do()
 
 	if err != nil {
@@ -191,7 +206,7 @@ func (receiver Writer) CopySingleSheet(params CopySingleSheetParam) error {
 
 	_, err = receiver.sheetsService.Spreadsheets.
 		BatchUpdate(params.ToSpreadsheetID, request).
-		Context(context.Background()).
+		Context(receiver.requestContext()).
 		Do()
 	if err != nil {
 		log.Fatalf("Unable to rename sheet: %v", err)
@@ -206,7 +221,7 @@ type DeleteSheetParams struct {
 }
 
 func (receiver Writer) DeleteSheet(params DeleteSheetParams) error {
-	resp, err := receiver.sheetsService.Spreadsheets.Get(params.SpreadsheetID).Do()
+	resp, err := receiver.sheetsService.Spreadsheets.Get(params.SpreadsheetID).Context(receiver.requestContext()).Do()
 	if err != nil {
 		log.Error("Unable to retrieve data from sheet:", err)
 		return err
@@ -227,7 +242,7 @@ func (receiver Writer) DeleteSheet(params DeleteSheetParams) error {
 
 			_, err = receiver.sheetsService.Spreadsheets.
 				BatchUpdate(params.SpreadsheetID, request).
-				Context(context.Background()).
+				Context(receiver.requestContext()).
 				Do()
 			if err != nil {
 				return err
@@ -249,7 +264,7 @@ type DuplicateSpreadsheetResult struct {
 }
 
 func (receiver Writer) DuplicateSpreadsheet(params DuplicateSpreadsheetParams) (DuplicateSpreadsheetResult, error) {
-	ctx := context.Background()
+	ctx := receiver.requestContext()
 	resp, err := receiver.sheetsService.Spreadsheets.
 		Get(params.SourceSpreadsheetID).
 		Context(ctx).
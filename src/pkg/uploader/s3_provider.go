@@ -11,9 +11,12 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"sen-global-api/pkg/logger"
+	"sen-global-api/pkg/metrics"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/feature/cloudfront/sign"
@@ -49,9 +52,18 @@ func NewS3Provider(accessKey, secretKey, bucketName, region, domain, cloudFrontK
 		"",
 	))
 
+	// wrap the AWS default transport so its dial, TLS and idle timeouts are kept,
+	// the wrappers forward the request id of the request context and count the calls
+	defaultClient := awshttp.NewBuildableClient()
+	httpClient := &http.Client{
+		Transport: logger.NewTransport(metrics.NewTransport(metrics.ServiceS3, defaultClient.GetTransport())),
+		Timeout:   defaultClient.GetTimeout(),
+	}
+
 	cfg, err := config.LoadDefaultConfig(context.Background(),
 		config.WithRegion(region),
 		config.WithCredentialsProvider(creds),
+		config.WithHTTPClient(httpClient),
 	)
 	if err != nil {
 		log.Fatalln(err)
@@ -62,6 +74,18 @@ func NewS3Provider(accessKey, secretKey, bucketName, region, domain, cloudFrontK
 	return provider
 }
 
+// Ping checks that the bucket is reachable with the configured credentials.
+func (p *s3Provider) Ping(ctx context.Context) error {
+	client := s3.NewFromConfig(p.config)
+	_, err := client.HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(p.bucketName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reach S3 bucket %s %w", p.bucketName, err)
+	}
+	return nil
+}
+
 func (p *s3Provider) SaveFileUploaded(ctx context.Context, data []byte, key string, mode UploadMode) (*string, error) {
 
 	fileBytes := bytes.NewReader(data)